@@ -16,6 +16,7 @@ type ApiChange struct {
 
 	Id          string
 	Args        []any
+	Message     string // the message template of a custom rule, used instead of the localized message of the id
 	Comment     string
 	Level       Level
 	Operation   string
@@ -33,9 +34,14 @@ type ApiChange struct {
 // NewApiChange creates a new ApiChange
 // TODO: use opInfo to simplify the function signature
 func NewApiChange(id string, config *Config, args []any, comment string, operationsSources *diff.OperationsSourcesMap, operation *openapi3.Operation, method, path string) ApiChange {
-	return ApiChange{
+	return newApiChangeWithLevel(id, config.getLogLevel(id), config, args, comment, operationsSources, operation, method, path)
+}
+
+// newApiChangeWithLevel is like NewApiChange but takes the level of the change rather than the level of its id
+func newApiChangeWithLevel(id string, level Level, config *Config, args []any, comment string, operationsSources *diff.OperationsSourcesMap, operation *openapi3.Operation, method, path string) ApiChange {
+	change := ApiChange{
 		Id:          id,
-		Level:       config.getLogLevel(id),
+		Level:       level,
 		Args:        args,
 		Comment:     comment,
 		OperationId: operation.OperationID,
@@ -46,6 +52,32 @@ func NewApiChange(id string, config *Config, args []any, comment string, operati
 			Attributes: getAttributes(config, operation),
 		},
 	}
+	return change.withSource(config, operation)
+}
+
+// withSource points the change at the source position of a spec element, like an operation, a parameter or a schema
+// if the element can't be located, the change is returned unmodified
+func (c ApiChange) withSource(config *Config, element any) ApiChange {
+	return c.withPosition(config.locateElement(element))
+}
+
+// withDeletedSource points the change at the source position of a deleted element in the base, given by an element of the base and a JSON pointer relative to it, like /enum/2 under a schema
+// an empty pointer refers to the element itself
+func (c ApiChange) withDeletedSource(config *Config, element any, pointer string) ApiChange {
+	return c.withPosition(config.locateDeleted(element, pointer))
+}
+
+func (c ApiChange) withPosition(file string, position load.Position, ok bool) ApiChange {
+	if !ok {
+		return c
+	}
+
+	c.SourceFile = file
+	c.SourceLine = position.Line
+	c.SourceLineEnd = position.LineEnd
+	c.SourceColumn = position.Column
+	c.SourceColumnEnd = position.ColumnEnd
+	return c
 }
 
 func getAttributes(config *Config, operation *openapi3.Operation) map[string]any {
@@ -86,7 +118,7 @@ func (c ApiChange) GetId() string {
 }
 
 func (c ApiChange) GetText(l Localizer) string {
-	return l(c.Id, colorizedValues(c.Args)...)
+	return localizeMessage(l, c.Id, c.Message, colorizedValues(c.Args))
 }
 
 func (c ApiChange) GetArgs() []any {
@@ -94,7 +126,7 @@ func (c ApiChange) GetArgs() []any {
 }
 
 func (c ApiChange) GetUncolorizedText(l Localizer) string {
-	return l(c.Id, quotedValues(c.Args)...)
+	return localizeMessage(l, c.Id, c.Message, quotedValues(c.Args))
 }
 
 func (c ApiChange) GetComment(l Localizer) string {
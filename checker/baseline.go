@@ -0,0 +1,180 @@
+package checker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BaselineVersion is the version of the baseline file format
+const BaselineVersion = 1
+
+// Baseline is a snapshot of accepted changes
+// changes are identified by fingerprints which don't depend on the localized text of the changes, or on their level
+type Baseline struct {
+	Version int             `yaml:"version"`
+	Changes []BaselineEntry `yaml:"changes"`
+}
+
+// BaselineEntry is an accepted change
+// the fields, except for the fingerprint, are informational and make the baseline file easier to review
+type BaselineEntry struct {
+	Fingerprint string   `yaml:"fingerprint"`
+	Id          string   `yaml:"id"`
+	Level       string   `yaml:"level"`
+	Section     string   `yaml:"section"`
+	Operation   string   `yaml:"operation,omitempty"`
+	Path        string   `yaml:"path,omitempty"`
+	Args        []string `yaml:"args,omitempty"`
+	Text        string   `yaml:"text"`
+}
+
+// GetFingerprint returns a stable fingerprint of a change, built from its section, id, operation, path and arguments
+func GetFingerprint(change Change) string {
+	return getFingerprint(change.GetSection(), change.GetId(), change.GetOperation(), change.GetPath(), normalizeArgs(change.GetArgs()))
+}
+
+func getFingerprint(section, id, operation, path string, args []string) string {
+	fields := append([]string{section, id, operation, path}, args...)
+	hash := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(hash[:16])
+}
+
+func normalizeArgs(args []any) []string {
+	result := make([]string, len(args))
+	for i, arg := range args {
+		result[i] = interfaceToString(arg)
+	}
+	return result
+}
+
+// NewBaseline creates a baseline of the given changes, sorted like the changes are
+func NewBaseline(changes Changes, l Localizer) *Baseline {
+	sorted := make(Changes, len(changes))
+	copy(sorted, changes)
+	sort.Sort(sorted)
+
+	result := Baseline{
+		Version: BaselineVersion,
+		Changes: make([]BaselineEntry, 0, len(sorted)),
+	}
+
+	fingerprints := map[string]struct{}{}
+	for _, change := range sorted {
+		fingerprint := GetFingerprint(change)
+		if _, ok := fingerprints[fingerprint]; ok {
+			continue
+		}
+		fingerprints[fingerprint] = struct{}{}
+
+		result.Changes = append(result.Changes, BaselineEntry{
+			Fingerprint: fingerprint,
+			Id:          change.GetId(),
+			Level:       change.GetLevel().String(),
+			Section:     change.GetSection(),
+			Operation:   change.GetOperation(),
+			Path:        change.GetPath(),
+			Args:        normalizeArgs(change.GetArgs()),
+			Text:        change.GetUncolorizedText(l),
+		})
+	}
+
+	return &result
+}
+
+// LoadBaseline reads a baseline file
+func LoadBaseline(file string) (*Baseline, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadBaseline(f)
+}
+
+// ReadBaseline reads a baseline from a reader
+func ReadBaseline(source io.Reader) (*Baseline, error) {
+	var result Baseline
+
+	decoder := yaml.NewDecoder(source)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&result); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty baseline")
+		}
+		return nil, err
+	}
+
+	if result.Version != BaselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d", result.Version)
+	}
+
+	for i, entry := range result.Changes {
+		if entry.Fingerprint == "" {
+			return nil, fmt.Errorf("missing fingerprint in baseline entry #%d", i+1)
+		}
+	}
+
+	return &result, nil
+}
+
+// SaveBaseline writes a baseline file
+func (baseline *Baseline) SaveBaseline(file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	if err := baseline.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Write writes the baseline in YAML format
+func (baseline *Baseline) Write(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(baseline); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// Filter returns the changes which aren't in the baseline, and the baseline entries which no longer occur in the changes
+func (baseline *Baseline) Filter(changes Changes) (Changes, []BaselineEntry) {
+	accepted := map[string]struct{}{}
+	for _, entry := range baseline.Changes {
+		accepted[entry.Fingerprint] = struct{}{}
+	}
+
+	result := make(Changes, 0)
+	occurred := map[string]struct{}{}
+	for _, change := range changes {
+		fingerprint := GetFingerprint(change)
+		if _, ok := accepted[fingerprint]; ok {
+			occurred[fingerprint] = struct{}{}
+			continue
+		}
+		result = append(result, change)
+	}
+
+	resolved := []BaselineEntry{}
+	for _, entry := range baseline.Changes {
+		if _, ok := occurred[entry.Fingerprint]; !ok {
+			resolved = append(resolved, entry)
+		}
+	}
+
+	return result, resolved
+}
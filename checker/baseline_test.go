@@ -0,0 +1,91 @@
+package checker_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+)
+
+func getBaselineChanges(t *testing.T) checker.Changes {
+	t.Helper()
+
+	rules := loadCustomRules(t)
+	return getCustomRulesChanges(t, allChecksConfig().WithCustomRules(rules))
+}
+
+func TestGetFingerprint_IgnoresTextAndLevel(t *testing.T) {
+	change := checker.ApiChange{
+		Id:        "response-header-removed",
+		Args:      []any{"X-Rate-Limit", "200"},
+		Level:     checker.ERR,
+		Operation: "GET",
+		Path:      "/billing",
+	}
+	fingerprint := checker.GetFingerprint(change)
+
+	change.Level = checker.WARN
+	change.Message = "another text %s %s"
+	change.Comment = "a comment"
+	require.Equal(t, fingerprint, checker.GetFingerprint(change))
+
+	change.Args = []any{"X-Rate-Limit", "201"}
+	require.NotEqual(t, fingerprint, checker.GetFingerprint(change))
+}
+
+func TestNewBaseline(t *testing.T) {
+	errs := getBaselineChanges(t)
+	require.NotEmpty(t, errs)
+
+	baseline := checker.NewBaseline(errs, checker.NewLocalizer("en"))
+	require.Equal(t, checker.BaselineVersion, baseline.Version)
+	require.Len(t, baseline.Changes, len(errs))
+	for _, entry := range baseline.Changes {
+		require.NotEmpty(t, entry.Fingerprint)
+		require.NotEmpty(t, entry.Text)
+	}
+}
+
+func TestBaseline_WriteAndRead(t *testing.T) {
+	errs := getBaselineChanges(t)
+	baseline := checker.NewBaseline(errs, checker.NewLocalizer("en"))
+
+	var buf bytes.Buffer
+	require.NoError(t, baseline.Write(&buf))
+
+	read, err := checker.ReadBaseline(&buf)
+	require.NoError(t, err)
+	require.Equal(t, baseline, read)
+}
+
+func TestBaseline_Filter(t *testing.T) {
+	errs := getBaselineChanges(t)
+	baseline := checker.NewBaseline(errs[1:], checker.NewLocalizer("en"))
+	baseline.Changes = append(baseline.Changes, checker.BaselineEntry{Fingerprint: "resolved", Id: "some-id"})
+
+	result, resolved := baseline.Filter(errs)
+	require.Equal(t, checker.Changes{errs[0]}, result)
+	require.Len(t, resolved, 1)
+	require.Equal(t, "resolved", resolved[0].Fingerprint)
+}
+
+func TestReadBaseline_Invalid(t *testing.T) {
+	_, err := checker.ReadBaseline(strings.NewReader(""))
+	require.EqualError(t, err, "empty baseline")
+
+	_, err = checker.ReadBaseline(strings.NewReader("version: 2\n"))
+	require.EqualError(t, err, "unsupported baseline version 2")
+
+	_, err = checker.ReadBaseline(strings.NewReader("version: 1\nchanges:\n  - id: some-id\n"))
+	require.EqualError(t, err, "missing fingerprint in baseline entry #1")
+
+	_, err = checker.ReadBaseline(strings.NewReader("version: 1\nunknown: 1\n"))
+	require.Error(t, err)
+}
+
+func TestLoadBaseline_NoFile(t *testing.T) {
+	_, err := checker.LoadBaseline("../data/baseline/no-file.yaml")
+	require.Error(t, err)
+}
@@ -14,6 +14,8 @@ type Change interface {
 	GetPath() string
 	GetSource() string
 	GetAttributes() map[string]any
+	GetObservedCalls() (int, bool)
+	GetCounterexample() *Counterexample
 	GetSourceFile() string
 	GetSourceLine() int
 	GetSourceLineEnd() int
@@ -25,9 +27,57 @@ type Change interface {
 }
 
 type CommonChange struct {
-	Attributes map[string]any
+	Attributes     map[string]any
+	ObservedCalls  *int            // the number of recorded calls affected by the change, if traffic was analyzed
+	Counterexample *Counterexample // a request which is valid against the base and invalid against the revision, if one was generated
 }
 
 func (c CommonChange) GetAttributes() map[string]any {
 	return c.Attributes
 }
+
+// GetObservedCalls returns the number of recorded calls affected by the change, and whether it is known
+func (c CommonChange) GetObservedCalls() (int, bool) {
+	if c.ObservedCalls == nil {
+		return 0, false
+	}
+	return *c.ObservedCalls, true
+}
+
+// GetCounterexample returns a request which demonstrates the change, or nil
+func (c CommonChange) GetCounterexample() *Counterexample {
+	return c.Counterexample
+}
+
+// WithObservedCalls returns a copy of the change annotated with the number of recorded calls that it affects
+func WithObservedCalls(change Change, calls int) Change {
+	return withCommonChange(change, func(c *CommonChange) {
+		c.ObservedCalls = &calls
+	})
+}
+
+// WithCounterexample returns a copy of the change annotated with a request which demonstrates it
+func WithCounterexample(change Change, counterexample *Counterexample) Change {
+	return withCommonChange(change, func(c *CommonChange) {
+		c.Counterexample = counterexample
+	})
+}
+
+// withCommonChange returns a copy of the change with its common fields updated
+func withCommonChange(change Change, update func(*CommonChange)) Change {
+	switch c := change.(type) {
+	case ApiChange:
+		update(&c.CommonChange)
+		return c
+	case ComponentChange:
+		update(&c.CommonChange)
+		return c
+	case SecurityChange:
+		update(&c.CommonChange)
+		return c
+	case WebhookChange:
+		update(&c.CommonChange)
+		return c
+	}
+	return change
+}
@@ -34,7 +34,7 @@ func APIOperationIdUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.O
 				args = []any{operationItem.Revision.OperationID}
 			}
 
-			result = append(result, NewApiChange(
+			change := NewApiChange(
 				id,
 				config,
 				args,
@@ -43,7 +43,11 @@ func APIOperationIdUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.O
 				op,
 				operation,
 				path,
-			))
+			)
+			if id == APIOperationIdRemovedId {
+				change = change.withDeletedSource(config, op, "/operationId")
+			}
+			result = append(result, change)
 		}
 	}
 	return result
@@ -0,0 +1,42 @@
+package checker
+
+import (
+	"github.com/tufin/oasdiff/diff"
+)
+
+const (
+	EndpointPathChangedId = "endpoint-path-changed"
+)
+
+// APIPathChangedCheck reports endpoints which were moved to another path
+// moved endpoints are only matched when diff.Config.MatchMovedEndpoints is set
+func APIPathChangedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	if diffReport.PathsDiff == nil {
+		return result
+	}
+
+	for path, pathItem := range diffReport.PathsDiff.Modified {
+		if pathItem.OperationsDiff == nil {
+			continue
+		}
+
+		for operation, operationItem := range pathItem.OperationsDiff.Modified {
+			if operationItem.PathDiff == nil {
+				continue
+			}
+
+			result = append(result, NewApiChange(
+				EndpointPathChangedId,
+				config,
+				[]any{operationItem.PathDiff.From, operationItem.PathDiff.To},
+				"",
+				operationsSources,
+				operationItem.Revision,
+				operation,
+				path,
+			).withDeletedSource(config, operationItem.Base, ""))
+		}
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+// BC: moving an endpoint to another path is breaking
+func TestEndpointPathChanged(t *testing.T) {
+	s1, err := open("../data/moved-endpoints/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/moved-endpoints/revision.yaml")
+	require.NoError(t, err)
+
+	config := diff.NewConfig()
+	config.MatchMovedEndpoints = true
+	d, osm, err := diff.GetWithOperationsSourcesMap(config, s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.APIPathChangedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 2)
+	require.ElementsMatch(t, []checker.Change{
+		checker.ApiChange{
+			Id:          checker.EndpointPathChangedId,
+			Args:        []any{"/users/{id}", "/accounts/{accountId}"},
+			Level:       checker.ERR,
+			Operation:   "GET",
+			Path:        "/accounts/{accountId}",
+			Source:      load.NewSource("../data/moved-endpoints/revision.yaml"),
+			OperationId: "getUser",
+		},
+		checker.ApiChange{
+			Id:          checker.EndpointPathChangedId,
+			Args:        []any{"/users/{id}", "/accounts/{accountId}"},
+			Level:       checker.ERR,
+			Operation:   "DELETE",
+			Path:        "/accounts/{accountId}",
+			Source:      load.NewSource("../data/moved-endpoints/revision.yaml"),
+			OperationId: "deleteAccount",
+		},
+	}, errs)
+	require.Equal(t, "endpoint path changed from '/users/{id}' to '/accounts/{accountId}'", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// moved endpoints are reported as path changes rather than as removals
+func TestEndpointPathChanged_NotRemoved(t *testing.T) {
+	s1, err := open("../data/moved-endpoints/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/moved-endpoints/revision.yaml")
+	require.NoError(t, err)
+
+	config := diff.NewConfig()
+	config.MatchMovedEndpoints = true
+	d, osm, err := diff.GetWithOperationsSourcesMap(config, s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibility(allChecksConfig(), d, osm)
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		require.Equal(t, checker.EndpointPathChangedId, err.GetId())
+	}
+}
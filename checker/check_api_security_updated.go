@@ -15,7 +15,7 @@ const (
 	APIGlobalSecurityScopeRemovedId = "api-global-security-scope-removed"
 )
 
-func checkGlobalSecurity(diffReport *diff.Diff) Changes {
+func checkGlobalSecurity(diffReport *diff.Diff, config *Config) Changes {
 	result := make(Changes, 0)
 	if diffReport.SecurityDiff == nil {
 		return result
@@ -26,7 +26,7 @@ func checkGlobalSecurity(diffReport *diff.Diff) Changes {
 			Id:    APIGlobalSecurityAddedCheckId,
 			Level: INFO,
 			Args:  []any{addedSecurity},
-		})
+		}.withSource(config, "/security", false))
 	}
 
 	for _, removedSecurity := range diffReport.SecurityDiff.Deleted {
@@ -34,7 +34,7 @@ func checkGlobalSecurity(diffReport *diff.Diff) Changes {
 			Id:    APIGlobalSecurityRemovedCheckId,
 			Level: INFO,
 			Args:  []any{removedSecurity},
-		})
+		}.withSource(config, "/security", true))
 	}
 
 	for _, updatedSecurity := range diffReport.SecurityDiff.Modified {
@@ -44,14 +44,14 @@ func checkGlobalSecurity(diffReport *diff.Diff) Changes {
 					Id:    APIGlobalSecurityScopeAddedId,
 					Level: INFO,
 					Args:  []any{addedScope, securitySchemeName},
-				})
+				}.withSource(config, "/security", false))
 			}
 			for _, deletedScope := range updatedSecuritySchemeScopes.Deleted {
 				result = append(result, SecurityChange{
 					Id:    APIGlobalSecurityScopeRemovedId,
 					Level: INFO,
 					Args:  []any{deletedScope, securitySchemeName},
-				})
+				}.withSource(config, "/security", false))
 			}
 		}
 	}
@@ -62,7 +62,7 @@ func checkGlobalSecurity(diffReport *diff.Diff) Changes {
 func APISecurityUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
 	result := make(Changes, 0)
 
-	result = append(result, checkGlobalSecurity(diffReport)...)
+	result = append(result, checkGlobalSecurity(diffReport, config)...)
 
 	if diffReport.PathsDiff == nil || diffReport.PathsDiff.Modified == nil {
 		return result
@@ -109,7 +109,7 @@ func APISecurityUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.Oper
 					operationItem.Revision,
 					operation,
 					path,
-				))
+				).withDeletedSource(config, operationItem.Base, "/security"))
 			}
 
 			for _, updatedSecurity := range operationItem.SecurityDiff.Modified {
@@ -139,7 +139,7 @@ func APISecurityUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.Oper
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, operationItem.Base, "/security"))
 					}
 				}
 			}
@@ -44,7 +44,7 @@ func APISunsetChangedCheck(diffReport *diff.Diff, operationsSources *diff.Operat
 					opRevision,
 					operation,
 					path,
-				))
+				).withDeletedSource(config, opBase, "/"+diff.SunsetExtension))
 				continue
 			}
 
@@ -1,7 +1,10 @@
 package checker
 
 import (
+	"strconv"
+
 	"github.com/tufin/oasdiff/diff"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -37,7 +40,7 @@ func APITagUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.Operation
 					op,
 					operation,
 					path,
-				))
+				).withDeletedSource(config, op, "/tags/"+strconv.Itoa(slices.Index(op.Tags, tag))))
 			}
 
 			for _, tag := range operationItem.TagsDiff.Added {
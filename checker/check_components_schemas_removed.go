@@ -2,6 +2,7 @@ package checker
 
 import (
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
 )
 
 const (
@@ -21,7 +22,7 @@ func APIComponentsSchemaRemovedCheck(diffReport *diff.Diff, operationsSources *d
 			Level:     config.getLogLevel(APISchemasRemovedId),
 			Args:      []any{deletedSchema},
 			Component: ComponentSchemas,
-		})
+		}.withSource(config, "/components/schemas/"+load.EscapePointerToken(deletedSchema), true))
 	}
 	return result
 }
@@ -0,0 +1,27 @@
+package checker
+
+import (
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+const (
+	APISchemasRenamedId = "api-schema-renamed"
+)
+
+func APIComponentsSchemaRenamedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	if diffReport.ComponentsDiff.SchemasDiff == nil {
+		return result
+	}
+
+	for _, renamedSchema := range diffReport.ComponentsDiff.SchemasDiff.Renamed {
+		result = append(result, ComponentChange{
+			Id:        APISchemasRenamedId,
+			Level:     config.getLogLevel(APISchemasRenamedId),
+			Args:      []any{renamedSchema.From, renamedSchema.To},
+			Component: ComponentSchemas,
+		}.withSource(config, "/components/schemas/"+load.EscapePointerToken(renamedSchema.To), false))
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+)
+
+// CL: renaming a component schema
+func TestComponentSchemaRenamed(t *testing.T) {
+	s1, err := open("../data/checker/component_schema_renamed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/component_schema_renamed_revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.APIComponentsSchemaRenamedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 2)
+	require.Equal(t, checker.APISchemasRenamedId, errs[0].GetId())
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+	require.Equal(t, "renamed the schema 'Dog' to 'Canine'", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+	require.Equal(t, "renamed the schema 'Pet' to 'Animal'", errs[1].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// BC: renaming a component schema without changing its structure is not breaking
+func TestComponentSchemaRenamed_NotBreaking(t *testing.T) {
+	s1, err := open("../data/checker/component_schema_renamed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/component_schema_renamed_revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibility(allChecksConfig().WithOptionalCheck(checker.APISchemasRemovedId), d, osm)
+	require.Empty(t, errs)
+}
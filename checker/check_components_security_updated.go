@@ -2,6 +2,7 @@ package checker
 
 import (
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
 )
 
 const (
@@ -17,7 +18,7 @@ const (
 
 const ComponentSecuritySchemes = "securitySchemes"
 
-func checkOAuthUpdates(updatedSecurity *diff.SecuritySchemeDiff, updatedSecurityName string) Changes {
+func checkOAuthUpdates(config *Config, updatedSecurity *diff.SecuritySchemeDiff, updatedSecurityName string) Changes {
 	result := make(Changes, 0)
 
 	if updatedSecurity.OAuthFlowsDiff == nil {
@@ -34,7 +35,7 @@ func checkOAuthUpdates(updatedSecurity *diff.SecuritySchemeDiff, updatedSecurity
 			Level:     INFO,
 			Args:      []any{updatedSecurityName, urlDiff.From, urlDiff.To},
 			Component: ComponentSecuritySchemes,
-		})
+		}.withSource(config, securitySchemePointer(updatedSecurityName), false))
 	}
 
 	if tokenDiff := updatedSecurity.OAuthFlowsDiff.ImplicitDiff.TokenURLDiff; tokenDiff != nil {
@@ -43,7 +44,7 @@ func checkOAuthUpdates(updatedSecurity *diff.SecuritySchemeDiff, updatedSecurity
 			Level:     INFO,
 			Args:      []any{updatedSecurityName, tokenDiff.From, tokenDiff.To},
 			Component: ComponentSecuritySchemes,
-		})
+		}.withSource(config, securitySchemePointer(updatedSecurityName), false))
 	}
 
 	if scopesDiff := updatedSecurity.OAuthFlowsDiff.ImplicitDiff.ScopesDiff; scopesDiff != nil {
@@ -53,7 +54,7 @@ func checkOAuthUpdates(updatedSecurity *diff.SecuritySchemeDiff, updatedSecurity
 				Level:     INFO,
 				Args:      []any{updatedSecurityName, addedScope},
 				Component: ComponentSecuritySchemes,
-			})
+			}.withSource(config, securitySchemePointer(updatedSecurityName), false))
 		}
 
 		for _, removedScope := range scopesDiff.Deleted {
@@ -62,7 +63,7 @@ func checkOAuthUpdates(updatedSecurity *diff.SecuritySchemeDiff, updatedSecurity
 				Level:     INFO,
 				Args:      []any{updatedSecurityName, removedScope},
 				Component: ComponentSecuritySchemes,
-			})
+			}.withSource(config, securitySchemePointer(updatedSecurityName), false))
 		}
 
 		for name, modifiedScope := range scopesDiff.Modified {
@@ -71,7 +72,7 @@ func checkOAuthUpdates(updatedSecurity *diff.SecuritySchemeDiff, updatedSecurity
 				Level:     INFO,
 				Args:      []any{updatedSecurityName, name, modifiedScope.From, modifiedScope.To},
 				Component: ComponentSecuritySchemes,
-			})
+			}.withSource(config, securitySchemePointer(updatedSecurityName), false))
 		}
 
 	}
@@ -91,7 +92,7 @@ func APIComponentsSecurityUpdatedCheck(diffReport *diff.Diff, operationsSources
 			Level:     INFO,
 			Args:      []any{updatedSecurity},
 			Component: ComponentSecuritySchemes,
-		})
+		}.withSource(config, securitySchemePointer(updatedSecurity), false))
 	}
 
 	for _, updatedSecurity := range diffReport.ComponentsDiff.SecuritySchemesDiff.Deleted {
@@ -100,11 +101,11 @@ func APIComponentsSecurityUpdatedCheck(diffReport *diff.Diff, operationsSources
 			Level:     INFO,
 			Args:      []any{updatedSecurity},
 			Component: ComponentSecuritySchemes,
-		})
+		}.withSource(config, securitySchemePointer(updatedSecurity), true))
 	}
 
 	for updatedSecurityName, updatedSecurity := range diffReport.ComponentsDiff.SecuritySchemesDiff.Modified {
-		result = append(result, checkOAuthUpdates(updatedSecurity, updatedSecurityName)...)
+		result = append(result, checkOAuthUpdates(config, updatedSecurity, updatedSecurityName)...)
 
 		if updatedSecurity.TypeDiff != nil {
 			result = append(result, ComponentChange{
@@ -112,9 +113,13 @@ func APIComponentsSecurityUpdatedCheck(diffReport *diff.Diff, operationsSources
 				Level:     INFO,
 				Args:      []any{updatedSecurityName, updatedSecurity.TypeDiff.From, updatedSecurity.TypeDiff.To},
 				Component: ComponentSecuritySchemes,
-			})
+			}.withSource(config, securitySchemePointer(updatedSecurityName), false))
 		}
 	}
 
 	return result
 }
+
+func securitySchemePointer(name string) string {
+	return "/components/securitySchemes/" + load.EscapePointerToken(name)
+}
@@ -0,0 +1,317 @@
+package checker
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+// customRuleEvent is a single change in the diff that custom rules are matched against
+type customRuleEvent struct {
+	path     string // endpoint path, empty for components
+	method   string // endpoint method, empty for components
+	pointer  string // JSON pointer of the change within the operation, or under /components
+	action   string // add, remove or change
+	base     any
+	revision any
+}
+
+// name returns the last token of the pointer, typically the name of an added or removed element
+func (event customRuleEvent) name() string {
+	tokens := strings.Split(event.pointer, "/")
+	return unescapePointerToken(tokens[len(tokens)-1])
+}
+
+func (event customRuleEvent) tokens() []string {
+	return strings.Split(strings.TrimPrefix(event.pointer, "/"), "/")
+}
+
+// direction returns request or response according to the part of the operation that was changed, or an empty string
+func (event customRuleEvent) direction() string {
+	if event.path == "" {
+		return ""
+	}
+
+	switch event.tokens()[0] {
+	case "parameters", "requestBody":
+		return "request"
+	case "responses":
+		return "response"
+	}
+	return ""
+}
+
+// location returns the innermost location of the change, or an empty string
+func (event customRuleEvent) location() string {
+	tokens := event.tokens()
+	// the last token is the name of the changed element, and isn't a location
+	tokens = tokens[:len(tokens)-1]
+
+	for i := len(tokens) - 1; i >= 0; i-- {
+		switch tokens[i] {
+		case "properties":
+			return "properties"
+		case "headers":
+			return "headers"
+		}
+	}
+
+	if event.path == "" {
+		return "components"
+	}
+
+	switch event.tokens()[0] {
+	case "parameters":
+		return "parameters"
+	case "requestBody", "responses":
+		return "body"
+	case "securityRequirements":
+		return "security"
+	}
+	return ""
+}
+
+// CustomRulesCheck applies the custom rules from the configuration to the diff
+func CustomRulesCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	if len(config.CustomRules) == 0 {
+		return result
+	}
+
+	if diffReport.PathsDiff != nil {
+		for _, path := range sortedKeys(diffReport.PathsDiff.Modified) {
+			pathDiff := diffReport.PathsDiff.Modified[path]
+			if pathDiff.OperationsDiff == nil {
+				continue
+			}
+
+			for _, method := range pathDiff.OperationsDiff.Added {
+				result = append(result, applyCustomRules(config, operationsSources, pathDiff.Revision.GetOperation(method), customRuleEvent{path: path, method: method, action: "add"})...)
+			}
+
+			for _, method := range pathDiff.OperationsDiff.Deleted {
+				result = append(result, applyCustomRules(config, operationsSources, pathDiff.Base.GetOperation(method), customRuleEvent{path: path, method: method, action: "remove"})...)
+			}
+
+			for _, method := range sortedKeys(pathDiff.OperationsDiff.Modified) {
+				methodDiff := pathDiff.OperationsDiff.Modified[method]
+				for _, event := range getOperationEvents(path, method, methodDiff) {
+					result = append(result, applyCustomRules(config, operationsSources, methodDiff.Revision, event)...)
+				}
+			}
+		}
+
+		for _, path := range diffReport.PathsDiff.Added {
+			for _, method := range sortedKeys(diffReport.PathsDiff.Revision.Value(path).Operations()) {
+				result = append(result, applyCustomRules(config, operationsSources, diffReport.PathsDiff.Revision.Value(path).GetOperation(method), customRuleEvent{path: path, method: method, action: "add"})...)
+			}
+		}
+
+		for _, path := range diffReport.PathsDiff.Deleted {
+			for _, method := range sortedKeys(diffReport.PathsDiff.Base.Value(path).Operations()) {
+				result = append(result, applyCustomRules(config, operationsSources, diffReport.PathsDiff.Base.Value(path).GetOperation(method), customRuleEvent{path: path, method: method, action: "remove"})...)
+			}
+		}
+	}
+
+	for _, event := range getDiffEvents("/components", diffReport.ComponentsDiff) {
+		for _, rule := range config.CustomRules {
+			if !rule.matches(event) {
+				continue
+			}
+			tokens := event.tokens()
+			component := ""
+			if len(tokens) > 1 {
+				component = tokens[1]
+			}
+			result = append(result, ComponentChange{
+				Id:        rule.Id,
+				Level:     config.getLogLevel(rule.Id),
+				Args:      rule.getArgs(event),
+				Message:   rule.format,
+				Component: component,
+			}.withSource(config, event.pointer, event.action == "remove"))
+		}
+	}
+
+	return result
+}
+
+func applyCustomRules(config *Config, operationsSources *diff.OperationsSourcesMap, operation *openapi3.Operation, event customRuleEvent) Changes {
+	result := make(Changes, 0)
+	for _, rule := range config.CustomRules {
+		if !rule.matches(event) {
+			continue
+		}
+		change := NewApiChange(rule.Id, config, rule.getArgs(event), "", operationsSources, operation, event.method, event.path)
+		change.Message = rule.format
+		result = append(result, change)
+	}
+	return result
+}
+
+// getOperationEvents returns the changes of a modified operation
+// the values of added and removed extensions are taken from the operation, for other added and removed elements the value is their name
+func getOperationEvents(path, method string, methodDiff *diff.MethodDiff) []customRuleEvent {
+	result := getDiffEvents("", methodDiff)
+	for i := range result {
+		result[i].path = path
+		result[i].method = method
+
+		if !strings.HasPrefix(result[i].pointer, "/extensions/") || strings.Count(result[i].pointer, "/") != 2 {
+			continue
+		}
+		switch result[i].action {
+		case "add":
+			result[i].revision = getExtensionValue(methodDiff.Revision, result[i].name())
+		case "remove":
+			result[i].base = getExtensionValue(methodDiff.Base, result[i].name())
+		}
+	}
+	return result
+}
+
+func getExtensionValue(operation *openapi3.Operation, name string) any {
+	if operation == nil {
+		return nil
+	}
+
+	value, ok := operation.Extensions[name]
+	if !ok {
+		return name
+	}
+
+	if raw, ok := value.(json.RawMessage); ok {
+		var result any
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return name
+		}
+		return result
+	}
+	return value
+}
+
+// getDiffEvents flattens a diff into a list of changes by walking its JSON representation:
+//   - a from/to pair is a change
+//   - the items of added and deleted lists are additions and removals
+//   - the keys of modified maps are names of elements rather than diff fields, so they are added to the pointer as is
+//   - JSON patch operations, used for extensions, are converted to additions, removals and changes
+func getDiffEvents(pointer string, diffElement any) []customRuleEvent {
+	data, err := json.Marshal(diffElement)
+	if err != nil {
+		return nil
+	}
+
+	var node any
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil
+	}
+
+	result := []customRuleEvent{}
+	walkDiff(pointer, node, false, &result)
+	return result
+}
+
+func walkDiff(pointer string, node any, names bool, result *[]customRuleEvent) {
+	switch node := node.(type) {
+	case map[string]any:
+		if !names && isValueDiff(node) {
+			*result = append(*result, customRuleEvent{pointer: pointer, action: "change", base: node["from"], revision: node["to"]})
+			return
+		}
+
+		for _, key := range sortedKeys(node) {
+			child := node[key]
+			if names {
+				walkDiff(pointer+"/"+load.EscapePointerToken(key), child, false, result)
+				continue
+			}
+
+			switch key {
+			case "added":
+				walkNames(pointer, child, "add", result)
+			case "deleted":
+				walkNames(pointer, child, "remove", result)
+			case "modified", "mediaTypeModified":
+				walkDiff(pointer, child, true, result)
+			default:
+				walkDiff(pointer+"/"+load.EscapePointerToken(key), child, false, result)
+			}
+		}
+	case []any:
+		for _, item := range node {
+			if patch, ok := item.(map[string]any); ok {
+				addPatchEvent(pointer, patch, result)
+			}
+		}
+	}
+}
+
+// walkNames adds the items of an added or deleted list, which may be grouped in a map, like parameters which are grouped by location
+func walkNames(pointer string, node any, action string, result *[]customRuleEvent) {
+	switch node := node.(type) {
+	case []any:
+		for _, item := range node {
+			name := customRuleValueToString(item)
+			event := customRuleEvent{pointer: pointer + "/" + load.EscapePointerToken(name), action: action}
+			if action == "add" {
+				event.revision = item
+			} else {
+				event.base = item
+			}
+			*result = append(*result, event)
+		}
+	case map[string]any:
+		for _, key := range sortedKeys(node) {
+			walkNames(pointer+"/"+load.EscapePointerToken(key), node[key], action, result)
+		}
+	}
+}
+
+func addPatchEvent(pointer string, patch map[string]any, result *[]customRuleEvent) {
+	path, _ := patch["path"].(string)
+	event := customRuleEvent{pointer: pointer + path}
+
+	switch patch["op"] {
+	case "add":
+		event.action = "add"
+		event.revision = patch["value"]
+	case "remove":
+		event.action = "remove"
+		event.base = patch["oldValue"]
+	case "replace":
+		event.action = "change"
+		event.base = patch["oldValue"]
+		event.revision = patch["value"]
+	default:
+		return
+	}
+
+	*result = append(*result, event)
+}
+
+func isValueDiff(node map[string]any) bool {
+	if len(node) != 2 {
+		return false
+	}
+	_, from := node["from"]
+	_, to := node["to"]
+	return from && to
+}
+
+func unescapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
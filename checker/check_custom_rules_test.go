@@ -0,0 +1,174 @@
+package checker_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+func getCustomRulesChanges(t *testing.T, config *checker.Config) checker.Changes {
+	t.Helper()
+
+	s1, err := open("../data/custom-rules/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/custom-rules/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	return checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.INFO)
+}
+
+func loadCustomRules(t *testing.T) checker.CustomRules {
+	t.Helper()
+
+	rules, err := checker.LoadCustomRules("../data/custom-rules/rules.yaml")
+	require.NoError(t, err)
+	return rules
+}
+
+// BC: a change which is matched by a custom rule with the error level is breaking
+func TestCustomRules(t *testing.T) {
+	config := checker.NewConfig(checker.BackwardCompatibilityChecks{}).WithCustomRules(loadCustomRules(t))
+	errs := getCustomRulesChanges(t, config)
+	require.Len(t, errs, 4)
+
+	l := checker.NewDefaultLocalizer()
+	texts := []string{}
+	for _, err := range errs {
+		texts = append(texts, err.GetId()+": "+err.GetUncolorizedText(l))
+	}
+	require.ElementsMatch(t, []string{
+		"public-endpoint-audience-changed: endpoint is no longer public, x-audience changed from 'public' to 'internal'",
+		"public-endpoint-audience-changed: endpoint is no longer public, x-audience changed from 'public' to 'undefined'",
+		"billing-response-header-removed: removed the response header 'X-Rate-Limit'",
+		"schema-property-removed: removed the property 'total' from '/components/schemas/Invoice/properties/total'",
+	}, texts)
+
+	require.Contains(t, errs, checker.ApiChange{
+		Id:          "billing-response-header-removed",
+		Args:        []any{"X-Rate-Limit"},
+		Message:     "removed the response header %s",
+		Level:       checker.ERR,
+		Operation:   "GET",
+		Path:        "/billing/invoices",
+		Source:      load.NewSource("../data/custom-rules/revision.yaml"),
+		OperationId: "listInvoices",
+	})
+}
+
+// custom rules are only applied when they are added to the configuration
+func TestCustomRules_NotConfigured(t *testing.T) {
+	errs := getCustomRulesChanges(t, singleCheckConfig(checker.CustomRulesCheck))
+	require.Empty(t, errs)
+}
+
+func TestCustomRules_SeverityLevels(t *testing.T) {
+	rules := loadCustomRules(t)
+
+	severityLevels, err := checker.ProcessSeverityLevels("../data/custom-rules/severity-levels.txt", rules...)
+	require.NoError(t, err)
+
+	config := checker.NewConfig(checker.BackwardCompatibilityChecks{}).WithCustomRules(rules).WithSeverityLevels(severityLevels)
+	for _, err := range getCustomRulesChanges(t, config) {
+		if err.GetId() == "public-endpoint-audience-changed" {
+			require.Equal(t, checker.WARN, err.GetLevel())
+		}
+	}
+}
+
+func TestCustomRules_SeverityLevelsWithoutRules(t *testing.T) {
+	_, err := checker.ProcessSeverityLevels("../data/custom-rules/severity-levels.txt")
+	require.EqualError(t, err, "invalid rule id \"public-endpoint-audience-changed\" on line 1")
+}
+
+func TestCustomRules_Ignore(t *testing.T) {
+	errs := getCustomRulesChanges(t, checker.NewConfig(checker.BackwardCompatibilityChecks{}).WithCustomRules(loadCustomRules(t)))
+	require.Len(t, errs, 4)
+
+	errs, err := checker.ProcessIgnoredBackwardCompatibilityErrors(checker.ERR, errs, "../data/custom-rules/ignore-err.txt", checker.NewDefaultLocalizer())
+	require.NoError(t, err)
+	require.Len(t, errs, 3)
+	for _, err := range errs {
+		require.NotEqual(t, "billing-response-header-removed", err.GetId())
+	}
+}
+
+func TestCustomRules_Rules(t *testing.T) {
+	rules := loadCustomRules(t).Rules()
+	require.Len(t, rules, 3)
+	require.Equal(t, "billing-response-header-removed", rules[1].Id)
+	require.Equal(t, checker.ERR, rules[1].Level)
+	require.Equal(t, "removed the response header {name}", rules[1].Description)
+	require.Equal(t, checker.DirectionResponse, rules[1].Direction)
+	require.Equal(t, checker.LocationHeaders, rules[1].Location)
+	require.Equal(t, checker.ActionRemove, rules[1].Action)
+}
+
+func TestCustomRules_Conditions(t *testing.T) {
+	rules, err := checker.ParseCustomRules(strings.NewReader(`
+rules:
+  - id: audience-became-internal
+    level: warn
+    message: audience changed to {revision}
+    match:
+      pointer: /extensions/x-audience
+      action: change
+      revision:
+        matches: ^int
+  - id: audience-removed-from-internal
+    level: warn
+    message: audience {base} removed
+    match:
+      pointer: /extensions/x-audience
+      base:
+        equals: internal
+      revision:
+        exists: false
+`))
+	require.NoError(t, err)
+
+	errs := getCustomRulesChanges(t, checker.NewConfig(checker.BackwardCompatibilityChecks{}).WithCustomRules(rules))
+	require.Len(t, errs, 2)
+
+	l := checker.NewDefaultLocalizer()
+	require.ElementsMatch(t, []string{"GET /billing/invoices audience changed to 'internal'", "GET /users audience 'internal' removed"}, []string{
+		errs[0].GetOperation() + " " + errs[0].GetPath() + " " + errs[0].GetUncolorizedText(l),
+		errs[1].GetOperation() + " " + errs[1].GetPath() + " " + errs[1].GetUncolorizedText(l),
+	})
+}
+
+func TestParseCustomRules_Invalid(t *testing.T) {
+	tests := []struct {
+		rules string
+		err   string
+	}{
+		{"rules:\n  - level: err\n    message: m", `invalid custom rule #1 "": missing id`},
+		{"rules:\n  - id: x\n    level: high\n    message: m", `invalid custom rule #1 "x": invalid level high`},
+		{"rules:\n  - id: x\n    level: err", `invalid custom rule #1 "x": missing message`},
+		{"rules:\n  - id: x\n    level: err\n    message: '{value}'", `invalid custom rule #1 "x": unknown placeholder {value} in message, supported placeholders: path, method, pointer, name, base, revision`},
+		{"rules:\n  - id: x\n    level: err\n    message: m\n    match:\n      action: rename", `invalid custom rule #1 "x": invalid action "rename"`},
+		{"rules:\n  - id: x\n    level: err\n    message: m\n    match:\n      base:\n        matches: '['", "invalid custom rule #1 \"x\": invalid base condition: error parsing regexp: missing closing ]: `[`"},
+		{"rules:\n  - id: api-path-removed-without-deprecation\n    level: err\n    message: m", `invalid custom rule #1 "api-path-removed-without-deprecation": id is already used by a built-in rule`},
+		{"rules:\n  - id: x\n    level: err\n    message: m\n  - id: x\n    level: warn\n    message: m", `invalid custom rule #2 "x": duplicate id`},
+	}
+
+	for _, test := range tests {
+		_, err := checker.ParseCustomRules(strings.NewReader(test.rules))
+		require.EqualError(t, err, test.err)
+	}
+}
+
+func TestParseCustomRules_UnknownField(t *testing.T) {
+	_, err := checker.ParseCustomRules(strings.NewReader("rules:\n  - id: x\n    level: err\n    message: m\n    when: {}"))
+	require.Error(t, err)
+}
+
+func TestLoadCustomRules_InvalidFile(t *testing.T) {
+	_, err := checker.LoadCustomRules("../data/custom-rules/missing.yaml")
+	require.Error(t, err)
+}
@@ -44,7 +44,7 @@ func NewRequestNonPathParameterCheck(diffReport *diff.Diff, operationsSources *d
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, param.Value))
 							break
 						}
 					}
@@ -51,7 +51,7 @@ func NewRequiredRequestHeaderPropertyCheck(diffReport *diff.Diff, operationsSour
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, newProperty))
 						})
 				}
 			}
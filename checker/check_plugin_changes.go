@@ -0,0 +1,54 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/diff"
+)
+
+// PluginChangesCheck reports the changes which were returned by check plugins, see Config.WithPluginChanges
+func PluginChangesCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+
+	for _, pluginChange := range config.PluginChanges {
+		// plugin texts are already rendered, so they are used as message templates without placeholders
+		message := strings.ReplaceAll(pluginChange.Text, "%", "%%")
+		level := config.getPluginLevel(pluginChange)
+
+		if pluginChange.Path == "" {
+			result = append(result, ComponentChange{
+				Id:        pluginChange.Id,
+				Level:     level,
+				Message:   message,
+				Comment:   pluginChange.Comment,
+				Component: pluginChange.Component,
+			})
+			continue
+		}
+
+		change := newApiChangeWithLevel(pluginChange.Id, level, config, nil, pluginChange.Comment, operationsSources, findOperation(diffReport, pluginChange.Path, pluginChange.Operation), pluginChange.Operation, pluginChange.Path)
+		change.Message = message
+		result = append(result, change)
+	}
+
+	return result
+}
+
+// findOperation returns the operation in the revision, or in the base for deleted operations
+// an empty operation is returned if the operation doesn't exist in either spec
+func findOperation(diffReport *diff.Diff, path, method string) *openapi3.Operation {
+	if diffReport.PathsDiff != nil {
+		for _, paths := range []*openapi3.Paths{diffReport.PathsDiff.Revision, diffReport.PathsDiff.Base} {
+			if paths == nil {
+				continue
+			}
+			if pathItem := paths.Value(path); pathItem != nil {
+				if operation := pathItem.GetOperation(method); operation != nil {
+					return operation
+				}
+			}
+		}
+	}
+	return &openapi3.Operation{}
+}
@@ -0,0 +1,142 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+var pluginChanges = checker.PluginChanges{
+	{Id: "audience-changed", Level: "err", Path: "/billing/invoices", Operation: "GET", Text: "the audience of the endpoint changed, 100% breaking"},
+	{Id: "schemas-reviewed", Level: "info", Component: "schemas", Text: "component schemas were reviewed"},
+}
+
+func withPluginChanges(t *testing.T, config *checker.Config, changes checker.PluginChanges) *checker.Config {
+	t.Helper()
+
+	config, err := config.WithPluginChanges(changes)
+	require.NoError(t, err)
+	return config
+}
+
+// BC: a change which is reported by a plugin with the error level is breaking
+func TestPluginChanges(t *testing.T) {
+	config := withPluginChanges(t, checker.NewConfig(checker.BackwardCompatibilityChecks{}), pluginChanges)
+	errs := getCustomRulesChanges(t, config)
+	require.ElementsMatch(t, checker.Changes{
+		checker.ApiChange{
+			Id:          "audience-changed",
+			Message:     "the audience of the endpoint changed, 100%% breaking",
+			Level:       checker.ERR,
+			Operation:   "GET",
+			Path:        "/billing/invoices",
+			Source:      load.NewSource("../data/custom-rules/revision.yaml"),
+			OperationId: "listInvoices",
+		},
+		checker.ComponentChange{
+			Id:        "schemas-reviewed",
+			Message:   "component schemas were reviewed",
+			Level:     checker.INFO,
+			Component: "schemas",
+		},
+	}, errs)
+	require.Equal(t, "the audience of the endpoint changed, 100% breaking", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+func TestPluginChanges_SeverityLevels(t *testing.T) {
+	config := withPluginChanges(t, checker.NewConfig(checker.BackwardCompatibilityChecks{}), pluginChanges).WithSeverityLevels(map[string]checker.Level{
+		"audience-changed": checker.WARN,
+	})
+	errs := getCustomRulesChanges(t, config)
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		if err.GetId() == "audience-changed" {
+			require.Equal(t, checker.WARN, err.GetLevel())
+		}
+	}
+}
+
+func TestPluginChanges_Levels(t *testing.T) {
+	config := withPluginChanges(t, checker.NewConfig(checker.BackwardCompatibilityChecks{}), checker.PluginChanges{
+		{Id: "audience-changed", Level: "warn", Path: "/billing/invoices", Operation: "GET", Text: "the audience of the endpoint changed"},
+		{Id: "audience-changed", Level: "err", Component: "schemas", Text: "the audience of a schema changed"},
+	})
+	errs := getCustomRulesChanges(t, config)
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		if err.GetPath() == "" {
+			require.Equal(t, checker.ERR, err.GetLevel())
+		} else {
+			require.Equal(t, checker.WARN, err.GetLevel())
+		}
+	}
+}
+
+func TestPluginChanges_LevelsUntilLevel(t *testing.T) {
+	config := withPluginChanges(t, checker.NewConfig(checker.BackwardCompatibilityChecks{}), checker.PluginChanges{
+		{Id: "audience-changed", Level: "warn", Path: "/billing/invoices", Operation: "GET", Text: "the audience of the endpoint changed"},
+		{Id: "audience-changed", Level: "err", Component: "schemas", Text: "the audience of a schema changed"},
+	})
+	s1, err := open("../data/custom-rules/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/custom-rules/revision.yaml")
+	require.NoError(t, err)
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	errs := checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.ERR)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+}
+
+func TestPluginChanges_UnknownOperation(t *testing.T) {
+	config := withPluginChanges(t, checker.NewConfig(checker.BackwardCompatibilityChecks{}), checker.PluginChanges{
+		{Id: "unknown-operation", Level: "warn", Path: "/unknown", Operation: "GET", Text: "unknown"},
+	})
+	errs := getCustomRulesChanges(t, config)
+	require.Len(t, errs, 1)
+	require.Equal(t, "/unknown", errs[0].GetPath())
+	require.Empty(t, errs[0].GetOperationId())
+}
+
+func TestPluginChanges_BuiltInId(t *testing.T) {
+	_, err := checker.NewConfig(checker.GetAllChecks()).WithPluginChanges(checker.PluginChanges{
+		{Id: "api-path-removed-without-deprecation", Level: "err", Text: "a path was removed"},
+	})
+	require.EqualError(t, err, `invalid change "api-path-removed-without-deprecation": id is already used by a built-in rule`)
+}
+
+func TestPluginChanges_CustomRuleId(t *testing.T) {
+	_, err := checker.NewConfig(checker.BackwardCompatibilityChecks{}).WithCustomRules(loadCustomRules(t)).WithPluginChanges(checker.PluginChanges{
+		{Id: "schema-property-removed", Level: "err", Text: "a property was removed"},
+	})
+	require.EqualError(t, err, `invalid change "schema-property-removed": id is already used by a custom rule`)
+}
+
+func TestPluginChanges_SeveralPlugins(t *testing.T) {
+	config := withPluginChanges(t, checker.NewConfig(checker.BackwardCompatibilityChecks{}), pluginChanges[:1])
+	config = withPluginChanges(t, config, pluginChanges[1:])
+	require.Len(t, getCustomRulesChanges(t, config), 2)
+}
+
+func TestNewPluginRequest(t *testing.T) {
+	s1, err := open("../data/custom-rules/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/custom-rules/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	require.NotNil(t, d.EndpointsDiff)
+
+	request := checker.NewPluginRequest(d, osm, s1.Spec, s2.Spec)
+	require.Equal(t, checker.PluginProtocolVersion, request.Version)
+	require.Nil(t, request.Diff.EndpointsDiff)
+	require.NotNil(t, d.EndpointsDiff)
+	require.Equal(t, d.PathsDiff, request.Diff.PathsDiff)
+	require.Equal(t, "../data/custom-rules/base.yaml", request.OperationsSources.Base["/billing/payments"]["POST"])
+	require.Equal(t, "../data/custom-rules/revision.yaml", request.OperationsSources.Revision["/users"]["GET"])
+}
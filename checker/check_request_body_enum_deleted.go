@@ -49,7 +49,7 @@ func RequestBodyEnumValueRemovedCheck(diffReport *diff.Diff, operationsSources *
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withDeletedSource(config, mediaTypeItem.SchemaDiff.Base, enumValuePointer(mediaTypeItem.SchemaDiff.Base, enumVal)))
 				}
 			}
 		}
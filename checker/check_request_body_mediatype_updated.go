@@ -2,6 +2,7 @@ package checker
 
 import (
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
 )
 
 const (
@@ -50,7 +51,7 @@ func RequestBodyMediaTypeChangedCheck(diffReport *diff.Diff, operationsSources *
 					operationItem.Revision,
 					operation,
 					path,
-				))
+				).withDeletedSource(config, operationItem.Base, "/requestBody/content/"+load.EscapePointerToken(mediaType)))
 			}
 		}
 	}
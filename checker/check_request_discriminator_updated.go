@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tufin/oasdiff/diff"
 )
 
@@ -50,23 +51,38 @@ func RequestDiscriminatorUpdatedCheck(diffReport *diff.Diff, operationsSources *
 				))
 			}
 
+			appendDeletedItem := func(base *openapi3.Schema, pointer string, messageId string, a ...any) {
+				result = append(result, NewApiChange(
+					messageId,
+					config,
+					a,
+					"",
+					operationsSources,
+					operationItem.Revision,
+					operation,
+					path,
+				).withDeletedSource(config, base, pointer))
+			}
+
 			for _, mediaTypeDiff := range operationItem.RequestBodyDiff.ContentDiff.MediaTypeModified {
 				if mediaTypeDiff.SchemaDiff == nil {
 					continue
 				}
 
 				processDiscriminatorDiffForRequest(
-					mediaTypeDiff.SchemaDiff.DiscriminatorDiff,
+					mediaTypeDiff.SchemaDiff,
 					"",
-					appendResultItem)
+					appendResultItem,
+					appendDeletedItem)
 
 				CheckModifiedPropertiesDiff(
 					mediaTypeDiff.SchemaDiff,
 					func(propertyPath string, propertyName string, propertyDiff *diff.SchemaDiff, parent *diff.SchemaDiff) {
 						processDiscriminatorDiffForRequest(
-							propertyDiff.DiscriminatorDiff,
+							propertyDiff,
 							propertyFullName(propertyPath, propertyName),
-							appendResultItem)
+							appendResultItem,
+							appendDeletedItem)
 					})
 
 			}
@@ -76,10 +92,12 @@ func RequestDiscriminatorUpdatedCheck(diffReport *diff.Diff, operationsSources *
 }
 
 func processDiscriminatorDiffForRequest(
-	discriminatorDiff *diff.DiscriminatorDiff,
+	schemaDiff *diff.SchemaDiff,
 	propertyName string,
-	appendResultItem func(messageId string, a ...any)) {
+	appendResultItem func(messageId string, a ...any),
+	appendDeletedItem func(base *openapi3.Schema, pointer string, messageId string, a ...any)) {
 
+	discriminatorDiff := schemaDiff.DiscriminatorDiff
 	if discriminatorDiff == nil {
 		return
 	}
@@ -99,9 +117,9 @@ func processDiscriminatorDiffForRequest(
 	}
 	if discriminatorDiff.Deleted {
 		if propertyName == "" {
-			appendResultItem(messageIdPrefix + "-removed")
+			appendDeletedItem(schemaDiff.Base, "/discriminator", messageIdPrefix+"-removed")
 		} else {
-			appendResultItem(messageIdPrefix+"-removed", propertyName)
+			appendDeletedItem(schemaDiff.Base, "/discriminator", messageIdPrefix+"-removed", propertyName)
 		}
 		return
 	}
@@ -133,10 +151,10 @@ func processDiscriminatorDiffForRequest(
 
 		if len(discriminatorDiff.MappingDiff.Deleted) > 0 {
 			if propertyName == "" {
-				appendResultItem(messageIdPrefix+"-mapping-deleted",
+				appendDeletedItem(schemaDiff.Base, "/discriminator/mapping", messageIdPrefix+"-mapping-deleted",
 					discriminatorDiff.MappingDiff.Deleted)
 			} else {
-				appendResultItem(messageIdPrefix+"-mapping-deleted",
+				appendDeletedItem(schemaDiff.Base, "/discriminator/mapping", messageIdPrefix+"-mapping-deleted",
 					discriminatorDiff.MappingDiff.Deleted,
 					propertyName)
 			}
@@ -44,7 +44,7 @@ func RequestHeaderPropertyBecameEnumCheck(diffReport *diff.Diff, operationsSourc
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, paramDiff.Revision))
 					}
 
 					CheckModifiedPropertiesDiff(
@@ -64,7 +64,7 @@ func RequestHeaderPropertyBecameEnumCheck(diffReport *diff.Diff, operationsSourc
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, propertyDiff.Revision))
 						})
 				}
 			}
@@ -57,7 +57,7 @@ func RequestHeaderPropertyBecameRequiredCheck(diffReport *diff.Diff, operationsS
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, paramDiff.Revision))
 						}
 					}
 
@@ -84,7 +84,7 @@ func RequestHeaderPropertyBecameRequiredCheck(diffReport *diff.Diff, operationsS
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withSource(config, propertyDiff.Revision))
 							}
 						})
 				}
@@ -43,7 +43,7 @@ func RequestParameterBecameEnumCheck(diffReport *diff.Diff, operationsSources *d
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withSource(config, paramItem.Revision))
 				}
 			}
 		}
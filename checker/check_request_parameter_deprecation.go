@@ -53,7 +53,7 @@ func RequestParameterDeprecationCheck(diffReport *diff.Diff, operationsSources *
 							op,
 							operation,
 							path,
-						))
+						).withSource(config, paramItem.Revision))
 						continue
 					}
 
@@ -85,7 +85,7 @@ func RequestParameterDeprecationCheck(diffReport *diff.Diff, operationsSources *
 							op,
 							operation,
 							path,
-						))
+						).withSource(config, paramItem.Revision))
 						continue
 					}
 
@@ -101,7 +101,7 @@ func RequestParameterDeprecationCheck(diffReport *diff.Diff, operationsSources *
 							op,
 							operation,
 							path,
-						))
+						).withSource(config, paramItem.Revision))
 						continue
 					}
 
@@ -115,7 +115,7 @@ func RequestParameterDeprecationCheck(diffReport *diff.Diff, operationsSources *
 						op,
 						operation,
 						path,
-					))
+					).withSource(config, paramItem.Revision))
 				}
 			}
 		}
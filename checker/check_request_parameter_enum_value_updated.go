@@ -44,7 +44,7 @@ func RequestParameterEnumValueUpdatedCheck(diffReport *diff.Diff, operationsSour
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, paramItem.SchemaDiff.Base, enumValuePointer(paramItem.SchemaDiff.Base, enumVal)))
 					}
 					for _, enumVal := range enumDiff.Added {
 						result = append(result, NewApiChange(
@@ -56,7 +56,7 @@ func RequestParameterEnumValueUpdatedCheck(diffReport *diff.Diff, operationsSour
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, paramItem.Revision))
 					}
 				}
 			}
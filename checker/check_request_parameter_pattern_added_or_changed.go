@@ -48,7 +48,7 @@ func RequestParameterPatternAddedOrChangedCheck(diffReport *diff.Diff, operation
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, paramItem.Revision))
 					} else if patternDiff.To == "" {
 						result = append(result, NewApiChange(
 							RequestParameterPatternRemovedId,
@@ -59,7 +59,7 @@ func RequestParameterPatternAddedOrChangedCheck(diffReport *diff.Diff, operation
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, paramItem.SchemaDiff.Base, "/pattern"))
 					} else {
 						id := RequestParameterPatternChangedId
 						comment := PatternChangedCommentId
@@ -78,7 +78,7 @@ func RequestParameterPatternAddedOrChangedCheck(diffReport *diff.Diff, operation
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, paramItem.Revision))
 					}
 				}
 			}
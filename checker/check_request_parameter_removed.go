@@ -61,7 +61,7 @@ func checkParameterRemoval(opInfo opInfo, param *openapi3.Parameter) Change {
 			opInfo.operation,
 			opInfo.method,
 			opInfo.path,
-		)
+		).withSource(opInfo.config, param)
 	}
 
 	sunset, ok := getSunset(param.Extensions)
@@ -75,7 +75,7 @@ func checkParameterRemoval(opInfo opInfo, param *openapi3.Parameter) Change {
 			opInfo.operation,
 			opInfo.method,
 			opInfo.path,
-		)
+		).withSource(opInfo.config, param)
 	}
 
 	date, err := getSunsetDate(sunset)
@@ -93,7 +93,7 @@ func checkParameterRemoval(opInfo opInfo, param *openapi3.Parameter) Change {
 			opInfo.operation,
 			opInfo.method,
 			opInfo.path,
-		)
+		).withSource(opInfo.config, param)
 	}
 	return nil
 }
@@ -108,5 +108,5 @@ func getRequestParameterSunsetParse(opInfo opInfo, param *openapi3.Parameter, er
 		opInfo.operation,
 		opInfo.method,
 		opInfo.path,
-	)
+	).withSource(opInfo.config, param)
 }
@@ -47,7 +47,7 @@ func RequestParameterRequiredValueUpdatedCheck(diffReport *diff.Diff, operations
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withSource(config, paramItem.Revision))
 				}
 			}
 		}
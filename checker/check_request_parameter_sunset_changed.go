@@ -54,7 +54,7 @@ func RequestParameterSunsetChangedCheck(diffReport *diff.Diff, operationsSources
 							opRevision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, paramItem.Base, "/"+diff.SunsetExtension))
 						continue
 					}
 
@@ -96,7 +96,7 @@ func RequestParameterSunsetChangedCheck(diffReport *diff.Diff, operationsSources
 							opRevision,
 							operation,
 							path,
-						))
+						).withSource(config, paramItem.Revision))
 					}
 				}
 			}
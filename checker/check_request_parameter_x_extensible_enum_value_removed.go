@@ -1,6 +1,8 @@
 package checker
 
 import (
+	"strconv"
+
 	"github.com/tufin/oasdiff/diff"
 	"golang.org/x/exp/slices"
 )
@@ -75,7 +77,7 @@ func RequestParameterXExtensibleEnumValueRemovedCheck(diffReport *diff.Diff, ope
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, paramItem.SchemaDiff.Base, "/"+diff.XExtensibleEnumExtension+"/"+strconv.Itoa(slices.Index(fromSlice, enumVal))))
 					}
 				}
 			}
@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tufin/oasdiff/diff"
 )
 
@@ -35,6 +36,19 @@ func RequestParameterDefaultValueChangedCheck(diffReport *diff.Diff, operationsS
 					path,
 				))
 			}
+
+			appendDeletedItem := func(base *openapi3.Schema, pointer string, messageId string, a ...any) {
+				result = append(result, NewApiChange(
+					messageId,
+					config,
+					a,
+					"",
+					operationsSources,
+					operationItem.Revision,
+					operation,
+					path,
+				).withDeletedSource(config, base, pointer))
+			}
 			for paramLocation, paramDiffs := range operationItem.ParametersDiff.Modified {
 				for paramName, paramDiff := range paramDiffs {
 
@@ -60,7 +74,7 @@ func RequestParameterDefaultValueChangedCheck(diffReport *diff.Diff, operationsS
 					if defaultValueDiff.From == nil {
 						appendResultItem(RequestParameterDefaultValueAddedId, paramLocation, paramName, defaultValueDiff.To)
 					} else if defaultValueDiff.To == nil {
-						appendResultItem(RequestParameterDefaultValueRemovedId, paramLocation, paramName, defaultValueDiff.From)
+						appendDeletedItem(paramDiff.SchemaDiff.Base, "/default", RequestParameterDefaultValueRemovedId, paramLocation, paramName, defaultValueDiff.From)
 					} else {
 						appendResultItem(RequestParameterDefaultValueChangedId, paramLocation, paramName, defaultValueDiff.From, defaultValueDiff.To)
 					}
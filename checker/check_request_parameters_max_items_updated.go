@@ -50,7 +50,7 @@ func RequestParameterMaxItemsUpdatedCheck(diffReport *diff.Diff, operationsSourc
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withSource(config, paramDiff.Revision))
 				}
 			}
 		}
@@ -44,7 +44,7 @@ func RequestParameterMaxLengthSetCheck(diffReport *diff.Diff, operationsSources
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withSource(config, paramDiff.Revision))
 				}
 			}
 		}
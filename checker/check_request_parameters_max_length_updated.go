@@ -50,7 +50,7 @@ func RequestParameterMaxLengthUpdatedCheck(diffReport *diff.Diff, operationsSour
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withSource(config, paramDiff.Revision))
 				}
 			}
 		}
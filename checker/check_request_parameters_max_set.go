@@ -44,7 +44,7 @@ func RequestParameterMaxSetCheck(diffReport *diff.Diff, operationsSources *diff.
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withSource(config, paramDiff.Revision))
 				}
 			}
 		}
@@ -51,7 +51,7 @@ func RequestParameterMaxUpdatedCheck(diffReport *diff.Diff, operationsSources *d
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withSource(config, paramDiff.Revision))
 				}
 			}
 		}
@@ -54,7 +54,7 @@ func RequestParameterTypeChangedCheck(diffReport *diff.Diff, operationsSources *
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, paramDiff.Revision))
 					}
 
 					CheckModifiedPropertiesDiff(
@@ -78,7 +78,7 @@ func RequestParameterTypeChangedCheck(diffReport *diff.Diff, operationsSources *
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withSource(config, propertyDiff.Revision))
 							}
 						})
 				}
@@ -36,7 +36,7 @@ func NewRequestPathParameterCheck(diffReport *diff.Diff, operationsSources *diff
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withSource(config, operationItem.Revision.Parameters.GetByInAndName(paramLocation, paramName)))
 				}
 			}
 		}
@@ -58,7 +58,7 @@ func RequestPropertyAllOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withDeletedSource(config, mediaTypeDiff.SchemaDiff.Base, subschemaPointer("allOf", mediaTypeDiff.SchemaDiff.AllOfDiff.Deleted)))
 				}
 
 				CheckModifiedPropertiesDiff(
@@ -80,7 +80,7 @@ func RequestPropertyAllOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, propertyDiff.Revision))
 						}
 
 						if len(propertyDiff.AllOfDiff.Deleted) > 0 {
@@ -93,7 +93,7 @@ func RequestPropertyAllOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withDeletedSource(config, propertyDiff.Base, subschemaPointer("allOf", propertyDiff.AllOfDiff.Deleted)))
 						}
 					})
 			}
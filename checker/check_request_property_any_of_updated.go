@@ -58,7 +58,7 @@ func RequestPropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withDeletedSource(config, mediaTypeDiff.SchemaDiff.Base, subschemaPointer("anyOf", mediaTypeDiff.SchemaDiff.AnyOfDiff.Deleted)))
 				}
 
 				CheckModifiedPropertiesDiff(
@@ -80,7 +80,7 @@ func RequestPropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, propertyDiff.Revision))
 						}
 
 						if len(propertyDiff.AnyOfDiff.Deleted) > 0 {
@@ -93,7 +93,7 @@ func RequestPropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withDeletedSource(config, propertyDiff.Base, subschemaPointer("anyOf", propertyDiff.AnyOfDiff.Deleted)))
 						}
 					})
 			}
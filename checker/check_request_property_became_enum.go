@@ -47,7 +47,7 @@ func RequestPropertyBecameEnumCheck(diffReport *diff.Diff, operationsSources *di
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, propertyDiff.Revision))
 					})
 			}
 		}
@@ -0,0 +1,95 @@
+package checker
+
+import (
+	"github.com/tufin/oasdiff/diff"
+)
+
+const (
+	RequestBodyConstAddedId       = "request-body-const-added"
+	RequestBodyConstChangedId     = "request-body-const-changed"
+	RequestBodyConstRemovedId     = "request-body-const-removed"
+	RequestPropertyConstAddedId   = "request-property-const-added"
+	RequestPropertyConstChangedId = "request-property-const-changed"
+	RequestPropertyConstRemovedId = "request-property-const-removed"
+)
+
+// RequestPropertyConstUpdatedCheck checks the JSON Schema 2020-12 const keyword of request bodies and their properties
+// Adding or changing a const value restricts the values that clients may send
+func RequestPropertyConstUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	if diffReport.PathsDiff == nil {
+		return result
+	}
+	for path, pathItem := range diffReport.PathsDiff.Modified {
+		if pathItem.OperationsDiff == nil {
+			continue
+		}
+		for operation, operationItem := range pathItem.OperationsDiff.Modified {
+			if operationItem.RequestBodyDiff == nil ||
+				operationItem.RequestBodyDiff.ContentDiff == nil ||
+				operationItem.RequestBodyDiff.ContentDiff.MediaTypeModified == nil {
+				continue
+			}
+
+			newChange := func(messageId string, a ...any) ApiChange {
+				return NewApiChange(
+					messageId,
+					config,
+					a,
+					"",
+					operationsSources,
+					operationItem.Revision,
+					operation,
+					path,
+				)
+			}
+
+			modifiedMediaTypes := operationItem.RequestBodyDiff.ContentDiff.MediaTypeModified
+			for mediaType, mediaTypeDiff := range modifiedMediaTypes {
+				if mediaTypeDiff.SchemaDiff == nil {
+					continue
+				}
+
+				if constDiff := mediaTypeDiff.SchemaDiff.ConstDiff; constDiff != nil {
+					if constDiff.Added {
+						result = append(result, newChange(RequestBodyConstAddedId, mediaType, getConstArg(constDiff.To)))
+					} else if constDiff.Deleted {
+						result = append(result, newChange(RequestBodyConstRemovedId, mediaType, getConstArg(constDiff.From)))
+					} else {
+						result = append(result, newChange(RequestBodyConstChangedId, mediaType, getConstArg(constDiff.From), getConstArg(constDiff.To)))
+					}
+				}
+
+				CheckModifiedPropertiesDiff(
+					mediaTypeDiff.SchemaDiff,
+					func(propertyPath string, propertyName string, propertyDiff *diff.SchemaDiff, parent *diff.SchemaDiff) {
+						constDiff := propertyDiff.ConstDiff
+						if constDiff == nil {
+							return
+						}
+
+						propName := propertyFullName(propertyPath, propertyName)
+
+						var change ApiChange
+						if constDiff.Added {
+							change = newChange(RequestPropertyConstAddedId, propName, getConstArg(constDiff.To))
+						} else if constDiff.Deleted {
+							change = newChange(RequestPropertyConstRemovedId, propName, getConstArg(constDiff.From))
+						} else {
+							change = newChange(RequestPropertyConstChangedId, propName, getConstArg(constDiff.From), getConstArg(constDiff.To))
+						}
+						result = append(result, change.withSource(config, propertyDiff.Revision))
+					})
+			}
+		}
+	}
+	return result
+}
+
+// getConstArg returns a const value as an argument of a change, a const value of null is shown as null rather than as undefined
+func getConstArg(value any) any {
+	if value == nil {
+		return "null"
+	}
+	return value
+}
@@ -0,0 +1,121 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+// BC: restricting a request property to a const value is breaking
+func TestRequestPropertyConstAdded(t *testing.T) {
+	s1, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyConstUpdatedCheck), d, osm, checker.INFO)
+	require.Equal(t, checker.Changes{
+		checker.ApiChange{
+			Id:          checker.RequestPropertyConstAddedId,
+			Args:        []any{"kind", "standard"},
+			Level:       checker.ERR,
+			Operation:   "POST",
+			Path:        "/orders",
+			Source:      load.NewSource("../data/schema-keywords/revision.yaml"),
+			OperationId: "createOrder",
+		},
+	}, errs)
+}
+
+// BC: changing the const value of a request property is breaking
+func TestRequestPropertyConstChanged(t *testing.T) {
+	s1, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Components.Schemas["Order"].Value.Properties["kind"].Value.Extensions["const"] = "express"
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyConstUpdatedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.RequestPropertyConstChangedId, errs[0].GetId())
+	require.Equal(t, []any{"kind", "standard", "express"}, errs[0].GetArgs())
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+}
+
+// CL: removing the const value of a request property
+func TestRequestPropertyConstRemoved(t *testing.T) {
+	s1, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyConstUpdatedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.RequestPropertyConstRemovedId, errs[0].GetId())
+	require.Equal(t, []any{"kind", "standard"}, errs[0].GetArgs())
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+}
+
+// BC: changing a const value of null is a change rather than an addition
+func TestRequestPropertyConstNullChanged(t *testing.T) {
+	s1, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Components.Schemas["Order"].Value.Properties["kind"].Value.Extensions["const"] = nil
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyConstUpdatedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.RequestPropertyConstChangedId, errs[0].GetId())
+	require.Equal(t, []any{"kind", "null", "standard"}, errs[0].GetArgs())
+	require.Equal(t, "the request property 'kind' const value changed from 'null' to 'standard'", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// CL: removing a const value of null
+func TestRequestPropertyConstNullRemoved(t *testing.T) {
+	s1, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Components.Schemas["Order"].Value.Properties["kind"].Value.Extensions["const"] = nil
+	delete(s2.Spec.Components.Schemas["Order"].Value.Properties["kind"].Value.Extensions, "const")
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyConstUpdatedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.RequestPropertyConstRemovedId, errs[0].GetId())
+	require.Equal(t, []any{"kind", "null"}, errs[0].GetArgs())
+}
+
+// BC: restricting the request body to a const value is breaking
+func TestRequestBodyConstAdded(t *testing.T) {
+	s1, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Components.Schemas["Order"].Value.Extensions = map[string]any{"const": map[string]any{"kind": "standard"}}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyConstUpdatedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.RequestBodyConstAddedId, errs[0].GetId())
+	require.Equal(t, []any{"application/json", map[string]any{"kind": "standard"}}, errs[0].GetArgs())
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+}
@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tufin/oasdiff/diff"
 )
 
@@ -42,6 +43,19 @@ func RequestPropertyDefaultValueChangedCheck(diffReport *diff.Diff, operationsSo
 				))
 			}
 
+			appendDeletedItem := func(base *openapi3.Schema, pointer string, messageId string, a ...any) {
+				result = append(result, NewApiChange(
+					messageId,
+					config,
+					a,
+					"",
+					operationsSources,
+					operationItem.Revision,
+					operation,
+					path,
+				).withDeletedSource(config, base, pointer))
+			}
+
 			modifiedMediaTypes := operationItem.RequestBodyDiff.ContentDiff.MediaTypeModified
 			for mediaType, mediaTypeDiff := range modifiedMediaTypes {
 				if mediaTypeDiff.SchemaDiff != nil && mediaTypeDiff.SchemaDiff.DefaultDiff != nil {
@@ -50,7 +64,7 @@ func RequestPropertyDefaultValueChangedCheck(diffReport *diff.Diff, operationsSo
 					if defaultValueDiff.From == nil {
 						appendResultItem(RequestBodyDefaultValueAddedId, mediaType, defaultValueDiff.To)
 					} else if defaultValueDiff.To == nil {
-						appendResultItem(RequestBodyDefaultValueRemovedId, mediaType, defaultValueDiff.From)
+						appendDeletedItem(mediaTypeDiff.SchemaDiff.Base, "/default", RequestBodyDefaultValueRemovedId, mediaType, defaultValueDiff.From)
 					} else {
 						appendResultItem(RequestBodyDefaultValueChangedId, mediaType, defaultValueDiff.From, defaultValueDiff.To)
 					}
@@ -68,7 +82,7 @@ func RequestPropertyDefaultValueChangedCheck(diffReport *diff.Diff, operationsSo
 						if defaultValueDiff.From == nil {
 							appendResultItem(RequestPropertyDefaultValueAddedId, propertyName, defaultValueDiff.To)
 						} else if defaultValueDiff.To == nil {
-							appendResultItem(RequestPropertyDefaultValueRemovedId, propertyName, defaultValueDiff.From)
+							appendDeletedItem(propertyDiff.Base, "/default", RequestPropertyDefaultValueRemovedId, propertyName, defaultValueDiff.From)
 						} else {
 							appendResultItem(RequestPropertyDefaultValueChangedId, propertyName, defaultValueDiff.From, defaultValueDiff.To)
 						}
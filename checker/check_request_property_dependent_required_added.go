@@ -0,0 +1,98 @@
+package checker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+	"github.com/tufin/oasdiff/utils"
+)
+
+const (
+	RequestBodyDependentRequiredAddedId     = "request-body-dependent-required-added"
+	RequestPropertyDependentRequiredAddedId = "request-property-dependent-required-added"
+)
+
+// RequestPropertyDependentRequiredAddedCheck checks the JSON Schema 2020-12 dependentRequired keyword of request bodies and their properties
+// Requiring new properties when another property is present rejects requests which were valid before
+func RequestPropertyDependentRequiredAddedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	if diffReport.PathsDiff == nil {
+		return result
+	}
+	for path, pathItem := range diffReport.PathsDiff.Modified {
+		if pathItem.OperationsDiff == nil {
+			continue
+		}
+		for operation, operationItem := range pathItem.OperationsDiff.Modified {
+			if operationItem.RequestBodyDiff == nil ||
+				operationItem.RequestBodyDiff.ContentDiff == nil ||
+				operationItem.RequestBodyDiff.ContentDiff.MediaTypeModified == nil {
+				continue
+			}
+
+			newChange := func(messageId string, a ...any) ApiChange {
+				return NewApiChange(
+					messageId,
+					config,
+					a,
+					"",
+					operationsSources,
+					operationItem.Revision,
+					operation,
+					path,
+				)
+			}
+
+			modifiedMediaTypes := operationItem.RequestBodyDiff.ContentDiff.MediaTypeModified
+			for mediaType, mediaTypeDiff := range modifiedMediaTypes {
+				if mediaTypeDiff.SchemaDiff == nil {
+					continue
+				}
+
+				processAddedDependentRequired(mediaTypeDiff.SchemaDiff, func(dependency string, required utils.StringList) {
+					result = append(result, newChange(RequestBodyDependentRequiredAddedId, strings.Join(required, ", "), dependency, mediaType))
+				})
+
+				CheckModifiedPropertiesDiff(
+					mediaTypeDiff.SchemaDiff,
+					func(propertyPath string, propertyName string, propertyDiff *diff.SchemaDiff, parent *diff.SchemaDiff) {
+						propName := propertyFullName(propertyPath, propertyName)
+
+						processAddedDependentRequired(propertyDiff, func(dependency string, required utils.StringList) {
+							result = append(result, newChange(RequestPropertyDependentRequiredAddedId, strings.Join(required, ", "), dependency, propName).withSource(config, propertyDiff.Revision))
+						})
+					})
+			}
+		}
+	}
+	return result
+}
+
+// processAddedDependentRequired calls the processor for each property that requires new properties when it is present
+func processAddedDependentRequired(schemaDiff *diff.SchemaDiff, processor func(dependency string, required utils.StringList)) {
+	dependentRequiredDiff := schemaDiff.DependentRequiredDiff
+	if dependentRequiredDiff == nil {
+		return
+	}
+
+	revision := load.GetSchemaKeywords(schemaDiff.Revision).DependentRequired
+	for _, dependency := range dependentRequiredDiff.Added {
+		if required := utils.StringList(revision[dependency]); len(required) > 0 {
+			processor(dependency, required)
+		}
+	}
+
+	modified := make([]string, 0, len(dependentRequiredDiff.Modified))
+	for dependency := range dependentRequiredDiff.Modified {
+		modified = append(modified, dependency)
+	}
+	sort.Strings(modified)
+
+	for _, dependency := range modified {
+		if added := dependentRequiredDiff.Modified[dependency].Added; len(added) > 0 {
+			processor(dependency, added.Sort())
+		}
+	}
+}
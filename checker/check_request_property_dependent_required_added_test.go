@@ -0,0 +1,51 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+// BC: requiring request properties when another property is present is breaking
+func TestRequestPropertyDependentRequiredAdded(t *testing.T) {
+	s1, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyDependentRequiredAddedCheck), d, osm, checker.INFO)
+	require.Equal(t, checker.Changes{
+		checker.ApiChange{
+			Id:          checker.RequestPropertyDependentRequiredAddedId,
+			Args:        []any{"address, zip", "card", "billing"},
+			Level:       checker.ERR,
+			Operation:   "POST",
+			Path:        "/orders",
+			Source:      load.NewSource("../data/schema-keywords/revision.yaml"),
+			OperationId: "createOrder",
+		},
+	}, errs)
+}
+
+// BC: adding a property to an existing dependentRequired list of the request body is breaking
+func TestRequestBodyDependentRequiredAdded(t *testing.T) {
+	s1, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Components.Schemas["Order"].Value.Extensions = map[string]any{"dependentRequired": map[string]any{"delivery": []any{"kind"}}}
+	s2.Spec.Components.Schemas["Order"].Value.Extensions = map[string]any{"dependentRequired": map[string]any{"delivery": []any{"kind", "tags"}}}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyDependentRequiredAddedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.RequestBodyDependentRequiredAddedId, errs[0].GetId())
+	require.Equal(t, []any{"tags", "delivery", "application/json"}, errs[0].GetArgs())
+}
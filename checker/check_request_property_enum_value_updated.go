@@ -54,7 +54,7 @@ func RequestPropertyEnumValueUpdatedCheck(diffReport *diff.Diff, operationsSourc
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withDeletedSource(config, propertyDiff.Base, enumValuePointer(propertyDiff.Base, enumVal)))
 						}
 
 						for _, enumVal := range enumDiff.Added {
@@ -67,7 +67,7 @@ func RequestPropertyEnumValueUpdatedCheck(diffReport *diff.Diff, operationsSourc
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, propertyDiff.Revision))
 						}
 					})
 			}
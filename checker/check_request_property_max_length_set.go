@@ -68,7 +68,7 @@ func RequestPropertyMaxLengthSetCheck(diffReport *diff.Diff, operationsSources *
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, propertyDiff.Revision))
 					})
 			}
 		}
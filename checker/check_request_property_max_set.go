@@ -68,7 +68,7 @@ func RequestPropertyMaxSetCheck(diffReport *diff.Diff, operationsSources *diff.O
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, propertyDiff.Revision))
 					})
 			}
 		}
@@ -73,7 +73,7 @@ func RequestPropertyMinItemsIncreasedCheck(diffReport *diff.Diff, operationsSour
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, propertyDiff.Revision))
 					})
 			}
 		}
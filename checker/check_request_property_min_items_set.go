@@ -68,7 +68,7 @@ func RequestPropertyMinItemsSetCheck(diffReport *diff.Diff, operationsSources *d
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withSource(config, propertyDiff.Revision))
 					})
 			}
 		}
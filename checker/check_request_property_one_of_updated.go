@@ -58,7 +58,7 @@ func RequestPropertyOneOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withDeletedSource(config, mediaTypeDiff.SchemaDiff.Base, subschemaPointer("oneOf", mediaTypeDiff.SchemaDiff.OneOfDiff.Deleted)))
 				}
 
 				CheckModifiedPropertiesDiff(
@@ -80,7 +80,7 @@ func RequestPropertyOneOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, propertyDiff.Revision))
 						}
 
 						if len(propertyDiff.OneOfDiff.Deleted) > 0 {
@@ -93,7 +93,7 @@ func RequestPropertyOneOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withDeletedSource(config, propertyDiff.Base, subschemaPointer("oneOf", propertyDiff.OneOfDiff.Deleted)))
 						}
 					})
 			}
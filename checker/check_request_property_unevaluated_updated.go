@@ -0,0 +1,79 @@
+package checker
+
+import (
+	"github.com/tufin/oasdiff/diff"
+)
+
+const (
+	RequestBodyUnevaluatedPropertiesDisallowedId     = "request-body-unevaluated-properties-disallowed"
+	RequestPropertyUnevaluatedPropertiesDisallowedId = "request-property-unevaluated-properties-disallowed"
+	RequestBodyUnevaluatedItemsDisallowedId          = "request-body-unevaluated-items-disallowed"
+	RequestPropertyUnevaluatedItemsDisallowedId      = "request-property-unevaluated-items-disallowed"
+)
+
+// RequestPropertyUnevaluatedUpdatedCheck checks the JSON Schema 2020-12 unevaluatedProperties and unevaluatedItems keywords of request bodies and their properties
+// Setting them to false rejects requests with properties or items that no other keyword of the schema describes
+func RequestPropertyUnevaluatedUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	if diffReport.PathsDiff == nil {
+		return result
+	}
+	for path, pathItem := range diffReport.PathsDiff.Modified {
+		if pathItem.OperationsDiff == nil {
+			continue
+		}
+		for operation, operationItem := range pathItem.OperationsDiff.Modified {
+			if operationItem.RequestBodyDiff == nil ||
+				operationItem.RequestBodyDiff.ContentDiff == nil ||
+				operationItem.RequestBodyDiff.ContentDiff.MediaTypeModified == nil {
+				continue
+			}
+
+			newChange := func(messageId string, a ...any) ApiChange {
+				return NewApiChange(
+					messageId,
+					config,
+					a,
+					"",
+					operationsSources,
+					operationItem.Revision,
+					operation,
+					path,
+				)
+			}
+
+			modifiedMediaTypes := operationItem.RequestBodyDiff.ContentDiff.MediaTypeModified
+			for mediaType, mediaTypeDiff := range modifiedMediaTypes {
+				if mediaTypeDiff.SchemaDiff == nil {
+					continue
+				}
+
+				if becameDisallowed(mediaTypeDiff.SchemaDiff.UnevaluatedPropertiesAllowedDiff) {
+					result = append(result, newChange(RequestBodyUnevaluatedPropertiesDisallowedId, mediaType))
+				}
+				if becameDisallowed(mediaTypeDiff.SchemaDiff.UnevaluatedItemsAllowedDiff) {
+					result = append(result, newChange(RequestBodyUnevaluatedItemsDisallowedId, mediaType))
+				}
+
+				CheckModifiedPropertiesDiff(
+					mediaTypeDiff.SchemaDiff,
+					func(propertyPath string, propertyName string, propertyDiff *diff.SchemaDiff, parent *diff.SchemaDiff) {
+						propName := propertyFullName(propertyPath, propertyName)
+
+						if becameDisallowed(propertyDiff.UnevaluatedPropertiesAllowedDiff) {
+							result = append(result, newChange(RequestPropertyUnevaluatedPropertiesDisallowedId, propName).withSource(config, propertyDiff.Revision))
+						}
+						if becameDisallowed(propertyDiff.UnevaluatedItemsAllowedDiff) {
+							result = append(result, newChange(RequestPropertyUnevaluatedItemsDisallowedId, propName).withSource(config, propertyDiff.Revision))
+						}
+					})
+			}
+		}
+	}
+	return result
+}
+
+// becameDisallowed indicates whether a boolean schema keyword, which allows anything when it is unset, was set to false
+func becameDisallowed(allowedDiff *diff.ValueDiff) bool {
+	return allowedDiff != nil && allowedDiff.To == false
+}
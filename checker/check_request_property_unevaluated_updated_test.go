@@ -0,0 +1,55 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+// BC: disallowing unevaluated properties or items in the request is breaking
+func TestRequestPropertyUnevaluatedDisallowed(t *testing.T) {
+	s1, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyUnevaluatedUpdatedCheck), d, osm, checker.INFO)
+	require.ElementsMatch(t, checker.Changes{
+		checker.ApiChange{
+			Id:          checker.RequestBodyUnevaluatedPropertiesDisallowedId,
+			Args:        []any{"application/json"},
+			Level:       checker.ERR,
+			Operation:   "POST",
+			Path:        "/orders",
+			Source:      load.NewSource("../data/schema-keywords/revision.yaml"),
+			OperationId: "createOrder",
+		},
+		checker.ApiChange{
+			Id:          checker.RequestPropertyUnevaluatedItemsDisallowedId,
+			Args:        []any{"coordinates"},
+			Level:       checker.ERR,
+			Operation:   "POST",
+			Path:        "/orders",
+			Source:      load.NewSource("../data/schema-keywords/revision.yaml"),
+			OperationId: "createOrder",
+		},
+	}, errs)
+}
+
+// CL: allowing unevaluated properties in the request
+func TestRequestPropertyUnevaluatedAllowed(t *testing.T) {
+	s1, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyUnevaluatedUpdatedCheck), d, osm, checker.INFO)
+	require.Empty(t, errs)
+}
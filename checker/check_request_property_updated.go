@@ -44,7 +44,7 @@ func RequestPropertyUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, propertyItem))
 						}
 					})
 				CheckAddedPropertiesDiff(
@@ -67,7 +67,7 @@ func RequestPropertyUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withSource(config, propertyItem))
 							} else {
 								result = append(result, NewApiChange(
 									NewRequiredRequestPropertyWithDefaultId,
@@ -78,7 +78,7 @@ func RequestPropertyUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withSource(config, propertyItem))
 							}
 						} else {
 							result = append(result, NewApiChange(
@@ -90,7 +90,7 @@ func RequestPropertyUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, propertyItem))
 						}
 					})
 			}
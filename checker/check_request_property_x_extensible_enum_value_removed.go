@@ -1,6 +1,8 @@
 package checker
 
 import (
+	"strconv"
+
 	"github.com/tufin/oasdiff/diff"
 	"golang.org/x/exp/slices"
 )
@@ -78,7 +80,7 @@ func RequestPropertyXExtensibleEnumValueRemovedCheck(diffReport *diff.Diff, oper
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withDeletedSource(config, propertyDiff.Base, "/"+diff.XExtensibleEnumExtension+"/"+strconv.Itoa(slices.Index(fromSlice, enumVal))))
 						}
 					})
 			}
@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tufin/oasdiff/diff"
 )
 
@@ -48,6 +49,19 @@ func ResponseDiscriminatorUpdatedCheck(diffReport *diff.Diff, operationsSources
 				))
 			}
 
+			appendDeletedItem := func(base *openapi3.Schema, pointer string, messageId string, a ...any) {
+				result = append(result, NewApiChange(
+					messageId,
+					config,
+					a,
+					"",
+					operationsSources,
+					operationItem.Revision,
+					operation,
+					path,
+				).withDeletedSource(config, base, pointer))
+			}
+
 			for responseStatus, responsesDiff := range operationItem.ResponsesDiff.Modified {
 				if responsesDiff.ContentDiff == nil || responsesDiff.ContentDiff.MediaTypeModified == nil {
 					continue
@@ -60,19 +74,21 @@ func ResponseDiscriminatorUpdatedCheck(diffReport *diff.Diff, operationsSources
 					}
 
 					processDiscriminatorDiff(
-						mediaTypeDiff.SchemaDiff.DiscriminatorDiff,
+						mediaTypeDiff.SchemaDiff,
 						responseStatus,
 						"",
-						appendResultItem)
+						appendResultItem,
+						appendDeletedItem)
 
 					CheckModifiedPropertiesDiff(
 						mediaTypeDiff.SchemaDiff,
 						func(propertyPath string, propertyName string, propertyDiff *diff.SchemaDiff, parent *diff.SchemaDiff) {
 							processDiscriminatorDiff(
-								propertyDiff.DiscriminatorDiff,
+								propertyDiff,
 								responseStatus,
 								propertyFullName(propertyPath, propertyName),
-								appendResultItem)
+								appendResultItem,
+								appendDeletedItem)
 						})
 				}
 			}
@@ -82,11 +98,13 @@ func ResponseDiscriminatorUpdatedCheck(diffReport *diff.Diff, operationsSources
 }
 
 func processDiscriminatorDiff(
-	discriminatorDiff *diff.DiscriminatorDiff,
+	schemaDiff *diff.SchemaDiff,
 	responseStatus string,
 	propertyName string,
-	appendResultItem func(messageId string, a ...any)) {
+	appendResultItem func(messageId string, a ...any),
+	appendDeletedItem func(base *openapi3.Schema, pointer string, messageId string, a ...any)) {
 
+	discriminatorDiff := schemaDiff.DiscriminatorDiff
 	if discriminatorDiff == nil {
 		return
 	}
@@ -106,9 +124,9 @@ func processDiscriminatorDiff(
 	}
 	if discriminatorDiff.Deleted {
 		if propertyName == "" {
-			appendResultItem(messageIdPrefix+"-removed", responseStatus)
+			appendDeletedItem(schemaDiff.Base, "/discriminator", messageIdPrefix+"-removed", responseStatus)
 		} else {
-			appendResultItem(messageIdPrefix+"-removed", propertyName, responseStatus)
+			appendDeletedItem(schemaDiff.Base, "/discriminator", messageIdPrefix+"-removed", propertyName, responseStatus)
 		}
 		return
 	}
@@ -144,11 +162,11 @@ func processDiscriminatorDiff(
 
 		if len(discriminatorDiff.MappingDiff.Deleted) > 0 {
 			if propertyName == "" {
-				appendResultItem(messageIdPrefix+"-mapping-deleted",
+				appendDeletedItem(schemaDiff.Base, "/discriminator/mapping", messageIdPrefix+"-mapping-deleted",
 					discriminatorDiff.MappingDiff.Deleted,
 					responseStatus)
 			} else {
-				appendResultItem(messageIdPrefix+"-mapping-deleted",
+				appendDeletedItem(schemaDiff.Base, "/discriminator/mapping", messageIdPrefix+"-mapping-deleted",
 					discriminatorDiff.MappingDiff.Deleted,
 					propertyName,
 					responseStatus)
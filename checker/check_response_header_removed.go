@@ -2,6 +2,7 @@ package checker
 
 import (
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
 )
 
 const (
@@ -45,7 +46,7 @@ func ResponseHeaderRemovedCheck(diffReport *diff.Diff, operationsSources *diff.O
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, responseDiff.Base, "/headers/"+load.EscapePointerToken(headerName)))
 					} else {
 						result = append(result, NewApiChange(
 							OptionalResponseHeaderRemovedId,
@@ -56,7 +57,7 @@ func ResponseHeaderRemovedCheck(diffReport *diff.Diff, operationsSources *diff.O
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, responseDiff.Base, "/headers/"+load.EscapePointerToken(headerName)))
 					}
 				}
 			}
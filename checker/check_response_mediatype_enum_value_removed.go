@@ -52,7 +52,7 @@ func ResponseMediaTypeEnumValueRemovedCheck(diffReport *diff.Diff, operationsSou
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, mediaTypeItem.SchemaDiff.Base, enumValuePointer(mediaTypeItem.SchemaDiff.Base, enumVal)))
 					}
 				}
 			}
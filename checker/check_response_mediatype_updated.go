@@ -2,6 +2,7 @@ package checker
 
 import (
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
 )
 
 const (
@@ -42,7 +43,7 @@ func ResponseMediaTypeUpdatedCheck(diffReport *diff.Diff, operationsSources *dif
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withDeletedSource(config, responsesDiff.Base, "/content/"+load.EscapePointerToken(mediaType)))
 				}
 				for _, mediaType := range responsesDiff.ContentDiff.MediaTypeAdded {
 					result = append(result, NewApiChange(
@@ -61,7 +61,7 @@ func ResponsePropertyAllOfUpdatedCheck(diffReport *diff.Diff, operationsSources
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, mediaTypeDiff.SchemaDiff.Base, subschemaPointer("allOf", mediaTypeDiff.SchemaDiff.AllOfDiff.Deleted)))
 					}
 
 					CheckModifiedPropertiesDiff(
@@ -82,7 +82,7 @@ func ResponsePropertyAllOfUpdatedCheck(diffReport *diff.Diff, operationsSources
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withSource(config, propertyDiff.Revision))
 							}
 
 							if len(propertyDiff.AllOfDiff.Deleted) > 0 {
@@ -96,7 +96,7 @@ func ResponsePropertyAllOfUpdatedCheck(diffReport *diff.Diff, operationsSources
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withDeletedSource(config, propertyDiff.Base, subschemaPointer("allOf", propertyDiff.AllOfDiff.Deleted)))
 							}
 						})
 				}
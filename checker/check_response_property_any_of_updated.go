@@ -62,7 +62,7 @@ func ResponsePropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, mediaTypeDiff.SchemaDiff.Base, subschemaPointer("anyOf", mediaTypeDiff.SchemaDiff.AnyOfDiff.Deleted)))
 					}
 
 					CheckModifiedPropertiesDiff(
@@ -83,7 +83,7 @@ func ResponsePropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withSource(config, propertyDiff.Revision))
 							}
 
 							if len(propertyDiff.AnyOfDiff.Deleted) > 0 {
@@ -97,7 +97,7 @@ func ResponsePropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withDeletedSource(config, propertyDiff.Base, subschemaPointer("anyOf", propertyDiff.AnyOfDiff.Deleted)))
 							}
 						})
 				}
@@ -69,7 +69,7 @@ func ResponsePropertyBecameNullableCheck(diffReport *diff.Diff, operationsSource
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, propertyDiff.Revision))
 						})
 				}
 			}
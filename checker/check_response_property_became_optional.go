@@ -93,7 +93,7 @@ func ResponsePropertyBecameOptionalCheck(diffReport *diff.Diff, operationsSource
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withSource(config, propertyDiff.Revision))
 							}
 						})
 				}
@@ -0,0 +1,92 @@
+package checker
+
+import (
+	"github.com/tufin/oasdiff/diff"
+)
+
+const (
+	ResponseBodyConstAddedId       = "response-body-const-added"
+	ResponseBodyConstChangedId     = "response-body-const-changed"
+	ResponseBodyConstRemovedId     = "response-body-const-removed"
+	ResponsePropertyConstAddedId   = "response-property-const-added"
+	ResponsePropertyConstChangedId = "response-property-const-changed"
+	ResponsePropertyConstRemovedId = "response-property-const-removed"
+)
+
+// ResponsePropertyConstUpdatedCheck checks the JSON Schema 2020-12 const keyword of response bodies and their properties
+// Changing or removing a const value means that clients may receive values that they don't expect
+func ResponsePropertyConstUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	if diffReport.PathsDiff == nil {
+		return result
+	}
+	for path, pathItem := range diffReport.PathsDiff.Modified {
+		if pathItem.OperationsDiff == nil {
+			continue
+		}
+		for operation, operationItem := range pathItem.OperationsDiff.Modified {
+			if operationItem.ResponsesDiff == nil || operationItem.ResponsesDiff.Modified == nil {
+				continue
+			}
+
+			newChange := func(messageId string, a ...any) ApiChange {
+				return NewApiChange(
+					messageId,
+					config,
+					a,
+					"",
+					operationsSources,
+					operationItem.Revision,
+					operation,
+					path,
+				)
+			}
+
+			for responseStatus, responseDiff := range operationItem.ResponsesDiff.Modified {
+				if responseDiff.ContentDiff == nil ||
+					responseDiff.ContentDiff.MediaTypeModified == nil {
+					continue
+				}
+
+				modifiedMediaTypes := responseDiff.ContentDiff.MediaTypeModified
+				for mediaType, mediaTypeDiff := range modifiedMediaTypes {
+					if mediaTypeDiff.SchemaDiff == nil {
+						continue
+					}
+
+					if constDiff := mediaTypeDiff.SchemaDiff.ConstDiff; constDiff != nil {
+						if constDiff.Added {
+							result = append(result, newChange(ResponseBodyConstAddedId, mediaType, getConstArg(constDiff.To), responseStatus))
+						} else if constDiff.Deleted {
+							result = append(result, newChange(ResponseBodyConstRemovedId, mediaType, getConstArg(constDiff.From), responseStatus))
+						} else {
+							result = append(result, newChange(ResponseBodyConstChangedId, mediaType, getConstArg(constDiff.From), getConstArg(constDiff.To), responseStatus))
+						}
+					}
+
+					CheckModifiedPropertiesDiff(
+						mediaTypeDiff.SchemaDiff,
+						func(propertyPath string, propertyName string, propertyDiff *diff.SchemaDiff, parent *diff.SchemaDiff) {
+							constDiff := propertyDiff.ConstDiff
+							if constDiff == nil {
+								return
+							}
+
+							propName := propertyFullName(propertyPath, propertyName)
+
+							var change ApiChange
+							if constDiff.Added {
+								change = newChange(ResponsePropertyConstAddedId, propName, getConstArg(constDiff.To), responseStatus)
+							} else if constDiff.Deleted {
+								change = newChange(ResponsePropertyConstRemovedId, propName, getConstArg(constDiff.From), responseStatus)
+							} else {
+								change = newChange(ResponsePropertyConstChangedId, propName, getConstArg(constDiff.From), getConstArg(constDiff.To), responseStatus)
+							}
+							result = append(result, change.withSource(config, propertyDiff.Revision))
+						})
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+// BC: changing the const value of a response property is breaking
+func TestResponsePropertyConstChanged(t *testing.T) {
+	s1, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponsePropertyConstUpdatedCheck), d, osm, checker.INFO)
+	require.Equal(t, checker.Changes{
+		checker.ApiChange{
+			Id:          checker.ResponsePropertyConstChangedId,
+			Args:        []any{"status", "created", "accepted", "201"},
+			Level:       checker.ERR,
+			Operation:   "POST",
+			Path:        "/orders",
+			Source:      load.NewSource("../data/schema-keywords/revision.yaml"),
+			OperationId: "createOrder",
+		},
+	}, errs)
+}
+
+// BC: removing the const value of a response property is breaking
+func TestResponsePropertyConstRemoved(t *testing.T) {
+	s1, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	delete(s2.Spec.Components.Schemas["OrderCreated"].Value.Properties["status"].Value.Extensions, "const")
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponsePropertyConstUpdatedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.ResponsePropertyConstRemovedId, errs[0].GetId())
+	require.Equal(t, []any{"status", "created", "201"}, errs[0].GetArgs())
+	require.Equal(t, checker.WARN, errs[0].GetLevel())
+}
+
+// CL: restricting the response body to a const value
+func TestResponseBodyConstAdded(t *testing.T) {
+	s1, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Components.Schemas["OrderCreated"].Value.Extensions = map[string]any{"const": map[string]any{"status": "created"}}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponsePropertyConstUpdatedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.ResponseBodyConstAddedId, errs[0].GetId())
+	require.Equal(t, []any{"application/json", map[string]any{"status": "created"}, "201"}, errs[0].GetArgs())
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+}
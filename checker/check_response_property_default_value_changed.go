@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tufin/oasdiff/diff"
 )
 
@@ -40,6 +41,19 @@ func ResponsePropertyDefaultValueChangedCheck(diffReport *diff.Diff, operationsS
 				))
 			}
 
+			appendDeletedItem := func(base *openapi3.Schema, pointer string, messageId string, a ...any) {
+				result = append(result, NewApiChange(
+					messageId,
+					config,
+					a,
+					"",
+					operationsSources,
+					operationItem.Revision,
+					operation,
+					path,
+				).withDeletedSource(config, base, pointer))
+			}
+
 			for responseStatus, responseDiff := range operationItem.ResponsesDiff.Modified {
 				if responseDiff.ContentDiff == nil ||
 					responseDiff.ContentDiff.MediaTypeModified == nil {
@@ -53,7 +67,7 @@ func ResponsePropertyDefaultValueChangedCheck(diffReport *diff.Diff, operationsS
 						if defaultValueDiff.From == nil {
 							appendResultItem(ResponseBodyDefaultValueAddedId, mediaType, defaultValueDiff.To, responseStatus)
 						} else if defaultValueDiff.To == nil {
-							appendResultItem(ResponseBodyDefaultValueRemovedId, mediaType, defaultValueDiff.From, responseStatus)
+							appendDeletedItem(mediaTypeDiff.SchemaDiff.Base, "/default", ResponseBodyDefaultValueRemovedId, mediaType, defaultValueDiff.From, responseStatus)
 						} else {
 							appendResultItem(ResponseBodyDefaultValueChangedId, mediaType, defaultValueDiff.From, defaultValueDiff.To, responseStatus)
 						}
@@ -70,7 +84,7 @@ func ResponsePropertyDefaultValueChangedCheck(diffReport *diff.Diff, operationsS
 							if defaultValueDiff.From == nil {
 								appendResultItem(ResponsePropertyDefaultValueAddedId, propertyName, defaultValueDiff.To, responseStatus)
 							} else if defaultValueDiff.To == nil {
-								appendResultItem(ResponsePropertyDefaultValueRemovedId, propertyName, defaultValueDiff.From, responseStatus)
+								appendDeletedItem(propertyDiff.Base, "/default", ResponsePropertyDefaultValueRemovedId, propertyName, defaultValueDiff.From, responseStatus)
 							} else {
 								appendResultItem(ResponsePropertyDefaultValueChangedId, propertyName, defaultValueDiff.From, defaultValueDiff.To, responseStatus)
 							}
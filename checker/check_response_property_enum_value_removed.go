@@ -47,7 +47,7 @@ func ResponseParameterEnumValueRemovedCheck(diffReport *diff.Diff, operationsSou
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withDeletedSource(config, propertyDiff.Base, enumValuePointer(propertyDiff.Base, enumVal)))
 							}
 						})
 				}
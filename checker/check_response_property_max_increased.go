@@ -77,7 +77,7 @@ func ResponsePropertyMaxIncreasedCheck(diffReport *diff.Diff, operationsSources
 								operationItem.Revision,
 								operation,
 								path,
-							))
+							).withSource(config, propertyDiff.Revision))
 						})
 				}
 
@@ -61,7 +61,7 @@ func ResponsePropertyOneOfUpdated(diffReport *diff.Diff, operationsSources *diff
 							operationItem.Revision,
 							operation,
 							path,
-						))
+						).withDeletedSource(config, mediaTypeDiff.SchemaDiff.Base, subschemaPointer("oneOf", mediaTypeDiff.SchemaDiff.OneOfDiff.Deleted)))
 					}
 
 					CheckModifiedPropertiesDiff(
@@ -83,7 +83,7 @@ func ResponsePropertyOneOfUpdated(diffReport *diff.Diff, operationsSources *diff
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withSource(config, propertyDiff.Revision))
 							}
 
 							if len(propertyDiff.OneOfDiff.Deleted) > 0 {
@@ -96,7 +96,7 @@ func ResponsePropertyOneOfUpdated(diffReport *diff.Diff, operationsSources *diff
 									operationItem.Revision,
 									operation,
 									path,
-								))
+								).withDeletedSource(config, propertyDiff.Base, subschemaPointer("oneOf", propertyDiff.OneOfDiff.Deleted)))
 							}
 						})
 				}
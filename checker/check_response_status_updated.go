@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
 )
 
 const (
@@ -62,7 +63,7 @@ func responseStatusUpdated(diffReport *diff.Diff, operationsSources *diff.Operat
 						operationItem.Revision,
 						operation,
 						path,
-					))
+					).withDeletedSource(config, operationItem.Base, "/responses/"+load.EscapePointerToken(responseStatus)))
 				}
 			}
 
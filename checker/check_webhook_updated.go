@@ -0,0 +1,267 @@
+package checker
+
+import (
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	WebhookAddedId                          = "webhook-added"
+	WebhookRemovedId                        = "webhook-removed"
+	WebhookRequestBodyRemovedId             = "webhook-request-body-removed"
+	WebhookRequestMediaTypeRemovedId        = "webhook-request-media-type-removed"
+	WebhookRequestPropertyRemovedId         = "webhook-request-property-removed"
+	WebhookRequestPropertyAddedId           = "webhook-request-property-added"
+	WebhookRequestPropertyBecameOptionalId  = "webhook-request-property-became-optional"
+	WebhookRequestPropertyTypeChangedId     = "webhook-request-property-type-changed"
+	WebhookResponseSuccessStatusRemovedId   = "webhook-response-success-status-removed"
+	WebhookResponseRequiredPropertyAddedId  = "webhook-response-required-property-added"
+	WebhookResponsePropertyBecameRequiredId = "webhook-response-property-became-required"
+)
+
+// WebhookUpdatedCheck checks the webhooks of OpenAPI 3.1 specs
+// The API is the sender of webhook requests, so the usual semantics are reversed:
+// the request body is a payload that subscribers consume, like a response, and the response is sent by the subscribers, like a request
+func WebhookUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	webhooksDiff := diffReport.WebhooksDiff
+	if webhooksDiff == nil {
+		return result
+	}
+
+	for _, webhook := range webhooksDiff.Added {
+		for method, operation := range webhooksDiff.Revision.Value(webhook).Operations() {
+			result = append(result, NewWebhookChange(WebhookAddedId, config, nil, "", operation, method, webhook))
+		}
+	}
+
+	for _, webhook := range webhooksDiff.Deleted {
+		for method, operation := range webhooksDiff.Base.Value(webhook).Operations() {
+			result = append(result, NewWebhookChange(WebhookRemovedId, config, nil, "", operation, method, webhook))
+		}
+	}
+
+	for webhook, pathDiff := range webhooksDiff.Modified {
+		if pathDiff.OperationsDiff == nil {
+			continue
+		}
+
+		for _, method := range pathDiff.OperationsDiff.Added {
+			result = append(result, NewWebhookChange(WebhookAddedId, config, nil, "", pathDiff.Revision.GetOperation(method), method, webhook))
+		}
+
+		for _, method := range pathDiff.OperationsDiff.Deleted {
+			result = append(result, NewWebhookChange(WebhookRemovedId, config, nil, "", pathDiff.Base.GetOperation(method), method, webhook))
+		}
+
+		for method, methodDiff := range pathDiff.OperationsDiff.Modified {
+			result = append(result, checkWebhookRequestBody(config, methodDiff, method, webhook)...)
+			result = append(result, checkWebhookResponses(config, methodDiff, method, webhook)...)
+		}
+	}
+
+	return result
+}
+
+// checkWebhookRequestBody checks the payload sent to subscribers, which must not lose any data that subscribers may rely on
+func checkWebhookRequestBody(config *Config, methodDiff *diff.MethodDiff, method, webhook string) Changes {
+	result := make(Changes, 0)
+
+	requestBodyDiff := methodDiff.RequestBodyDiff
+	if requestBodyDiff == nil {
+		return result
+	}
+
+	if requestBodyDiff.Deleted {
+		return append(result, NewWebhookChange(WebhookRequestBodyRemovedId, config, nil, "", methodDiff.Revision, method, webhook).withDeletedSource(config, methodDiff.Base, "/requestBody"))
+	}
+
+	if requestBodyDiff.ContentDiff == nil {
+		return result
+	}
+
+	for _, mediaType := range requestBodyDiff.ContentDiff.MediaTypeDeleted {
+		result = append(result, NewWebhookChange(WebhookRequestMediaTypeRemovedId, config, []any{mediaType}, "", methodDiff.Revision, method, webhook).withDeletedSource(config, methodDiff.Base, "/requestBody/content/"+load.EscapePointerToken(mediaType)))
+	}
+
+	for mediaType, mediaTypeDiff := range requestBodyDiff.ContentDiff.MediaTypeModified {
+		if mediaTypeDiff.SchemaDiff == nil {
+			continue
+		}
+
+		CheckDeletedPropertiesDiff(
+			mediaTypeDiff.SchemaDiff,
+			func(propertyPath string, propertyName string, propertyItem *openapi3.Schema, parent *diff.SchemaDiff) {
+				// readOnly properties aren't sent in the payload
+				if propertyItem.ReadOnly {
+					return
+				}
+
+				result = append(result, NewWebhookChange(
+					WebhookRequestPropertyRemovedId,
+					config,
+					[]any{propertyFullName(propertyPath, propertyName)},
+					"",
+					methodDiff.Revision,
+					method,
+					webhook,
+				).withSource(config, propertyItem))
+			})
+
+		CheckAddedPropertiesDiff(
+			mediaTypeDiff.SchemaDiff,
+			func(propertyPath string, propertyName string, propertyItem *openapi3.Schema, parent *diff.SchemaDiff) {
+				// readOnly properties aren't sent in the payload
+				if propertyItem.ReadOnly {
+					return
+				}
+
+				result = append(result, NewWebhookChange(
+					WebhookRequestPropertyAddedId,
+					config,
+					[]any{propertyFullName(propertyPath, propertyName)},
+					"",
+					methodDiff.Revision,
+					method,
+					webhook,
+				).withSource(config, propertyItem))
+			})
+
+		processRequiredDiff(mediaTypeDiff.SchemaDiff, func(schemaDiff *diff.SchemaDiff, propertyPath string) {
+			for _, name := range schemaDiff.RequiredDiff.Deleted {
+				if schemaDiff.Base.Properties[name] == nil || schemaDiff.Revision.Properties[name] == nil {
+					// added and removed properties are processed by the checks above
+					continue
+				}
+
+				result = append(result, NewWebhookChange(
+					WebhookRequestPropertyBecameOptionalId,
+					config,
+					[]any{propertyFullName(propertyPath, name)},
+					"",
+					methodDiff.Revision,
+					method,
+					webhook,
+				).withSource(config, schemaDiff.Revision.Properties[name].Value))
+			}
+		})
+
+		CheckModifiedPropertiesDiff(
+			mediaTypeDiff.SchemaDiff,
+			func(propertyPath string, propertyName string, propertyDiff *diff.SchemaDiff, parent *diff.SchemaDiff) {
+				if propertyDiff == nil || propertyDiff.Revision == nil {
+					return
+				}
+
+				// subscribers consume the payload, so type changes are checked like in a response
+				if !breakingTypeFormatChangedInResponseProperty(propertyDiff.TypeDiff, propertyDiff.FormatDiff, mediaType, propertyDiff) {
+					return
+				}
+
+				result = append(result, NewWebhookChange(
+					WebhookRequestPropertyTypeChangedId,
+					config,
+					[]any{propertyFullName(propertyPath, propertyName), getBaseType(propertyDiff), getBaseFormat(propertyDiff), getRevisionType(propertyDiff), getRevisionFormat(propertyDiff)},
+					"",
+					methodDiff.Revision,
+					method,
+					webhook,
+				).withSource(config, propertyDiff.Revision))
+			})
+	}
+
+	return result
+}
+
+// checkWebhookResponses checks the responses that subscribers send back, which must not demand anything new from them
+func checkWebhookResponses(config *Config, methodDiff *diff.MethodDiff, method, webhook string) Changes {
+	result := make(Changes, 0)
+
+	responsesDiff := methodDiff.ResponsesDiff
+	if responsesDiff == nil {
+		return result
+	}
+
+	for _, responseStatus := range responsesDiff.Deleted {
+		status, err := strconv.Atoi(responseStatus)
+		if err != nil || status < 200 || status > 299 {
+			continue
+		}
+
+		result = append(result, NewWebhookChange(WebhookResponseSuccessStatusRemovedId, config, []any{responseStatus}, "", methodDiff.Revision, method, webhook).withDeletedSource(config, methodDiff.Base, "/responses/"+load.EscapePointerToken(responseStatus)))
+	}
+
+	for responseStatus, responseDiff := range responsesDiff.Modified {
+		if responseDiff.ContentDiff == nil {
+			continue
+		}
+
+		for _, mediaTypeDiff := range responseDiff.ContentDiff.MediaTypeModified {
+			if mediaTypeDiff.SchemaDiff == nil {
+				continue
+			}
+
+			CheckAddedPropertiesDiff(
+				mediaTypeDiff.SchemaDiff,
+				func(propertyPath string, propertyName string, propertyItem *openapi3.Schema, parent *diff.SchemaDiff) {
+					// writeOnly properties aren't sent in the response
+					if propertyItem.WriteOnly || !slices.Contains(parent.Revision.Required, propertyName) {
+						return
+					}
+
+					result = append(result, NewWebhookChange(
+						WebhookResponseRequiredPropertyAddedId,
+						config,
+						[]any{propertyFullName(propertyPath, propertyName), responseStatus},
+						"",
+						methodDiff.Revision,
+						method,
+						webhook,
+					).withSource(config, propertyItem))
+				})
+
+			processRequiredDiff(mediaTypeDiff.SchemaDiff, func(schemaDiff *diff.SchemaDiff, propertyPath string) {
+				for _, name := range schemaDiff.RequiredDiff.Added {
+					if schemaDiff.Base.Properties[name] == nil || schemaDiff.Revision.Properties[name] == nil {
+						// added and removed properties are processed separately
+						continue
+					}
+					if schemaDiff.Revision.Properties[name].Value.WriteOnly {
+						continue
+					}
+
+					result = append(result, NewWebhookChange(
+						WebhookResponsePropertyBecameRequiredId,
+						config,
+						[]any{propertyFullName(propertyPath, name), responseStatus},
+						"",
+						methodDiff.Revision,
+						method,
+						webhook,
+					).withSource(config, schemaDiff.Revision.Properties[name].Value))
+				}
+			})
+		}
+	}
+
+	return result
+}
+
+// processRequiredDiff calls the processor for a schema and each of its modified properties that have changes in their required lists
+func processRequiredDiff(schemaDiff *diff.SchemaDiff, processor func(schemaDiff *diff.SchemaDiff, propertyPath string)) {
+	if schemaDiff.RequiredDiff != nil {
+		processor(schemaDiff, "")
+	}
+
+	CheckModifiedPropertiesDiff(
+		schemaDiff,
+		func(propertyPath string, propertyName string, propertyDiff *diff.SchemaDiff, _ *diff.SchemaDiff) {
+			if propertyDiff.RequiredDiff != nil {
+				processor(propertyDiff, propertyFullName(propertyPath, propertyName))
+			}
+		})
+}
@@ -0,0 +1,275 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+func getWebhookIds(changes checker.Changes) []string {
+	ids := make([]string, len(changes))
+	for i, change := range changes {
+		ids[i] = change.GetId()
+	}
+	return ids
+}
+
+// getWebhook returns a webhook of a loaded spec, which can be modified to simulate a change
+func getWebhook(t *testing.T, specInfo *load.SpecInfo, name string) *openapi3.PathItem {
+	t.Helper()
+
+	webhooks, err := load.GetWebhooks(specInfo.Spec)
+	require.NoError(t, err)
+	return webhooks.Value(name)
+}
+
+// BC: removing webhooks and webhook payload properties is breaking
+func TestWebhookUpdated(t *testing.T) {
+	s1, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/webhooks/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.WebhookUpdatedCheck), d, osm, checker.INFO)
+	require.ElementsMatch(t, []string{
+		checker.WebhookAddedId,
+		checker.WebhookRemovedId,
+		checker.WebhookRequestPropertyRemovedId,
+		checker.WebhookRequestPropertyAddedId,
+		checker.WebhookRequestPropertyBecameOptionalId,
+		checker.WebhookRequestPropertyTypeChangedId,
+		checker.WebhookResponseRequiredPropertyAddedId,
+	}, getWebhookIds(errs))
+
+	require.Contains(t, errs, checker.WebhookChange{
+		Id:          checker.WebhookRequestPropertyRemovedId,
+		Args:        []any{"color"},
+		Level:       checker.ERR,
+		Operation:   "POST",
+		OperationId: "newPet",
+		Webhook:     "newPet",
+	})
+
+	require.Contains(t, errs, checker.WebhookChange{
+		Id:          checker.WebhookResponseRequiredPropertyAddedId,
+		Args:        []any{"status", "200"},
+		Level:       checker.ERR,
+		Operation:   "POST",
+		OperationId: "newPet",
+		Webhook:     "newPet",
+	})
+}
+
+// BC: removing a success status that subscribers may return is breaking
+func TestWebhookResponseSuccessStatusRemoved(t *testing.T) {
+	s1, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+
+	responses := getWebhook(t, s2, "newPet").Post.Responses
+	responses.Set("204", &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("no content")})
+	responses.Delete("200")
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.WebhookUpdatedCheck), d, osm, checker.INFO)
+	require.Equal(t, checker.Changes{
+		checker.WebhookChange{
+			Id:          checker.WebhookResponseSuccessStatusRemovedId,
+			Args:        []any{"200"},
+			Level:       checker.ERR,
+			Operation:   "POST",
+			OperationId: "newPet",
+			Webhook:     "newPet",
+		},
+	}, errs)
+}
+
+// BC: making a property of the subscriber response required is breaking
+func TestWebhookResponsePropertyBecameRequired(t *testing.T) {
+	s1, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Components.Schemas["Ack"].Value.Required = []string{"received"}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.WebhookUpdatedCheck), d, osm, checker.INFO)
+	require.Equal(t, checker.Changes{
+		checker.WebhookChange{
+			Id:          checker.WebhookResponsePropertyBecameRequiredId,
+			Args:        []any{"received", "200"},
+			Level:       checker.ERR,
+			Operation:   "POST",
+			OperationId: "newPet",
+			Webhook:     "newPet",
+		},
+	}, errs)
+}
+
+// BC: removing the request body sent to subscribers is breaking
+func TestWebhookRequestBodyRemoved(t *testing.T) {
+	s1, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+
+	getWebhook(t, s2, "petRemoved").Post.RequestBody = nil
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.WebhookUpdatedCheck), d, osm, checker.INFO)
+	require.Equal(t, checker.Changes{
+		checker.WebhookChange{
+			Id:        checker.WebhookRequestBodyRemovedId,
+			Level:     checker.ERR,
+			Operation: "POST",
+			Webhook:   "petRemoved",
+		},
+	}, errs)
+}
+
+func TestWebhookUpdated_Source(t *testing.T) {
+	s1, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/webhooks/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	config := singleCheckConfig(checker.WebhookUpdatedCheck).WithSourceMaps(load.NewSourceMaps(s1), load.NewSourceMaps(s2))
+	errs := checker.CheckBackwardCompatibility(config, d, osm)
+
+	for _, change := range errs {
+		if change.GetId() == checker.WebhookRequestPropertyRemovedId {
+			require.Equal(t, "../data/webhooks/base.yaml", change.GetSourceFile())
+			require.Equal(t, 46, change.GetSourceLine())
+			return
+		}
+	}
+	require.Fail(t, "webhook-request-property-removed not found")
+}
+
+// BC: readOnly properties aren't sent in the payload, so removing or adding them isn't reported, unlike writeOnly properties
+func TestWebhookRequestPropertyReadOnlyWriteOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		readOnly bool
+		expected []string
+	}{
+		{name: "readOnly", readOnly: true, expected: []string{}},
+		{name: "writeOnly", readOnly: false, expected: []string{checker.WebhookRequestPropertyRemovedId, checker.WebhookRequestPropertyAddedId}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s1, err := open("../data/webhooks/base.yaml")
+			require.NoError(t, err)
+			s2, err := open("../data/webhooks/base.yaml")
+			require.NoError(t, err)
+
+			s1.Spec.Components.Schemas["Pet"].Value.Properties["color"].Value.ReadOnly = tc.readOnly
+			s1.Spec.Components.Schemas["Pet"].Value.Properties["color"].Value.WriteOnly = !tc.readOnly
+			delete(s2.Spec.Components.Schemas["Pet"].Value.Properties, "color")
+
+			property := openapi3.NewStringSchema()
+			property.ReadOnly = tc.readOnly
+			property.WriteOnly = !tc.readOnly
+			s2.Spec.Components.Schemas["Pet"].Value.Properties["secret"] = openapi3.NewSchemaRef("", property)
+
+			d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+			require.NoError(t, err)
+			errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.WebhookUpdatedCheck), d, osm, checker.INFO)
+
+			// the Pet schema is the payload of both webhooks
+			expected := []string{}
+			for range 2 {
+				expected = append(expected, tc.expected...)
+			}
+			require.ElementsMatch(t, expected, getWebhookIds(errs))
+		})
+	}
+}
+
+// BC: writeOnly properties aren't sent in the subscriber response, so adding them as required or making them required isn't reported, unlike readOnly properties
+func TestWebhookResponsePropertyReadOnlyWriteOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		readOnly bool
+		expected []string
+	}{
+		{name: "readOnly", readOnly: true, expected: []string{checker.WebhookResponseRequiredPropertyAddedId, checker.WebhookResponsePropertyBecameRequiredId}},
+		{name: "writeOnly", readOnly: false, expected: []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s1, err := open("../data/webhooks/base.yaml")
+			require.NoError(t, err)
+			s2, err := open("../data/webhooks/base.yaml")
+			require.NoError(t, err)
+
+			for _, spec := range []*load.SpecInfo{s1, s2} {
+				received := spec.Spec.Components.Schemas["Ack"].Value.Properties["received"].Value
+				received.ReadOnly = tc.readOnly
+				received.WriteOnly = !tc.readOnly
+			}
+
+			property := openapi3.NewStringSchema()
+			property.ReadOnly = tc.readOnly
+			property.WriteOnly = !tc.readOnly
+			s2.Spec.Components.Schemas["Ack"].Value.Properties["token"] = openapi3.NewSchemaRef("", property)
+			s2.Spec.Components.Schemas["Ack"].Value.Required = []string{"received", "token"}
+
+			d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+			require.NoError(t, err)
+			errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.WebhookUpdatedCheck), d, osm, checker.INFO)
+			require.ElementsMatch(t, tc.expected, getWebhookIds(errs))
+		})
+	}
+}
+
+// deleted webhook elements point at the element in the base
+func TestWebhookUpdated_DeletedSource(t *testing.T) {
+	s1, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+
+	newPet := getWebhook(t, s2, "newPet").Post
+	newPet.RequestBody.Value.Content["text/plain"] = openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema())
+	delete(newPet.RequestBody.Value.Content, "application/json")
+	newPet.Responses.Set("204", &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("no content")})
+	newPet.Responses.Delete("200")
+	getWebhook(t, s2, "petRemoved").Post.RequestBody = nil
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	config := singleCheckConfig(checker.WebhookUpdatedCheck).WithSourceMaps(load.NewSourceMaps(s1), load.NewSourceMaps(s2))
+	errs := checker.CheckBackwardCompatibility(config, d, osm)
+
+	// one-based line numbers in the base
+	expected := map[string]int{
+		checker.WebhookRequestMediaTypeRemovedId:      12,
+		checker.WebhookResponseSuccessStatusRemovedId: 16,
+		checker.WebhookRequestBodyRemovedId:           24,
+	}
+
+	actual := map[string]int{}
+	for _, change := range errs {
+		if _, ok := expected[change.GetId()]; ok {
+			require.Equal(t, "../data/webhooks/base.yaml", change.GetSourceFile(), change.GetId())
+			actual[change.GetId()] = change.GetSourceLine() + 1
+		}
+	}
+	require.Equal(t, expected, actual)
+}
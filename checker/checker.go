@@ -39,7 +39,7 @@ func CheckBackwardCompatibilityUntilLevel(config *Config, diffReport *diff.Diff,
 
 	filteredResult := make(Changes, 0)
 	for _, change := range result {
-		if config.getLogLevel(change.GetId()) >= level {
+		if config.getChangeLevel(change) >= level {
 			filteredResult = append(filteredResult, change)
 		}
 	}
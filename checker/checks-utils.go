@@ -2,6 +2,8 @@ package checker
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -242,3 +244,23 @@ func IsDecreased(from interface{}, to interface{}) bool {
 	}
 	return false
 }
+
+// enumValuePointer returns the JSON pointer of a value in the enum of a schema, relative to the schema
+func enumValuePointer(schema *openapi3.Schema, value any) string {
+	if schema != nil {
+		for i, enumValue := range schema.Enum {
+			if reflect.DeepEqual(enumValue, value) {
+				return "/enum/" + strconv.Itoa(i)
+			}
+		}
+	}
+	return "/enum"
+}
+
+// subschemaPointer returns the JSON pointer of the first deleted subschema of a schema, like /allOf/1, relative to the schema
+func subschemaPointer(keyword string, deleted diff.Subschemas) string {
+	if len(deleted) == 0 {
+		return "/" + keyword
+	}
+	return "/" + keyword + "/" + strconv.Itoa(deleted[0].Index)
+}
@@ -13,6 +13,7 @@ type ComponentChange struct {
 
 	Id        string
 	Args      []any
+	Message   string // the message template of a custom rule, used instead of the localized message of the id
 	Comment   string
 	Level     Level
 	Component string
@@ -24,6 +25,22 @@ type ComponentChange struct {
 	SourceColumnEnd int
 }
 
+// withSource points the change at the source position of a JSON pointer, taken from the base for deleted elements and from the revision otherwise
+// if the pointer can't be located, the change is returned unmodified
+func (c ComponentChange) withSource(config *Config, pointer string, deleted bool) ComponentChange {
+	file, position, ok := config.locatePointer(pointer, deleted)
+	if !ok {
+		return c
+	}
+
+	c.SourceFile = file
+	c.SourceLine = position.Line
+	c.SourceLineEnd = position.LineEnd
+	c.SourceColumn = position.Column
+	c.SourceColumnEnd = position.ColumnEnd
+	return c
+}
+
 func (c ComponentChange) GetSection() string {
 	return "components"
 }
@@ -42,7 +59,7 @@ func (c ComponentChange) GetId() string {
 }
 
 func (c ComponentChange) GetText(l Localizer) string {
-	return l(c.Id, colorizedValues(c.Args)...)
+	return localizeMessage(l, c.Id, c.Message, colorizedValues(c.Args))
 }
 
 func (c ComponentChange) GetArgs() []any {
@@ -50,7 +67,7 @@ func (c ComponentChange) GetArgs() []any {
 }
 
 func (c ComponentChange) GetUncolorizedText(l Localizer) string {
-	return l(c.Id, quotedValues(c.Args)...)
+	return localizeMessage(l, c.Id, c.Message, quotedValues(c.Args))
 }
 
 func (c ComponentChange) GetComment(l Localizer) string {
@@ -1,6 +1,13 @@
 package checker
 
-import "log"
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/tufin/oasdiff/load"
+	"github.com/tufin/oasdiff/utils"
+)
 
 type Config struct {
 	Checks              BackwardCompatibilityChecks
@@ -8,6 +15,12 @@ type Config struct {
 	MinSunsetStableDays uint
 	LogLevels           map[string]Level
 	Attributes          []string
+	BaseSourceMaps      load.SourceMaps
+	RevisionSourceMaps  load.SourceMaps
+	CustomRules         CustomRules
+	PluginChanges       PluginChanges
+	pluginIds           utils.StringSet
+	pluginLevels        map[string]Level // the severity levels of plugin ids which were overridden by WithSeverityLevels
 }
 
 const (
@@ -40,12 +53,61 @@ func (config *Config) WithOptionalChecks(ids []string) *Config {
 
 func (config *Config) WithSeverityLevels(severityLevels map[string]Level) *Config {
 	for id, level := range severityLevels {
+		if config.pluginIds.Contains(id) {
+			config.pluginLevels[id] = level
+			continue
+		}
 		config.setLogLevel(id, level)
 	}
 
 	return config
 }
 
+// WithCustomRules adds custom rules, declared in a configuration file, to the checks.
+// Call it before WithSeverityLevels so that the severity levels of custom rules can be overridden too.
+func (config *Config) WithCustomRules(rules CustomRules) *Config {
+	if len(rules) == 0 {
+		return config
+	}
+
+	config.CustomRules = rules
+	for id, level := range rulesToLevels(rules.Rules()) {
+		config.LogLevels[id] = level
+	}
+	config.Checks = append(config.Checks, CustomRulesCheck)
+	return config
+}
+
+// WithPluginChanges adds the changes which were returned by check plugins, it can be called once for each plugin.
+// Each change is reported with its own level, unless the level of its id is overridden by calling WithSeverityLevels afterwards.
+// Call it after WithCustomRules, plugin ids mustn't clash with the ids of built-in checks and custom rules.
+func (config *Config) WithPluginChanges(changes PluginChanges) (*Config, error) {
+	for _, change := range changes {
+		if _, ok := config.LogLevels[change.Id]; ok {
+			return nil, fmt.Errorf("invalid change %q: id is already used by %s", change.Id, config.describeId(change.Id))
+		}
+	}
+
+	if config.pluginIds == nil {
+		config.pluginIds = utils.StringSet{}
+		config.pluginLevels = map[string]Level{}
+		config.Checks = append(config.Checks, PluginChangesCheck)
+	}
+	config.PluginChanges = append(config.PluginChanges, changes...)
+	for _, id := range changes.Ids() {
+		config.pluginIds.Add(id)
+	}
+	return config, nil
+}
+
+// describeId describes the rule which uses an id
+func (config *Config) describeId(id string) string {
+	if slices.Contains(config.CustomRules.Ids(), id) {
+		return "a custom rule"
+	}
+	return "a built-in rule"
+}
+
 // WithDeprecation sets the number of days before sunset for deprecation warnings.
 func (config *Config) WithDeprecation(deprecationDaysBeta uint, deprecationDaysStable uint) *Config {
 	config.MinSunsetBetaDays = deprecationDaysBeta
@@ -70,6 +132,34 @@ func (config *Config) WithAttributes(attributes []string) *Config {
 	return config
 }
 
+// WithSourceMaps sets the source maps of the base and revision specs, used to locate changes in the source files.
+func (config *Config) WithSourceMaps(base, revision load.SourceMaps) *Config {
+	config.BaseSourceMaps = base
+	config.RevisionSourceMaps = revision
+	return config
+}
+
+// locateElement finds the source file and position of a spec element in the revision or in the base
+func (config *Config) locateElement(element any) (string, load.Position, bool) {
+	if file, position, ok := config.RevisionSourceMaps.LocateElement(element); ok {
+		return file, position, true
+	}
+	return config.BaseSourceMaps.LocateElement(element)
+}
+
+// locateDeleted finds the source file and position of a deleted element in the base, given by an element of the base and a JSON pointer relative to it
+func (config *Config) locateDeleted(element any, pointer string) (string, load.Position, bool) {
+	return config.BaseSourceMaps.LocateElementPointer(element, pointer)
+}
+
+// locatePointer finds the source file and position of a JSON pointer in the revision, or in the base for deleted elements
+func (config *Config) locatePointer(pointer string, deleted bool) (string, load.Position, bool) {
+	if deleted {
+		return config.BaseSourceMaps.LocatePointer(pointer)
+	}
+	return config.RevisionSourceMaps.LocatePointer(pointer)
+}
+
 func (config *Config) getLogLevel(checkId string) Level {
 	level, ok := config.LogLevels[checkId]
 
@@ -81,6 +171,22 @@ func (config *Config) getLogLevel(checkId string) Level {
 
 }
 
+// getPluginLevel returns the level of a plugin change, or the level of its id if it was overridden by WithSeverityLevels
+func (config *Config) getPluginLevel(change PluginChange) Level {
+	if level, ok := config.pluginLevels[change.Id]; ok {
+		return level
+	}
+	return change.getLevel()
+}
+
+// getChangeLevel returns the level of a change for filtering: plugin changes have their own levels, other changes have the levels of their ids
+func (config *Config) getChangeLevel(change Change) Level {
+	if config.pluginIds.Contains(change.GetId()) {
+		return change.GetLevel()
+	}
+	return config.getLogLevel(change.GetId())
+}
+
 func (config *Config) setLogLevel(checkId string, level Level) {
 	if _, ok := config.LogLevels[checkId]; !ok {
 		log.Fatal("failed to set log level with invalid check id: ", checkId)
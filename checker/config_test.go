@@ -8,8 +8,8 @@ import (
 )
 
 const (
-	numOfChecks = 93
-	numOfIds    = 273
+	numOfChecks = 100
+	numOfIds    = 304
 )
 
 func TestNewConfig(t *testing.T) {
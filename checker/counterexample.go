@@ -0,0 +1,27 @@
+package checker
+
+import "encoding/json"
+
+// Counterexample is a concrete request which is valid against the base spec and invalid against the revision
+type Counterexample struct {
+	Method  string            `json:"method" yaml:"method"`
+	Path    string            `json:"path" yaml:"path"`                           // the path with values for its path parameters
+	Query   string            `json:"query,omitempty" yaml:"query,omitempty"`     // the encoded query string
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // including the Cookie and Content-Type headers
+	Body    any               `json:"body,omitempty" yaml:"body,omitempty"`       // the JSON body
+}
+
+// String returns a one-line summary of the request: the method, the path with the query string and the JSON body
+// headers are omitted, they are available in the JSON and YAML outputs
+func (ce *Counterexample) String() string {
+	result := ce.Method + " " + ce.Path
+	if ce.Query != "" {
+		result += "?" + ce.Query
+	}
+	if ce.Body != nil {
+		if body, err := json.Marshal(ce.Body); err == nil {
+			result += " " + string(body)
+		}
+	}
+	return result
+}
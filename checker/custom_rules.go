@@ -0,0 +1,384 @@
+package checker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/tufin/oasdiff/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CustomRule is a breaking-change rule which is declared in a configuration file rather than implemented in code
+// A custom rule matches the changes in the diff by their endpoint, JSON pointer, location, direction and action, and by their base and revision values
+type CustomRule struct {
+	Id          string          `yaml:"id"`
+	Level       string          `yaml:"level"`
+	Message     string          `yaml:"message"`
+	Description string          `yaml:"description"`
+	Match       CustomRuleMatch `yaml:"match"`
+
+	level   Level
+	format  string
+	args    []string
+	path    *regexp.Regexp
+	pointer *regexp.Regexp
+}
+
+// CustomRuleMatch describes the changes that a custom rule applies to, all conditions must be met
+type CustomRuleMatch struct {
+	Path      string               `yaml:"path"`      // glob of the endpoint path, for example: /billing/**
+	Pointer   string               `yaml:"pointer"`   // glob of the JSON pointer of the change within the operation or under /components, for example: /responses/*/headers/*
+	Location  string               `yaml:"location"`  // one of: body, parameters, properties, headers, security, components
+	Direction string               `yaml:"direction"` // one of: request, response
+	Action    string               `yaml:"action"`    // one of: add, remove, change
+	Base      *CustomRuleCondition `yaml:"base"`      // condition on the value in the base
+	Revision  *CustomRuleCondition `yaml:"revision"`  // condition on the value in the revision
+}
+
+// CustomRuleCondition is a condition on the base or revision value of a change, all conditions must be met
+type CustomRuleCondition struct {
+	Exists  *bool  `yaml:"exists"`
+	Equals  any    `yaml:"equals"`
+	Matches string `yaml:"matches"`
+
+	regex *regexp.Regexp
+}
+
+// CustomRules is a list of custom rules
+type CustomRules []CustomRule
+
+type customRulesFile struct {
+	Rules CustomRules `yaml:"rules"`
+}
+
+// customRulePlaceholders are the placeholders that can be used in the message of a custom rule
+var customRulePlaceholders = []string{"path", "method", "pointer", "name", "base", "revision"}
+
+var customRulePlaceholderRegex = regexp.MustCompile(`\{([a-z]+)\}`)
+
+// LoadCustomRules reads custom rules from a YAML file
+func LoadCustomRules(file string) (CustomRules, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseCustomRules(f)
+}
+
+// ParseCustomRules reads custom rules from a YAML document and validates them
+func ParseCustomRules(source io.Reader) (CustomRules, error) {
+	var rulesFile customRulesFile
+
+	decoder := yaml.NewDecoder(source)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&rulesFile); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	builtInIds := utils.StringList(GetAllRuleIds()).ToStringSet()
+	ids := utils.StringSet{}
+
+	for i := range rulesFile.Rules {
+		rule := &rulesFile.Rules[i]
+
+		if err := rule.init(); err != nil {
+			return nil, fmt.Errorf("invalid custom rule #%d %q: %w", i+1, rule.Id, err)
+		}
+
+		if builtInIds.Contains(rule.Id) {
+			return nil, fmt.Errorf("invalid custom rule #%d %q: id is already used by a built-in rule", i+1, rule.Id)
+		}
+
+		if ids.Contains(rule.Id) {
+			return nil, fmt.Errorf("invalid custom rule #%d %q: duplicate id", i+1, rule.Id)
+		}
+		ids.Add(rule.Id)
+	}
+
+	return rulesFile.Rules, nil
+}
+
+// init validates the rule and prepares it for matching
+func (rule *CustomRule) init() error {
+	if rule.Id == "" {
+		return errors.New("missing id")
+	}
+
+	if strings.ContainsFunc(rule.Id, func(r rune) bool { return r == ' ' || r == '\t' }) {
+		return errors.New("id mustn't contain whitespace")
+	}
+
+	level, err := NewLevel(rule.Level)
+	if err != nil {
+		return err
+	}
+	rule.level = level
+
+	if rule.Message == "" {
+		return errors.New("missing message")
+	}
+
+	if rule.format, rule.args, err = parseMessageTemplate(rule.Message); err != nil {
+		return err
+	}
+
+	if rule.path, err = compileGlob(rule.Match.Path); err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+
+	if rule.pointer, err = compileGlob(rule.Match.Pointer); err != nil {
+		return fmt.Errorf("invalid pointer: %w", err)
+	}
+
+	if _, err := newCustomRuleLocation(rule.Match.Location); err != nil {
+		return err
+	}
+
+	if _, err := newCustomRuleDirection(rule.Match.Direction); err != nil {
+		return err
+	}
+
+	if _, err := newCustomRuleAction(rule.Match.Action); err != nil {
+		return err
+	}
+
+	if err := rule.Match.Base.init(); err != nil {
+		return fmt.Errorf("invalid base condition: %w", err)
+	}
+
+	if err := rule.Match.Revision.init(); err != nil {
+		return fmt.Errorf("invalid revision condition: %w", err)
+	}
+
+	return nil
+}
+
+func (condition *CustomRuleCondition) init() error {
+	if condition == nil || condition.Matches == "" {
+		return nil
+	}
+
+	var err error
+	condition.regex, err = regexp.Compile(condition.Matches)
+	return err
+}
+
+// parseMessageTemplate converts a message with placeholders, like {base}, to a format string and the list of its arguments
+func parseMessageTemplate(message string) (string, []string, error) {
+	args := []string{}
+	var err error
+
+	format := customRulePlaceholderRegex.ReplaceAllStringFunc(strings.ReplaceAll(message, "%", "%%"), func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if !slices.Contains(customRulePlaceholders, name) {
+			err = fmt.Errorf("unknown placeholder %s in message, supported placeholders: %s", placeholder, strings.Join(customRulePlaceholders, ", "))
+			return placeholder
+		}
+		args = append(args, name)
+		return "%s"
+	})
+
+	return format, args, err
+}
+
+// compileGlob converts a glob to a regular expression, where * and ? match within a single path segment and ** matches across segments
+// an empty glob matches everything and results in a nil regular expression
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	if glob == "" {
+		return nil, nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			builder.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			builder.WriteString("[^/]*")
+		case glob[i] == '?':
+			builder.WriteString("[^/]")
+		default:
+			builder.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	builder.WriteString("$")
+
+	return regexp.Compile(builder.String())
+}
+
+func newCustomRuleLocation(location string) (Location, error) {
+	switch location {
+	case "":
+		return LocationNone, nil
+	case "body":
+		return LocationBody, nil
+	case "parameters":
+		return LocationParameters, nil
+	case "properties":
+		return LocationProperties, nil
+	case "headers":
+		return LocationHeaders, nil
+	case "security":
+		return LocationSecurity, nil
+	case "components":
+		return LocationComponents, nil
+	}
+	return LocationNone, fmt.Errorf("invalid location %q", location)
+}
+
+func newCustomRuleDirection(direction string) (Direction, error) {
+	switch direction {
+	case "":
+		return DirectionNone, nil
+	case "request":
+		return DirectionRequest, nil
+	case "response":
+		return DirectionResponse, nil
+	}
+	return DirectionNone, fmt.Errorf("invalid direction %q", direction)
+}
+
+func newCustomRuleAction(action string) (Action, error) {
+	switch action {
+	case "":
+		return ActionNone, nil
+	case "add":
+		return ActionAdd, nil
+	case "remove":
+		return ActionRemove, nil
+	case "change":
+		return ActionChange, nil
+	}
+	return ActionNone, fmt.Errorf("invalid action %q", action)
+}
+
+// Rules returns the custom rules as backward compatibility rules, all handled by CustomRulesCheck
+func (rules CustomRules) Rules() BackwardCompatibilityRules {
+	result := make(BackwardCompatibilityRules, 0, len(rules))
+	for _, rule := range rules {
+		direction, _ := newCustomRuleDirection(rule.Match.Direction)
+		location, _ := newCustomRuleLocation(rule.Match.Location)
+		action, _ := newCustomRuleAction(rule.Match.Action)
+
+		description := rule.Description
+		if description == "" {
+			description = rule.Message
+		}
+
+		result = append(result, BackwardCompatibilityRule{
+			Id:          rule.Id,
+			Level:       rule.level,
+			Description: description,
+			Handler:     CustomRulesCheck,
+			Direction:   direction,
+			Location:    location,
+			Action:      action,
+		})
+	}
+	return result
+}
+
+// Ids returns the ids of the custom rules
+func (rules CustomRules) Ids() []string {
+	return rulesToIIs(rules.Rules())
+}
+
+// matches indicates whether a change in the diff is matched by the rule
+func (rule CustomRule) matches(event customRuleEvent) bool {
+	if rule.path != nil && (event.path == "" || !rule.path.MatchString(event.path)) {
+		return false
+	}
+
+	if rule.pointer != nil && !rule.pointer.MatchString(event.pointer) {
+		return false
+	}
+
+	if rule.Match.Location != "" && rule.Match.Location != event.location() {
+		return false
+	}
+
+	if rule.Match.Direction != "" && rule.Match.Direction != event.direction() {
+		return false
+	}
+
+	if rule.Match.Action != "" && rule.Match.Action != event.action {
+		return false
+	}
+
+	return rule.Match.Base.matches(event.base) && rule.Match.Revision.matches(event.revision)
+}
+
+func (condition *CustomRuleCondition) matches(value any) bool {
+	if condition == nil {
+		return true
+	}
+
+	if condition.Exists != nil && *condition.Exists != (value != nil) {
+		return false
+	}
+
+	if condition.Equals != nil && (value == nil || customRuleValueToString(condition.Equals) != customRuleValueToString(value)) {
+		return false
+	}
+
+	if condition.regex != nil && (value == nil || !condition.regex.MatchString(customRuleValueToString(value))) {
+		return false
+	}
+
+	return true
+}
+
+// getArgs returns the values of the placeholders in the message of the rule
+func (rule CustomRule) getArgs(event customRuleEvent) []any {
+	result := make([]any, len(rule.args))
+	for i, arg := range rule.args {
+		switch arg {
+		case "path":
+			result[i] = event.path
+		case "method":
+			result[i] = event.method
+		case "pointer":
+			result[i] = event.pointer
+		case "name":
+			result[i] = event.name()
+		case "base":
+			result[i] = customRuleArg(event.base)
+		case "revision":
+			result[i] = customRuleArg(event.revision)
+		}
+	}
+	return result
+}
+
+func customRuleArg(value any) any {
+	if value == nil {
+		return nil
+	}
+	return customRuleValueToString(value)
+}
+
+// customRuleValueToString converts a value to a string so that values from YAML and JSON can be compared
+func customRuleValueToString(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,228 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IgnoreEntry is an entry of a structured ignore file which ignores the changes with the given id and, optionally, method, path, operationId and argument values
+// unlike lines of a textual ignore file, entries don't depend on the wording or language of the change messages
+type IgnoreEntry struct {
+	Id          string    `yaml:"id"`
+	Method      string    `yaml:"method"`
+	Path        string    `yaml:"path"` // glob of the path, for example: /billing/**
+	OperationId string    `yaml:"operationId"`
+	Args        []string  `yaml:"args"` // each of these values must be one of the arguments of the change
+	Reason      string    `yaml:"reason"`
+	Owner       string    `yaml:"owner"`
+	Expires     time.Time `yaml:"expires"` // optional, the entry fails the run from this date on
+
+	Line int `yaml:"-"` // the line of the entry in the ignore file
+	path *regexp.Regexp
+}
+
+// IgnoreEntries is the content of a structured ignore file
+type IgnoreEntries []IgnoreEntry
+
+type ignoreFile struct {
+	Ignore yaml.Node `yaml:"ignore"`
+}
+
+// IsStructuredIgnoreFile indicates whether an ignore file is a structured YAML ignore file, rather than a textual one
+func IsStructuredIgnoreFile(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// LoadIgnoreEntries reads a structured ignore file
+func LoadIgnoreEntries(file string) (IgnoreEntries, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseIgnoreEntries(f)
+}
+
+// ParseIgnoreEntries reads the entries of a structured ignore file and validates them
+func ParseIgnoreEntries(source io.Reader) (IgnoreEntries, error) {
+	var file ignoreFile
+
+	decoder := yaml.NewDecoder(source)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if file.Ignore.Kind == 0 {
+		return IgnoreEntries{}, nil
+	}
+
+	if file.Ignore.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("line %d: ignore must be a list of entries", file.Ignore.Line)
+	}
+
+	result := make(IgnoreEntries, 0, len(file.Ignore.Content))
+	for _, node := range file.Ignore.Content {
+		entry, err := decodeIgnoreEntry(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore entry on line %d: %w", node.Line, err)
+		}
+		entry.Line = node.Line
+
+		if err := entry.init(); err != nil {
+			return nil, fmt.Errorf("invalid ignore entry on line %d: %w", entry.Line, err)
+		}
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+var ignoreEntryFields = []string{"id", "method", "path", "operationId", "args", "reason", "owner", "expires"}
+
+// decodeIgnoreEntry decodes an entry, rejecting unknown fields, which are likely to be typos
+func decodeIgnoreEntry(node *yaml.Node) (IgnoreEntry, error) {
+	var result IgnoreEntry
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			if key := node.Content[i]; !slices.Contains(ignoreEntryFields, key.Value) {
+				return result, fmt.Errorf("unknown field %q on line %d", key.Value, key.Line)
+			}
+		}
+	}
+
+	err := node.Decode(&result)
+	return result, err
+}
+
+func (entry *IgnoreEntry) init() error {
+	if entry.Id == "" {
+		return errors.New("missing id")
+	}
+
+	if entry.Reason == "" {
+		return errors.New("missing reason")
+	}
+
+	if entry.Owner == "" {
+		return errors.New("missing owner")
+	}
+
+	var err error
+	if entry.path, err = compileGlob(entry.Path); err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+
+	return nil
+}
+
+// String describes the entry in error messages
+func (entry IgnoreEntry) String() string {
+	result := entry.Id
+	if entry.Method != "" {
+		result += " " + strings.ToUpper(entry.Method)
+	}
+	if entry.Path != "" {
+		result += " " + entry.Path
+	}
+	return fmt.Sprintf("line %d (%s)", entry.Line, result)
+}
+
+// IsExpired indicates whether the entry expired by the given time
+func (entry IgnoreEntry) IsExpired(now time.Time) bool {
+	return !entry.Expires.IsZero() && !now.Before(entry.Expires)
+}
+
+// Match indicates whether the entry ignores the change
+func (entry IgnoreEntry) Match(change Change) bool {
+	if entry.Id != change.GetId() {
+		return false
+	}
+
+	if entry.Method != "" && !strings.EqualFold(entry.Method, change.GetOperation()) {
+		return false
+	}
+
+	if entry.path != nil && !entry.path.MatchString(change.GetPath()) {
+		return false
+	}
+
+	if entry.OperationId != "" && entry.OperationId != change.GetOperationId() {
+		return false
+	}
+
+	for _, arg := range entry.Args {
+		if !containsArg(change.GetArgs(), arg) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsArg(args []any, value string) bool {
+	for _, arg := range args {
+		if interfaceToString(arg) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IgnoreResult describes the entries of a structured ignore file which need attention
+type IgnoreResult struct {
+	Expired IgnoreEntries // entries which expired and should be removed or renewed
+	Stale   IgnoreEntries // entries which didn't match any change
+}
+
+// Apply removes the changes of the given level which are matched by the entries
+// it returns the remaining changes, and the entries which expired by the given time or didn't match any change
+func (entries IgnoreEntries) Apply(level Level, errs Changes, now time.Time) (Changes, IgnoreResult) {
+	result := make(Changes, 0, len(errs))
+	matched := make([]bool, len(entries))
+
+	for _, err := range errs {
+		ignored := false
+		if err.GetLevel() == level {
+			for i, entry := range entries {
+				if entry.Match(err) {
+					matched[i] = true
+					ignored = true
+				}
+			}
+		}
+		if !ignored {
+			result = append(result, err)
+		}
+	}
+
+	ignoreResult := IgnoreResult{
+		Expired: IgnoreEntries{},
+		Stale:   IgnoreEntries{},
+	}
+	for i, entry := range entries {
+		if entry.IsExpired(now) {
+			ignoreResult.Expired = append(ignoreResult.Expired, entry)
+		}
+		if !matched[i] {
+			ignoreResult.Stale = append(ignoreResult.Stale, entry)
+		}
+	}
+
+	return result, ignoreResult
+}
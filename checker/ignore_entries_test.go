@@ -0,0 +1,119 @@
+package checker_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+)
+
+func getIgnoreEntriesChanges(t *testing.T) checker.Changes {
+	t.Helper()
+
+	rules := loadCustomRules(t)
+	return getCustomRulesChanges(t, allChecksConfig().WithCustomRules(rules))
+}
+
+func TestIsStructuredIgnoreFile(t *testing.T) {
+	require.True(t, checker.IsStructuredIgnoreFile("ignore.yaml"))
+	require.True(t, checker.IsStructuredIgnoreFile("ignore.YML"))
+	require.False(t, checker.IsStructuredIgnoreFile("ignore.txt"))
+	require.False(t, checker.IsStructuredIgnoreFile("ignore.md"))
+}
+
+func TestLoadIgnoreEntries(t *testing.T) {
+	entries, err := checker.LoadIgnoreEntries("../data/ignore/ignore-err.yaml")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, "billing-response-header-removed", entries[0].Id)
+	require.Equal(t, []string{"X-Rate-Limit"}, entries[0].Args)
+	require.Equal(t, "billing-team", entries[0].Owner)
+	require.Equal(t, time.Date(2999, 12, 31, 0, 0, 0, 0, time.UTC), entries[0].Expires)
+	require.Equal(t, 2, entries[0].Line)
+	require.True(t, entries[1].Expires.IsZero())
+	require.Equal(t, "line 9 (public-endpoint-audience-changed /billing/payments)", entries[1].String())
+}
+
+func TestIgnoreEntries_Apply(t *testing.T) {
+	errs := getIgnoreEntriesChanges(t)
+
+	entries, err := checker.LoadIgnoreEntries("../data/ignore/ignore-err.yaml")
+	require.NoError(t, err)
+
+	result, ignoreResult := entries.Apply(checker.ERR, errs, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, result, len(errs)-2)
+	for _, change := range result {
+		require.NotEqual(t, "billing-response-header-removed", change.GetId())
+		require.NotEqual(t, "/billing/payments", change.GetPath())
+	}
+	require.Empty(t, ignoreResult.Expired)
+	require.Len(t, ignoreResult.Stale, 1)
+	require.Equal(t, "/users", ignoreResult.Stale[0].Path)
+}
+
+func TestIgnoreEntries_ApplyOtherLevel(t *testing.T) {
+	errs := getIgnoreEntriesChanges(t)
+
+	entries, err := checker.LoadIgnoreEntries("../data/ignore/ignore-err.yaml")
+	require.NoError(t, err)
+
+	result, ignoreResult := entries.Apply(checker.WARN, errs, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, errs, result)
+	require.Len(t, ignoreResult.Stale, 3)
+}
+
+func TestIgnoreEntries_ApplyArgs(t *testing.T) {
+	errs := getIgnoreEntriesChanges(t)
+
+	entries, err := checker.LoadIgnoreEntries("../data/ignore/ignore-warn.yaml")
+	require.NoError(t, err)
+
+	result, ignoreResult := entries.Apply(checker.WARN, errs, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, result, len(errs)-1)
+	require.Empty(t, ignoreResult.Stale)
+
+	entries[0].Args = []string{"X-Rate-Limit", "201"}
+	result, ignoreResult = entries.Apply(checker.WARN, errs, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, errs, result)
+	require.Len(t, ignoreResult.Stale, 1)
+}
+
+func TestIgnoreEntries_Expired(t *testing.T) {
+	errs := getIgnoreEntriesChanges(t)
+
+	entries, err := checker.LoadIgnoreEntries("../data/ignore/ignore-err-expired.yaml")
+	require.NoError(t, err)
+
+	_, ignoreResult := entries.Apply(checker.ERR, errs, time.Date(2020, 1, 30, 0, 0, 0, 0, time.UTC))
+	require.Empty(t, ignoreResult.Expired)
+
+	_, ignoreResult = entries.Apply(checker.ERR, errs, time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, ignoreResult.Expired, 1)
+	require.Empty(t, ignoreResult.Stale)
+}
+
+func TestParseIgnoreEntries_Empty(t *testing.T) {
+	entries, err := checker.ParseIgnoreEntries(strings.NewReader(""))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestParseIgnoreEntries_Invalid(t *testing.T) {
+	tests := []struct {
+		entries string
+		err     string
+	}{
+		{"ignore:\n  id: x", "line 2: ignore must be a list of entries"},
+		{"ignore:\n  - reason: r\n    owner: o", "invalid ignore entry on line 2: missing id"},
+		{"ignore:\n  - id: x\n    owner: o", "invalid ignore entry on line 2: missing reason"},
+		{"ignore:\n  - id: x\n    reason: r", "invalid ignore entry on line 2: missing owner"},
+		{"ignore:\n  - id: x\n    reason: r\n    owner: o\n    expiry: 2025-01-01", `invalid ignore entry on line 2: unknown field "expiry" on line 5`},
+	}
+
+	for _, test := range tests {
+		_, err := checker.ParseIgnoreEntries(strings.NewReader(test.entries))
+		require.EqualError(t, err, test.err)
+	}
+}
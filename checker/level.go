@@ -74,22 +74,38 @@ func (level Level) IsBreaking() bool {
 }
 
 // ProcessSeverityLevels reads a file with severity levels and returns a map of severity levels
-func ProcessSeverityLevels(file string) (map[string]Level, error) {
+// the ids in the file may refer to built-in rules and to the given custom rules
+func ProcessSeverityLevels(file string, customRules ...CustomRule) (map[string]Level, error) {
 	f, err := os.Open(file)
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
 
-	return GetSeverityLevels(f)
+	return GetSeverityLevels(f, customRules...)
+}
+
+// ProcessPluginSeverityLevels is like ProcessSeverityLevels but also accepts the ids of the changes reported by plugins
+func ProcessPluginSeverityLevels(file string, customRules CustomRules, pluginChanges PluginChanges) (map[string]Level, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return getSeverityLevels(f, utils.StringList(append(append(GetAllRuleIds(), customRules.Ids()...), pluginChanges.Ids()...)).ToStringSet())
 }
 
 // GetSeverityLevels reads severity levels from a reader and returns a map of severity levels
-func GetSeverityLevels(source io.Reader) (map[string]Level, error) {
+// the ids may refer to built-in rules and to the given custom rules
+func GetSeverityLevels(source io.Reader, customRules ...CustomRule) (map[string]Level, error) {
+	return getSeverityLevels(source, utils.StringList(append(GetAllRuleIds(), CustomRules(customRules).Ids()...)).ToStringSet())
+}
 
-	result := map[string]Level{}
+// getSeverityLevels reads severity levels from a reader, validating the ids against the given ids
+func getSeverityLevels(source io.Reader, validIds utils.StringSet) (map[string]Level, error) {
 
-	validIds := utils.StringList(GetAllRuleIds()).ToStringSet()
+	result := map[string]Level{}
 
 	scanner := bufio.NewScanner(source)
 
@@ -40,6 +40,21 @@ func TestProcessSeverityLevels_OK(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestProcessPluginSeverityLevels_OK(t *testing.T) {
+	m, err := checker.ProcessPluginSeverityLevels("../data/plugins/severity-levels.txt", nil, checker.PluginChanges{{Id: "audience-changed"}, {Id: "schemas-reviewed"}})
+	require.Equal(t, map[string]checker.Level{
+		"audience-changed": checker.NONE,
+		"schemas-reviewed": checker.INFO,
+	}, m)
+	require.NoError(t, err)
+}
+
+func TestProcessPluginSeverityLevels_InvalidRuleId(t *testing.T) {
+	m, err := checker.ProcessPluginSeverityLevels("../data/plugins/severity-levels.txt", nil, checker.PluginChanges{{Id: "audience-changed"}})
+	require.Nil(t, m)
+	require.EqualError(t, err, `invalid rule id "schemas-reviewed" on line 2`)
+}
+
 func TestGetSeverityLevels_InvalidLine(t *testing.T) {
 	m, err := checker.GetSeverityLevels(strings.NewReader("invalid"))
 	require.Nil(t, m)
@@ -44,6 +44,8 @@ var localizations = map[string]string{
 	"en.messages.api-removed-without-deprecation-description":                "endpoint deleted without deprecation",
 	"en.messages.api-schema-removed":                                         "removed the schema %s",
 	"en.messages.api-schema-removed-description":                             "schema deleted from components/schemas",
+	"en.messages.api-schema-renamed":                                         "renamed the schema %s to %s",
+	"en.messages.api-schema-renamed-description":                             "schema renamed in components/schemas without changing its structure, or with minor changes only",
 	"en.messages.api-security-added":                                         "the endpoint scheme security %s was added to the API",
 	"en.messages.api-security-added-description":                             "security requirements added to endpoint",
 	"en.messages.api-security-component-added":                               "the component security scheme %s was added",
@@ -84,6 +86,8 @@ var localizations = map[string]string{
 	"en.messages.endpoint-added-description":                                          "endpoint added",
 	"en.messages.endpoint-deprecated":                                                 "endpoint deprecated",
 	"en.messages.endpoint-deprecated-description":                                     "endpoint deprecated",
+	"en.messages.endpoint-path-changed":                                               "endpoint path changed from %s to %s",
+	"en.messages.endpoint-path-changed-description":                                   "endpoint moved to another path, matched by operationId or by the x-previous-path extension",
 	"en.messages.endpoint-reactivated":                                                "endpoint reactivated",
 	"en.messages.endpoint-reactivated-description":                                    "endpoint reactivated (deprecation set to false)",
 	"en.messages.in":                                                                  "in",
@@ -130,12 +134,20 @@ var localizations = map[string]string{
 	"en.messages.request-body-became-optional-description":                            "request body became optional",
 	"en.messages.request-body-became-required":                                        "request body became required",
 	"en.messages.request-body-became-required-description":                            "request body became required",
+	"en.messages.request-body-const-added":                                            "the request body %s was restricted to the const value %s",
+	"en.messages.request-body-const-added-description":                                "request body restricted to const value",
+	"en.messages.request-body-const-changed":                                          "the request body %s const value changed from %s to %s",
+	"en.messages.request-body-const-changed-description":                              "request body const value changed",
+	"en.messages.request-body-const-removed":                                          "the request body %s const value %s was removed",
+	"en.messages.request-body-const-removed-description":                              "request body const value removed",
 	"en.messages.request-body-default-value-added":                                    "the request body %s default value %s was added",
 	"en.messages.request-body-default-value-added-description":                        "request body default value set",
 	"en.messages.request-body-default-value-changed":                                  "the request body %s default value changed from %s to %s",
 	"en.messages.request-body-default-value-changed-description":                      "request body default value modified",
 	"en.messages.request-body-default-value-removed":                                  "the request body %s default value %s was removed",
 	"en.messages.request-body-default-value-removed-description":                      "request body default value unset",
+	"en.messages.request-body-dependent-required-added":                               "the request properties %s became required when %s is present in the request body %s",
+	"en.messages.request-body-dependent-required-added-description":                   "request body dependent required properties added",
 	"en.messages.request-body-discriminator-added":                                    "added request discriminator",
 	"en.messages.request-body-discriminator-added-description":                        "request body discriminator added",
 	"en.messages.request-body-discriminator-mapping-added":                            "added %s mapping keys to the request discriminator",
@@ -193,6 +205,10 @@ var localizations = map[string]string{
 	"en.messages.request-body-type-changed-description":                               "request body type changed",
 	"en.messages.request-body-type-generalized":                                       "the request's body type/format was generalized from %s/%s to %s/%s",
 	"en.messages.request-body-type-generalized-description":                           "request body type generalized",
+	"en.messages.request-body-unevaluated-items-disallowed":                           "the request body %s no longer allows unevaluated items",
+	"en.messages.request-body-unevaluated-items-disallowed-description":               "request body unevaluated items disallowed",
+	"en.messages.request-body-unevaluated-properties-disallowed":                      "the request body %s no longer allows unevaluated properties",
+	"en.messages.request-body-unevaluated-properties-disallowed-description":          "request body unevaluated properties disallowed",
 	"en.messages.request-header-property-became-enum":                                 "the %s request header's property %s was restricted to a list of enum values",
 	"en.messages.request-header-property-became-enum-description":                     "request header property restricted to enum",
 	"en.messages.request-header-property-became-required":                             "the %s request header's property %s became required",
@@ -310,12 +326,20 @@ var localizations = map[string]string{
 	"en.messages.request-property-became-required-description":                        "request property became required",
 	"en.messages.request-property-became-required-with-default":                       "the request property %s with a default value became required",
 	"en.messages.request-property-became-required-with-default-description":           "request property with a default value became required",
+	"en.messages.request-property-const-added":                                        "the request property %s was restricted to the const value %s",
+	"en.messages.request-property-const-added-description":                            "request property restricted to const value",
+	"en.messages.request-property-const-changed":                                      "the request property %s const value changed from %s to %s",
+	"en.messages.request-property-const-changed-description":                          "request property const value changed",
+	"en.messages.request-property-const-removed":                                      "the request property %s const value %s was removed",
+	"en.messages.request-property-const-removed-description":                          "request property const value removed",
 	"en.messages.request-property-default-value-added":                                "the %s request property default value %s was added",
 	"en.messages.request-property-default-value-added-description":                    "request property default value set",
 	"en.messages.request-property-default-value-changed":                              "the %s request property default value changed from %s to %s",
 	"en.messages.request-property-default-value-changed-description":                  "request property default value changed",
 	"en.messages.request-property-default-value-removed":                              "the %s request property default value %s was removed",
 	"en.messages.request-property-default-value-removed-description":                  "request property default value unset",
+	"en.messages.request-property-dependent-required-added":                           "the request properties %s became required when %s is present in the request property %s",
+	"en.messages.request-property-dependent-required-added-description":               "request property dependent required properties added",
 	"en.messages.request-property-discriminator-added":                                "added discriminator to %s request property",
 	"en.messages.request-property-discriminator-added-description":                    "request property discriminator added",
 	"en.messages.request-property-discriminator-mapping-added":                        "added %s discriminator mapping keys to the %s request property",
@@ -381,6 +405,10 @@ var localizations = map[string]string{
 	"en.messages.request-property-type-changed-description":                           "request property type changed",
 	"en.messages.request-property-type-generalized":                                   "the %s request property type/format was generalized from %s/%s to %s/%s",
 	"en.messages.request-property-type-generalized-description":                       "request property type generalized",
+	"en.messages.request-property-unevaluated-items-disallowed":                       "the request property %s no longer allows unevaluated items",
+	"en.messages.request-property-unevaluated-items-disallowed-description":           "request property unevaluated items disallowed",
+	"en.messages.request-property-unevaluated-properties-disallowed":                  "the request property %s no longer allows unevaluated properties",
+	"en.messages.request-property-unevaluated-properties-disallowed-description":      "request property unevaluated properties disallowed",
 	"en.messages.request-property-x-extensible-enum-value-removed":                    "removed the x-extensible-enum value %s of the request property %s",
 	"en.messages.request-property-x-extensible-enum-value-removed-description":        "request property x-extensible-enum value removed",
 	"en.messages.request-read-only-property-enum-value-removed":                       "removed the enum value %s of the request read-only property %s",
@@ -411,6 +439,12 @@ var localizations = map[string]string{
 	"en.messages.response-body-any-of-removed-description":                            "sub-schema removed from anyOf in response body",
 	"en.messages.response-body-became-nullable":                                       "the response's body became nullable",
 	"en.messages.response-body-became-nullable-description":                           "response body became nullable",
+	"en.messages.response-body-const-added":                                           "the response body %s was restricted to the const value %s for the status %s",
+	"en.messages.response-body-const-added-description":                               "response body restricted to const value",
+	"en.messages.response-body-const-changed":                                         "the response body %s const value changed from %s to %s for the status %s",
+	"en.messages.response-body-const-changed-description":                             "response body const value changed",
+	"en.messages.response-body-const-removed":                                         "the response body %s const value %s was removed for the status %s",
+	"en.messages.response-body-const-removed-description":                             "response body const value removed",
 	"en.messages.response-body-default-value-added":                                   "the response body %s default value %s was added for the status %s",
 	"en.messages.response-body-default-value-added-description":                       "response body default value set",
 	"en.messages.response-body-default-value-changed":                                 "the response body %s default value changed from %s to %s for the status %s",
@@ -491,6 +525,12 @@ var localizations = map[string]string{
 	"en.messages.response-property-became-optional-description":                       "response property became optional",
 	"en.messages.response-property-became-required":                                   "the response property %s became required for the status %s",
 	"en.messages.response-property-became-required-description":                       "response property became required",
+	"en.messages.response-property-const-added":                                       "the response property %s was restricted to the const value %s for the status %s",
+	"en.messages.response-property-const-added-description":                           "response property restricted to const value",
+	"en.messages.response-property-const-changed":                                     "the response property %s const value changed from %s to %s for the status %s",
+	"en.messages.response-property-const-changed-description":                         "response property const value changed",
+	"en.messages.response-property-const-removed":                                     "the response property %s const value %s was removed for the status %s",
+	"en.messages.response-property-const-removed-description":                         "response property const value removed",
 	"en.messages.response-property-default-value-added":                               "the %s response's property default value %s was added for the status %s",
 	"en.messages.response-property-default-value-added-description":                   "response property default value set",
 	"en.messages.response-property-default-value-changed":                             "the %s response's property default value changed from %s to %s for the status %s",
@@ -571,6 +611,28 @@ var localizations = map[string]string{
 	"en.messages.sunset-deleted-description":                                          "sunset deleted",
 	"en.messages.total-changes":                                                       "%d changes: %d %s, %d %s, %d %s\n",
 	"en.messages.total-errors":                                                        "%d breaking changes: %d %s, %d %s\n",
+	"en.messages.webhook-added":                                                       "webhook added",
+	"en.messages.webhook-added-description":                                           "webhook added",
+	"en.messages.webhook-removed":                                                     "webhook removed",
+	"en.messages.webhook-removed-description":                                         "webhook removed",
+	"en.messages.webhook-request-body-removed":                                        "removed the request body sent to subscribers",
+	"en.messages.webhook-request-body-removed-description":                            "webhook request body removed",
+	"en.messages.webhook-request-media-type-removed":                                  "removed the media type %s from the request body sent to subscribers",
+	"en.messages.webhook-request-media-type-removed-description":                      "webhook request media type removed",
+	"en.messages.webhook-request-property-added":                                      "added the property %s to the request body sent to subscribers",
+	"en.messages.webhook-request-property-added-description":                          "webhook request property added",
+	"en.messages.webhook-request-property-became-optional":                            "the property %s of the request body sent to subscribers became optional",
+	"en.messages.webhook-request-property-became-optional-description":                "webhook request property became optional",
+	"en.messages.webhook-request-property-removed":                                    "removed the property %s from the request body sent to subscribers",
+	"en.messages.webhook-request-property-removed-description":                        "webhook request property removed",
+	"en.messages.webhook-request-property-type-changed":                               "the type/format of the property %s of the request body sent to subscribers changed from %s/%s to %s/%s",
+	"en.messages.webhook-request-property-type-changed-description":                   "webhook request property type changed",
+	"en.messages.webhook-response-property-became-required":                           "the property %s of the subscriber response with the status %s became required",
+	"en.messages.webhook-response-property-became-required-description":               "webhook response property became required",
+	"en.messages.webhook-response-required-property-added":                            "added the required property %s to the subscriber response with the status %s",
+	"en.messages.webhook-response-required-property-added-description":                "webhook response required property added",
+	"en.messages.webhook-response-success-status-removed":                             "removed the success response with the status %s that subscribers may return",
+	"en.messages.webhook-response-success-status-removed-description":                 "webhook response success status removed",
 	"ru.messages.api-deprecated-sunset-missing":                                       "API устарел без даты прекращения действия",
 	"ru.messages.api-deprecated-sunset-parse":                                         "не удалось проанализировать дату заката: %v",
 	"ru.messages.api-global-security-added":                                           "схема безопасности %s была добавлена к API",
@@ -591,6 +653,7 @@ var localizations = map[string]string{
 	"ru.messages.api-removed-with-deprecation":                                        "API удалён с процедурой deprecation",
 	"ru.messages.api-removed-without-deprecation":                                     "API удалён без deprecation",
 	"ru.messages.api-schema-removed":                                                  "удалена схема %s",
+	"ru.messages.api-schema-renamed":                                                  "схема %s переименована в %s",
 	"ru.messages.api-security-added":                                                  "схема безопасности точки доступа %s была добавлена к API",
 	"ru.messages.api-security-component-added":                                        "компонент схемы безопасности %s был добавлен",
 	"ru.messages.api-security-component-oauth-scope-added":                            "добавлено разрешение OAuth %s для компонента схемы безопасности %s",
@@ -610,6 +673,7 @@ var localizations = map[string]string{
 	"ru.messages.api-tag-added":                                                       "тег API %s добавлен",
 	"ru.messages.api-tag-removed":                                                     "Тег API %s удален",
 	"ru.messages.at":                                                                  "в",
+	"ru.messages.endpoint-path-changed":                                               "путь эндпоинта изменен с %s на %s",
 	"ru.messages.in":                                                                  "в",
 	"ru.messages.new-optional-request-default-parameter-to-existing-path":             "добавлен новый необязательный %s параметр запроса %s ко всем операциям пути",
 	"ru.messages.new-optional-request-parameter":                                      "добавлен новый необязательный %s параметр зароса %s",
@@ -633,9 +697,13 @@ var localizations = map[string]string{
 	"ru.messages.request-body-became-nullable":                                        "тело запроса стало обнуляемым",
 	"ru.messages.request-body-became-optional":                                        "тело запроса стало необязательным",
 	"ru.messages.request-body-became-required":                                        "тело запроса стало обязательным",
+	"ru.messages.request-body-const-added":                                            "тело запроса %s было ограничено константой %s",
+	"ru.messages.request-body-const-changed":                                          "константа тела запроса %s изменена с %s на %s",
+	"ru.messages.request-body-const-removed":                                          "константа тела запроса %s со значением %s удалена",
 	"ru.messages.request-body-default-value-added":                                    "добавлено значение по умолчанию %s для тела запроса",
 	"ru.messages.request-body-default-value-changed":                                  "значение по умолчанию для тела запроса изменено с %s на %s",
 	"ru.messages.request-body-default-value-removed":                                  "удалено значение по умолчанию %s для тела запроса %s",
+	"ru.messages.request-body-dependent-required-added":                               "поля запроса %s стали обязательными при наличии %s в теле запроса %s",
 	"ru.messages.request-body-discriminator-added":                                    "добавлен дискриминатор запроса",
 	"ru.messages.request-body-discriminator-mapping-added":                            "добавлены ключи сопоставления %s для дискриминатора запроса",
 	"ru.messages.request-body-discriminator-mapping-changed":                          "значение для ключа %s изменено с %s на %s в дискриминаторе запроса",
@@ -667,6 +735,8 @@ var localizations = map[string]string{
 	"ru.messages.request-body-one-of-removed":                                         "удалён %s из списка 'oneOf' тела запроса",
 	"ru.messages.request-body-type-changed":                                           "изменился type/format тела запроса с %s/%s на %s/%s",
 	"ru.messages.request-body-type-generalized":                                       "изменился type/format запроса обобщён с %s/%s до %s/%s",
+	"ru.messages.request-body-unevaluated-items-disallowed":                           "тело запроса %s больше не допускает неописанные элементы",
+	"ru.messages.request-body-unevaluated-properties-disallowed":                      "тело запроса %s больше не допускает неописанные поля",
 	"ru.messages.request-header-property-became-enum":                                 "свойство %s заголовка запроса %s было ограничено списком значений перечисления",
 	"ru.messages.request-header-property-became-required":                             "в заголовке запроса %s поле %s стало обязательным",
 	"ru.messages.request-optional-property-became-not-read-only":                      "необязательное поле запроса %s перестало быть только для чтения",
@@ -723,9 +793,13 @@ var localizations = map[string]string{
 	"ru.messages.request-property-became-optional":                                    "поле запроса %s стало необязательным",
 	"ru.messages.request-property-became-required":                                    "поле запроса %s стало обязательным",
 	"ru.messages.request-property-became-required-with-default":                       "свойство запроса %s стало обязательным со значением по умолчанию",
+	"ru.messages.request-property-const-added":                                        "поле запроса %s было ограничено константой %s",
+	"ru.messages.request-property-const-changed":                                      "константа поля запроса %s изменена с %s на %s",
+	"ru.messages.request-property-const-removed":                                      "константа поля запроса %s со значением %s удалена",
 	"ru.messages.request-property-default-value-added":                                "добавлено значение по умолчанию %s для свойства запроса %s",
 	"ru.messages.request-property-default-value-changed":                              "значение по умолчанию для свойства запроса %s изменено с %s на %s",
 	"ru.messages.request-property-default-value-removed":                              "удалено значение по умолчанию %s для свойства запроса %s",
+	"ru.messages.request-property-dependent-required-added":                           "поля запроса %s стали обязательными при наличии %s в поле запроса %s",
 	"ru.messages.request-property-discriminator-added":                                "добавлен дискриминатор к свойству запроса %s",
 	"ru.messages.request-property-discriminator-mapping-added":                        "добавлены ключи сопоставления дискриминатора %s для свойства запроса %s",
 	"ru.messages.request-property-discriminator-mapping-changed":                      "значение для ключа дискриминатора %s изменено с %s на %s для свойства запроса %s",
@@ -761,6 +835,8 @@ var localizations = map[string]string{
 	"ru.messages.request-property-removed":                                            "удалено поле запроса %s",
 	"ru.messages.request-property-type-changed":                                       "у поля запроса %s изменился type/format с %s/%s на %s/%s",
 	"ru.messages.request-property-type-generalized":                                   "Тип/формат поля запроса %s был обобщен с %s/%s на %s/%s.",
+	"ru.messages.request-property-unevaluated-items-disallowed":                       "поле запроса %s больше не допускает неописанные элементы",
+	"ru.messages.request-property-unevaluated-properties-disallowed":                  "поле запроса %s больше не допускает неописанные поля",
 	"ru.messages.request-property-x-extensible-enum-value-removed":                    "удалено значение x-extensible-enum %s в поле запроса %s",
 	"ru.messages.request-read-only-property-enum-value-removed":                       "удалено enum значение %s из поля запроса только для чтения %s",
 	"ru.messages.request-read-only-property-max-decreased":                            "максимальное значение поля запроса только для чтения %s уменьшено до %s",
@@ -776,6 +852,9 @@ var localizations = map[string]string{
 	"ru.messages.response-body-any-of-added":                                          "добавлено %s в список 'anyOf' тела ответа для статуса ответа %s",
 	"ru.messages.response-body-any-of-removed":                                        "удалён %s из списка 'anyOf' тела ответа для статуса ответа %s",
 	"ru.messages.response-body-became-nullable":                                       "у тела ответа стало обнуляемым",
+	"ru.messages.response-body-const-added":                                           "тело ответа %s было ограничено константой %s для статуса %s",
+	"ru.messages.response-body-const-changed":                                         "константа тела ответа %s изменена с %s на %s для статуса %s",
+	"ru.messages.response-body-const-removed":                                         "константа тела ответа %s со значением %s удалена для статуса %s",
 	"ru.messages.response-body-default-value-added":                                   "добавлено значение по умолчанию %s для тела ответа для статуса %s",
 	"ru.messages.response-body-default-value-changed":                                 "значение по умолчанию для тела ответа %s изменено с %s на %s для статуса %s",
 	"ru.messages.response-body-default-value-removed":                                 "удалено значение по умолчанию %s для тела ответа для статуса %s",
@@ -817,6 +896,9 @@ var localizations = map[string]string{
 	"ru.messages.response-property-became-optional":                                   "поле ответа %s стало необязательным для ответа со статусом %s",
 	"ru.messages.response-property-became-required":                                   "свойство %s перестало быть необязательным для ответа со статусом %s",
 	"ru.messages.response-property-became-write-only":                                 "свойство %s перестало быть только для записи для ответа со статусом %s",
+	"ru.messages.response-property-const-added":                                       "поле ответа %s было ограничено константой %s для статуса %s",
+	"ru.messages.response-property-const-changed":                                     "константа поля ответа %s изменена с %s на %s для статуса %s",
+	"ru.messages.response-property-const-removed":                                     "константа поля ответа %s со значением %s удалена для статуса %s",
 	"ru.messages.response-property-default-value-added":                               "добавлено значение по умолчанию %s для свойства ответа %s для статуса %s",
 	"ru.messages.response-property-default-value-changed":                             "значение по умолчанию для свойства ответа %s изменено с %s на %s для статуса %s",
 	"ru.messages.response-property-default-value-removed":                             "удалено значение по умолчанию %s для свойства ответа %s для статуса %s",
@@ -858,6 +940,17 @@ var localizations = map[string]string{
 	"ru.messages.sunset-deleted":                                                      "удалена дата sunset date у API, но сохранён deprecated=true",
 	"ru.messages.total-changes":                                                       "%s изменений: %s %s, %s %s, %s %s\n",
 	"ru.messages.total-errors":                                                        "%s критические изменения: %s %s, %s %s\n",
+	"ru.messages.webhook-added":                                                       "добавлен вебхук",
+	"ru.messages.webhook-removed":                                                     "удалён вебхук",
+	"ru.messages.webhook-request-body-removed":                                        "удалено тело запроса, отправляемого подписчикам",
+	"ru.messages.webhook-request-media-type-removed":                                  "удалён media type %s из тела запроса, отправляемого подписчикам",
+	"ru.messages.webhook-request-property-added":                                      "добавлено поле %s в тело запроса, отправляемого подписчикам",
+	"ru.messages.webhook-request-property-became-optional":                            "поле %s тела запроса, отправляемого подписчикам, стало необязательным",
+	"ru.messages.webhook-request-property-removed":                                    "удалено поле %s из тела запроса, отправляемого подписчикам",
+	"ru.messages.webhook-request-property-type-changed":                               "type/format поля %s тела запроса, отправляемого подписчикам, изменен с %s/%s на %s/%s",
+	"ru.messages.webhook-response-property-became-required":                           "поле %s ответа подписчика со статусом %s стало обязательным",
+	"ru.messages.webhook-response-required-property-added":                            "добавлено обязательное поле %s в ответ подписчика со статусом %s",
+	"ru.messages.webhook-response-success-status-removed":                             "удален успешный (2xx) статус ответа подписчика %s",
 }
 
 type Replacements map[string]interface{}
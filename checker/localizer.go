@@ -28,3 +28,11 @@ func NewLocalizer(locale string) Localizer {
 		return fmt.Sprintf(pattern, args...)
 	}
 }
+
+// localizeMessage returns the text of a change, which is either given by the message template of a custom rule or localized by the change id
+func localizeMessage(l Localizer, id, message string, args []any) string {
+	if message != "" {
+		return fmt.Sprintf(message, args...)
+	}
+	return l(id, args...)
+}
@@ -0,0 +1,208 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/utils"
+)
+
+// PluginProtocolVersion is the version of the JSON protocol between oasdiff and check plugins
+const PluginProtocolVersion = 1
+
+// DefaultPluginTimeout is the time that a check plugin may run, unless the context given to RunPlugin ends sooner
+const DefaultPluginTimeout = time.Minute
+
+// PluginRequest is sent to the standard input of a check plugin
+type PluginRequest struct {
+	Version           int                     `json:"version"`
+	Diff              *diff.Diff              `json:"diff"`
+	Base              *openapi3.T             `json:"base,omitempty"`
+	Revision          *openapi3.T             `json:"revision,omitempty"`
+	OperationsSources PluginOperationsSources `json:"operationsSources"`
+}
+
+// PluginOperationsSources maps the paths and methods of the base and revision operations to the files that they were loaded from
+type PluginOperationsSources struct {
+	Base     map[string]map[string]string `json:"base"`
+	Revision map[string]map[string]string `json:"revision"`
+}
+
+// PluginResponse is read from the standard output of a check plugin
+type PluginResponse struct {
+	Changes PluginChanges `json:"changes"`
+}
+
+// PluginChange is a change reported by a check plugin
+// changes in the paths section have a path and an operation, other changes may specify the component that they belong to
+type PluginChange struct {
+	Id        string `json:"id"`
+	Level     string `json:"level"`
+	Text      string `json:"text"`
+	Comment   string `json:"comment,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Operation string `json:"operation,omitempty"`
+	Component string `json:"component,omitempty"`
+}
+
+// PluginChanges is a list of changes reported by check plugins
+type PluginChanges []PluginChange
+
+// NewPluginRequest creates the request sent to check plugins
+// base and revision may be nil, for example, when comparing collections of specs
+// like the json output of the diff, the request excludes the endpoints section of the diff since its keys can't be represented in JSON
+func NewPluginRequest(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, base, revision *openapi3.T) *PluginRequest {
+	var diffWithoutEndpoints *diff.Diff
+	if diffReport != nil {
+		diffCopy := *diffReport
+		diffCopy.EndpointsDiff = nil
+		diffWithoutEndpoints = &diffCopy
+	}
+
+	result := PluginRequest{
+		Version:  PluginProtocolVersion,
+		Diff:     diffWithoutEndpoints,
+		Base:     base,
+		Revision: revision,
+		OperationsSources: PluginOperationsSources{
+			Base:     map[string]map[string]string{},
+			Revision: map[string]map[string]string{},
+		},
+	}
+
+	if diffReport == nil || diffReport.PathsDiff == nil || operationsSources == nil {
+		return &result
+	}
+
+	addOperationsSources(result.OperationsSources.Base, diffReport.PathsDiff.Base, operationsSources)
+	addOperationsSources(result.OperationsSources.Revision, diffReport.PathsDiff.Revision, operationsSources)
+
+	return &result
+}
+
+func addOperationsSources(result map[string]map[string]string, paths *openapi3.Paths, operationsSources *diff.OperationsSourcesMap) {
+	if paths == nil {
+		return
+	}
+
+	for path, pathItem := range paths.Map() {
+		for method, operation := range pathItem.Operations() {
+			source, ok := (*operationsSources)[operation]
+			if !ok {
+				continue
+			}
+			if _, ok := result[path]; !ok {
+				result[path] = map[string]string{}
+			}
+			result[path][method] = source
+		}
+	}
+}
+
+// RunPlugin runs a check plugin: an executable which reads a PluginRequest from its standard input and writes a PluginResponse to its standard output
+// the plugin is killed when the context ends or after DefaultPluginTimeout, whichever comes first
+func RunPlugin(ctx context.Context, plugin string, request *PluginRequest) (PluginChanges, error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize the request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultPluginTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, plugin)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// don't wait for subprocesses of a killed plugin which keep its output open
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("plugin was stopped: %w", ctxErr)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%w: %s", err, message)
+		}
+		return nil, err
+	}
+
+	var response PluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+
+	if err := response.Changes.init(); err != nil {
+		return nil, err
+	}
+
+	return response.Changes, nil
+}
+
+// init validates the changes and normalizes their operations
+func (changes PluginChanges) init() error {
+	builtInIds := utils.StringList(GetAllRuleIds()).ToStringSet()
+	for i := range changes {
+		if err := changes[i].init(builtInIds); err != nil {
+			return fmt.Errorf("invalid change #%d %q: %w", i+1, changes[i].Id, err)
+		}
+	}
+	return nil
+}
+
+// Ids returns the ids of the changes, without duplicates
+func (changes PluginChanges) Ids() []string {
+	result := []string{}
+	seen := utils.StringSet{}
+	for _, change := range changes {
+		if seen.Contains(change.Id) {
+			continue
+		}
+		seen.Add(change.Id)
+		result = append(result, change.Id)
+	}
+	return result
+}
+
+func (change *PluginChange) init(builtInIds utils.StringSet) error {
+	if change.Id == "" {
+		return errors.New("missing id")
+	}
+
+	if builtInIds.Contains(change.Id) {
+		return errors.New("id is already used by a built-in rule")
+	}
+
+	if _, err := NewLevel(change.Level); err != nil {
+		return err
+	}
+
+	if change.Text == "" {
+		return errors.New("missing text")
+	}
+
+	if (change.Path == "") != (change.Operation == "") {
+		return errors.New("path and operation must be specified together")
+	}
+	change.Operation = strings.ToUpper(change.Operation)
+
+	return nil
+}
+
+// getLevel returns the level of the change, or INVALID if it isn't a valid level
+func (change PluginChange) getLevel() Level {
+	level, err := NewLevel(change.Level)
+	if err != nil {
+		return INVALID
+	}
+	return level
+}
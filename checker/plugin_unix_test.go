@@ -0,0 +1,66 @@
+//go:build unix
+
+package checker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+)
+
+func getPluginRequest(t *testing.T) *checker.PluginRequest {
+	t.Helper()
+
+	s1, err := open("../data/custom-rules/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/custom-rules/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	return checker.NewPluginRequest(d, osm, s1.Spec, s2.Spec)
+}
+
+func TestRunPlugin(t *testing.T) {
+	changes, err := checker.RunPlugin(context.Background(), "../data/plugins/audience.sh", getPluginRequest(t))
+	require.NoError(t, err)
+	require.Equal(t, checker.PluginChanges{
+		{Id: "audience-changed", Level: "err", Path: "/billing/invoices", Operation: "GET", Text: "the audience of the endpoint changed, 100% breaking", Comment: "ask the platform team before changing the audience"},
+		{Id: "schemas-reviewed", Level: "info", Component: "schemas", Text: "component schemas were reviewed"},
+	}, changes)
+}
+
+func TestRunPlugin_NoChanges(t *testing.T) {
+	changes, err := checker.RunPlugin(context.Background(), "../data/plugins/audience.sh", checker.NewPluginRequest(&diff.Diff{}, nil, nil, nil))
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestRunPlugin_Failed(t *testing.T) {
+	_, err := checker.RunPlugin(context.Background(), "../data/plugins/fail.sh", getPluginRequest(t))
+	require.EqualError(t, err, "exit status 3: something went wrong")
+}
+
+func TestRunPlugin_InvalidChange(t *testing.T) {
+	_, err := checker.RunPlugin(context.Background(), "../data/plugins/invalid.sh", getPluginRequest(t))
+	require.EqualError(t, err, `invalid change #1 "audience-changed": invalid level `)
+}
+
+func TestRunPlugin_NotFound(t *testing.T) {
+	_, err := checker.RunPlugin(context.Background(), "../data/plugins/missing.sh", getPluginRequest(t))
+	require.Error(t, err)
+}
+
+func TestRunPlugin_Timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := checker.RunPlugin(ctx, "../data/plugins/hang.sh", getPluginRequest(t))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
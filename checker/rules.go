@@ -228,6 +228,13 @@ func GetAllRules() BackwardCompatibilityRules {
 		newBackwardCompatibilityRule(RequestBodyBecomeNullableId, INFO, RequestPropertyBecameNotNullableCheck, DirectionRequest, LocationBody, ActionChange),
 		newBackwardCompatibilityRule(RequestPropertyBecomeNotNullableId, ERR, RequestPropertyBecameNotNullableCheck, DirectionRequest, LocationProperties, ActionChange),
 		newBackwardCompatibilityRule(RequestPropertyBecomeNullableId, INFO, RequestPropertyBecameNotNullableCheck, DirectionRequest, LocationProperties, ActionChange),
+		// RequestPropertyConstUpdatedCheck
+		newBackwardCompatibilityRule(RequestBodyConstAddedId, ERR, RequestPropertyConstUpdatedCheck, DirectionRequest, LocationBody, ActionAdd),
+		newBackwardCompatibilityRule(RequestBodyConstChangedId, ERR, RequestPropertyConstUpdatedCheck, DirectionRequest, LocationBody, ActionChange),
+		newBackwardCompatibilityRule(RequestBodyConstRemovedId, INFO, RequestPropertyConstUpdatedCheck, DirectionRequest, LocationBody, ActionRemove),
+		newBackwardCompatibilityRule(RequestPropertyConstAddedId, ERR, RequestPropertyConstUpdatedCheck, DirectionRequest, LocationProperties, ActionAdd),
+		newBackwardCompatibilityRule(RequestPropertyConstChangedId, ERR, RequestPropertyConstUpdatedCheck, DirectionRequest, LocationProperties, ActionChange),
+		newBackwardCompatibilityRule(RequestPropertyConstRemovedId, INFO, RequestPropertyConstUpdatedCheck, DirectionRequest, LocationProperties, ActionRemove),
 		// RequestPropertyDefaultValueChangedCheck
 		newBackwardCompatibilityRule(RequestBodyDefaultValueAddedId, INFO, RequestPropertyDefaultValueChangedCheck, DirectionRequest, LocationBody, ActionAdd),
 		newBackwardCompatibilityRule(RequestBodyDefaultValueRemovedId, INFO, RequestPropertyDefaultValueChangedCheck, DirectionRequest, LocationBody, ActionRemove),
@@ -235,6 +242,9 @@ func GetAllRules() BackwardCompatibilityRules {
 		newBackwardCompatibilityRule(RequestPropertyDefaultValueAddedId, INFO, RequestPropertyDefaultValueChangedCheck, DirectionRequest, LocationProperties, ActionAdd),
 		newBackwardCompatibilityRule(RequestPropertyDefaultValueRemovedId, INFO, RequestPropertyDefaultValueChangedCheck, DirectionRequest, LocationProperties, ActionRemove),
 		newBackwardCompatibilityRule(RequestPropertyDefaultValueChangedId, INFO, RequestPropertyDefaultValueChangedCheck, DirectionRequest, LocationProperties, ActionChange),
+		// RequestPropertyDependentRequiredAddedCheck
+		newBackwardCompatibilityRule(RequestBodyDependentRequiredAddedId, ERR, RequestPropertyDependentRequiredAddedCheck, DirectionRequest, LocationBody, ActionAdd),
+		newBackwardCompatibilityRule(RequestPropertyDependentRequiredAddedId, ERR, RequestPropertyDependentRequiredAddedCheck, DirectionRequest, LocationProperties, ActionAdd),
 		// RequestPropertyEnumValueUpdatedCheck
 		newBackwardCompatibilityRule(RequestPropertyEnumValueRemovedId, ERR, RequestPropertyEnumValueUpdatedCheck, DirectionRequest, LocationProperties, ActionRemove),
 		newBackwardCompatibilityRule(RequestReadOnlyPropertyEnumValueRemovedId, INFO, RequestPropertyEnumValueUpdatedCheck, DirectionRequest, LocationProperties, ActionRemove),
@@ -296,6 +306,11 @@ func GetAllRules() BackwardCompatibilityRules {
 		newBackwardCompatibilityRule(RequestBodyTypeChangedId, ERR, RequestPropertyTypeChangedCheck, DirectionRequest, LocationBody, ActionChange),
 		newBackwardCompatibilityRule(RequestPropertyTypeGeneralizedId, INFO, RequestPropertyTypeChangedCheck, DirectionRequest, LocationProperties, ActionGeneralize),
 		newBackwardCompatibilityRule(RequestPropertyTypeChangedId, ERR, RequestPropertyTypeChangedCheck, DirectionRequest, LocationProperties, ActionChange),
+		// RequestPropertyUnevaluatedUpdatedCheck
+		newBackwardCompatibilityRule(RequestBodyUnevaluatedPropertiesDisallowedId, ERR, RequestPropertyUnevaluatedUpdatedCheck, DirectionRequest, LocationBody, ActionChange),
+		newBackwardCompatibilityRule(RequestPropertyUnevaluatedPropertiesDisallowedId, ERR, RequestPropertyUnevaluatedUpdatedCheck, DirectionRequest, LocationProperties, ActionChange),
+		newBackwardCompatibilityRule(RequestBodyUnevaluatedItemsDisallowedId, ERR, RequestPropertyUnevaluatedUpdatedCheck, DirectionRequest, LocationBody, ActionChange),
+		newBackwardCompatibilityRule(RequestPropertyUnevaluatedItemsDisallowedId, ERR, RequestPropertyUnevaluatedUpdatedCheck, DirectionRequest, LocationProperties, ActionChange),
 		// RequestPropertyUpdatedCheck
 		newBackwardCompatibilityRule(RequestPropertyRemovedId, WARN, RequestPropertyUpdatedCheck, DirectionRequest, LocationProperties, ActionRemove),
 		newBackwardCompatibilityRule(NewRequiredRequestPropertyId, ERR, RequestPropertyUpdatedCheck, DirectionRequest, LocationProperties, ActionAdd),
@@ -366,6 +381,13 @@ func GetAllRules() BackwardCompatibilityRules {
 		// ResponsePropertyBecameRequiredCheck
 		newBackwardCompatibilityRule(ResponsePropertyBecameRequiredId, INFO, ResponsePropertyBecameRequiredCheck, DirectionResponse, LocationProperties, ActionChange),
 		newBackwardCompatibilityRule(ResponseWriteOnlyPropertyBecameRequiredId, INFO, ResponsePropertyBecameRequiredCheck, DirectionResponse, LocationProperties, ActionChange),
+		// ResponsePropertyConstUpdatedCheck
+		newBackwardCompatibilityRule(ResponseBodyConstAddedId, INFO, ResponsePropertyConstUpdatedCheck, DirectionResponse, LocationBody, ActionAdd),
+		newBackwardCompatibilityRule(ResponseBodyConstChangedId, ERR, ResponsePropertyConstUpdatedCheck, DirectionResponse, LocationBody, ActionChange),
+		newBackwardCompatibilityRule(ResponseBodyConstRemovedId, WARN, ResponsePropertyConstUpdatedCheck, DirectionResponse, LocationBody, ActionRemove),
+		newBackwardCompatibilityRule(ResponsePropertyConstAddedId, INFO, ResponsePropertyConstUpdatedCheck, DirectionResponse, LocationProperties, ActionAdd),
+		newBackwardCompatibilityRule(ResponsePropertyConstChangedId, ERR, ResponsePropertyConstUpdatedCheck, DirectionResponse, LocationProperties, ActionChange),
+		newBackwardCompatibilityRule(ResponsePropertyConstRemovedId, WARN, ResponsePropertyConstUpdatedCheck, DirectionResponse, LocationProperties, ActionRemove),
 		// ResponsePropertyDefaultValueChangedCheck
 		newBackwardCompatibilityRule(ResponseBodyDefaultValueAddedId, INFO, ResponsePropertyDefaultValueChangedCheck, DirectionResponse, LocationBody, ActionAdd),
 		newBackwardCompatibilityRule(ResponseBodyDefaultValueRemovedId, INFO, ResponsePropertyDefaultValueChangedCheck, DirectionResponse, LocationBody, ActionRemove),
@@ -421,6 +443,20 @@ func GetAllRules() BackwardCompatibilityRules {
 		// ResponseNonSuccessStatusUpdatedCheck
 		newBackwardCompatibilityRule(ResponseNonSuccessStatusRemovedId, INFO, ResponseNonSuccessStatusUpdatedCheck, DirectionResponse, LocationNone, ActionRemove), // optional
 		newBackwardCompatibilityRule(ResponseNonSuccessStatusAddedId, INFO, ResponseNonSuccessStatusUpdatedCheck, DirectionResponse, LocationNone, ActionAdd),
+		// WebhookUpdatedCheck
+		newBackwardCompatibilityRule(WebhookAddedId, INFO, WebhookUpdatedCheck, DirectionNone, LocationNone, ActionAdd),
+		newBackwardCompatibilityRule(WebhookRemovedId, ERR, WebhookUpdatedCheck, DirectionNone, LocationNone, ActionRemove),
+		newBackwardCompatibilityRule(WebhookRequestBodyRemovedId, ERR, WebhookUpdatedCheck, DirectionRequest, LocationBody, ActionRemove),
+		newBackwardCompatibilityRule(WebhookRequestMediaTypeRemovedId, ERR, WebhookUpdatedCheck, DirectionRequest, LocationBody, ActionRemove),
+		newBackwardCompatibilityRule(WebhookRequestPropertyRemovedId, ERR, WebhookUpdatedCheck, DirectionRequest, LocationProperties, ActionRemove),
+		newBackwardCompatibilityRule(WebhookRequestPropertyAddedId, INFO, WebhookUpdatedCheck, DirectionRequest, LocationProperties, ActionAdd),
+		newBackwardCompatibilityRule(WebhookRequestPropertyBecameOptionalId, ERR, WebhookUpdatedCheck, DirectionRequest, LocationProperties, ActionChange),
+		newBackwardCompatibilityRule(WebhookRequestPropertyTypeChangedId, ERR, WebhookUpdatedCheck, DirectionRequest, LocationProperties, ActionChange),
+		newBackwardCompatibilityRule(WebhookResponseSuccessStatusRemovedId, ERR, WebhookUpdatedCheck, DirectionResponse, LocationNone, ActionRemove),
+		newBackwardCompatibilityRule(WebhookResponseRequiredPropertyAddedId, ERR, WebhookUpdatedCheck, DirectionResponse, LocationProperties, ActionAdd),
+		newBackwardCompatibilityRule(WebhookResponsePropertyBecameRequiredId, ERR, WebhookUpdatedCheck, DirectionResponse, LocationProperties, ActionChange),
+		// APIPathChangedCheck
+		newBackwardCompatibilityRule(EndpointPathChangedId, ERR, APIPathChangedCheck, DirectionNone, LocationNone, ActionChange),
 		// APIOperationIdUpdatedCheck
 		newBackwardCompatibilityRule(APIOperationIdRemovedId, INFO, APIOperationIdUpdatedCheck, DirectionNone, LocationNone, ActionRemove), // optional
 		newBackwardCompatibilityRule(APIOperationIdAddId, INFO, APIOperationIdUpdatedCheck, DirectionNone, LocationNone, ActionAdd),
@@ -429,6 +465,8 @@ func GetAllRules() BackwardCompatibilityRules {
 		newBackwardCompatibilityRule(APITagAddedId, INFO, APITagUpdatedCheck, DirectionNone, LocationNone, ActionAdd),
 		// APIComponentsSchemaRemovedCheck
 		newBackwardCompatibilityRule(APISchemasRemovedId, INFO, APIComponentsSchemaRemovedCheck, DirectionNone, LocationComponents, ActionRemove), // optional
+		// APIComponentsSchemaRenamedCheck
+		newBackwardCompatibilityRule(APISchemasRenamedId, INFO, APIComponentsSchemaRenamedCheck, DirectionNone, LocationComponents, ActionChange),
 		// ResponseParameterEnumValueRemovedCheck
 		newBackwardCompatibilityRule(ResponsePropertyEnumValueRemovedId, INFO, ResponseParameterEnumValueRemovedCheck, DirectionResponse, LocationProperties, ActionRemove), // optional
 		// ResponseMediaTypeEnumValueRemovedCheck
@@ -23,6 +23,22 @@ type SecurityChange struct {
 	SourceColumnEnd int
 }
 
+// withSource points the change at the source position of a JSON pointer, taken from the base for deleted elements and from the revision otherwise
+// if the pointer can't be located, the change is returned unmodified
+func (c SecurityChange) withSource(config *Config, pointer string, deleted bool) SecurityChange {
+	file, position, ok := config.locatePointer(pointer, deleted)
+	if !ok {
+		return c
+	}
+
+	c.SourceFile = file
+	c.SourceLine = position.Line
+	c.SourceLineEnd = position.LineEnd
+	c.SourceColumn = position.Column
+	c.SourceColumnEnd = position.ColumnEnd
+	return c
+}
+
 func (c SecurityChange) GetSection() string {
 	return "security"
 }
@@ -0,0 +1,264 @@
+package checker
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tufin/oasdiff/diff"
+)
+
+// SemverBump is a semantic versioning bump
+type SemverBump int
+
+const (
+	SemverNone SemverBump = iota
+	SemverPatch
+	SemverMinor
+	SemverMajor
+)
+
+func (bump SemverBump) String() string {
+	switch bump {
+	case SemverPatch:
+		return "patch"
+	case SemverMinor:
+		return "minor"
+	case SemverMajor:
+		return "major"
+	}
+	return "none"
+}
+
+func (bump SemverBump) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(bump.String())), nil
+}
+
+func (bump SemverBump) MarshalYAML() (interface{}, error) {
+	return bump.String(), nil
+}
+
+// docsOnlyIds are the ids of changes which only affect the documentation of the API
+var docsOnlyIds = map[string]struct{}{
+	"api-tag-added":   {},
+	"api-tag-removed": {},
+}
+
+// GetRequiredBump returns the minimal semantic versioning bump required by the changes:
+//   - major if there are breaking changes (level ERR)
+//   - minor if there are other changes, like added endpoints or optional fields
+//   - patch if the specs only differ in their documentation, like descriptions, which aren't reported as changes
+//   - none if the specs are identical
+func GetRequiredBump(changes Changes, diffReport *diff.Diff) SemverBump {
+	result := SemverNone
+	if !diffReport.Empty() {
+		result = SemverPatch
+	}
+
+	for _, change := range changes {
+		if change.GetLevel() == ERR {
+			return SemverMajor
+		}
+		if _, ok := docsOnlyIds[change.GetId()]; ok {
+			result = max(result, SemverPatch)
+			continue
+		}
+		result = SemverMinor
+	}
+
+	return result
+}
+
+var semverRegex = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// Semver is a parsed semantic version, with an optional "v" prefix
+type Semver struct {
+	Prefix     string
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease string
+}
+
+// ParseSemver parses a semantic version like 1.2.3, v1.2.3 or 1.2.3-beta+build
+func ParseSemver(version string) (Semver, error) {
+	match := semverRegex.FindStringSubmatch(version)
+	if match == nil {
+		return Semver{}, fmt.Errorf("%q isn't a semantic version", version)
+	}
+
+	result := Semver{Prefix: match[1], PreRelease: match[5]}
+	var err error
+	if result.Major, err = strconv.ParseUint(match[2], 10, 64); err != nil {
+		return Semver{}, fmt.Errorf("%q isn't a semantic version: %w", version, err)
+	}
+	if result.Minor, err = strconv.ParseUint(match[3], 10, 64); err != nil {
+		return Semver{}, fmt.Errorf("%q isn't a semantic version: %w", version, err)
+	}
+	if result.Patch, err = strconv.ParseUint(match[4], 10, 64); err != nil {
+		return Semver{}, fmt.Errorf("%q isn't a semantic version: %w", version, err)
+	}
+
+	return result, nil
+}
+
+func (version Semver) String() string {
+	result := fmt.Sprintf("%s%d.%d.%d", version.Prefix, version.Major, version.Minor, version.Patch)
+	if version.PreRelease != "" {
+		result += "-" + version.PreRelease
+	}
+	return result
+}
+
+// Bump returns the next release version after the given bump
+// a pre-release is followed by its release if the release is already a large enough bump, like 1.0.0-rc.1 to 1.0.0 for a major bump
+func (version Semver) Bump(bump SemverBump) Semver {
+	result := Semver{Prefix: version.Prefix, Major: version.Major, Minor: version.Minor, Patch: version.Patch}
+	if bump == SemverNone {
+		result.PreRelease = version.PreRelease
+		return result
+	}
+	if version.PreRelease != "" && bump <= version.releaseBump() {
+		return result
+	}
+
+	switch bump {
+	case SemverMajor:
+		result.Major++
+		result.Minor = 0
+		result.Patch = 0
+	case SemverMinor:
+		result.Minor++
+		result.Patch = 0
+	case SemverPatch:
+		result.Patch++
+	}
+	return result
+}
+
+// releaseBump returns the bump that the release of the version represents: major for x.0.0, minor for x.y.0 and patch otherwise
+func (version Semver) releaseBump() SemverBump {
+	switch {
+	case version.Minor == 0 && version.Patch == 0:
+		return SemverMajor
+	case version.Patch == 0:
+		return SemverMinor
+	}
+	return SemverPatch
+}
+
+// getBumpTo returns the bump from the version to the given version, or none if the given version isn't greater
+// a later version than a pre-release, like its release 1.0.0 after 1.0.0-rc.1 or the next pre-release 1.0.0-rc.2, is at least the bump of the release
+func (version Semver) getBumpTo(other Semver) SemverBump {
+	result := SemverNone
+	switch {
+	case other.Major != version.Major:
+		if other.Major > version.Major {
+			result = SemverMajor
+		}
+	case other.Minor != version.Minor:
+		if other.Minor > version.Minor {
+			result = SemverMinor
+		}
+	case other.Patch != version.Patch:
+		if other.Patch > version.Patch {
+			result = SemverPatch
+		}
+	default:
+		if version.PreRelease != "" && comparePreReleases(version.PreRelease, other.PreRelease) < 0 {
+			return version.releaseBump()
+		}
+		return SemverNone
+	}
+
+	if result != SemverNone && version.PreRelease != "" {
+		return max(result, version.releaseBump())
+	}
+	return result
+}
+
+// comparePreReleases compares the pre-release parts of two versions with the same major, minor and patch, by semantic versioning precedence: https://semver.org/#spec-item-11
+// a version without a pre-release has a higher precedence than its pre-releases
+func comparePreReleases(preRelease1, preRelease2 string) int {
+	switch {
+	case preRelease1 == preRelease2:
+		return 0
+	case preRelease1 == "":
+		return 1
+	case preRelease2 == "":
+		return -1
+	}
+
+	identifiers1 := strings.Split(preRelease1, ".")
+	identifiers2 := strings.Split(preRelease2, ".")
+	for i := range min(len(identifiers1), len(identifiers2)) {
+		if result := comparePreReleaseIdentifiers(identifiers1[i], identifiers2[i]); result != 0 {
+			return result
+		}
+	}
+	return cmp.Compare(len(identifiers1), len(identifiers2))
+}
+
+// comparePreReleaseIdentifiers compares numeric identifiers numerically, and other identifiers lexically, numeric identifiers have a lower precedence
+func comparePreReleaseIdentifiers(identifier1, identifier2 string) int {
+	number1, err1 := strconv.ParseUint(identifier1, 10, 64)
+	number2, err2 := strconv.ParseUint(identifier2, 10, 64)
+	switch {
+	case err1 == nil && err2 == nil:
+		return cmp.Compare(number1, number2)
+	case err1 == nil:
+		return -1
+	case err2 == nil:
+		return 1
+	}
+	return strings.Compare(identifier1, identifier2)
+}
+
+// initialDevelopmentBump returns the bump that a version in initial development (0.y.z) requires instead of the given bump
+// anything may change in initial development, so breaking changes require a minor bump and other changes require a patch bump, like Cargo does
+func (version Semver) initialDevelopmentBump(bump SemverBump) SemverBump {
+	if version.Major != 0 || bump == SemverNone {
+		return bump
+	}
+	return max(bump-1, SemverPatch)
+}
+
+// SemverCheck compares the version change between the base and the revision to the version bump required by the changes
+type SemverCheck struct {
+	BaseVersion        string     `json:"baseVersion" yaml:"baseVersion"`
+	RevisionVersion    string     `json:"revisionVersion" yaml:"revisionVersion"`
+	RequiredBump       SemverBump `json:"requiredBump" yaml:"requiredBump"`
+	ActualBump         SemverBump `json:"actualBump" yaml:"actualBump"`
+	RecommendedVersion string     `json:"recommendedVersion" yaml:"recommendedVersion"`
+}
+
+// NewSemverCheck computes the version bump required by the changes, and compares it to the change of info.version between the base and the revision
+// when the base version is in initial development (0.y.z), the required bump is one level lower, see Semver.initialDevelopmentBump
+func NewSemverCheck(changes Changes, diffReport *diff.Diff, baseVersion, revisionVersion string) (*SemverCheck, error) {
+	base, err := ParseSemver(baseVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base version: %w", err)
+	}
+
+	revision, err := ParseSemver(revisionVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid revision version: %w", err)
+	}
+
+	required := base.initialDevelopmentBump(GetRequiredBump(changes, diffReport))
+
+	return &SemverCheck{
+		BaseVersion:        baseVersion,
+		RevisionVersion:    revisionVersion,
+		RequiredBump:       required,
+		ActualBump:         base.getBumpTo(revision),
+		RecommendedVersion: base.Bump(required).String(),
+	}, nil
+}
+
+// IsSufficient indicates whether the actual version bump is at least the required one
+func (check *SemverCheck) IsSufficient() bool {
+	return check.ActualBump >= check.RequiredBump
+}
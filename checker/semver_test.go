@@ -0,0 +1,157 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+func getSemverDiff(t *testing.T, revision string) (checker.Changes, *diff.Diff, *load.SpecInfoPair) {
+	t.Helper()
+
+	s1, err := open("../data/semver/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/semver/" + revision)
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	changes := checker.CheckBackwardCompatibilityUntilLevel(allChecksConfig(), d, osm, checker.INFO)
+	return changes, d, load.NewSpecInfoPair(s1, s2)
+}
+
+func TestParseSemver(t *testing.T) {
+	version, err := checker.ParseSemver("v1.2.3-beta.1+build")
+	require.NoError(t, err)
+	require.Equal(t, checker.Semver{Prefix: "v", Major: 1, Minor: 2, Patch: 3, PreRelease: "beta.1"}, version)
+	require.Equal(t, "v1.2.3-beta.1", version.String())
+
+	_, err = checker.ParseSemver("1.2")
+	require.EqualError(t, err, `"1.2" isn't a semantic version`)
+
+	_, err = checker.ParseSemver("01.2.3")
+	require.Error(t, err)
+}
+
+func TestSemver_Bump(t *testing.T) {
+	version, err := checker.ParseSemver("1.2.3-rc1")
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", version.Bump(checker.SemverMajor).String())
+	require.Equal(t, "1.3.0", version.Bump(checker.SemverMinor).String())
+	require.Equal(t, "1.2.3", version.Bump(checker.SemverPatch).String())
+	require.Equal(t, "1.2.3-rc1", version.Bump(checker.SemverNone).String())
+}
+
+func TestSemver_BumpPreRelease(t *testing.T) {
+	// the release of a pre-release is enough for bumps up to the bump that the release represents
+	version, err := checker.ParseSemver("1.0.0-rc.1")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", version.Bump(checker.SemverMajor).String())
+	require.Equal(t, "1.0.0", version.Bump(checker.SemverPatch).String())
+
+	version, err = checker.ParseSemver("v1.2.0-rc.1")
+	require.NoError(t, err)
+	require.Equal(t, "v2.0.0", version.Bump(checker.SemverMajor).String())
+	require.Equal(t, "v1.2.0", version.Bump(checker.SemverMinor).String())
+}
+
+func TestGetRequiredBump(t *testing.T) {
+	for revision, expected := range map[string]checker.SemverBump{
+		"base.yaml":             checker.SemverNone,
+		"revision-docs.yaml":    checker.SemverPatch,
+		"revision-added.yaml":   checker.SemverMinor,
+		"revision-removed.yaml": checker.SemverMajor,
+	} {
+		changes, d, _ := getSemverDiff(t, revision)
+		require.Equal(t, expected, checker.GetRequiredBump(changes, d), revision)
+	}
+}
+
+func TestGetRequiredBump_DocsOnlyChange(t *testing.T) {
+	changes := checker.Changes{
+		checker.ApiChange{Id: "api-tag-added", Level: checker.INFO},
+	}
+	require.Equal(t, checker.SemverPatch, checker.GetRequiredBump(changes, &diff.Diff{}))
+}
+
+func TestNewSemverCheck(t *testing.T) {
+	changes, d, pair := getSemverDiff(t, "revision-removed.yaml")
+
+	semverCheck, err := checker.NewSemverCheck(changes, d, pair.GetBaseVersion(), pair.GetRevisionVersion())
+	require.NoError(t, err)
+	require.Equal(t, &checker.SemverCheck{
+		BaseVersion:        "1.2.3",
+		RevisionVersion:    "1.3.0",
+		RequiredBump:       checker.SemverMajor,
+		ActualBump:         checker.SemverMinor,
+		RecommendedVersion: "2.0.0",
+	}, semverCheck)
+	require.False(t, semverCheck.IsSufficient())
+}
+
+func TestNewSemverCheck_Sufficient(t *testing.T) {
+	changes, d, pair := getSemverDiff(t, "revision-docs.yaml")
+
+	semverCheck, err := checker.NewSemverCheck(changes, d, pair.GetBaseVersion(), pair.GetRevisionVersion())
+	require.NoError(t, err)
+	require.True(t, semverCheck.IsSufficient())
+	require.Equal(t, "1.2.4", semverCheck.RecommendedVersion)
+}
+
+func TestNewSemverCheck_Invalid(t *testing.T) {
+	_, err := checker.NewSemverCheck(checker.Changes{}, &diff.Diff{}, "1.0", "1.0.1")
+	require.EqualError(t, err, `invalid base version: "1.0" isn't a semantic version`)
+
+	_, err = checker.NewSemverCheck(checker.Changes{}, &diff.Diff{}, "1.0.0", "n/a")
+	require.EqualError(t, err, `invalid revision version: "n/a" isn't a semantic version`)
+}
+
+var breakingChanges = checker.Changes{
+	checker.ApiChange{Id: "api-path-removed-without-deprecation", Level: checker.ERR},
+}
+
+func TestNewSemverCheck_PreRelease(t *testing.T) {
+	for _, test := range []struct {
+		base, revision string
+		actual         checker.SemverBump
+		recommended    string
+	}{
+		{"1.0.0-rc.1", "1.0.0", checker.SemverMajor, "1.0.0"},
+		{"1.0.0-rc.1", "1.0.0-rc.2", checker.SemverMajor, "1.0.0"},
+		{"1.0.0-alpha", "1.0.0-alpha.1", checker.SemverMajor, "1.0.0"},
+		{"1.0.0-alpha.beta", "1.0.0-beta", checker.SemverMajor, "1.0.0"},
+		{"1.0.0-rc.9", "1.0.0-rc.10", checker.SemverMajor, "1.0.0"},
+		{"1.0.0-rc.1", "1.0.1", checker.SemverMajor, "1.0.0"},
+		{"1.0.0-rc.2", "1.0.0-rc.1", checker.SemverNone, "1.0.0"},
+		{"1.0.0-rc.1", "1.0.0-rc.1", checker.SemverNone, "1.0.0"},
+		{"1.0.0", "1.0.0-rc.1", checker.SemverNone, "2.0.0"},
+		{"1.2.0-rc.1", "1.2.0", checker.SemverMinor, "2.0.0"},
+		{"1.2.0", "2.0.0-rc.1", checker.SemverMajor, "2.0.0"},
+	} {
+		semverCheck, err := checker.NewSemverCheck(breakingChanges, &diff.Diff{}, test.base, test.revision)
+		require.NoError(t, err)
+		require.Equal(t, test.actual, semverCheck.ActualBump, "%s -> %s", test.base, test.revision)
+		require.Equal(t, test.recommended, semverCheck.RecommendedVersion, "%s -> %s", test.base, test.revision)
+	}
+}
+
+func TestNewSemverCheck_InitialDevelopment(t *testing.T) {
+	// in initial development, breaking changes require a minor bump
+	semverCheck, err := checker.NewSemverCheck(breakingChanges, &diff.Diff{}, "0.1.0", "0.2.0")
+	require.NoError(t, err)
+	require.Equal(t, checker.SemverMinor, semverCheck.RequiredBump)
+	require.Equal(t, "0.2.0", semverCheck.RecommendedVersion)
+	require.True(t, semverCheck.IsSufficient())
+
+	// and other changes require a patch bump
+	changes, d, _ := getSemverDiff(t, "revision-added.yaml")
+	semverCheck, err = checker.NewSemverCheck(changes, d, "0.1.0", "0.1.0")
+	require.NoError(t, err)
+	require.Equal(t, checker.SemverPatch, semverCheck.RequiredBump)
+	require.Equal(t, "0.1.1", semverCheck.RecommendedVersion)
+	require.False(t, semverCheck.IsSufficient())
+}
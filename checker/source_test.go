@@ -0,0 +1,154 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+func sourceMapsConfig(c checker.BackwardCompatibilityCheck, s1, s2 *load.SpecInfo) *checker.Config {
+	return singleCheckConfig(c).WithSourceMaps(load.NewSourceMaps(s1), load.NewSourceMaps(s2))
+}
+
+// changes to properties point at the property in the revision
+func TestSource_Property(t *testing.T) {
+	s1, err := open("../data/checker/request_property_max_length_set_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_property_max_length_set_revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(sourceMapsConfig(checker.RequestPropertyMaxLengthSetCheck, s1, s2), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, "../data/checker/request_property_max_length_set_revision.yaml", errs[0].GetSourceFile())
+	require.Equal(t, 14, errs[0].GetSourceLine())
+	require.Equal(t, 16, errs[0].GetSourceColumn())
+	require.Equal(t, 16, errs[0].GetSourceLineEnd())
+}
+
+// deleted parameters point at the parameter in the base
+func TestSource_DeletedParameter(t *testing.T) {
+	s1, err := open("../data/openapi-test1.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/openapi-test3.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(sourceMapsConfig(checker.RequestParameterRemovedCheck, s1, s2), d, osm, checker.INFO)
+	require.NotEmpty(t, errs)
+	for _, change := range errs {
+		require.Equal(t, "../data/openapi-test1.yaml", change.GetSourceFile())
+		require.NotZero(t, change.GetSourceLine())
+	}
+}
+
+// changes to global security point at the security section
+func TestSource_GlobalSecurity(t *testing.T) {
+	s1, err := open("../data/checker/api_security_global_added_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/api_security_global_added_revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(sourceMapsConfig(checker.APISecurityUpdatedCheck, s1, s2), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, "../data/checker/api_security_global_added_revision.yaml", errs[0].GetSourceFile())
+	require.Equal(t, 4, errs[0].GetSourceLine())
+}
+
+// without source maps, changes have no position
+func TestSource_NoSourceMaps(t *testing.T) {
+	s1, err := open("../data/checker/request_property_max_length_set_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_property_max_length_set_revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyMaxLengthSetCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Zero(t, errs[0].GetSourceLine())
+}
+
+// deleted elements point at the element in the base
+func TestSource_Deleted(t *testing.T) {
+	s1, err := open("../data/source/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/source/revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	config := allChecksConfig().WithSourceMaps(load.NewSourceMaps(s1), load.NewSourceMaps(s2))
+	errs := checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.INFO)
+
+	// one-based line numbers in the base
+	expected := map[string]int{
+		checker.APIOperationIdRemovedId:                       10,
+		checker.APISunsetDeletedId:                            12,
+		checker.APITagRemovedId:                               15,
+		checker.APISecurityRemovedCheckId:                     16,
+		checker.APISecurityScopeRemovedId:                     16,
+		checker.RequestParameterEnumValueRemovedId:            28,
+		checker.RequestParameterPatternRemovedId:              29,
+		checker.RequestParameterDefaultValueRemovedId:         30,
+		checker.RequestParameterXExtensibleEnumValueRemovedId: 33,
+		checker.RequestParameterSunsetDeletedId:               37,
+		checker.RequiredResponseHeaderRemovedId:               44,
+		checker.OptionalResponseHeaderRemovedId:               48,
+		checker.ResponseBodyDefaultValueRemovedId:             55,
+		checker.ResponseBodyDiscriminatorRemovedId:            56,
+		checker.ResponsePropertyDefaultValueRemovedId:         63,
+		checker.ResponsePropertyEnumValueRemovedId:            66,
+		checker.ResponseMediaTypeRemovedId:                    67,
+		checker.ResponseMediaTypeEnumValueRemovedId:           75,
+		checker.ResponseSuccessStatusRemovedId:                76,
+		checker.ResponseNonSuccessStatusRemovedId:             78,
+		checker.RequestBodyDefaultValueRemovedId:              86,
+		checker.RequestBodyDiscriminatorMappingDeletedId:      89,
+		checker.RequestPropertyDefaultValueRemovedId:          96,
+		checker.RequestPropertyPatternRemovedId:               97,
+		checker.RequestPropertyEnumValueRemovedId:             100,
+		checker.RequestPropertyXExtensibleEnumValueRemovedId:  103,
+		checker.RequestPropertyAllOfRemovedId:                 107,
+		checker.RequestBodyMediaTypeRemovedId:                 108,
+		checker.RequestBodyEnumValueRemovedId:                 116,
+	}
+
+	actual := map[string]int{}
+	for _, change := range errs {
+		if _, ok := expected[change.GetId()]; ok {
+			require.Equal(t, "../data/source/base.yaml", change.GetSourceFile(), change.GetId())
+			actual[change.GetId()] = change.GetSourceLine() + 1
+		}
+	}
+	require.Equal(t, expected, actual)
+}
+
+// moved endpoints point at the operation in the base
+func TestSource_PathChanged(t *testing.T) {
+	s1, err := open("../data/moved-endpoints/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/moved-endpoints/revision.yaml")
+	require.NoError(t, err)
+
+	diffConfig := diff.NewConfig()
+	diffConfig.MatchMovedEndpoints = true
+	d, osm, err := diff.GetWithOperationsSourcesMap(diffConfig, s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(sourceMapsConfig(checker.APIPathChangedCheck, s1, s2), d, osm, checker.INFO)
+	require.Len(t, errs, 2)
+
+	lines := []int{}
+	for _, change := range errs {
+		require.Equal(t, "../data/moved-endpoints/base.yaml", change.GetSourceFile())
+		lines = append(lines, change.GetSourceLine()+1)
+	}
+	require.ElementsMatch(t, []int{13, 25}, lines)
+}
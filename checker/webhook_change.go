@@ -0,0 +1,168 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TwiN/go-color"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/load"
+)
+
+// WebhookChange represents a change in the Webhooks Section of an OpenAPI 3.1 spec
+type WebhookChange struct {
+	CommonChange
+
+	Id          string
+	Args        []any
+	Comment     string
+	Level       Level
+	Operation   string
+	OperationId string
+	Webhook     string
+
+	SourceFile      string
+	SourceLine      int
+	SourceLineEnd   int
+	SourceColumn    int
+	SourceColumnEnd int
+}
+
+// NewWebhookChange creates a new WebhookChange
+func NewWebhookChange(id string, config *Config, args []any, comment string, operation *openapi3.Operation, method, webhook string) WebhookChange {
+	change := WebhookChange{
+		Id:          id,
+		Level:       config.getLogLevel(id),
+		Args:        args,
+		Comment:     comment,
+		OperationId: operation.OperationID,
+		Operation:   method,
+		Webhook:     webhook,
+		CommonChange: CommonChange{
+			Attributes: getAttributes(config, operation),
+		},
+	}
+	return change.withSource(config, operation)
+}
+
+// withSource points the change at the source position of a spec element, like an operation or a schema
+// if the element can't be located, the change is returned unmodified
+func (c WebhookChange) withSource(config *Config, element any) WebhookChange {
+	return c.withPosition(config.locateElement(element))
+}
+
+// withDeletedSource points the change at the source position of a deleted element in the base, given by an element of the base and a JSON pointer relative to it, like /enum/2 under a schema
+// an empty pointer refers to the element itself
+func (c WebhookChange) withDeletedSource(config *Config, element any, pointer string) WebhookChange {
+	return c.withPosition(config.locateDeleted(element, pointer))
+}
+
+func (c WebhookChange) withPosition(file string, position load.Position, ok bool) WebhookChange {
+	if !ok {
+		return c
+	}
+
+	c.SourceFile = file
+	c.SourceLine = position.Line
+	c.SourceLineEnd = position.LineEnd
+	c.SourceColumn = position.Column
+	c.SourceColumnEnd = position.ColumnEnd
+	return c
+}
+
+func (c WebhookChange) GetSection() string {
+	return "webhooks"
+}
+
+func (c WebhookChange) IsBreaking() bool {
+	return c.GetLevel().IsBreaking()
+}
+
+// MatchIgnore matches ignore lines of the form: "webhook POST newPet <change text>"
+// webhook names aren't paths, so the ignore path isn't used
+func (c WebhookChange) MatchIgnore(ignorePath, ignoreLine string, l Localizer) bool {
+	return strings.Contains(ignoreLine, "webhook") &&
+		strings.Contains(ignoreLine, strings.ToLower(c.Operation+" "+c.Webhook)) &&
+		strings.Contains(ignoreLine, strings.ToLower(c.GetUncolorizedText(l)))
+}
+
+func (c WebhookChange) GetId() string {
+	return c.Id
+}
+
+func (c WebhookChange) GetText(l Localizer) string {
+	return l(c.Id, colorizedValues(c.Args)...)
+}
+
+func (c WebhookChange) GetArgs() []any {
+	return c.Args
+}
+
+func (c WebhookChange) GetUncolorizedText(l Localizer) string {
+	return l(c.Id, quotedValues(c.Args)...)
+}
+
+func (c WebhookChange) GetComment(l Localizer) string {
+	return l(c.Comment)
+}
+
+func (c WebhookChange) GetLevel() Level {
+	return c.Level
+}
+
+func (c WebhookChange) GetOperation() string {
+	return c.Operation
+}
+
+func (c WebhookChange) GetOperationId() string {
+	return c.OperationId
+}
+
+// GetPath returns the name of the webhook
+func (c WebhookChange) GetPath() string {
+	return c.Webhook
+}
+
+func (c WebhookChange) GetSource() string {
+	return ""
+}
+
+func (c WebhookChange) GetSourceFile() string {
+	return c.SourceFile
+}
+
+func (c WebhookChange) GetSourceLine() int {
+	return c.SourceLine
+}
+
+func (c WebhookChange) GetSourceLineEnd() int {
+	return c.SourceLineEnd
+}
+
+func (c WebhookChange) GetSourceColumn() int {
+	return c.SourceColumn
+}
+
+func (c WebhookChange) GetSourceColumnEnd() int {
+	return c.SourceColumnEnd
+}
+
+func (c WebhookChange) SingleLineError(l Localizer, colorMode ColorMode) string {
+	const format = "%s, %s webhook %s %s %s [%s]. %s"
+
+	if isColorEnabled(colorMode) {
+		return fmt.Sprintf(format, c.Level.PrettyString(), l("in"), color.InGreen(c.Operation), color.InGreen(c.Webhook), c.GetText(l), color.InYellow(c.Id), c.GetComment(l))
+	}
+
+	return fmt.Sprintf(format, c.Level.String(), l("in"), c.Operation, c.Webhook, c.GetUncolorizedText(l), c.Id, c.GetComment(l))
+}
+
+func (c WebhookChange) MultiLineError(l Localizer, colorMode ColorMode) string {
+	const format = "%s\t[%s] \t\n\t%s webhook %s %s\n\t\t%s%s"
+
+	if isColorEnabled(colorMode) {
+		return fmt.Sprintf(format, c.Level.PrettyString(), color.InYellow(c.Id), l("in"), color.InGreen(c.Operation), color.InGreen(c.Webhook), c.GetText(l), multiLineComment(c.GetComment(l)))
+	}
+
+	return fmt.Sprintf(format, c.Level.String(), c.Id, l("in"), c.Operation, c.Webhook, c.GetUncolorizedText(l), multiLineComment(c.GetComment(l)))
+}
@@ -0,0 +1,50 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+)
+
+var webhookChange = checker.WebhookChange{
+	Id:              "change_id",
+	Comment:         "comment",
+	Level:           checker.ERR,
+	Args:            []any{1},
+	Operation:       "POST",
+	OperationId:     "newPet",
+	Webhook:         "newPet",
+	SourceFile:      "sourceFile",
+	SourceLine:      1,
+	SourceLineEnd:   2,
+	SourceColumn:    3,
+	SourceColumnEnd: 4,
+}
+
+func TestWebhookChange(t *testing.T) {
+	require.Equal(t, "webhooks", webhookChange.GetSection())
+	require.Equal(t, "comment", webhookChange.GetComment(MockLocalizer))
+	require.Equal(t, "newPet", webhookChange.GetOperationId())
+	require.Equal(t, "newPet", webhookChange.GetPath())
+	require.Equal(t, "", webhookChange.GetSource())
+	require.Equal(t, []any{1}, webhookChange.GetArgs())
+	require.Equal(t, "sourceFile", webhookChange.GetSourceFile())
+	require.Equal(t, 1, webhookChange.GetSourceLine())
+	require.Equal(t, 2, webhookChange.GetSourceLineEnd())
+	require.Equal(t, 3, webhookChange.GetSourceColumn())
+	require.Equal(t, 4, webhookChange.GetSourceColumnEnd())
+}
+
+func TestWebhookChange_MatchIgnore(t *testing.T) {
+	require.True(t, webhookChange.MatchIgnore("", "error, in webhook post newpet this is a breaking change. [change_id]. comment", MockLocalizer))
+	require.False(t, webhookChange.MatchIgnore("", "error, in api post newpet this is a breaking change. [change_id]. comment", MockLocalizer))
+}
+
+func TestWebhookChange_SingleLineError(t *testing.T) {
+	require.Equal(t, "error, in webhook POST newPet This is a breaking change. [change_id]. comment", webhookChange.SingleLineError(MockLocalizer, checker.ColorNever))
+}
+
+func TestWebhookChange_MultiLineError_NoColor(t *testing.T) {
+	require.Equal(t, "error\t[change_id] \t\n\tin webhook POST newPet\n\t\tThis is a breaking change.\n\t\tcomment", webhookChange.MultiLineError(MockLocalizer, checker.ColorNever))
+}
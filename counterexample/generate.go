@@ -0,0 +1,483 @@
+package counterexample
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/utils"
+)
+
+// Annotate returns the changes annotated with counterexamples, for the request-side breaking changes for which a counterexample was found
+func Annotate(diffReport *diff.Diff, changes checker.Changes) checker.Changes {
+	result := make(checker.Changes, len(changes))
+	for i, change := range changes {
+		result[i] = change
+		if counterexample := Generate(diffReport, change); counterexample != nil {
+			result[i] = checker.WithCounterexample(change, counterexample)
+		}
+	}
+	return result
+}
+
+// IsRequestChange indicates whether a change is a breaking change to the requests of an endpoint
+func IsRequestChange(change checker.Change) bool {
+	if change.GetLevel() < checker.WARN || change.GetPath() == "" || change.GetOperation() == "" {
+		return false
+	}
+
+	id := change.GetId()
+	return strings.HasPrefix(id, "request-") || strings.HasPrefix(id, "new-required-request-") || strings.HasPrefix(id, "new-request-")
+}
+
+// Generate returns a minimal request which is valid against the base and invalid against the revision, or nil if none was found
+// candidate requests are built from the base spec, starting with a minimal request and continuing with boundary values of the element that the change refers to
+// each candidate is validated against both specs, so only verified counterexamples are returned
+func Generate(diffReport *diff.Diff, change checker.Change) *checker.Counterexample {
+	if !IsRequestChange(change) {
+		return nil
+	}
+
+	endpoint, ok := newEndpointPair(diffReport, change.GetPath(), change.GetOperation())
+	if !ok {
+		return nil
+	}
+
+	for _, candidate := range endpoint.getCandidates(change) {
+		if endpoint.base.validate(candidate) == nil && endpoint.revision.validate(candidate) != nil {
+			return candidate.counterexample()
+		}
+	}
+
+	return nil
+}
+
+// endpoint is an operation of a spec which requests are validated against
+type endpoint struct {
+	path      string
+	pathItem  *openapi3.PathItem
+	method    string
+	operation *openapi3.Operation
+}
+
+type endpointPair struct {
+	base     *endpoint
+	revision *endpoint
+}
+
+func newEndpointPair(diffReport *diff.Diff, path, method string) (*endpointPair, bool) {
+	if diffReport == nil || diffReport.PathsDiff == nil {
+		return nil, false
+	}
+
+	pathDiff, ok := diffReport.PathsDiff.Modified[path]
+	if !ok || pathDiff.Base == nil || pathDiff.Revision == nil {
+		return nil, false
+	}
+
+	baseOperation := pathDiff.Base.GetOperation(method)
+	revisionOperation := pathDiff.Revision.GetOperation(method)
+	if baseOperation == nil || revisionOperation == nil {
+		return nil, false
+	}
+
+	return &endpointPair{
+		base:     &endpoint{path: path, pathItem: pathDiff.Base, method: method, operation: baseOperation},
+		revision: &endpoint{path: findPath(diffReport.PathsDiff.Revision, pathDiff.Revision, path), pathItem: pathDiff.Revision, method: method, operation: revisionOperation},
+	}, true
+}
+
+// findPath returns the path of a path item, which may differ from the base path in the names of its path parameters
+func findPath(paths *openapi3.Paths, pathItem *openapi3.PathItem, defaultPath string) string {
+	if paths != nil {
+		for path, item := range paths.Map() {
+			if item == pathItem {
+				return path
+			}
+		}
+	}
+	return defaultPath
+}
+
+// request is a candidate counterexample
+type request struct {
+	method      string
+	pathParams  map[string]string // values of the path parameters of the base, by their names
+	pathValues  []string          // values of the path parameters, by their position in the path
+	path        string
+	query       url.Values
+	headers     map[string]string
+	cookies     map[string]string
+	contentType string
+	body        any
+}
+
+func (r *request) clone() *request {
+	result := *r
+	result.query = url.Values{}
+	for key, values := range r.query {
+		result.query[key] = slices.Clone(values)
+	}
+	result.pathParams = cloneMap(r.pathParams)
+	result.headers = cloneMap(r.headers)
+	result.cookies = cloneMap(r.cookies)
+	return &result
+}
+
+func cloneMap(m map[string]string) map[string]string {
+	result := make(map[string]string, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
+
+func (r *request) counterexample() *checker.Counterexample {
+	headers := cloneMap(r.headers)
+	if r.contentType != "" {
+		headers["Content-Type"] = r.contentType
+	}
+	if cookie := r.cookieHeader(); cookie != "" {
+		headers["Cookie"] = cookie
+	}
+	if len(headers) == 0 {
+		headers = nil
+	}
+
+	return &checker.Counterexample{
+		Method:  strings.ToUpper(r.method),
+		Path:    r.path,
+		Query:   r.query.Encode(),
+		Headers: headers,
+		Body:    r.body,
+	}
+}
+
+func (r *request) cookieHeader() string {
+	cookies := []string{}
+	for _, name := range utils.StringList(mapKeys(r.cookies)).Sort() {
+		cookies = append(cookies, name+"="+r.cookies[name])
+	}
+	return strings.Join(cookies, "; ")
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
+
+// parameters returns the parameters of the endpoint, where operation parameters override path item parameters
+func (e *endpoint) parameters() openapi3.Parameters {
+	result := openapi3.Parameters{}
+	for _, parameter := range e.pathItem.Parameters {
+		if parameter.Value != nil && e.operation.Parameters.GetByInAndName(parameter.Value.In, parameter.Value.Name) == nil {
+			result = append(result, parameter)
+		}
+	}
+	for _, parameter := range e.operation.Parameters {
+		if parameter.Value != nil {
+			result = append(result, parameter)
+		}
+	}
+	return result
+}
+
+// jsonMediaType returns the first JSON media type of the request body, or an empty string
+func (e *endpoint) jsonMediaType() (string, *openapi3.MediaType) {
+	if e.operation.RequestBody == nil || e.operation.RequestBody.Value == nil {
+		return "", nil
+	}
+
+	content := e.operation.RequestBody.Value.Content
+	for _, mediaType := range utils.StringList(mapKeys(content)).Sort() {
+		if strings.Contains(mediaType, "json") {
+			return mediaType, content[mediaType]
+		}
+	}
+	return "", nil
+}
+
+// minimalRequest returns a request with the required parameters and body of the endpoint
+func (e *endpoint) minimalRequest() *request {
+	result := &request{
+		method:     e.method,
+		pathParams: map[string]string{},
+		query:      url.Values{},
+		headers:    map[string]string{},
+		cookies:    map[string]string{},
+	}
+
+	for _, parameter := range e.parameters() {
+		if parameter.Value.In == openapi3.ParameterInPath || parameter.Value.Required {
+			setParameter(result, parameter.Value, minimalValue(parameter.Value.Schema, 0))
+		}
+	}
+
+	if e.operation.RequestBody != nil && e.operation.RequestBody.Value != nil && e.operation.RequestBody.Value.Required {
+		if contentType, mediaType := e.jsonMediaType(); mediaType != nil {
+			result.contentType = contentType
+			result.body = minimalValue(mediaType.Schema, 0)
+		}
+	}
+
+	result.path, result.pathValues = e.fillPath(result)
+	return result
+}
+
+// fillPath returns the path with the values of the path parameters of the request
+func (e *endpoint) fillPath(r *request) (string, []string) {
+	_, _, params := utils.NormalizeTemplatedPath(e.path)
+	values := make([]string, len(params))
+	path := e.path
+	for i, param := range params {
+		value, ok := r.pathParams[param]
+		if !ok {
+			value = "1"
+		}
+		values[i] = value
+		path = strings.Replace(path, "{"+param+"}", url.PathEscape(value), 1)
+	}
+	return path, values
+}
+
+// setParameter sets a parameter of the request, path parameters are kept aside until the path is filled, see endpoint.fillPath
+func setParameter(r *request, parameter *openapi3.Parameter, value any) {
+	text := parameterValueToString(value)
+	switch parameter.In {
+	case openapi3.ParameterInPath:
+		r.pathParams[parameter.Name] = text
+	case openapi3.ParameterInQuery:
+		if array, ok := value.([]any); ok {
+			r.query.Del(parameter.Name)
+			for _, item := range array {
+				r.query.Add(parameter.Name, parameterValueToString(item))
+			}
+			return
+		}
+		r.query.Set(parameter.Name, text)
+	case openapi3.ParameterInHeader:
+		r.headers[http.CanonicalHeaderKey(parameter.Name)] = text
+	case openapi3.ParameterInCookie:
+		r.cookies[parameter.Name] = text
+	}
+}
+
+func parameterValueToString(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = parameterValueToString(item)
+		}
+		return strings.Join(items, ",")
+	case float64:
+		return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%f", value), "0"), ".")
+	}
+	return fmt.Sprint(value)
+}
+
+// compatibleRequest returns the minimal request of the base, extended with the parameters and body properties required by the revision
+// unlike the minimal request of the base, it isn't rejected by the revision for reasons unrelated to the change being demonstrated
+func (pair *endpointPair) compatibleRequest() *request {
+	result := pair.base.minimalRequest()
+	revision := pair.revision.minimalRequest()
+
+	for _, parameter := range pair.revision.parameters() {
+		if parameter.Value.In == openapi3.ParameterInPath || !parameter.Value.Required {
+			continue
+		}
+		if baseParameter := pair.base.parameters().GetByInAndName(parameter.Value.In, parameter.Value.Name); baseParameter != nil {
+			if !baseParameter.Required {
+				setParameter(result, baseParameter, minimalValue(baseParameter.Schema, 0))
+			}
+			continue
+		}
+		setParameter(result, parameter.Value, minimalValue(parameter.Value.Schema, 0))
+	}
+
+	if revision.contentType != "" {
+		if result.contentType == "" {
+			if contentType, mediaType := pair.base.jsonMediaType(); mediaType != nil {
+				result.contentType = contentType
+				result.body = minimalValue(mediaType.Schema, 0)
+			}
+		}
+		result.body = mergeValues(result.body, revision.body)
+	}
+
+	return result
+}
+
+// mergeValues adds the properties of the other object which are missing in the value, recursively
+func mergeValues(value, other any) any {
+	object, ok := value.(map[string]any)
+	otherObject, otherOk := other.(map[string]any)
+	if !ok || !otherOk {
+		return value
+	}
+
+	result := make(map[string]any, len(object)+len(otherObject))
+	for key, child := range otherObject {
+		result[key] = child
+	}
+	for key, child := range object {
+		result[key] = mergeValues(child, otherObject[key])
+	}
+	return result
+}
+
+// getCandidates returns candidate requests for the change, from the smallest to the most specific
+// the candidates are variations of a request which is compatible with both specs, and the minimal request of the base comes last since it demonstrates newly required elements
+func (pair *endpointPair) getCandidates(change checker.Change) []*request {
+	compatible := pair.compatibleRequest()
+
+	result := []*request{compatible}
+	result = append(result, pair.getParameterCandidates(change, compatible)...)
+	result = append(result, pair.getBodyCandidates(change, compatible)...)
+	return append(result, pair.base.minimalRequest())
+}
+
+// getParameterCandidates returns variations of the request with values of the parameters which the change refers to by their location and name
+func (pair *endpointPair) getParameterCandidates(change checker.Change, compatible *request) []*request {
+	result := []*request{}
+	for _, parameter := range pair.base.parameters() {
+		if !refersToParameter(change, parameter.Value) {
+			continue
+		}
+		var revisionSchema *openapi3.SchemaRef
+		if revisionParameter := pair.revision.parameters().GetByInAndName(parameter.Value.In, parameter.Value.Name); revisionParameter != nil {
+			revisionSchema = revisionParameter.Schema
+		}
+		for _, value := range append(outOfRangeValues(parameter.Value.Schema, revisionSchema), candidateValues(parameter.Value.Schema)...) {
+			candidate := compatible.clone()
+			setParameter(candidate, parameter.Value, value)
+			candidate.path, candidate.pathValues = pair.base.fillPath(candidate)
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// getBodyCandidates returns variations of the request with an optional body, values of the body for request-body changes, and values of the body properties which the change refers to by their names
+func (pair *endpointPair) getBodyCandidates(change checker.Change, compatible *request) []*request {
+	contentType, mediaType := pair.base.jsonMediaType()
+	if mediaType == nil || mediaType.Schema == nil {
+		return nil
+	}
+
+	bodyCandidate := compatible.clone()
+	if bodyCandidate.contentType == "" {
+		bodyCandidate.contentType = contentType
+		bodyCandidate.body = minimalValue(mediaType.Schema, 0)
+	}
+	result := []*request{bodyCandidate}
+
+	var revisionSchema *openapi3.SchemaRef
+	if _, revisionMediaType := pair.revision.jsonMediaType(); revisionMediaType != nil {
+		revisionSchema = revisionMediaType.Schema
+	}
+
+	if strings.HasPrefix(change.GetId(), "request-body-") {
+		for _, value := range append(outOfRangeValues(mediaType.Schema, revisionSchema), candidateValues(mediaType.Schema)...) {
+			candidate := bodyCandidate.clone()
+			candidate.body = value
+			result = append(result, candidate)
+		}
+	}
+
+	for _, arg := range change.GetArgs() {
+		property, ok := arg.(string)
+		if !ok {
+			continue
+		}
+		propertySchema, ok := findProperty(mediaType.Schema, property)
+		if !ok {
+			continue
+		}
+		revisionPropertySchema, _ := findProperty(revisionSchema, property)
+		for _, value := range append(outOfRangeValues(propertySchema, revisionPropertySchema), candidateValues(propertySchema)...) {
+			candidate := bodyCandidate.clone()
+			candidate.body = setProperty(bodyCandidate.body, mediaType.Schema, propertyTokens(property), value)
+			result = append(result, candidate)
+		}
+	}
+
+	return result
+}
+
+// refersToParameter indicates whether the arguments of the change include the location of the parameter followed by its name
+func refersToParameter(change checker.Change, parameter *openapi3.Parameter) bool {
+	args := change.GetArgs()
+	for i := 0; i+1 < len(args); i++ {
+		if fmt.Sprint(args[i]) == parameter.In && fmt.Sprint(args[i+1]) == parameter.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// validate validates a request against the endpoint
+func (e *endpoint) validate(r *request) error {
+	_, _, params := utils.NormalizeTemplatedPath(e.path)
+	if len(params) != len(r.pathValues) {
+		return fmt.Errorf("the number of path parameters doesn't match")
+	}
+	pathParams := make(map[string]string, len(params))
+	for i, param := range params {
+		pathParams[param] = r.pathValues[i]
+	}
+
+	var body io.Reader = http.NoBody
+	if r.contentType != "" {
+		data, err := json.Marshal(r.body)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	httpRequest, err := http.NewRequest(strings.ToUpper(r.method), "http://localhost"+r.path+"?"+r.query.Encode(), body)
+	if err != nil {
+		return err
+	}
+	for name, value := range r.headers {
+		httpRequest.Header.Set(name, value)
+	}
+	for name, value := range r.cookies {
+		httpRequest.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+	if r.contentType != "" {
+		httpRequest.Header.Set("Content-Type", r.contentType)
+	}
+
+	return openapi3filter.ValidateRequest(context.Background(), &openapi3filter.RequestValidationInput{
+		Request:    httpRequest,
+		PathParams: pathParams,
+		Route: &routers.Route{
+			Spec:      &openapi3.T{},
+			Path:      e.path,
+			PathItem:  e.pathItem,
+			Method:    strings.ToUpper(e.method),
+			Operation: e.operation,
+		},
+		Options: &openapi3filter.Options{
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	})
+}
@@ -0,0 +1,115 @@
+package counterexample_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/counterexample"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+)
+
+func loadSpec(t *testing.T, file string) *openapi3.T {
+	t.Helper()
+
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromFile(file)
+	require.NoError(t, err)
+	return spec
+}
+
+func getDiff(t *testing.T) (*diff.Diff, checker.Changes) {
+	t.Helper()
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(),
+		&load.SpecInfo{Spec: loadSpec(t, "../data/counterexample/base.yaml")},
+		&load.SpecInfo{Spec: loadSpec(t, "../data/counterexample/revision.yaml")})
+	require.NoError(t, err)
+	return d, checker.CheckBackwardCompatibility(checker.NewConfig(checker.GetAllChecks()), d, osm)
+}
+
+func findChange(t *testing.T, changes checker.Changes, id string) checker.Change {
+	t.Helper()
+
+	for _, change := range changes {
+		if change.GetId() == id {
+			return change
+		}
+	}
+	require.Failf(t, "change not found", "id: %s", id)
+	return nil
+}
+
+func TestGenerate_NewRequiredProperty(t *testing.T) {
+	d, changes := getDiff(t)
+
+	ce := counterexample.Generate(d, findChange(t, changes, checker.NewRequiredRequestPropertyId))
+	require.Equal(t, &checker.Counterexample{
+		Method:  "PUT",
+		Path:    "/users/a",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    map[string]any{"name": "a"},
+	}, ce)
+}
+
+func TestGenerate_ParameterBecameEnum(t *testing.T) {
+	d, changes := getDiff(t)
+
+	ce := counterexample.Generate(d, findChange(t, changes, checker.RequestParameterBecameEnumId))
+	require.NotNil(t, ce)
+	require.Equal(t, "mode=a", ce.Query)
+	// the body satisfies the revision, so the request is only rejected because of the parameter
+	require.Equal(t, map[string]any{"email": "a", "name": "a"}, ce.Body)
+}
+
+func TestGenerate_ParameterMaxDecreased(t *testing.T) {
+	d, changes := getDiff(t)
+
+	ce := counterexample.Generate(d, findChange(t, changes, checker.RequestParameterMaxDecreasedId))
+	require.NotNil(t, ce)
+	require.Equal(t, "limit=101", ce.Query)
+}
+
+func TestGenerate_PropertyMaxLengthDecreased(t *testing.T) {
+	d, changes := getDiff(t)
+
+	ce := counterexample.Generate(d, findChange(t, changes, checker.RequestPropertyMaxLengthDecreasedId))
+	require.NotNil(t, ce)
+	require.Equal(t, strings.Repeat("a", 101), ce.Body.(map[string]any)["name"])
+}
+
+func TestGenerate_NestedPropertyMaxLengthSet(t *testing.T) {
+	d, changes := getDiff(t)
+
+	ce := counterexample.Generate(d, findChange(t, changes, checker.RequestPropertyMaxLengthSetId))
+	require.NotNil(t, ce)
+	require.Equal(t, map[string]any{"city": "a", "zip": "aaaaaa"}, ce.Body.(map[string]any)["address"])
+}
+
+func TestGenerate_NotRequestChange(t *testing.T) {
+	d, _ := getDiff(t)
+
+	change := checker.ApiChange{
+		Id:        checker.APIRemovedWithoutDeprecationId,
+		Level:     checker.ERR,
+		Operation: "PUT",
+		Path:      "/users/{id}",
+	}
+	require.False(t, counterexample.IsRequestChange(change))
+	require.Nil(t, counterexample.Generate(d, change))
+}
+
+func TestAnnotate(t *testing.T) {
+	d, changes := getDiff(t)
+
+	annotated := counterexample.Annotate(d, changes)
+	require.Len(t, annotated, len(changes))
+	for _, change := range annotated {
+		if counterexample.IsRequestChange(change) {
+			require.NotNil(t, change.GetCounterexample(), change.GetId())
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package counterexample
+
+import (
+	"math"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxDepth limits the generation of nested values, for example, in recursive schemas
+const maxDepth = 8
+
+// maxLength limits the length of generated strings and arrays
+const maxLength = 10000
+
+// minimalValue returns a small value which is valid against the schema, as far as possible
+func minimalValue(schemaRef *openapi3.SchemaRef, depth int) any {
+	if schemaRef == nil || schemaRef.Value == nil || depth > maxDepth {
+		return nil
+	}
+	schema := schemaRef.Value
+
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+	if schema.Default != nil {
+		return schema.Default
+	}
+	if schema.Example != nil {
+		return schema.Example
+	}
+
+	if len(schema.AllOf) > 0 {
+		return mergeObjects(schema, depth)
+	}
+	if len(schema.OneOf) > 0 {
+		return minimalValue(schema.OneOf[0], depth+1)
+	}
+	if len(schema.AnyOf) > 0 {
+		return minimalValue(schema.AnyOf[0], depth+1)
+	}
+
+	switch {
+	case schema.Type.Is(openapi3.TypeString):
+		return stringOfLength(schema, max(schema.MinLength, 1))
+	case schema.Type.Is(openapi3.TypeInteger):
+		return clampNumber(schema, 1, true)
+	case schema.Type.Is(openapi3.TypeNumber):
+		return clampNumber(schema, 1, false)
+	case schema.Type.Is(openapi3.TypeBoolean):
+		return true
+	case schema.Type.Is(openapi3.TypeArray):
+		return arrayOfLength(schema, schema.MinItems, depth)
+	case schema.Type.Is(openapi3.TypeObject) || len(schema.Properties) > 0:
+		return minimalObject(schema, depth)
+	}
+
+	return "a"
+}
+
+// candidateValues returns values which are valid against the schema, as far as possible, including its boundary values
+func candidateValues(schemaRef *openapi3.SchemaRef) []any {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil
+	}
+	schema := schemaRef.Value
+
+	result := []any{}
+	result = append(result, schema.Enum...)
+	if schema.Default != nil {
+		result = append(result, schema.Default)
+	}
+	if schema.Example != nil {
+		result = append(result, schema.Example)
+	}
+	if schema.Nullable {
+		result = append(result, nil)
+	}
+
+	switch {
+	case schema.Type.Is(openapi3.TypeString):
+		result = append(result, stringOfLength(schema, max(schema.MinLength, 1)))
+		if schema.MinLength == 0 {
+			result = append(result, "")
+		}
+		if schema.MaxLength != nil && *schema.MaxLength <= maxLength {
+			result = append(result, stringOfLength(schema, *schema.MaxLength))
+		}
+	case schema.Type.Is(openapi3.TypeInteger), schema.Type.Is(openapi3.TypeNumber):
+		integer := schema.Type.Is(openapi3.TypeInteger)
+		for _, value := range []float64{0, 1, -1, 1000000} {
+			result = append(result, clampNumber(schema, value, integer))
+		}
+		if schema.Min != nil {
+			result = append(result, boundary(*schema.Min, schema.ExclusiveMin, 1, integer))
+		}
+		if schema.Max != nil {
+			result = append(result, boundary(*schema.Max, schema.ExclusiveMax, -1, integer))
+		}
+	case schema.Type.Is(openapi3.TypeBoolean):
+		result = append(result, true, false)
+	case schema.Type.Is(openapi3.TypeArray):
+		result = append(result, arrayOfLength(schema, schema.MinItems, 0), arrayOfLength(schema, max(schema.MinItems, 1), 0))
+		if schema.MaxItems != nil && *schema.MaxItems <= maxLength {
+			result = append(result, arrayOfLength(schema, *schema.MaxItems, 0))
+		}
+	default:
+		result = append(result, minimalValue(schemaRef, 0))
+	}
+
+	return result
+}
+
+// outOfRangeValues returns values just outside the constraints of the revision schema, formatted like the base schema
+// these are the smallest demonstrations of constraints which were added or tightened
+func outOfRangeValues(baseRef, revisionRef *openapi3.SchemaRef) []any {
+	if baseRef == nil || baseRef.Value == nil || revisionRef == nil || revisionRef.Value == nil {
+		return nil
+	}
+	base, revision := baseRef.Value, revisionRef.Value
+
+	result := []any{}
+	switch {
+	case base.Type.Is(openapi3.TypeString):
+		if revision.MaxLength != nil && *revision.MaxLength < maxLength {
+			result = append(result, stringOfLength(base, *revision.MaxLength+1))
+		}
+		if revision.MinLength > 0 {
+			result = append(result, stringOfLength(base, revision.MinLength-1))
+		}
+	case base.Type.Is(openapi3.TypeInteger), base.Type.Is(openapi3.TypeNumber):
+		integer := base.Type.Is(openapi3.TypeInteger)
+		if revision.Max != nil {
+			result = append(result, boundary(*revision.Max, !revision.ExclusiveMax, 1, integer))
+		}
+		if revision.Min != nil {
+			result = append(result, boundary(*revision.Min, !revision.ExclusiveMin, -1, integer))
+		}
+	case base.Type.Is(openapi3.TypeArray):
+		if revision.MaxItems != nil && *revision.MaxItems < maxLength {
+			result = append(result, arrayOfLength(base, *revision.MaxItems+1, 0))
+		}
+		if revision.MinItems > 0 {
+			result = append(result, arrayOfLength(base, revision.MinItems-1, 0))
+		}
+	}
+	return result
+}
+
+// stringOfLength returns a string with the given length, in the format of the schema when possible
+func stringOfLength(schema *openapi3.Schema, length uint64) string {
+	if length > maxLength {
+		length = maxLength
+	}
+
+	switch schema.Format {
+	case "date":
+		return "2024-01-01"
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "uuid":
+		return "123e4567-e89b-12d3-a456-426614174000"
+	case "email":
+		if length > 8 {
+			return strings.Repeat("a", int(length)-8) + "@ex.com"
+		}
+		return "a@ex.com"
+	}
+
+	return strings.Repeat("a", int(length))
+}
+
+// boundary returns the smallest value allowed by a minimum (direction 1), or the largest value allowed by a maximum (direction -1)
+func boundary(value float64, exclusive bool, direction float64, integer bool) any {
+	if integer {
+		if direction > 0 {
+			value = math.Ceil(value)
+		} else {
+			value = math.Floor(value)
+		}
+		if exclusive {
+			value += direction
+		}
+		return int64(value)
+	}
+
+	if exclusive {
+		value += direction * 0.5
+	}
+	return value
+}
+
+// clampNumber returns the value, moved into the range of the schema
+func clampNumber(schema *openapi3.Schema, value float64, integer bool) any {
+	if schema.Min != nil && (value < *schema.Min || (schema.ExclusiveMin && value == *schema.Min)) {
+		return boundary(*schema.Min, schema.ExclusiveMin, 1, integer)
+	}
+	if schema.Max != nil && (value > *schema.Max || (schema.ExclusiveMax && value == *schema.Max)) {
+		return boundary(*schema.Max, schema.ExclusiveMax, -1, integer)
+	}
+	if integer {
+		return int64(value)
+	}
+	return value
+}
+
+func arrayOfLength(schema *openapi3.Schema, length uint64, depth int) []any {
+	if length > maxLength {
+		length = maxLength
+	}
+
+	result := make([]any, length)
+	for i := range result {
+		result[i] = minimalValue(schema.Items, depth+1)
+		// unique items are made distinct when possible
+		if text, ok := result[i].(string); ok && schema.UniqueItems {
+			result[i] = text + strings.Repeat("a", i)
+		}
+		if number, ok := result[i].(int64); ok && schema.UniqueItems {
+			result[i] = number + int64(i)
+		}
+	}
+	return result
+}
+
+func minimalObject(schema *openapi3.Schema, depth int) map[string]any {
+	result := map[string]any{}
+	for _, name := range schema.Required {
+		if property, ok := schema.Properties[name]; ok {
+			result[name] = minimalValue(property, depth+1)
+		}
+	}
+	return result
+}
+
+func mergeObjects(schema *openapi3.Schema, depth int) any {
+	result := minimalObject(schema, depth)
+	for _, subschema := range schema.AllOf {
+		value := minimalValue(subschema, depth+1)
+		object, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		for key, value := range object {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// findProperty finds the schema of a property by the property name used in changes, like data/items/name
+func findProperty(schemaRef *openapi3.SchemaRef, property string) (*openapi3.SchemaRef, bool) {
+	current := schemaRef
+	for _, token := range propertyTokens(property) {
+		if current == nil || current.Value == nil {
+			return nil, false
+		}
+
+		if token == "items" {
+			current = current.Value.Items
+			continue
+		}
+
+		current = findChild(current.Value, token, 0)
+	}
+	return current, current != nil
+}
+
+func findChild(schema *openapi3.Schema, name string, depth int) *openapi3.SchemaRef {
+	if depth > maxDepth {
+		return nil
+	}
+
+	if property, ok := schema.Properties[name]; ok {
+		return property
+	}
+
+	for _, subschemas := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, subschema := range subschemas {
+			if subschema.Value == nil {
+				continue
+			}
+			if property := findChild(subschema.Value, name, depth+1); property != nil {
+				return property
+			}
+		}
+	}
+	return nil
+}
+
+// propertyTokens splits a property name used in changes, skipping the subschema tokens like allOf[#1] which don't appear in payloads
+func propertyTokens(property string) []string {
+	result := []string{}
+	for _, token := range strings.Split(property, "/") {
+		if token == "" || strings.HasPrefix(token, "allOf[") || strings.HasPrefix(token, "anyOf[") || strings.HasPrefix(token, "oneOf[") {
+			continue
+		}
+		result = append(result, token)
+	}
+	return result
+}
+
+// setProperty returns a copy of the body with the property set to the value
+// missing intermediate objects and arrays are created with minimal values, arrays get a single item
+func setProperty(body any, schemaRef *openapi3.SchemaRef, tokens []string, value any) any {
+	if len(tokens) == 0 {
+		return value
+	}
+
+	if body == nil {
+		body = minimalValue(schemaRef, 0)
+	}
+
+	var schema *openapi3.Schema
+	if schemaRef != nil {
+		schema = schemaRef.Value
+	}
+
+	if tokens[0] == "items" {
+		var itemsRef *openapi3.SchemaRef
+		if schema != nil {
+			itemsRef = schema.Items
+		}
+		array, _ := body.([]any)
+		item := any(nil)
+		if len(array) > 0 {
+			item = array[0]
+		}
+		result := make([]any, max(len(array), 1))
+		copy(result, array)
+		result[0] = setProperty(item, itemsRef, tokens[1:], value)
+		return result
+	}
+
+	var childRef *openapi3.SchemaRef
+	if schema != nil {
+		childRef = findChild(schema, tokens[0], 0)
+	}
+	object, _ := body.(map[string]any)
+	result := make(map[string]any, len(object)+1)
+	for key, child := range object {
+		result[key] = child
+	}
+	result[tokens[0]] = setProperty(object[tokens[0]], childRef, tokens[1:], value)
+	return result
+}
@@ -0,0 +1,19 @@
+#!/bin/sh
+# A check plugin which reports that the audience of an endpoint changed when the diff contains a change to x-audience
+input=$(cat)
+case "$input" in
+  *'"version":1'*'"x-audience"'*) ;;
+  *)
+    echo '{"changes": []}'
+    exit 0
+    ;;
+esac
+
+cat <<'RESPONSE'
+{
+  "changes": [
+    {"id": "audience-changed", "level": "err", "path": "/billing/invoices", "operation": "get", "text": "the audience of the endpoint changed, 100% breaking", "comment": "ask the platform team before changing the audience"},
+    {"id": "schemas-reviewed", "level": "info", "component": "schemas", "text": "component schemas were reviewed"}
+  ]
+}
+RESPONSE
@@ -0,0 +1,4 @@
+#!/bin/sh
+# A check plugin which reports a change with the id of a custom rule in data/custom-rules/rules.yaml
+cat > /dev/null
+echo '{"changes": [{"id": "schema-property-removed", "level": "err", "text": "a property was removed"}]}'
@@ -0,0 +1,5 @@
+#!/bin/sh
+# A check plugin which fails
+cat > /dev/null
+echo "something went wrong" >&2
+exit 3
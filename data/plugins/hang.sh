@@ -0,0 +1,3 @@
+#!/bin/sh
+# A check plugin which never responds
+sleep 30
@@ -0,0 +1,4 @@
+#!/bin/sh
+# A check plugin which returns a change without a level
+cat > /dev/null
+echo '{"changes": [{"id": "audience-changed", "text": "the audience of the endpoint changed"}]}'
@@ -31,7 +31,7 @@ func getComponentsDiffInternal(config *Config, state *state, s1, s2 openapi3.Com
 	result := ComponentsDiff{}
 	var err error
 
-	result.SchemasDiff, err = getSchemasDiff(config, state, s1.Schemas, s2.Schemas)
+	result.SchemasDiff, err = getComponentSchemasDiff(config, state, s1.Schemas, s2.Schemas)
 	if err != nil {
 		return result, err
 	}
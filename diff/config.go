@@ -1,6 +1,7 @@
 package diff
 
 import (
+	"github.com/tufin/oasdiff/limits"
 	"github.com/tufin/oasdiff/utils"
 )
 
@@ -15,6 +16,8 @@ type Config struct {
 	PathStripPrefixRevision string
 	ExcludeElements         utils.StringSet
 	IncludePathParams       bool
+	MatchMovedEndpoints     bool
+	Limits                  *limits.Limits // limits on the schemas that are compared, unlimited if nil
 }
 
 const (
@@ -49,6 +52,11 @@ func (config *Config) WithExcludeElements(excludeElements []string) *Config {
 	return config
 }
 
+func (config *Config) WithLimits(l *limits.Limits) *Config {
+	config.Limits = l
+	return config
+}
+
 func (config *Config) IsExcludeExamples() bool {
 	return config.ExcludeElements.Contains(ExcludeExamplesOption)
 }
@@ -0,0 +1,34 @@
+package diff
+
+import (
+	"reflect"
+
+	"github.com/tufin/oasdiff/load"
+)
+
+// ConstDiff describes the changes between a pair of const keywords of JSON Schema 2020-12: https://json-schema.org/draft/2020-12/json-schema-validation#name-const
+// Added and Deleted tell a const which was added or deleted apart from a const whose value is null
+type ConstDiff struct {
+	Added   bool        `json:"added,omitempty" yaml:"added,omitempty"`
+	Deleted bool        `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	From    interface{} `json:"from" yaml:"from"`
+	To      interface{} `json:"to" yaml:"to"`
+}
+
+// Empty indicates whether a change was found in this element
+func (diff *ConstDiff) Empty() bool {
+	return diff == nil
+}
+
+func getConstDiff(keywords1, keywords2 load.SchemaKeywords) *ConstDiff {
+	if keywords1.HasConst == keywords2.HasConst && reflect.DeepEqual(keywords1.Const, keywords2.Const) {
+		return nil
+	}
+
+	return &ConstDiff{
+		Added:   !keywords1.HasConst,
+		Deleted: !keywords2.HasConst,
+		From:    keywords1.Const,
+		To:      keywords2.Const,
+	}
+}
@@ -0,0 +1,70 @@
+package diff
+
+import (
+	"github.com/tufin/oasdiff/utils"
+)
+
+// DependentRequiredDiff describes the changes between a pair of dependentRequired maps of JSON Schema 2020-12: https://json-schema.org/draft/2020-12/json-schema-validation#name-dependentrequired
+// Each key is a property whose presence requires the properties in its list
+type DependentRequiredDiff struct {
+	Added    utils.StringList          `json:"added,omitempty" yaml:"added,omitempty"`
+	Deleted  utils.StringList          `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	Modified ModifiedDependentRequired `json:"modified,omitempty" yaml:"modified,omitempty"`
+}
+
+// ModifiedDependentRequired maps properties to the changes in the properties that they require
+type ModifiedDependentRequired map[string]*StringsDiff
+
+// Empty indicates whether a change was found in this element
+func (diff *DependentRequiredDiff) Empty() bool {
+	if diff == nil {
+		return true
+	}
+
+	return len(diff.Added) == 0 &&
+		len(diff.Deleted) == 0 &&
+		len(diff.Modified) == 0
+}
+
+func newDependentRequiredDiff() *DependentRequiredDiff {
+	return &DependentRequiredDiff{
+		Added:    utils.StringList{},
+		Deleted:  utils.StringList{},
+		Modified: ModifiedDependentRequired{},
+	}
+}
+
+func getDependentRequiredDiff(dependentRequired1, dependentRequired2 map[string][]string) *DependentRequiredDiff {
+	diff := getDependentRequiredDiffInternal(dependentRequired1, dependentRequired2)
+
+	if diff.Empty() {
+		return nil
+	}
+
+	return diff
+}
+
+func getDependentRequiredDiffInternal(dependentRequired1, dependentRequired2 map[string][]string) *DependentRequiredDiff {
+	result := newDependentRequiredDiff()
+
+	for name, required1 := range dependentRequired1 {
+		if required2, ok := dependentRequired2[name]; ok {
+			if diff := getStringsDiff(required1, required2); !diff.Empty() {
+				result.Modified[name] = diff
+			}
+		} else {
+			result.Deleted = append(result.Deleted, name)
+		}
+	}
+
+	for name := range dependentRequired2 {
+		if _, ok := dependentRequired1[name]; !ok {
+			result.Added = append(result.Added, name)
+		}
+	}
+
+	result.Added.Sort()
+	result.Deleted.Sort()
+
+	return result
+}
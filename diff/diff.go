@@ -23,6 +23,7 @@ type Diff struct {
 	InfoDiff         *InfoDiff                 `json:"info,omitempty" yaml:"info,omitempty"`
 	PathsDiff        *PathsDiff                `json:"paths,omitempty" yaml:"paths,omitempty"`
 	EndpointsDiff    *EndpointsDiff            `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	WebhooksDiff     *WebhooksDiff             `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
 	SecurityDiff     *SecurityRequirementsDiff `json:"security,omitempty" yaml:"security,omitempty"`
 	ServersDiff      *ServersDiff              `json:"servers,omitempty" yaml:"servers,omitempty"`
 	TagsDiff         *TagsDiff                 `json:"tags,omitempty" yaml:"tags,omitempty"`
@@ -50,7 +51,7 @@ References are normally resolved automatically when you load the spec.
 In other cases you can resolve refs using https://pkg.go.dev/github.com/getkin/kin-openapi/openapi3#Loader.ResolveRefsIn.
 */
 func Get(config *Config, s1, s2 *openapi3.T) (*Diff, error) {
-	diff, err := getDiff(config, newState(), s1, s2)
+	diff, err := getDiff(config, newState(config), s1, s2)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +66,7 @@ References are normally resolved automatically when you load the spec.
 In other cases you can resolve refs using https://pkg.go.dev/github.com/getkin/kin-openapi/openapi3#Loader.ResolveRefsIn.
 */
 func GetWithOperationsSourcesMap(config *Config, s1, s2 *load.SpecInfo) (*Diff, *OperationsSourcesMap, error) {
-	diff, err := getDiff(config, newState(), s1.Spec, s2.Spec)
+	diff, err := getDiff(config, newState(config), s1.Spec, s2.Spec)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -100,7 +101,7 @@ References are normally resolved automatically when you load the spec.
 In other cases you can resolve refs using https://pkg.go.dev/github.com/getkin/kin-openapi/openapi3#Loader.ResolveRefsIn.
 */
 func GetPathsDiff(config *Config, s1, s2 []*load.SpecInfo) (*Diff, *OperationsSourcesMap, error) {
-	state := newState()
+	state := newState(config)
 	result := newDiff()
 	var err error
 	paths1, operationsSources1, err := mergedPaths(s1, config.IncludePathParams)
@@ -248,6 +249,19 @@ func getDiff(config *Config, state *state, s1, s2 *openapi3.T) (*Diff, error) {
 		return nil, errors.New("spec is nil")
 	}
 
+	// specs that weren't loaded by the load package may have unresolved references in their webhooks and JSON Schema 2020-12 keywords
+	// only local references are resolved here, external references must be resolved when the specs are loaded
+	if err := load.ResolveSchemaKeywords(s1); err != nil {
+		return nil, err
+	}
+	if err := load.ResolveSchemaKeywords(s2); err != nil {
+		return nil, err
+	}
+
+	// errors about exceeding the limits refer to component schemas by name
+	state.walk.AddComponents(s1.Components)
+	state.walk.AddComponents(s2.Components)
+
 	diff, err := getDiffInternal(config, state, s1, s2)
 	if err != nil {
 		return nil, err
@@ -265,7 +279,12 @@ func getDiffInternal(config *Config, state *state, s1, s2 *openapi3.T) (*Diff, e
 	result := newDiff()
 	var err error
 
-	result.ExtensionsDiff, err = getExtensionsDiff(config, s1.Extensions, s2.Extensions)
+	// renamed component schemas are matched before anything else so that references to them can be matched across the specs
+	if state.schemaRenames, err = getSchemaRenames(s1, s2); err != nil {
+		return nil, err
+	}
+
+	result.ExtensionsDiff, err = getExtensionsDiff(config, specExtensions(s1), specExtensions(s2))
 	if err != nil {
 		return nil, err
 	}
@@ -283,6 +302,18 @@ func getDiffInternal(config *Config, state *state, s1, s2 *openapi3.T) (*Diff, e
 		return nil, err
 	}
 
+	webhooks1, err := load.GetWebhooks(s1)
+	if err != nil {
+		return nil, err
+	}
+	webhooks2, err := load.GetWebhooks(s2)
+	if err != nil {
+		return nil, err
+	}
+	if result.WebhooksDiff, err = getWebhooksDiff(config, state, webhooks1, webhooks2); err != nil {
+		return nil, err
+	}
+
 	result.SecurityDiff = getSecurityRequirementsDiff(&s1.Security, &s2.Security)
 	result.ServersDiff = getServersDiff(config, &s1.Servers, &s2.Servers)
 	result.TagsDiff = getTagsDiff(config, s1.Tags, s2.Tags)
@@ -311,6 +342,7 @@ func (diff *Diff) GetSummary() *Summary {
 
 	// swagger
 	summary.add(diff.PathsDiff, PathsDetail)
+	summary.add(diff.WebhooksDiff, WebhooksDetail)
 	summary.add(diff.SecurityDiff, SecurityDetail)
 	summary.add(diff.ServersDiff, ServersDetail)
 	summary.add(diff.TagsDiff, TagsDetail)
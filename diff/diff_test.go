@@ -2,6 +2,8 @@ package diff_test
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -623,10 +625,68 @@ func TestOAS31(t *testing.T) {
 	d, err := diff.Get(diff.NewConfig(), s1, s2)
 	require.NoError(t, err)
 
-	// while specific 3.1 features, such as webhooks, are not yet supported by kin-openapi, the diff still works
 	require.Contains(t,
 		d.ComponentsDiff.SchemasDiff.Modified["Pet"].RequiredDiff.Added,
 		"tag")
+
+	// webhooks aren't modeled by kin-openapi, but they are compared like paths
+	require.Contains(t,
+		d.WebhooksDiff.Modified["newPet"].OperationsDiff.Modified["POST"].RequestBodyDiff.ContentDiff.MediaTypeModified["application/json"].SchemaDiff.RequiredDiff.Added,
+		"tag")
+}
+
+func TestWebhooks(t *testing.T) {
+	loader := openapi3.NewLoader()
+
+	s1, err := loader.LoadFromFile("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := loader.LoadFromFile("../data/webhooks/revision.yaml")
+	require.NoError(t, err)
+
+	d, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	require.Equal(t, utils.StringList{"petUpdated"}, d.WebhooksDiff.Added)
+	require.Equal(t, utils.StringList{"petRemoved"}, d.WebhooksDiff.Deleted)
+	require.Contains(t, d.WebhooksDiff.Modified, "newPet")
+	require.Nil(t, d.ExtensionsDiff)
+	require.Equal(t, &diff.SummaryDetails{Added: 1, Deleted: 1, Modified: 1}, d.GetSummary().Details[diff.WebhooksDetail])
+}
+
+func TestWebhooks_Unchanged(t *testing.T) {
+	loader := openapi3.NewLoader()
+
+	s1, err := loader.LoadFromFile("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := loader.LoadFromFile("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+
+	d, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	require.Empty(t, d)
+}
+
+func TestWebhooks_Sorted(t *testing.T) {
+	webhook := func() map[string]any {
+		return map[string]any{"post": map[string]any{"responses": map[string]any{"200": map[string]any{"description": "OK"}}}}
+	}
+	webhooks := func(names ...string) map[string]any {
+		result := map[string]any{}
+		for _, name := range names {
+			result[name] = webhook()
+		}
+		return result
+	}
+
+	s1 := &openapi3.T{Extensions: map[string]any{"webhooks": webhooks("d", "b", "f", "h")}}
+	s2 := &openapi3.T{Extensions: map[string]any{"webhooks": webhooks("g", "a", "e", "c")}}
+
+	d, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	require.Equal(t, utils.StringList{"a", "c", "e", "g"}, d.WebhooksDiff.Added)
+	require.Equal(t, utils.StringList{"b", "d", "f", "h"}, d.WebhooksDiff.Deleted)
 }
 
 func TestCircularSchema_Diff(t *testing.T) {
@@ -928,3 +988,251 @@ func TestDiff_ExtensionsIssue519(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, d)
 }
+
+func TestSchemaKeywords(t *testing.T) {
+	loader := openapi3.NewLoader()
+
+	s1, err := loader.LoadFromFile("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := loader.LoadFromFile("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+
+	d, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	schemaDiff := d.PathsDiff.Modified["/orders"].OperationsDiff.Modified["POST"].RequestBodyDiff.ContentDiff.MediaTypeModified["application/json"].SchemaDiff
+	require.Equal(t, &diff.ValueDiff{From: nil, To: false}, schemaDiff.UnevaluatedPropertiesAllowedDiff)
+	require.Nil(t, schemaDiff.ExtensionsDiff)
+
+	properties := schemaDiff.PropertiesDiff.Modified
+	require.Equal(t, &diff.ConstDiff{Added: true, From: nil, To: "standard"}, properties["kind"].ConstDiff)
+	require.Equal(t, utils.StringList{"card"}, properties["billing"].DependentRequiredDiff.Added)
+	require.Equal(t, &diff.ValueDiff{From: nil, To: false}, properties["coordinates"].UnevaluatedItemsAllowedDiff)
+	require.Equal(t, &diff.ValueDiff{From: float64(-90), To: float64(-45)}, properties["coordinates"].PrefixItemsDiff.Modified[0].MinDiff)
+	require.Equal(t, &diff.ValueDiff{From: uint64(1), To: uint64(2)}, properties["tags"].MinContainsDiff)
+	require.Equal(t, utils.StringList{"email"}, properties["delivery"].ThenDiff.RequiredDiff.Added)
+	require.Nil(t, properties["delivery"].IfDiff)
+}
+
+func TestSchemaKeywords_PrefixItemsAdded(t *testing.T) {
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	coordinates := s2.Components.Schemas["Order"].Value.Properties["coordinates"].Value
+	coordinates.Extensions["prefixItems"] = append(load.GetSchemaKeywords(coordinates).PrefixItems, openapi3.NewSchemaRef("", openapi3.NewFloat64Schema()))
+
+	d, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	require.Equal(t, []int{2}, d.ComponentsDiff.SchemasDiff.Modified["Order"].PropertiesDiff.Modified["coordinates"].PrefixItemsDiff.Added)
+}
+
+func TestSchemaKeywords_ExternalRef(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte("type: string"))
+	}))
+	defer server.Close()
+
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	// external references in specs which weren't loaded by the load package aren't fetched
+	s2.Components.Schemas["Order"].Value.Properties["coordinates"].Value.Extensions["contains"] = map[string]any{"$ref": server.URL + "/item.yaml"}
+
+	_, err = diff.Get(diff.NewConfig(), s1, s2)
+	require.ErrorContains(t, err, "failed to resolve schema keywords")
+	require.Zero(t, requests)
+}
+
+func TestWebhooks_ExternalRef(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte("post: {responses: {'200': {description: OK}}}"))
+	}))
+	defer server.Close()
+
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	// external references in specs which weren't loaded by the load package aren't fetched
+	s2.Extensions = map[string]any{"webhooks": map[string]any{"newOrder": map[string]any{"$ref": server.URL + "/webhook.yaml"}}}
+
+	_, err = diff.Get(diff.NewConfig(), s1, s2)
+	require.ErrorContains(t, err, "failed to resolve webhooks")
+	require.Zero(t, requests)
+}
+
+func TestSchemaRenamed(t *testing.T) {
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/checker/component_schema_renamed_base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/checker/component_schema_renamed_revision.yaml")
+	require.NoError(t, err)
+
+	d, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	schemasDiff := d.ComponentsDiff.SchemasDiff
+	require.Equal(t, diff.RenamedSchemas{{From: "Dog", To: "Canine"}, {From: "Pet", To: "Animal"}}, schemasDiff.Renamed)
+	require.Empty(t, schemasDiff.Added)
+	require.Empty(t, schemasDiff.Deleted)
+	require.Equal(t, utils.StringList{"nickname"}, schemasDiff.Modified["Animal"].PropertiesDiff.Added)
+	require.Equal(t, diff.SummaryDetails{Modified: 2}, d.GetSummary().GetSummaryDetails(diff.SchemasDetail))
+
+	// references to the renamed schema are matched as the same subschema
+	oneOfDiff := schemasDiff.Modified["Animal"].PropertiesDiff.Modified["kind"].OneOfDiff
+	require.Empty(t, oneOfDiff.Added)
+	require.Empty(t, oneOfDiff.Deleted)
+	require.Equal(t, "#/components/schemas/Dog -> #/components/schemas/Canine", oneOfDiff.Modified[0].String())
+}
+
+func TestSchemaRenamed_Different(t *testing.T) {
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/checker/component_schema_renamed_base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/checker/component_schema_renamed_base.yaml")
+	require.NoError(t, err)
+
+	// a schema with a different structure is considered a new schema
+	s2.Components.Schemas["Wolf"] = openapi3.NewSchemaRef("", openapi3.NewObjectSchema().WithProperty("howls", openapi3.NewBoolSchema()))
+	delete(s2.Components.Schemas, "Dog")
+
+	d, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	require.Empty(t, d.ComponentsDiff.SchemasDiff.Renamed)
+	require.Equal(t, utils.StringList{"Wolf"}, d.ComponentsDiff.SchemasDiff.Added)
+	require.Equal(t, utils.StringList{"Dog"}, d.ComponentsDiff.SchemasDiff.Deleted)
+}
+
+func TestSchemaRenamed_Ambiguous(t *testing.T) {
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/checker/component_schema_renamed_base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/checker/component_schema_renamed_base.yaml")
+	require.NoError(t, err)
+
+	// a deleted schema with two equally similar added schemas isn't paired with either
+	s2.Components.Schemas["Canine"] = s2.Components.Schemas["Dog"]
+	s2.Components.Schemas["Hound"] = s2.Components.Schemas["Dog"]
+	delete(s2.Components.Schemas, "Dog")
+
+	d, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	require.Empty(t, d.ComponentsDiff.SchemasDiff.Renamed)
+	require.ElementsMatch(t, utils.StringList{"Canine", "Hound"}, d.ComponentsDiff.SchemasDiff.Added)
+	require.Equal(t, utils.StringList{"Dog"}, d.ComponentsDiff.SchemasDiff.Deleted)
+}
+
+func getTrivialSchemaSpec(name, paramRef string) *openapi3.T {
+	spec := &openapi3.T{
+		OpenAPI: "3.0.1",
+		Info:    &openapi3.Info{Title: "Users", Version: "1.0.0"},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{name: openapi3.NewSchemaRef("", openapi3.NewStringSchema())},
+		},
+	}
+	if paramRef != "" {
+		param := openapi3.NewQueryParameter("id")
+		param.Schema = openapi3.NewSchemaRef("#/components/schemas/"+paramRef, spec.Components.Schemas[paramRef].Value)
+		operation := openapi3.NewOperation()
+		operation.AddParameter(param)
+		operation.AddResponse(200, openapi3.NewResponse().WithDescription("OK"))
+		spec.AddOperation("/users", "GET", operation)
+	}
+	return spec
+}
+
+func TestSchemaRenamed_Trivial(t *testing.T) {
+	// trivial schemas aren't paired by their structure alone
+	d, err := diff.Get(diff.NewConfig(), getTrivialSchemaSpec("UserId", ""), getTrivialSchemaSpec("OrderId", ""))
+	require.NoError(t, err)
+	require.Empty(t, d.ComponentsDiff.SchemasDiff.Renamed)
+	require.Equal(t, utils.StringList{"OrderId"}, d.ComponentsDiff.SchemasDiff.Added)
+	require.Equal(t, utils.StringList{"UserId"}, d.ComponentsDiff.SchemasDiff.Deleted)
+}
+
+func TestSchemaRenamed_TrivialWithRef(t *testing.T) {
+	// trivial schemas are paired when a reference to the deleted schema was replaced by a reference to the added schema
+	d, err := diff.Get(diff.NewConfig(), getTrivialSchemaSpec("UserId", "UserId"), getTrivialSchemaSpec("AccountId", "AccountId"))
+	require.NoError(t, err)
+	require.Equal(t, diff.RenamedSchemas{{From: "UserId", To: "AccountId"}}, d.ComponentsDiff.SchemasDiff.Renamed)
+	require.Empty(t, d.ComponentsDiff.SchemasDiff.Added)
+	require.Empty(t, d.ComponentsDiff.SchemasDiff.Deleted)
+}
+
+func TestMovedEndpoints(t *testing.T) {
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/moved-endpoints/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/moved-endpoints/revision.yaml")
+	require.NoError(t, err)
+
+	config := diff.NewConfig()
+	config.MatchMovedEndpoints = true
+	d, err := diff.Get(config, s1, s2)
+	require.NoError(t, err)
+
+	require.Empty(t, d.PathsDiff.Added)
+	require.Empty(t, d.PathsDiff.Deleted)
+	require.Empty(t, d.EndpointsDiff.Added)
+	require.Empty(t, d.EndpointsDiff.Deleted)
+
+	pathDiff := &diff.ValueDiff{From: "/users/{id}", To: "/accounts/{accountId}"}
+	operationsDiff := d.PathsDiff.Modified["/accounts/{accountId}"].OperationsDiff
+
+	// matched by operationId
+	require.Equal(t, pathDiff, operationsDiff.Modified["GET"].PathDiff)
+	require.NotNil(t, operationsDiff.Modified["GET"].ResponsesDiff)
+
+	// matched by x-previous-path
+	require.Equal(t, pathDiff, operationsDiff.Modified["DELETE"].PathDiff)
+	require.Equal(t, &diff.ValueDiff{From: "deleteUser", To: "deleteAccount"}, operationsDiff.Modified["DELETE"].OperationIDDiff)
+
+	// the original spec is left as is
+	require.NotNil(t, s1.Paths.Value("/users/{id}").Get)
+}
+
+func TestMovedEndpoints_Disabled(t *testing.T) {
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/moved-endpoints/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/moved-endpoints/revision.yaml")
+	require.NoError(t, err)
+
+	d, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	require.Equal(t, utils.StringList{"/accounts/{accountId}"}, d.PathsDiff.Added)
+	require.Equal(t, utils.StringList{"/users/{id}"}, d.PathsDiff.Deleted)
+}
+
+func TestMovedEndpoints_AmbiguousOperationId(t *testing.T) {
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/moved-endpoints/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/moved-endpoints/revision.yaml")
+	require.NoError(t, err)
+
+	// two deleted endpoints with the same operationId can't be told apart
+	s1.Paths.Set("/people/{id}", &openapi3.PathItem{Get: &openapi3.Operation{OperationID: "getUser", Responses: openapi3.NewResponses()}})
+
+	config := diff.NewConfig()
+	config.MatchMovedEndpoints = true
+	d, err := diff.Get(config, s1, s2)
+	require.NoError(t, err)
+	require.Equal(t, diff.Endpoints{{Method: "DELETE", Path: "/accounts/{accountId}"}}, d.EndpointsDiff.Modified.ToEndpoints())
+	require.ElementsMatch(t, diff.Endpoints{{Method: "GET", Path: "/users/{id}"}, {Method: "GET", Path: "/people/{id}"}}, d.EndpointsDiff.Deleted)
+	require.Equal(t, diff.Endpoints{{Method: "GET", Path: "/accounts/{accountId}"}}, d.EndpointsDiff.Added)
+}
@@ -72,7 +72,7 @@ func getEndpointsDiffInternal(config *Config, state *state, paths1, paths2 *open
 	paths1Mod := rewritePrefix(paths1.Map(), config.PathStripPrefixBase, config.PathPrefixBase)
 	paths2Mod := rewritePrefix(paths2.Map(), config.PathStripPrefixRevision, config.PathPrefixRevision)
 
-	addedPaths, deletedPaths, otherPaths := getPathItemsDiff(config, paths1Mod, paths2Mod)
+	paths1Mod, addedPaths, deletedPaths, otherPaths := getMovedPathItemsDiff(config, state, paths1Mod, paths2Mod)
 
 	for path, pathItem := range addedPaths.Map() {
 		for method := range pathItem.Operations() {
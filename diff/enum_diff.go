@@ -55,14 +55,17 @@ func getEnumDiffInternal(enum1, enum2 EnumValues) *EnumDiff {
 		diff.EnumDeleted = true
 	}
 
+	index1 := newEnumIndex(enum1)
+	index2 := newEnumIndex(enum2)
+
 	for _, v1 := range enum1 {
-		if !findValue(v1, enum2) {
+		if !index2.contains(v1) {
 			diff.Deleted = append(diff.Deleted, v1)
 		}
 	}
 
 	for _, v2 := range enum2 {
-		if !findValue(v2, enum1) {
+		if !index1.contains(v2) {
 			diff.Added = append(diff.Added, v2)
 		}
 	}
@@ -70,8 +73,34 @@ func getEnumDiffInternal(enum1, enum2 EnumValues) *EnumDiff {
 	return diff
 }
 
-func findValue(value interface{}, enum EnumValues) bool {
-	for _, other := range enum {
+// enumIndex looks up enum values, scalar values are looked up in a set so that comparing huge enums takes linear time
+type enumIndex struct {
+	scalars map[interface{}]struct{}
+	others  EnumValues // values which can't be set members, like lists and objects
+}
+
+func newEnumIndex(enum EnumValues) *enumIndex {
+	result := &enumIndex{
+		scalars: make(map[interface{}]struct{}, len(enum)),
+	}
+	for _, value := range enum {
+		if isScalarEnumValue(value) {
+			result.scalars[value] = struct{}{}
+		} else {
+			result.others = append(result.others, value)
+		}
+	}
+	return result
+}
+
+// contains indicates whether the enum contains a value which is deeply equal to the given value
+func (index *enumIndex) contains(value interface{}) bool {
+	if isScalarEnumValue(value) {
+		_, ok := index.scalars[value]
+		return ok
+	}
+
+	for _, other := range index.others {
 		if reflect.DeepEqual(value, other) {
 			return true
 		}
@@ -79,6 +108,15 @@ func findValue(value interface{}, enum EnumValues) bool {
 	return false
 }
 
+// isScalarEnumValue indicates whether equal values of a type are also deeply equal, so that they can be compared as set members
+func isScalarEnumValue(value interface{}) bool {
+	switch value.(type) {
+	case nil, string, bool, int, int64, uint64, float64:
+		return true
+	}
+	return false
+}
+
 // Patch applies the patch to an enum
 func (enumDiff *EnumDiff) Patch(enum *[]interface{}) {
 
@@ -87,9 +125,10 @@ func (enumDiff *EnumDiff) Patch(enum *[]interface{}) {
 	}
 
 	result := []interface{}{}
+	deleted := newEnumIndex(enumDiff.Deleted)
 
 	for _, value := range *enum {
-		if !findValue(value, enumDiff.Deleted) {
+		if !deleted.contains(value) {
 			result = append(result, value)
 		}
 	}
@@ -0,0 +1,62 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/load"
+)
+
+func loadDeep(t *testing.T) *openapi3.T {
+	t.Helper()
+
+	spec, err := load.NewSpecInfo(openapi3.NewLoader(), load.NewSource("../data/limits/deep.yaml"))
+	require.NoError(t, err)
+	return spec.Spec
+}
+
+func TestLimits_SchemaDepth(t *testing.T) {
+	_, err := diff.Get(diff.NewConfig().WithLimits(&limits.Limits{MaxSchemaDepth: 10}), loadDeep(t), loadDeep(t))
+	require.ErrorIs(t, err, limits.ErrLimitExceeded)
+	require.EqualError(t, err, `resource limit exceeded: an inline schema under "#/components/schemas/Deep" is nested deeper than max-schema-depth=10`)
+}
+
+func TestLimits_Schemas(t *testing.T) {
+	_, err := diff.Get(diff.NewConfig().WithLimits(&limits.Limits{MaxSchemas: 10}), loadDeep(t), loadDeep(t))
+	require.EqualError(t, err, `resource limit exceeded: visiting an inline schema under "#/components/schemas/Deep" exceeds max-schemas=10`)
+}
+
+func TestLimits_WithinLimits(t *testing.T) {
+	d, err := diff.Get(diff.NewConfig().WithLimits(&limits.Limits{MaxSchemaDepth: 100, MaxSchemas: 100}), loadDeep(t), loadDeep(t))
+	require.NoError(t, err)
+	require.Empty(t, d)
+}
+
+func TestLimits_HugeEnum(t *testing.T) {
+	const size = 100000
+
+	enum1 := make([]any, size)
+	enum2 := make([]any, size)
+	for i := range size {
+		enum1[i] = float64(i)
+		enum2[i] = float64(i + 1)
+	}
+
+	spec := func(enum []any) *openapi3.T {
+		return &openapi3.T{
+			OpenAPI: "3.0.1",
+			Paths:   openapi3.NewPaths(),
+			Components: &openapi3.Components{
+				Schemas: openapi3.Schemas{"Code": openapi3.NewSchemaRef("", openapi3.NewFloat64Schema().WithEnum(enum...))},
+			},
+		}
+	}
+
+	d, err := diff.Get(diff.NewConfig(), spec(enum1), spec(enum2))
+	require.NoError(t, err)
+	require.Equal(t, diff.EnumValues{float64(0)}, d.SchemasDiff.Modified["Code"].EnumDiff.Deleted)
+	require.Equal(t, diff.EnumValues{float64(size)}, d.SchemasDiff.Modified["Code"].EnumDiff.Added)
+}
@@ -6,6 +6,7 @@ import (
 
 // MethodDiff describes the changes between a pair of operation objects: https://swagger.io/specification/#operation-object
 type MethodDiff struct {
+	PathDiff         *ValueDiff                `json:"path,omitempty" yaml:"path,omitempty"` // set when the operation was moved to another path, see Config.MatchMovedEndpoints
 	ExtensionsDiff   *ExtensionsDiff           `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 	TagsDiff         *StringsDiff              `json:"tags,omitempty" yaml:"tags,omitempty"`
 	SummaryDiff      *ValueDiff                `json:"summary,omitempty" yaml:"summary,omitempty"`
@@ -56,6 +57,8 @@ func getMethodDiffInternal(config *Config, state *state, operation1, operation2
 	result := newMethodDiff()
 	var err error
 
+	result.PathDiff = state.movedEndpoints.getPathDiff(operation1)
+
 	result.ExtensionsDiff, err = getExtensionsDiff(config, operation1.Extensions, operation2.Extensions)
 	if err != nil {
 		return nil, err
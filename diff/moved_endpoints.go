@@ -0,0 +1,270 @@
+package diff
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/utils"
+)
+
+// PreviousPathExtension can be set on an operation, or a path, in the revision to indicate the path that it had in the base
+const PreviousPathExtension = "x-previous-path"
+
+// movedEndpoint describes an operation of the base which was moved to another path in the revision
+type movedEndpoint struct {
+	from          string
+	to            string
+	pathParamsMap PathParamsMap
+}
+
+// movedEndpoints keeps track of the operations which were moved to another path and the path items that were created for them in the base
+type movedEndpoints struct {
+	operations map[*openapi3.Operation]movedEndpoint
+	pathItems  map[*openapi3.PathItem]PathParamsMap
+}
+
+func newMovedEndpoints() movedEndpoints {
+	return movedEndpoints{
+		operations: map[*openapi3.Operation]movedEndpoint{},
+		pathItems:  map[*openapi3.PathItem]PathParamsMap{},
+	}
+}
+
+// getPathDiff returns the path change of a moved operation, or nil if it wasn't moved
+func (moved movedEndpoints) getPathDiff(operation *openapi3.Operation) *ValueDiff {
+	if endpoint, ok := moved.operations[operation]; ok {
+		return &ValueDiff{From: endpoint.from, To: endpoint.to}
+	}
+	return nil
+}
+
+// getPathParamsMap returns the path params map of a moved operation, or the given map if it wasn't moved
+func (moved movedEndpoints) getPathParamsMap(operation *openapi3.Operation, pathParamsMap PathParamsMap) PathParamsMap {
+	if endpoint, ok := moved.operations[operation]; ok {
+		return endpoint.pathParamsMap
+	}
+	return pathParamsMap
+}
+
+// getMovedPathItemsDiff is like getPathItemsDiff but, when config.MatchMovedEndpoints is set, it first moves the base operations which were moved in the revision to their new paths
+// it also returns the base paths after the moves
+func getMovedPathItemsDiff(config *Config, state *state, paths1, paths2 *openapi3.Paths) (*openapi3.Paths, openapi3.Paths, openapi3.Paths, pathItemPairs) {
+	if config.MatchMovedEndpoints {
+		paths1 = moveEndpoints(config, state, paths1, paths2)
+	}
+
+	addedPaths, deletedPaths, otherPaths := getPathItemsDiff(config, paths1, paths2)
+
+	for _, pathItemPair := range otherPaths {
+		if pathParamsMap, ok := state.movedEndpoints.pathItems[pathItemPair.PathItem1]; ok {
+			pathItemPair.PathParamsMap = pathParamsMap
+		}
+	}
+
+	return paths1, addedPaths, deletedPaths, otherPaths
+}
+
+type endpointOperation struct {
+	path      string
+	method    string
+	pathItem  *openapi3.PathItem
+	operation *openapi3.Operation
+}
+
+/*
+moveEndpoints matches the endpoints which were deleted from the base with endpoints which were added in the revision:
+  - an added endpoint with an x-previous-path extension is matched with the deleted endpoint with the same method at the previous path
+  - otherwise, an added endpoint is matched with the deleted endpoint with the same method and operationId, if there is exactly one such endpoint
+
+It returns a copy of the base paths where the matched operations are moved to their paths in the revision, so that they are compared to each other.
+The original paths and path items are left unmodified.
+*/
+func moveEndpoints(config *Config, state *state, paths1, paths2 *openapi3.Paths) *openapi3.Paths {
+	deleted := getUnmatchedOperations(config, paths1, paths2)
+	added := getUnmatchedOperations(config, paths2, paths1)
+	if len(deleted) == 0 || len(added) == 0 {
+		return paths1
+	}
+
+	matched := map[*openapi3.Operation]endpointOperation{}
+	moves := []struct{ from, to endpointOperation }{}
+	match := func(to endpointOperation, isMatch func(from endpointOperation) bool) {
+		var found *endpointOperation
+		for i, from := range deleted {
+			if _, ok := matched[from.operation]; ok || from.method != to.method || !isMatch(from) {
+				continue
+			}
+			if found != nil {
+				// ambiguous
+				return
+			}
+			found = &deleted[i]
+		}
+		if found != nil {
+			matched[found.operation] = to
+			moves = append(moves, struct{ from, to endpointOperation }{*found, to})
+		}
+	}
+
+	unmatched := []endpointOperation{}
+	for _, to := range added {
+		previousPath, ok := getPreviousPath(to.pathItem, to.operation)
+		if !ok {
+			unmatched = append(unmatched, to)
+			continue
+		}
+		match(to, func(from endpointOperation) bool {
+			return isSamePath(config, from.path, previousPath)
+		})
+	}
+
+	for _, to := range unmatched {
+		if to.operation.OperationID == "" {
+			continue
+		}
+		match(to, func(from endpointOperation) bool {
+			return from.operation.OperationID == to.operation.OperationID
+		})
+	}
+
+	if len(moves) == 0 {
+		return paths1
+	}
+
+	result := openapi3.NewPathsWithCapacity(paths1.Len())
+	copies := map[string]*openapi3.PathItem{}
+	getCopy := func(path string) *openapi3.PathItem {
+		if pathItem, ok := copies[path]; ok {
+			return pathItem
+		}
+		pathItem := copyPathItem(paths1.Value(path))
+		pathItem.Connect = paths1.Value(path).Connect
+		copies[path] = pathItem
+		result.Set(path, pathItem)
+		return pathItem
+	}
+
+	for path, pathItem := range paths1.Map() {
+		result.Set(path, pathItem)
+	}
+
+	for _, move := range moves {
+		getCopy(move.from.path).SetOperation(move.from.method, nil)
+
+		_, _, pathParams1 := utils.NormalizeTemplatedPath(move.from.path)
+		_, _, pathParams2 := utils.NormalizeTemplatedPath(move.to.path)
+		pathParamsMap, ok := NewPathParamsMap(pathParams1, pathParams2)
+		if !ok {
+			pathParamsMap = PathParamsMap{}
+		}
+
+		state.movedEndpoints.operations[move.from.operation] = movedEndpoint{
+			from:          move.from.path,
+			to:            move.to.path,
+			pathParamsMap: pathParamsMap,
+		}
+
+		if _, _, ok := findEndpoint(config, move.to.path, result); ok {
+			// the revision path matches an existing path in the base, which may have been renamed, so the operation is moved to it
+			for path := range result.Map() {
+				if isSamePath(config, path, move.to.path) {
+					getCopy(path).SetOperation(move.from.method, move.from.operation)
+					break
+				}
+			}
+			continue
+		}
+
+		// the operation is moved to a new path item with the path-level settings of the original one
+		pathItem := &openapi3.PathItem{
+			Extensions:  move.from.pathItem.Extensions,
+			Summary:     move.from.pathItem.Summary,
+			Description: move.from.pathItem.Description,
+			Servers:     move.from.pathItem.Servers,
+			Parameters:  move.from.pathItem.Parameters,
+		}
+		pathItem.SetOperation(move.from.method, move.from.operation)
+		state.movedEndpoints.pathItems[pathItem] = pathParamsMap
+		copies[move.to.path] = pathItem
+		result.Set(move.to.path, pathItem)
+	}
+
+	// paths without operations left were entirely moved
+	for path, pathItem := range copies {
+		if len(pathItem.Operations()) == 0 {
+			result.Delete(path)
+		}
+	}
+
+	return result
+}
+
+// getUnmatchedOperations returns the operations of paths1 which have no matching operation in paths2, sorted by path and method
+func getUnmatchedOperations(config *Config, paths1, paths2 *openapi3.Paths) []endpointOperation {
+	result := []endpointOperation{}
+	for _, path := range paths1.InMatchingOrder() {
+		pathItem1 := paths1.Value(path)
+		pathItem2, _, found := findEndpoint(config, path, paths2)
+		for _, method := range operations {
+			operation := pathItem1.GetOperation(method)
+			if operation == nil {
+				continue
+			}
+			if found && pathItem2.GetOperation(method) != nil {
+				continue
+			}
+			result = append(result, endpointOperation{
+				path:      path,
+				method:    method,
+				pathItem:  pathItem1,
+				operation: operation,
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].path != result[j].path {
+			return result[i].path < result[j].path
+		}
+		return result[i].method < result[j].method
+	})
+	return result
+}
+
+// isSamePath indicates whether two paths are the same endpoint path, ignoring path param names unless config.IncludePathParams is set
+func isSamePath(config *Config, path1, path2 string) bool {
+	if path1 == path2 {
+		return true
+	}
+
+	if config.IncludePathParams {
+		return false
+	}
+
+	normalized1, count1, _ := utils.NormalizeTemplatedPath(path1)
+	normalized2, count2, _ := utils.NormalizeTemplatedPath(path2)
+	return count1 == count2 && normalized1 == normalized2
+}
+
+// getPreviousPath returns the x-previous-path of an operation, or of its path item if the operation doesn't have one
+func getPreviousPath(pathItem *openapi3.PathItem, operation *openapi3.Operation) (string, bool) {
+	if previousPath, ok := getStringExtension(operation.Extensions, PreviousPathExtension); ok {
+		return previousPath, true
+	}
+	return getStringExtension(pathItem.Extensions, PreviousPathExtension)
+}
+
+func getStringExtension(extensions map[string]any, name string) (string, bool) {
+	switch value := extensions[name].(type) {
+	case string:
+		return value, value != ""
+	case json.RawMessage:
+		var result string
+		if err := json.Unmarshal(value, &result); err != nil {
+			return "", false
+		}
+		return result, result != ""
+	}
+	return "", false
+}
@@ -98,7 +98,7 @@ func (operationsDiff *OperationsDiff) diffOperation(config *Config, state *state
 		return nil
 	}
 
-	diff, err := getMethodDiff(config, state, operation1, operation2, pathParamsMap)
+	diff, err := getMethodDiff(config, state, operation1, operation2, state.movedEndpoints.getPathParamsMap(operation1, pathParamsMap))
 	if err != nil {
 		return err
 	}
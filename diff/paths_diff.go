@@ -61,7 +61,7 @@ func getPathsDiffInternal(config *Config, state *state, paths1, paths2 *openapi3
 	paths1Mod := rewritePrefix(paths1.Map(), config.PathStripPrefixBase, config.PathPrefixBase)
 	paths2Mod := rewritePrefix(paths2.Map(), config.PathStripPrefixRevision, config.PathPrefixRevision)
 
-	addedPaths, deletedPaths, otherPaths := getPathItemsDiff(config, paths1Mod, paths2Mod)
+	paths1Mod, addedPaths, deletedPaths, otherPaths := getMovedPathItemsDiff(config, state, paths1Mod, paths2Mod)
 
 	for endpoint := range addedPaths.Map() {
 		result.addAddedPath(endpoint)
@@ -0,0 +1,71 @@
+package diff
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// PrefixItemsDiff describes the changes between a pair of prefixItems lists of JSON Schema 2020-12: https://json-schema.org/draft/2020-12/json-schema-core#name-prefixitems
+// Prefix items are matched by position
+type PrefixItemsDiff struct {
+	Added    []int                   `json:"added,omitempty" yaml:"added,omitempty"`
+	Deleted  []int                   `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	Modified ModifiedPrefixItemsDiff `json:"modified,omitempty" yaml:"modified,omitempty"`
+}
+
+// ModifiedPrefixItemsDiff maps the positions of prefix items to their respective diffs
+type ModifiedPrefixItemsDiff map[int]*SchemaDiff
+
+// Empty indicates whether a change was found in this element
+func (diff *PrefixItemsDiff) Empty() bool {
+	if diff == nil {
+		return true
+	}
+
+	return len(diff.Added) == 0 &&
+		len(diff.Deleted) == 0 &&
+		len(diff.Modified) == 0
+}
+
+func newPrefixItemsDiff() *PrefixItemsDiff {
+	return &PrefixItemsDiff{
+		Added:    []int{},
+		Deleted:  []int{},
+		Modified: ModifiedPrefixItemsDiff{},
+	}
+}
+
+func getPrefixItemsDiff(config *Config, state *state, prefixItems1, prefixItems2 openapi3.SchemaRefs) (*PrefixItemsDiff, error) {
+	diff, err := getPrefixItemsDiffInternal(config, state, prefixItems1, prefixItems2)
+	if err != nil {
+		return nil, err
+	}
+
+	if diff.Empty() {
+		return nil, nil
+	}
+
+	return diff, nil
+}
+
+func getPrefixItemsDiffInternal(config *Config, state *state, prefixItems1, prefixItems2 openapi3.SchemaRefs) (*PrefixItemsDiff, error) {
+	result := newPrefixItemsDiff()
+
+	for i := 0; i < len(prefixItems1) || i < len(prefixItems2); i++ {
+		switch {
+		case i >= len(prefixItems1):
+			result.Added = append(result.Added, i)
+		case i >= len(prefixItems2):
+			result.Deleted = append(result.Deleted, i)
+		default:
+			diff, err := getSchemaDiff(config, state, prefixItems1[i], prefixItems2[i])
+			if err != nil {
+				return nil, err
+			}
+			if !diff.Empty() {
+				result.Modified[i] = diff
+			}
+		}
+	}
+
+	return result, nil
+}
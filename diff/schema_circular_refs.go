@@ -13,7 +13,7 @@ const (
 	circularRefStatusNoDiff
 )
 
-func getCircularRefsDiff(visited1, visited2 utils.VisitedRefs, schema1, schema2 *openapi3.SchemaRef) circularRefStatus {
+func getCircularRefsDiff(visited1, visited2 utils.VisitedRefs, renames schemaRenames, schema1, schema2 *openapi3.SchemaRef) circularRefStatus {
 
 	if schema1 == nil || schema2 == nil ||
 		schema1.Value == nil || schema2.Value == nil {
@@ -35,8 +35,8 @@ func getCircularRefsDiff(visited1, visited2 utils.VisitedRefs, schema1, schema2
 
 	// now we know that both refs are circular
 
-	// if they don't reference the same schema name, we consider them to be different
-	if schema1.Ref != schema2.Ref {
+	// if they don't reference the same schema name, or the renamed schema, we consider them to be different
+	if renames.revisionRef(schema1.Ref) != schema2.Ref {
 		return circularRefStatusDiff
 	}
 
@@ -4,53 +4,69 @@ import (
 	"errors"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/load"
 )
 
 // SchemaDiff describes the changes between a pair of schema objects: https://swagger.io/specification/#schema-object
 type SchemaDiff struct {
-	SchemaAdded                     bool                    `json:"schemaAdded,omitempty" yaml:"schemaAdded,omitempty"`
-	SchemaDeleted                   bool                    `json:"schemaDeleted,omitempty" yaml:"schemaDeleted,omitempty"`
-	CircularRefDiff                 bool                    `json:"circularRef,omitempty" yaml:"circularRef,omitempty"`
-	ExtensionsDiff                  *ExtensionsDiff         `json:"extensions,omitempty" yaml:"extensions,omitempty"`
-	OneOfDiff                       *SubschemasDiff         `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
-	AnyOfDiff                       *SubschemasDiff         `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
-	AllOfDiff                       *SubschemasDiff         `json:"allOf,omitempty" yaml:"allOf,omitempty"`
-	NotDiff                         *SchemaDiff             `json:"not,omitempty" yaml:"not,omitempty"`
-	TypeDiff                        *StringsDiff            `json:"type,omitempty" yaml:"type,omitempty"`
-	TitleDiff                       *ValueDiff              `json:"title,omitempty" yaml:"title,omitempty"`
-	FormatDiff                      *ValueDiff              `json:"format,omitempty" yaml:"format,omitempty"`
-	DescriptionDiff                 *ValueDiff              `json:"description,omitempty" yaml:"description,omitempty"`
-	EnumDiff                        *EnumDiff               `json:"enum,omitempty" yaml:"enum,omitempty"`
-	DefaultDiff                     *ValueDiff              `json:"default,omitempty" yaml:"default,omitempty"`
-	ExampleDiff                     *ValueDiff              `json:"example,omitempty" yaml:"example,omitempty"`
-	ExternalDocsDiff                *ExternalDocsDiff       `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
-	AdditionalPropertiesAllowedDiff *ValueDiff              `json:"additionalPropertiesAllowed,omitempty" yaml:"additionalPropertiesAllowed,omitempty"`
-	UniqueItemsDiff                 *ValueDiff              `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
-	ExclusiveMinDiff                *ValueDiff              `json:"exclusiveMin,omitempty" yaml:"exclusiveMin,omitempty"`
-	ExclusiveMaxDiff                *ValueDiff              `json:"exclusiveMax,omitempty" yaml:"exclusiveMax,omitempty"`
-	NullableDiff                    *ValueDiff              `json:"nullable,omitempty" yaml:"nullable,omitempty"`
-	ReadOnlyDiff                    *ValueDiff              `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
-	WriteOnlyDiff                   *ValueDiff              `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
-	AllowEmptyValueDiff             *ValueDiff              `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
-	XMLDiff                         *ValueDiff              `json:"XML,omitempty" yaml:"XML,omitempty"`
-	DeprecatedDiff                  *ValueDiff              `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
-	MinDiff                         *ValueDiff              `json:"min,omitempty" yaml:"min,omitempty"`
-	MaxDiff                         *ValueDiff              `json:"max,omitempty" yaml:"max,omitempty"`
-	MultipleOfDiff                  *ValueDiff              `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
-	MinLengthDiff                   *ValueDiff              `json:"minLength,omitempty" yaml:"minLength,omitempty"`
-	MaxLengthDiff                   *ValueDiff              `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
-	PatternDiff                     *ValueDiff              `json:"pattern,omitempty" yaml:"pattern,omitempty"`
-	MinItemsDiff                    *ValueDiff              `json:"minItems,omitempty" yaml:"minItems,omitempty"`
-	MaxItemsDiff                    *ValueDiff              `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
-	ItemsDiff                       *SchemaDiff             `json:"items,omitempty" yaml:"items,omitempty"`
-	RequiredDiff                    *RequiredPropertiesDiff `json:"required,omitempty" yaml:"required,omitempty"`
-	PropertiesDiff                  *SchemasDiff            `json:"properties,omitempty" yaml:"properties,omitempty"`
-	MinPropsDiff                    *ValueDiff              `json:"minProps,omitempty" yaml:"minProps,omitempty"`
-	MaxPropsDiff                    *ValueDiff              `json:"maxProps,omitempty" yaml:"maxProps,omitempty"`
-	AdditionalPropertiesDiff        *SchemaDiff             `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
-	DiscriminatorDiff               *DiscriminatorDiff      `json:"discriminatorDiff,omitempty" yaml:"discriminatorDiff,omitempty"`
-	Base                            *openapi3.Schema        `json:"-" yaml:"-"`
-	Revision                        *openapi3.Schema        `json:"-" yaml:"-"`
+	SchemaAdded                      bool                    `json:"schemaAdded,omitempty" yaml:"schemaAdded,omitempty"`
+	SchemaDeleted                    bool                    `json:"schemaDeleted,omitempty" yaml:"schemaDeleted,omitempty"`
+	CircularRefDiff                  bool                    `json:"circularRef,omitempty" yaml:"circularRef,omitempty"`
+	ExtensionsDiff                   *ExtensionsDiff         `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	OneOfDiff                        *SubschemasDiff         `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	AnyOfDiff                        *SubschemasDiff         `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	AllOfDiff                        *SubschemasDiff         `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	NotDiff                          *SchemaDiff             `json:"not,omitempty" yaml:"not,omitempty"`
+	TypeDiff                         *StringsDiff            `json:"type,omitempty" yaml:"type,omitempty"`
+	TitleDiff                        *ValueDiff              `json:"title,omitempty" yaml:"title,omitempty"`
+	FormatDiff                       *ValueDiff              `json:"format,omitempty" yaml:"format,omitempty"`
+	DescriptionDiff                  *ValueDiff              `json:"description,omitempty" yaml:"description,omitempty"`
+	EnumDiff                         *EnumDiff               `json:"enum,omitempty" yaml:"enum,omitempty"`
+	DefaultDiff                      *ValueDiff              `json:"default,omitempty" yaml:"default,omitempty"`
+	ExampleDiff                      *ValueDiff              `json:"example,omitempty" yaml:"example,omitempty"`
+	ExternalDocsDiff                 *ExternalDocsDiff       `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	AdditionalPropertiesAllowedDiff  *ValueDiff              `json:"additionalPropertiesAllowed,omitempty" yaml:"additionalPropertiesAllowed,omitempty"`
+	UniqueItemsDiff                  *ValueDiff              `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	ExclusiveMinDiff                 *ValueDiff              `json:"exclusiveMin,omitempty" yaml:"exclusiveMin,omitempty"`
+	ExclusiveMaxDiff                 *ValueDiff              `json:"exclusiveMax,omitempty" yaml:"exclusiveMax,omitempty"`
+	NullableDiff                     *ValueDiff              `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	ReadOnlyDiff                     *ValueDiff              `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+	WriteOnlyDiff                    *ValueDiff              `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
+	AllowEmptyValueDiff              *ValueDiff              `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
+	XMLDiff                          *ValueDiff              `json:"XML,omitempty" yaml:"XML,omitempty"`
+	DeprecatedDiff                   *ValueDiff              `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	MinDiff                          *ValueDiff              `json:"min,omitempty" yaml:"min,omitempty"`
+	MaxDiff                          *ValueDiff              `json:"max,omitempty" yaml:"max,omitempty"`
+	MultipleOfDiff                   *ValueDiff              `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	MinLengthDiff                    *ValueDiff              `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLengthDiff                    *ValueDiff              `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	PatternDiff                      *ValueDiff              `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MinItemsDiff                     *ValueDiff              `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItemsDiff                     *ValueDiff              `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	ItemsDiff                        *SchemaDiff             `json:"items,omitempty" yaml:"items,omitempty"`
+	RequiredDiff                     *RequiredPropertiesDiff `json:"required,omitempty" yaml:"required,omitempty"`
+	PropertiesDiff                   *SchemasDiff            `json:"properties,omitempty" yaml:"properties,omitempty"`
+	MinPropsDiff                     *ValueDiff              `json:"minProps,omitempty" yaml:"minProps,omitempty"`
+	MaxPropsDiff                     *ValueDiff              `json:"maxProps,omitempty" yaml:"maxProps,omitempty"`
+	AdditionalPropertiesDiff         *SchemaDiff             `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	DiscriminatorDiff                *DiscriminatorDiff      `json:"discriminatorDiff,omitempty" yaml:"discriminatorDiff,omitempty"`
+	ConstDiff                        *ConstDiff              `json:"const,omitempty" yaml:"const,omitempty"`
+	PrefixItemsDiff                  *PrefixItemsDiff        `json:"prefixItems,omitempty" yaml:"prefixItems,omitempty"`
+	ContainsDiff                     *SchemaDiff             `json:"contains,omitempty" yaml:"contains,omitempty"`
+	MinContainsDiff                  *ValueDiff              `json:"minContains,omitempty" yaml:"minContains,omitempty"`
+	MaxContainsDiff                  *ValueDiff              `json:"maxContains,omitempty" yaml:"maxContains,omitempty"`
+	IfDiff                           *SchemaDiff             `json:"if,omitempty" yaml:"if,omitempty"`
+	ThenDiff                         *SchemaDiff             `json:"then,omitempty" yaml:"then,omitempty"`
+	ElseDiff                         *SchemaDiff             `json:"else,omitempty" yaml:"else,omitempty"`
+	DependentRequiredDiff            *DependentRequiredDiff  `json:"dependentRequired,omitempty" yaml:"dependentRequired,omitempty"`
+	DependentSchemasDiff             *SchemasDiff            `json:"dependentSchemas,omitempty" yaml:"dependentSchemas,omitempty"`
+	UnevaluatedPropertiesAllowedDiff *ValueDiff              `json:"unevaluatedPropertiesAllowed,omitempty" yaml:"unevaluatedPropertiesAllowed,omitempty"`
+	UnevaluatedPropertiesDiff        *SchemaDiff             `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
+	UnevaluatedItemsAllowedDiff      *ValueDiff              `json:"unevaluatedItemsAllowed,omitempty" yaml:"unevaluatedItemsAllowed,omitempty"`
+	UnevaluatedItemsDiff             *SchemaDiff             `json:"unevaluatedItems,omitempty" yaml:"unevaluatedItems,omitempty"`
+	PropertyNamesDiff                *SchemaDiff             `json:"propertyNames,omitempty" yaml:"propertyNames,omitempty"`
+	Base                             *openapi3.Schema        `json:"-" yaml:"-"`
+	Revision                         *openapi3.Schema        `json:"-" yaml:"-"`
 }
 
 // Empty indicates whether a change was found in this element
@@ -64,6 +80,11 @@ func getSchemaDiff(config *Config, state *state, schema1, schema2 *openapi3.Sche
 		return diff, nil
 	}
 
+	if err := state.walk.Enter(schema1, schema2); err != nil {
+		return nil, err
+	}
+	defer state.walk.Leave()
+
 	diff, err := getSchemaDiffInternal(config, state, schema1, schema2)
 	if err != nil {
 		return nil, err
@@ -102,7 +123,7 @@ func getSchemaDiffInternal(config *Config, state *state, schema1, schema2 *opena
 		Revision: value2,
 	}
 
-	if status := getCircularRefsDiff(state.visitedSchemasBase, state.visitedSchemasRevision, schema1, schema2); status != circularRefStatusNone {
+	if status := getCircularRefsDiff(state.visitedSchemasBase, state.visitedSchemasRevision, state.schemaRenames, schema1, schema2); status != circularRefStatusNone {
 		switch status {
 		case circularRefStatusDiff:
 			result.CircularRefDiff = true
@@ -125,7 +146,7 @@ func getSchemaDiffInternal(config *Config, state *state, schema1, schema2 *opena
 
 	var err error
 
-	result.ExtensionsDiff, err = getExtensionsDiff(config, value1.Extensions, value2.Extensions)
+	result.ExtensionsDiff, err = getExtensionsDiff(config, schemaExtensions(value1), schemaExtensions(value2))
 	if err != nil {
 		return nil, err
 	}
@@ -199,9 +220,76 @@ func getSchemaDiffInternal(config *Config, state *state, schema1, schema2 *opena
 		return nil, err
 	}
 
+	if err := getSchemaKeywordsDiff(config, state, &result, load.GetSchemaKeywords(value1), load.GetSchemaKeywords(value2)); err != nil {
+		return nil, err
+	}
+
 	return &result, nil
 }
 
+// getSchemaKeywordsDiff adds the changes in JSON Schema 2020-12 keywords to the schema diff
+func getSchemaKeywordsDiff(config *Config, state *state, result *SchemaDiff, keywords1, keywords2 load.SchemaKeywords) error {
+	var err error
+
+	result.ConstDiff = getConstDiff(keywords1, keywords2)
+	if result.PrefixItemsDiff, err = getPrefixItemsDiff(config, state, keywords1.PrefixItems, keywords2.PrefixItems); err != nil {
+		return err
+	}
+	if result.ContainsDiff, err = getSchemaDiff(config, state, keywords1.Contains, keywords2.Contains); err != nil {
+		return err
+	}
+	result.MinContainsDiff = getUInt64RefDiff(keywords1.MinContains, keywords2.MinContains)
+	result.MaxContainsDiff = getUInt64RefDiff(keywords1.MaxContains, keywords2.MaxContains)
+	if result.IfDiff, err = getSchemaDiff(config, state, keywords1.If, keywords2.If); err != nil {
+		return err
+	}
+	if result.ThenDiff, err = getSchemaDiff(config, state, keywords1.Then, keywords2.Then); err != nil {
+		return err
+	}
+	if result.ElseDiff, err = getSchemaDiff(config, state, keywords1.Else, keywords2.Else); err != nil {
+		return err
+	}
+	result.DependentRequiredDiff = getDependentRequiredDiff(keywords1.DependentRequired, keywords2.DependentRequired)
+	if result.DependentSchemasDiff, err = getSchemasDiff(config, state, keywords1.DependentSchemas, keywords2.DependentSchemas); err != nil {
+		return err
+	}
+	result.UnevaluatedPropertiesAllowedDiff = getBoolRefDiff(keywords1.UnevaluatedProperties.Has, keywords2.UnevaluatedProperties.Has)
+	if result.UnevaluatedPropertiesDiff, err = getSchemaDiff(config, state, keywords1.UnevaluatedProperties.Schema, keywords2.UnevaluatedProperties.Schema); err != nil {
+		return err
+	}
+	result.UnevaluatedItemsAllowedDiff = getBoolRefDiff(keywords1.UnevaluatedItems.Has, keywords2.UnevaluatedItems.Has)
+	if result.UnevaluatedItemsDiff, err = getSchemaDiff(config, state, keywords1.UnevaluatedItems.Schema, keywords2.UnevaluatedItems.Schema); err != nil {
+		return err
+	}
+	if result.PropertyNamesDiff, err = getSchemaDiff(config, state, keywords1.PropertyNames, keywords2.PropertyNames); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// schemaExtensions returns the extensions of a schema without the JSON Schema 2020-12 keywords, which are compared separately
+func schemaExtensions(schema *openapi3.Schema) map[string]any {
+	found := false
+	for k := range schema.Extensions {
+		if load.IsSchemaKeyword(k) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return schema.Extensions
+	}
+
+	result := make(map[string]any, len(schema.Extensions))
+	for k, v := range schema.Extensions {
+		if !load.IsSchemaKeyword(k) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
 func derefSchema(ref *openapi3.SchemaRef) (*openapi3.Schema, error) {
 	if ref == nil || ref.Value == nil {
 		return nil, errors.New("schema reference is nil")
@@ -0,0 +1,322 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	componentSchemasPrefix = "#/components/schemas/"
+
+	// schemaRenameSimilarity is the minimal structural similarity between a deleted and an added component schema for them to be considered a rename
+	schemaRenameSimilarity = 0.8
+
+	// schemaRenameMinFeatures is the minimal number of features of both schemas for them to be considered a rename based on their structure alone
+	// trivial schemas, like {type: string}, are too common to be paired unless a reference to the deleted schema was replaced by a reference to the added one
+	schemaRenameMinFeatures = 3
+)
+
+// RenamedSchema describes a component schema which was renamed
+type RenamedSchema struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// RenamedSchemas is a list of renamed component schemas
+type RenamedSchemas []RenamedSchema
+
+// String returns a string representation of the renamed schema
+func (renamedSchema RenamedSchema) String() string {
+	return fmt.Sprintf("%s -> %s", renamedSchema.From, renamedSchema.To)
+}
+
+// schemaRenames maps the names of renamed component schemas in the base to their names in the revision
+type schemaRenames struct {
+	baseToRevision map[string]string
+	revisionToBase map[string]string
+}
+
+func newSchemaRenames() schemaRenames {
+	return schemaRenames{
+		baseToRevision: map[string]string{},
+		revisionToBase: map[string]string{},
+	}
+}
+
+func (renames schemaRenames) add(base, revision string) {
+	renames.baseToRevision[base] = revision
+	renames.revisionToBase[revision] = base
+}
+
+// revisionRef returns the reference that a base reference is expected to have in the revision
+func (renames schemaRenames) revisionRef(ref string) string {
+	return renameRef(ref, renames.baseToRevision)
+}
+
+// baseRef returns the reference that a revision reference had in the base
+func (renames schemaRenames) baseRef(ref string) string {
+	return renameRef(ref, renames.revisionToBase)
+}
+
+func renameRef(ref string, names map[string]string) string {
+	name, ok := strings.CutPrefix(ref, componentSchemasPrefix)
+	if !ok {
+		return ref
+	}
+	if renamed, ok := names[name]; ok {
+		return componentSchemasPrefix + renamed
+	}
+	return ref
+}
+
+/*
+getSchemaRenames pairs component schemas which were deleted from the base with component schemas which were added in the revision and have an identical or near-identical structure.
+The structure of a schema is described by the set of its leaf values, each identified by its path within the schema, and two schemas are compared by the overlap of these sets.
+A pair is matched only if each schema is the single most similar schema of the other, so ambiguous pairs are reported as deleted and added schemas.
+Trivial schemas are matched only if a reference to the deleted schema was replaced by a reference to the added schema at the same location in the spec.
+Schemas often refer to each other, so matching is repeated with the references of the base schemas replaced by the renames found so far, until no new renames are found.
+*/
+func getSchemaRenames(s1, s2 *openapi3.T) (schemaRenames, error) {
+	result := newSchemaRenames()
+	if s1.Components == nil || s2.Components == nil {
+		return result, nil
+	}
+
+	added, deleted, _ := diffSchemas(s1.Components.Schemas, s2.Components.Schemas)
+	if len(added) == 0 || len(deleted) == 0 {
+		return result, nil
+	}
+
+	addedFeatures, err := getSchemasFeatures(added, func(ref string) string { return ref })
+	if err != nil {
+		return result, err
+	}
+
+	refPairs, err := getSchemaRefPairs(s1, s2, deleted, added)
+	if err != nil {
+		return result, err
+	}
+
+	for {
+		deletedFeatures, err := getSchemasFeatures(deleted, result.revisionRef)
+		if err != nil {
+			return result, err
+		}
+
+		if !result.match(deletedFeatures, addedFeatures, refPairs) {
+			return result, nil
+		}
+	}
+}
+
+// schemaNamePair is a pair of names of a base and a revision component schema
+type schemaNamePair struct {
+	base, revision string
+}
+
+// bestMatch is the most similar schema to a schema, unique is false if several schemas are equally similar
+type bestMatch struct {
+	name       string
+	similarity float64
+	unique     bool
+}
+
+func (best *bestMatch) add(name string, similarity float64) {
+	switch {
+	case best.name == "" || similarity > best.similarity:
+		*best = bestMatch{name: name, similarity: similarity, unique: true}
+	case similarity == best.similarity:
+		best.unique = false
+	}
+}
+
+// match adds the pairs of base and revision schemas which aren't matched yet and are each other's single most similar schema, and returns whether any were added
+func (renames schemaRenames) match(deletedFeatures, addedFeatures map[string]schemaFeatures, refPairs map[schemaNamePair]struct{}) bool {
+	bestRevisions := map[string]*bestMatch{}
+	bestBases := map[string]*bestMatch{}
+
+	for base, features1 := range deletedFeatures {
+		if _, ok := renames.baseToRevision[base]; ok {
+			continue
+		}
+		for revision, features2 := range addedFeatures {
+			if _, ok := renames.revisionToBase[revision]; ok {
+				continue
+			}
+			similarity := getSimilarity(features1, features2)
+			if similarity < schemaRenameSimilarity {
+				continue
+			}
+			if bestRevisions[base] == nil {
+				bestRevisions[base] = &bestMatch{}
+			}
+			bestRevisions[base].add(revision, similarity)
+			if bestBases[revision] == nil {
+				bestBases[revision] = &bestMatch{}
+			}
+			bestBases[revision].add(base, similarity)
+		}
+	}
+
+	matched := false
+	for base, bestRevision := range bestRevisions {
+		if !bestRevision.unique {
+			continue
+		}
+		revision := bestRevision.name
+		if bestBase := bestBases[revision]; !bestBase.unique || bestBase.name != base {
+			continue
+		}
+		if _, ok := refPairs[schemaNamePair{base: base, revision: revision}]; !ok &&
+			(len(deletedFeatures[base]) < schemaRenameMinFeatures || len(addedFeatures[revision]) < schemaRenameMinFeatures) {
+			continue
+		}
+		renames.add(base, revision)
+		matched = true
+	}
+	return matched
+}
+
+// getSchemaRefPairs returns the pairs of deleted and added component schemas, such that a reference to the deleted schema in the base was replaced by a reference to the added schema at the same location in the revision
+func getSchemaRefPairs(s1, s2 *openapi3.T, deleted, added openapi3.Schemas) (map[schemaNamePair]struct{}, error) {
+	value1, err := toGeneric(s1)
+	if err != nil {
+		return nil, err
+	}
+	value2, err := toGeneric(s2)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[schemaNamePair]struct{}{}
+	addSchemaRefPairs(result, value1, value2, deleted, added)
+	return result, nil
+}
+
+func addSchemaRefPairs(result map[schemaNamePair]struct{}, value1, value2 any, deleted, added openapi3.Schemas) {
+	switch value1 := value1.(type) {
+	case map[string]any:
+		value2, ok := value2.(map[string]any)
+		if !ok {
+			return
+		}
+		for key, child1 := range value1 {
+			child2, ok := value2[key]
+			if !ok {
+				continue
+			}
+			if key != "$ref" {
+				addSchemaRefPairs(result, child1, child2, deleted, added)
+				continue
+			}
+			ref1, _ := child1.(string)
+			ref2, _ := child2.(string)
+			base, ok1 := strings.CutPrefix(ref1, componentSchemasPrefix)
+			revision, ok2 := strings.CutPrefix(ref2, componentSchemasPrefix)
+			if !ok1 || !ok2 || deleted[base] == nil || added[revision] == nil {
+				continue
+			}
+			result[schemaNamePair{base: base, revision: revision}] = struct{}{}
+		}
+	case []any:
+		value2, ok := value2.([]any)
+		if !ok {
+			return
+		}
+		for i := range min(len(value1), len(value2)) {
+			addSchemaRefPairs(result, value1[i], value2[i], deleted, added)
+		}
+	}
+}
+
+// toGeneric converts a value to its generic JSON representation
+func toGeneric(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type schemaFeatures map[string]struct{}
+
+func getSchemasFeatures(schemas openapi3.Schemas, rename func(ref string) string) (map[string]schemaFeatures, error) {
+	result := make(map[string]schemaFeatures, len(schemas))
+	for name, schemaRef := range schemas {
+		if schemaRef == nil || schemaRef.Value == nil {
+			continue
+		}
+		features, err := getSchemaFeatures(schemaRef.Value, rename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare component schema %q: %w", name, err)
+		}
+		result[name] = features
+	}
+	return result, nil
+}
+
+// getSchemaFeatures returns the leaf values of a schema with their paths
+// nested references are kept as references, so recursive schemas are supported, and renamed by the given function
+func getSchemaFeatures(schema *openapi3.Schema, rename func(ref string) string) (schemaFeatures, error) {
+	value, err := toGeneric(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	result := schemaFeatures{}
+	addSchemaFeatures(result, "", value, rename)
+	return result, nil
+}
+
+func addSchemaFeatures(features schemaFeatures, path string, value any, rename func(ref string) string) {
+	switch value := value.(type) {
+	case map[string]any:
+		if len(value) == 0 {
+			features[path+"={}"] = struct{}{}
+		}
+		for key, child := range value {
+			// titles and descriptions don't affect the wire format, unlike properties with these names
+			if (key == "title" || key == "description") && !strings.HasSuffix(path, "/properties") {
+				continue
+			}
+			if ref, ok := child.(string); ok && key == "$ref" {
+				child = rename(ref)
+			}
+			addSchemaFeatures(features, path+"/"+key, child, rename)
+		}
+	case []any:
+		if len(value) == 0 {
+			features[path+"=[]"] = struct{}{}
+		}
+		for i, child := range value {
+			addSchemaFeatures(features, fmt.Sprintf("%s/%d", path, i), child, rename)
+		}
+	default:
+		data, _ := json.Marshal(value)
+		features[path+"="+string(data)] = struct{}{}
+	}
+}
+
+// getSimilarity returns the Jaccard index of two sets of features
+func getSimilarity(features1, features2 schemaFeatures) float64 {
+	if len(features1) == 0 && len(features2) == 0 {
+		return 1
+	}
+
+	common := 0
+	for feature := range features1 {
+		if _, ok := features2[feature]; ok {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(features1)+len(features2)-common)
+}
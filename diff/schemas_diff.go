@@ -1,6 +1,8 @@
 package diff
 
 import (
+	"sort"
+
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tufin/oasdiff/utils"
 )
@@ -9,6 +11,7 @@ import (
 type SchemasDiff struct {
 	Added    utils.StringList   `json:"added,omitempty" yaml:"added,omitempty"`
 	Deleted  utils.StringList   `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	Renamed  RenamedSchemas     `json:"renamed,omitempty" yaml:"renamed,omitempty"` // component schemas only, modified renamed schemas also appear under Modified by their new name
 	Modified ModifiedSchemasMap `json:"modified,omitempty" yaml:"modified,omitempty"`
 	Base     openapi3.Schemas   `json:"-" yaml:"-"`
 	Revision openapi3.Schemas   `json:"-" yaml:"-"`
@@ -22,6 +25,7 @@ func (schemasDiff *SchemasDiff) Empty() bool {
 
 	return len(schemasDiff.Added) == 0 &&
 		len(schemasDiff.Deleted) == 0 &&
+		len(schemasDiff.Renamed) == 0 &&
 		len(schemasDiff.Modified) == 0
 }
 
@@ -53,6 +57,24 @@ func getSchemasDiff(config *Config, state *state, schemas1, schemas2 openapi3.Sc
 	return diff, nil
 }
 
+// getComponentSchemasDiff is like getSchemasDiff but it reports the renamed component schemas as renamed rather than as deleted and added
+func getComponentSchemasDiff(config *Config, state *state, schemas1, schemas2 openapi3.Schemas) (*SchemasDiff, error) {
+	diff, err := getSchemasDiffInternal(config, state, schemas1, schemas2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := diff.applyRenames(config, state); err != nil {
+		return nil, err
+	}
+
+	if diff.Empty() {
+		return nil, nil
+	}
+
+	return diff, nil
+}
+
 func getSchemasDiffInternal(config *Config, state *state, schemas1, schemas2 openapi3.Schemas) (*SchemasDiff, error) {
 
 	result := newSchemasDiff()
@@ -121,11 +143,51 @@ func (schemasDiff *SchemasDiff) addModifiedSchema(config *Config, state *state,
 	return schemasDiff.Modified.addSchemaDiff(config, state, schemaName, schemaRef1, schemaRef2)
 }
 
+// applyRenames replaces the deleted and added schemas which were renamed by renamed schemas
+func (schemasDiff *SchemasDiff) applyRenames(config *Config, state *state) error {
+	deleted := utils.StringList{}
+	for _, schema := range schemasDiff.Deleted {
+		revision, ok := state.schemaRenames.baseToRevision[schema]
+		if !ok {
+			deleted = append(deleted, schema)
+			continue
+		}
+
+		schemasDiff.Renamed = append(schemasDiff.Renamed, RenamedSchema{From: schema, To: revision})
+		if err := schemasDiff.addModifiedSchema(config, state, revision, schemasDiff.Base[schema], schemasDiff.Revision[revision]); err != nil {
+			return err
+		}
+	}
+	schemasDiff.Deleted = deleted
+
+	added := utils.StringList{}
+	for _, schema := range schemasDiff.Added {
+		if _, ok := state.schemaRenames.revisionToBase[schema]; !ok {
+			added = append(added, schema)
+		}
+	}
+	schemasDiff.Added = added
+
+	sort.Slice(schemasDiff.Renamed, func(i, j int) bool {
+		return schemasDiff.Renamed[i].From < schemasDiff.Renamed[j].From
+	})
+
+	return nil
+}
+
 func (schemasDiff *SchemasDiff) getSummary() *SummaryDetails {
+	// renamed schemas are counted as modified
+	modified := len(schemasDiff.Modified)
+	for _, renamed := range schemasDiff.Renamed {
+		if _, ok := schemasDiff.Modified[renamed.To]; !ok {
+			modified++
+		}
+	}
+
 	return &SummaryDetails{
 		Added:    len(schemasDiff.Added),
 		Deleted:  len(schemasDiff.Deleted),
-		Modified: len(schemasDiff.Modified),
+		Modified: modified,
 	}
 }
 
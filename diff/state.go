@@ -1,6 +1,9 @@
 package diff
 
-import "github.com/tufin/oasdiff/utils"
+import (
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/utils"
+)
 
 type direction int
 
@@ -14,14 +17,20 @@ type state struct {
 	visitedSchemasRevision utils.VisitedRefs
 	cache                  directionalSchemaDiffCache
 	direction              direction
+	schemaRenames          schemaRenames
+	movedEndpoints         movedEndpoints
+	walk                   *limits.Walk
 }
 
-func newState() *state {
+func newState(config *Config) *state {
 	return &state{
 		visitedSchemasBase:     utils.VisitedRefs{},
 		visitedSchemasRevision: utils.VisitedRefs{},
 		cache:                  newDirectionalSchemaDiffCache(),
 		direction:              directionRequest,
+		schemaRenames:          newSchemaRenames(),
+		movedEndpoints:         newMovedEndpoints(),
+		walk:                   config.Limits.NewWalk(),
 	}
 }
 
@@ -15,6 +15,7 @@ SubschemasDiff is a combination of two diffs:
 
  1. Diff of referenced schemas: subschemas under AllOf, AnyOf or OneOf defined as references to schemas under components/schemas
     - schemas with the same $ref across base and revision are compared to each other and based on the result are considered as modified or unmodified
+    - references to a renamed component schema are considered to be the same $ref
     - other schemas are considered added/deleted
 
  2. Diff of inline schemas: subschemas defined directly under AllOf, AnyOf or OneOf, without a reference to components/schemas
@@ -138,7 +139,7 @@ func getSubschemasRefDiff(config *Config, state *state, schemaRefs1, schemaRefs2
 		if !isSchemaRef(schemaRef1) {
 			continue
 		}
-		if schemaRef2, index2, found := refMap2.pop(schemaRef1.Ref); found {
+		if schemaRef2, index2, found := refMap2.pop(state.schemaRenames.revisionRef(schemaRef1.Ref)); found {
 			if err := result.appendModified(config, state, schemaRef1, schemaRef2, index1, index2); err != nil {
 				return result, err
 			}
@@ -152,7 +153,7 @@ func getSubschemasRefDiff(config *Config, state *state, schemaRefs1, schemaRefs2
 		if !isSchemaRef(schemaRef2) {
 			continue
 		}
-		if _, _, found := refMap1.pop(schemaRef2.Ref); !found {
+		if _, _, found := refMap1.pop(state.schemaRenames.baseRef(schemaRef2.Ref)); !found {
 			result.appendAdded(index2, schemaRef2, "")
 		}
 	}
@@ -0,0 +1,115 @@
+package diff
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/load"
+	"github.com/tufin/oasdiff/utils"
+)
+
+// WebhooksDiff describes the changes between a pair of webhooks maps of OpenAPI 3.1 specs: https://spec.openapis.org/oas/v3.1.0#fixed-fields
+// Webhooks are matched by name and each webhook is compared like a path item
+type WebhooksDiff struct {
+	Added    utils.StringList `json:"added,omitempty" yaml:"added,omitempty"`
+	Deleted  utils.StringList `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	Modified ModifiedPaths    `json:"modified,omitempty" yaml:"modified,omitempty"`
+	Base     *openapi3.Paths  `json:"-" yaml:"-"`
+	Revision *openapi3.Paths  `json:"-" yaml:"-"`
+}
+
+// Empty indicates whether a change was found in this element
+func (diff *WebhooksDiff) Empty() bool {
+	if diff == nil {
+		return true
+	}
+
+	return len(diff.Added) == 0 &&
+		len(diff.Deleted) == 0 &&
+		len(diff.Modified) == 0
+}
+
+func newWebhooksDiff() *WebhooksDiff {
+	return &WebhooksDiff{
+		Added:    utils.StringList{},
+		Deleted:  utils.StringList{},
+		Modified: ModifiedPaths{},
+	}
+}
+
+func getWebhooksDiff(config *Config, state *state, webhooks1, webhooks2 *openapi3.Paths) (*WebhooksDiff, error) {
+
+	diff, err := getWebhooksDiffInternal(config, state, webhooks1, webhooks2)
+	if err != nil {
+		return nil, err
+	}
+
+	if diff.Empty() {
+		return nil, nil
+	}
+
+	return diff, nil
+}
+
+func getWebhooksDiffInternal(config *Config, state *state, webhooks1, webhooks2 *openapi3.Paths) (*WebhooksDiff, error) {
+
+	result := newWebhooksDiff()
+
+	if webhooks1 == nil {
+		webhooks1 = openapi3.NewPaths()
+	}
+	if webhooks2 == nil {
+		webhooks2 = openapi3.NewPaths()
+	}
+
+	for name, pathItem1 := range webhooks1.Map() {
+		pathItem2 := webhooks2.Value(name)
+		if pathItem2 == nil {
+			result.Deleted = append(result.Deleted, name)
+			continue
+		}
+
+		if err := result.Modified.addPathDiff(config, state, name, &pathItemPair{
+			PathItem1:     pathItem1,
+			PathItem2:     pathItem2,
+			PathParamsMap: PathParamsMap{},
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	for name := range webhooks2.Map() {
+		if webhooks1.Value(name) == nil {
+			result.Added = append(result.Added, name)
+		}
+	}
+
+	result.Added.Sort()
+	result.Deleted.Sort()
+
+	result.Base = webhooks1
+	result.Revision = webhooks2
+
+	return result, nil
+}
+
+func (diff *WebhooksDiff) getSummary() *SummaryDetails {
+	return &SummaryDetails{
+		Added:    len(diff.Added),
+		Deleted:  len(diff.Deleted),
+		Modified: len(diff.Modified),
+	}
+}
+
+// specExtensions returns the extensions of a spec without the webhooks, which are compared separately
+func specExtensions(spec *openapi3.T) map[string]any {
+	if _, ok := spec.Extensions[load.WebhooksField]; !ok {
+		return spec.Extensions
+	}
+
+	result := make(map[string]any, len(spec.Extensions))
+	for k, v := range spec.Extensions {
+		if k != load.WebhooksField {
+			result[k] = v
+		}
+	}
+	return result
+}
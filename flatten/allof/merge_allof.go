@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/limits"
 )
 
 const (
@@ -64,18 +65,27 @@ type state struct {
 
 	// after mergeInternal is executed, circularAllOf contains all SchemaRefs which have circular allof.
 	circularAllOf openapi3.SchemaRefs
+
+	// enforces the limits on the schemas that are merged, if there are any.
+	walk *limits.Walk
 }
 
-func newState() *state {
+func newState(walk *limits.Walk) *state {
 	return &state{
 		mergedSchemas: map[*openapi3.Schema]*openapi3.Schema{},
 		refs:          map[string]bool{},
 		circularAllOf: openapi3.SchemaRefs{},
+		walk:          walk,
 	}
 }
 
 func Merge(schema openapi3.SchemaRef) (*openapi3.Schema, error) {
-	state := newState()
+	return merge(nil, schema)
+}
+
+// merge is like Merge, the walk enforces limits on the schemas that are merged, if there are any
+func merge(walk *limits.Walk, schema openapi3.SchemaRef) (*openapi3.Schema, error) {
+	state := newState(walk)
 	result, err := mergeInternal(state, &schema)
 	if err != nil {
 		return nil, err
@@ -186,6 +196,11 @@ func mergeInternal(state *state, base *openapi3.SchemaRef) (*openapi3.SchemaRef,
 		return openapi3.NewSchemaRef(base.Ref, cached), nil
 	}
 
+	if err := state.walk.Enter(base); err != nil {
+		return nil, err
+	}
+	defer state.walk.Leave()
+
 	result := openapi3.NewSchemaRef(base.Ref, openapi3.NewSchema())
 
 	// map original schema to result
@@ -354,6 +369,11 @@ func mergeSchemaRefs(state *state, srefs openapi3.SchemaRefs) (openapi3.SchemaRe
 // the function produces a single equivalent schema in the resultRef parameter.
 func flattenSchemas(state *state, result *openapi3.SchemaRef, schemas []*openapi3.SchemaRef) error {
 
+	if err := state.walk.Enter(result); err != nil {
+		return err
+	}
+	defer state.walk.Leave()
+
 	collection := collect(schemas)
 	var err error
 
@@ -974,14 +994,18 @@ func collect(schemas []*openapi3.SchemaRef) SchemaCollection {
 }
 
 // getCombinations calculates the cartesian product of groups of SchemaRefs.
-func getCombinations(groups []openapi3.SchemaRefs) []openapi3.SchemaRefs {
+func getCombinations(state *state, groups []openapi3.SchemaRefs) ([]openapi3.SchemaRefs, error) {
 	if len(groups) == 0 {
-		return []openapi3.SchemaRefs{}
+		return []openapi3.SchemaRefs{}, nil
 	}
 	result := []openapi3.SchemaRefs{{}}
 	for _, group := range groups {
 		var newResult []openapi3.SchemaRefs
 		for _, resultItem := range result {
+			// the product may take long to calculate, so the timeout is checked along the way
+			if err := state.walk.Check(0); err != nil {
+				return nil, err
+			}
 			for _, ref := range group {
 				combination := append(openapi3.SchemaRefs{}, resultItem...)
 				combination = append(combination, ref)
@@ -990,6 +1014,18 @@ func getCombinations(groups []openapi3.SchemaRefs) []openapi3.SchemaRefs {
 		}
 		result = newResult
 	}
+	return result, nil
+}
+
+// countCombinations returns the size of the cartesian product of groups of SchemaRefs, or math.MaxInt if it overflows.
+func countCombinations(groups []openapi3.SchemaRefs) int {
+	result := 1
+	for _, group := range groups {
+		if result > math.MaxInt/len(group) {
+			return math.MaxInt
+		}
+		result *= len(group)
+	}
 	return result
 }
 
@@ -998,6 +1034,9 @@ func flattenCombinations(state *state, combinations []openapi3.SchemaRefs) ([]*o
 	for _, combination := range combinations {
 		result := openapi3.NewSchemaRef("", openapi3.NewSchema())
 		err := flattenSchemas(state, result, combination)
+		if errors.Is(err, limits.ErrLimitExceeded) {
+			return nil, err
+		}
 		if err != nil {
 			continue
 		}
@@ -1067,7 +1106,15 @@ func resolveCombinations(state *state, collection []openapi3.SchemaRefs) (openap
 		return groups[0], nil
 	}
 
-	combinations := getCombinations(groups)
+	// the number of combinations grows exponentially with the number of groups
+	if err := state.walk.Check(countCombinations(groups)); err != nil {
+		return nil, err
+	}
+
+	combinations, err := getCombinations(state, groups)
+	if err != nil {
+		return nil, err
+	}
 	flattenedCombinations, err := flattenCombinations(state, combinations)
 	if err != nil {
 		return nil, err
@@ -2,12 +2,20 @@ package allof
 
 import (
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/limits"
 )
 
 // MergeSpec merges all instances in allOf in place
 func MergeSpec(spec *openapi3.T) (*openapi3.T, error) {
+	return MergeSpecWithLimits(spec, nil)
+}
+
+// MergeSpecWithLimits is like MergeSpec, it fails with limits.ErrLimitExceeded if merging exceeds the limits, a nil Limits is unlimited
+func MergeSpecWithLimits(spec *openapi3.T, l *limits.Limits) (*openapi3.T, error) {
+	walk := l.NewWalk()
+	walk.AddComponents(spec.Components)
 
-	if err := mergeComponents(spec.Components); err != nil {
+	if err := mergeComponents(walk, spec.Components); err != nil {
 		return spec, err
 	}
 
@@ -15,66 +23,66 @@ func MergeSpec(spec *openapi3.T) (*openapi3.T, error) {
 		if v == nil {
 			continue
 		}
-		if _, err := mergePathItem(v); err != nil {
+		if _, err := mergePathItem(walk, v); err != nil {
 			return spec, err
 		}
 	}
 	return spec, nil
 }
 
-func mergeComponents(components *openapi3.Components) error {
+func mergeComponents(walk *limits.Walk, components *openapi3.Components) error {
 	if components == nil {
 		return nil
 	}
 
 	var err error
 
-	if components.Schemas, err = mergeSchemas(components.Schemas); err != nil {
+	if components.Schemas, err = mergeSchemas(walk, components.Schemas); err != nil {
 		return err
 	}
 
-	if components.Parameters, err = mergeParametersMap(components.Parameters); err != nil {
+	if components.Parameters, err = mergeParametersMap(walk, components.Parameters); err != nil {
 		return err
 	}
 
-	if components.Headers, err = mergeHeaders(components.Headers); err != nil {
+	if components.Headers, err = mergeHeaders(walk, components.Headers); err != nil {
 		return err
 	}
 
-	if components.RequestBodies, err = mergeRequestBodies(components.RequestBodies); err != nil {
+	if components.RequestBodies, err = mergeRequestBodies(walk, components.RequestBodies); err != nil {
 		return err
 	}
 
-	if components.Responses, err = mergeResponseBodies(components.Responses); err != nil {
+	if components.Responses, err = mergeResponseBodies(walk, components.Responses); err != nil {
 		return err
 	}
 
-	if components.Callbacks, err = mergeCallbacks(components.Callbacks); err != nil {
+	if components.Callbacks, err = mergeCallbacks(walk, components.Callbacks); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func mergeOperation(operation *openapi3.Operation) (*openapi3.Operation, error) {
-	parameteres, err := mergeParameters(operation.Parameters)
+func mergeOperation(walk *limits.Walk, operation *openapi3.Operation) (*openapi3.Operation, error) {
+	parameteres, err := mergeParameters(walk, operation.Parameters)
 	if err != nil {
 		return operation, err
 	}
 	operation.Parameters = parameteres
 	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
-		content, err := mergeContent(operation.RequestBody.Value.Content)
+		content, err := mergeContent(walk, operation.RequestBody.Value.Content)
 		if err != nil {
 			return operation, err
 		}
 		operation.RequestBody.Value.Content = content
 	}
-	responses, err := mergeResponses(operation.Responses)
+	responses, err := mergeResponses(walk, operation.Responses)
 	if err != nil {
 		return operation, err
 	}
 	operation.Responses = responses
-	callbacks, err := mergeCallbacks(operation.Callbacks)
+	callbacks, err := mergeCallbacks(walk, operation.Callbacks)
 	if err != nil {
 		return operation, err
 	}
@@ -82,7 +90,7 @@ func mergeOperation(operation *openapi3.Operation) (*openapi3.Operation, error)
 	return operation, nil
 }
 
-func mergePathItem(pathItem *openapi3.PathItem) (*openapi3.PathItem, error) {
+func mergePathItem(walk *limits.Walk, pathItem *openapi3.PathItem) (*openapi3.PathItem, error) {
 	operations := []*openapi3.Operation{
 		pathItem.Connect, pathItem.Delete, pathItem.Get, pathItem.Head,
 		pathItem.Options, pathItem.Patch, pathItem.Post, pathItem.Put, pathItem.Trace,
@@ -90,13 +98,13 @@ func mergePathItem(pathItem *openapi3.PathItem) (*openapi3.PathItem, error) {
 
 	for _, op := range operations {
 		if op != nil {
-			if _, err := mergeOperation(op); err != nil {
+			if _, err := mergeOperation(walk, op); err != nil {
 				return pathItem, err
 			}
 		}
 	}
 
-	parameters, err := mergeParameters(pathItem.Parameters)
+	parameters, err := mergeParameters(walk, pathItem.Parameters)
 	if err != nil {
 		return pathItem, err
 	}
@@ -104,10 +112,10 @@ func mergePathItem(pathItem *openapi3.PathItem) (*openapi3.PathItem, error) {
 	return pathItem, nil
 }
 
-func mergeCallbacks(callbacks openapi3.Callbacks) (openapi3.Callbacks, error) {
+func mergeCallbacks(walk *limits.Walk, callbacks openapi3.Callbacks) (openapi3.Callbacks, error) {
 	for _, v := range callbacks {
 		for _, pathItem := range v.Value.Map() {
-			if _, err := mergePathItem(pathItem); err != nil {
+			if _, err := mergePathItem(walk, pathItem); err != nil {
 				return callbacks, err
 			}
 		}
@@ -115,12 +123,12 @@ func mergeCallbacks(callbacks openapi3.Callbacks) (openapi3.Callbacks, error) {
 	return callbacks, nil
 }
 
-func mergeSchemas(schemas openapi3.Schemas) (openapi3.Schemas, error) {
+func mergeSchemas(walk *limits.Walk, schemas openapi3.Schemas) (openapi3.Schemas, error) {
 	for _, s := range schemas {
 		if s == nil || s.Value == nil {
 			continue
 		}
-		m, err := Merge(*s)
+		m, err := merge(walk, *s)
 		if err != nil {
 			return schemas, err
 		}
@@ -129,46 +137,46 @@ func mergeSchemas(schemas openapi3.Schemas) (openapi3.Schemas, error) {
 	return schemas, nil
 }
 
-func mergeResponseBodies(responseBodies openapi3.ResponseBodies) (openapi3.ResponseBodies, error) {
+func mergeResponseBodies(walk *limits.Walk, responseBodies openapi3.ResponseBodies) (openapi3.ResponseBodies, error) {
 	for _, v := range responseBodies {
 		if v == nil || v.Value == nil {
 			continue
 		}
-		content, err := mergeContent(v.Value.Content)
+		content, err := mergeContent(walk, v.Value.Content)
 		if err != nil {
 			return responseBodies, err
 		}
 		v.Value.Content = content
-		if _, err := mergeHeaders(v.Value.Headers); err != nil {
+		if _, err := mergeHeaders(walk, v.Value.Headers); err != nil {
 			return responseBodies, err
 		}
 	}
 	return responseBodies, nil
 }
 
-func mergeResponses(responses *openapi3.Responses) (*openapi3.Responses, error) {
+func mergeResponses(walk *limits.Walk, responses *openapi3.Responses) (*openapi3.Responses, error) {
 	for _, v := range responses.Map() {
 		if v == nil || v.Value == nil {
 			continue
 		}
-		content, err := mergeContent(v.Value.Content)
+		content, err := mergeContent(walk, v.Value.Content)
 		if err != nil {
 			return responses, err
 		}
 		v.Value.Content = content
-		if _, err := mergeHeaders(v.Value.Headers); err != nil {
+		if _, err := mergeHeaders(walk, v.Value.Headers); err != nil {
 			return responses, err
 		}
 	}
 	return responses, nil
 }
 
-func mergeRequestBodies(rb openapi3.RequestBodies) (openapi3.RequestBodies, error) {
+func mergeRequestBodies(walk *limits.Walk, rb openapi3.RequestBodies) (openapi3.RequestBodies, error) {
 	for _, v := range rb {
 		if v == nil || v.Value == nil {
 			continue
 		}
-		content, err := mergeContent(v.Value.Content)
+		content, err := mergeContent(walk, v.Value.Content)
 		if err != nil {
 			return rb, err
 		}
@@ -177,40 +185,40 @@ func mergeRequestBodies(rb openapi3.RequestBodies) (openapi3.RequestBodies, erro
 	return rb, nil
 }
 
-func mergeParameters(parameters openapi3.Parameters) (openapi3.Parameters, error) {
+func mergeParameters(walk *limits.Walk, parameters openapi3.Parameters) (openapi3.Parameters, error) {
 	for _, v := range parameters {
 		if v == nil || v.Value == nil {
 			continue
 		}
-		if _, err := mergeParameter(v.Value); err != nil {
+		if _, err := mergeParameter(walk, v.Value); err != nil {
 			return parameters, err
 		}
 	}
 	return parameters, nil
 }
 
-func mergeParametersMap(parameters openapi3.ParametersMap) (openapi3.ParametersMap, error) {
+func mergeParametersMap(walk *limits.Walk, parameters openapi3.ParametersMap) (openapi3.ParametersMap, error) {
 	for _, v := range parameters {
 		if v == nil || v.Value == nil {
 			continue
 		}
-		if _, err := mergeParameter(v.Value); err != nil {
+		if _, err := mergeParameter(walk, v.Value); err != nil {
 			return parameters, err
 		}
 	}
 	return parameters, nil
 }
 
-func mergeParameter(p *openapi3.Parameter) (*openapi3.Parameter, error) {
+func mergeParameter(walk *limits.Walk, p *openapi3.Parameter) (*openapi3.Parameter, error) {
 	if p.Schema == nil || p.Schema.Value == nil {
 		return p, nil
 	}
-	m, err := Merge(*p.Schema)
+	m, err := merge(walk, *p.Schema)
 	if err != nil {
 		return p, err
 	}
 	p.Schema.Value = m
-	content, err := mergeContent(p.Content)
+	content, err := mergeContent(walk, p.Content)
 	if err != nil {
 		return p, err
 	}
@@ -218,12 +226,12 @@ func mergeParameter(p *openapi3.Parameter) (*openapi3.Parameter, error) {
 	return p, nil
 }
 
-func mergeContent(content openapi3.Content) (openapi3.Content, error) {
+func mergeContent(walk *limits.Walk, content openapi3.Content) (openapi3.Content, error) {
 	for _, mediaType := range content {
 		if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
 			continue
 		}
-		m, err := Merge(*mediaType.Schema)
+		m, err := merge(walk, *mediaType.Schema)
 		if err != nil {
 			return content, err
 		}
@@ -232,7 +240,7 @@ func mergeContent(content openapi3.Content) (openapi3.Content, error) {
 			if encoding == nil {
 				continue
 			}
-			headers, err := mergeHeaders(encoding.Headers)
+			headers, err := mergeHeaders(walk, encoding.Headers)
 			if err != nil {
 				return content, err
 			}
@@ -242,12 +250,12 @@ func mergeContent(content openapi3.Content) (openapi3.Content, error) {
 	return content, nil
 }
 
-func mergeHeaders(headers openapi3.Headers) (openapi3.Headers, error) {
+func mergeHeaders(walk *limits.Walk, headers openapi3.Headers) (openapi3.Headers, error) {
 	for _, v := range headers {
 		if v == nil || v.Value == nil {
 			continue
 		}
-		if _, err := mergeParameter(&v.Value.Parameter); err != nil {
+		if _, err := mergeParameter(walk, &v.Value.Parameter); err != nil {
 			return headers, err
 		}
 	}
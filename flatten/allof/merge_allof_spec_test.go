@@ -5,6 +5,8 @@ import (
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/flatten/allof"
+	"github.com/tufin/oasdiff/limits"
 	"github.com/tufin/oasdiff/load"
 )
 
@@ -71,3 +73,22 @@ func TestMergeSpec_MergeCircularAdditionalPropsNestedWithinAllOf(t *testing.T) {
 	NestedSelfReferentialSchema := merged.Components.Schemas["NestedSelfReferentialSchema"].Value
 	require.Equal(t, baseSchemaReferencedAdditionalPropSchema, NestedSelfReferentialSchema)
 }
+
+func TestMergeSpec_Limits(t *testing.T) {
+	loader := openapi3.NewLoader()
+	load.WithLimits(loader, &limits.Limits{MaxSchemas: 1000})
+	_, err := load.NewSpecInfo(loader, load.NewSource("../../data/limits/combinations.yaml"), load.WithFlattenAllOf())
+	require.ErrorIs(t, err, limits.ErrLimitExceeded)
+	require.EqualError(t, err, `failed to flatten allOf in "../../data/limits/combinations.yaml": resource limit exceeded: visiting an inline schema under "#/components/schemas/Combined" exceeds max-schemas=1000`)
+}
+
+func TestMergeSpec_LimitsDepth(t *testing.T) {
+	spec, err := load.NewSpecInfo(openapi3.NewLoader(), load.NewSource("../../data/limits/deep.yaml"))
+	require.NoError(t, err)
+
+	_, err = allof.MergeSpecWithLimits(spec.Spec, &limits.Limits{MaxSchemaDepth: 10})
+	require.EqualError(t, err, `resource limit exceeded: an inline schema under "#/components/schemas/Deep" is nested deeper than max-schema-depth=10`)
+
+	_, err = allof.MergeSpecWithLimits(spec.Spec, &limits.Limits{MaxSchemaDepth: 100})
+	require.NoError(t, err)
+}
@@ -5,17 +5,19 @@ import (
 )
 
 type Change struct {
-	Id          string         `json:"id,omitempty" yaml:"id,omitempty"`
-	Text        string         `json:"text,omitempty" yaml:"text,omitempty"`
-	Comment     string         `json:"comment,omitempty" yaml:"comment,omitempty"`
-	Level       checker.Level  `json:"level" yaml:"level"`
-	Operation   string         `json:"operation,omitempty" yaml:"operation,omitempty"`
-	OperationId string         `json:"operationId,omitempty" yaml:"operationId,omitempty"`
-	Path        string         `json:"path,omitempty" yaml:"path,omitempty"`
-	Source      string         `json:"source,omitempty" yaml:"source,omitempty"`
-	Section     string         `json:"section,omitempty" yaml:"section,omitempty"`
-	IsBreaking  bool           `json:"-" yaml:"-"`
-	Attributes  map[string]any `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	Id             string                  `json:"id,omitempty" yaml:"id,omitempty"`
+	Text           string                  `json:"text,omitempty" yaml:"text,omitempty"`
+	Comment        string                  `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Level          checker.Level           `json:"level" yaml:"level"`
+	Operation      string                  `json:"operation,omitempty" yaml:"operation,omitempty"`
+	OperationId    string                  `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Path           string                  `json:"path,omitempty" yaml:"path,omitempty"`
+	Source         string                  `json:"source,omitempty" yaml:"source,omitempty"`
+	Section        string                  `json:"section,omitempty" yaml:"section,omitempty"`
+	IsBreaking     bool                    `json:"-" yaml:"-"`
+	Attributes     map[string]any          `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	ObservedCalls  *int                    `json:"observedCalls,omitempty" yaml:"observedCalls,omitempty"`   // the number of recorded calls affected by the change, if traffic was analyzed
+	Counterexample *checker.Counterexample `json:"counterexample,omitempty" yaml:"counterexample,omitempty"` // a request which is accepted by the base and rejected by the revision, if requested
 }
 
 type Changes []Change
@@ -33,8 +35,31 @@ func NewChanges(originalChanges checker.Changes, l checker.Localizer) Changes {
 			OperationId: change.GetOperationId(),
 			Path:        change.GetPath(),
 			Source:      change.GetSource(),
+			IsBreaking:  change.IsBreaking(),
 			Attributes:  change.GetAttributes(),
 		}
+		if calls, ok := change.GetObservedCalls(); ok {
+			changes[i].ObservedCalls = &calls
+		}
+		changes[i].Counterexample = change.GetCounterexample()
 	}
 	return changes
 }
+
+// ChangelogWithSemver is the changelog output when a semantic versioning check is requested
+type ChangelogWithSemver struct {
+	Changes Changes              `json:"changes" yaml:"changes"`
+	Semver  *checker.SemverCheck `json:"semver" yaml:"semver"`
+}
+
+// newChangelog returns the changes, along with the semantic versioning check when it was requested
+func newChangelog(originalChanges checker.Changes, l checker.Localizer, opts RenderOpts) any {
+	changes := NewChanges(originalChanges, l)
+	if opts.Semver == nil {
+		return changes
+	}
+	return &ChangelogWithSemver{
+		Changes: changes,
+		Semver:  opts.Semver,
+	}
+}
@@ -2,9 +2,11 @@ package formatters
 
 import "github.com/tufin/oasdiff/checker"
 
+// Endpoint identifies an operation of a path, or of a webhook when Webhook is set
 type Endpoint struct {
 	Path      string
 	Operation string
+	Webhook   bool
 }
 
 type ChangesByEndpoint map[Endpoint]*Changes
@@ -14,20 +16,26 @@ func GroupChanges(changes checker.Changes, l checker.Localizer) ChangesByEndpoin
 	apiChanges := ChangesByEndpoint{}
 
 	for _, change := range changes {
+		var ep Endpoint
 		switch change.(type) {
 		case checker.ApiChange:
-			ep := Endpoint{Path: change.GetPath(), Operation: change.GetOperation()}
-			if c, ok := apiChanges[ep]; ok {
-				*c = append(*c, Change{
-					IsBreaking: change.IsBreaking(),
-					Text:       change.GetUncolorizedText(l),
-				})
-			} else {
-				apiChanges[ep] = &Changes{Change{
-					IsBreaking: change.IsBreaking(),
-					Text:       change.GetUncolorizedText(l),
-				}}
-			}
+			ep = Endpoint{Path: change.GetPath(), Operation: change.GetOperation()}
+		case checker.WebhookChange:
+			ep = Endpoint{Path: change.GetPath(), Operation: change.GetOperation(), Webhook: true}
+		default:
+			continue
+		}
+
+		if c, ok := apiChanges[ep]; ok {
+			*c = append(*c, Change{
+				IsBreaking: change.IsBreaking(),
+				Text:       change.GetUncolorizedText(l),
+			})
+		} else {
+			apiChanges[ep] = &Changes{Change{
+				IsBreaking: change.IsBreaking(),
+				Text:       change.GetUncolorizedText(l),
+			}}
 		}
 	}
 
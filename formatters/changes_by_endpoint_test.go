@@ -34,3 +34,15 @@ var changes = checker.Changes{
 func TestChanges_Group(t *testing.T) {
 	require.Contains(t, formatters.GroupChanges(changes, checker.NewDefaultLocalizer()), formatters.Endpoint{Path: "/test", Operation: "GET"})
 }
+
+func TestChanges_GroupWebhooks(t *testing.T) {
+	changes := checker.Changes{
+		checker.WebhookChange{
+			Id:        "webhook-removed",
+			Level:     checker.ERR,
+			Operation: "POST",
+			Webhook:   "newPet",
+		},
+	}
+	require.Contains(t, formatters.GroupChanges(changes, checker.NewDefaultLocalizer()), formatters.Endpoint{Path: "newPet", Operation: "POST", Webhook: true})
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/lint"
 	"github.com/tufin/oasdiff/load"
 )
 
@@ -70,11 +71,49 @@ func (f GitHubActionsFormatter) RenderChangelog(changes checker.Changes, opts Re
 
 func getMessage(change checker.Change, l checker.Localizer) string {
 	message := strings.ReplaceAll(change.GetUncolorizedText(l), "\n", "%0A")
+	if _, ok := change.(checker.WebhookChange); ok {
+		return fmt.Sprintf("in webhook %s %s %s", change.GetOperation(), change.GetPath(), message)
+	}
 	return fmt.Sprintf("in API %s %s %s", change.GetOperation(), change.GetPath(), message)
 }
 
+func (f GitHubActionsFormatter) RenderLint(errs lint.Errors, opts RenderOpts) ([]byte, error) {
+	var buf bytes.Buffer
+
+	// add error and warning count to job output parameters
+	counts := errs.GetLevelCount()
+	err := writeGitHubActionsJobOutputParameters(map[string]string{
+		"error_count":   fmt.Sprint(counts[lint.LEVEL_ERROR]),
+		"warning_count": fmt.Sprint(counts[lint.LEVEL_WARN]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// generate messages for each error (source file, line and column are optional)
+	for _, lintErr := range errs {
+		var params = []string{
+			"title=" + lintErr.Id,
+		}
+		if lintErr.SourceLine != 0 {
+			params = append(params, "file="+lintErr.Source)
+			params = append(params, "col="+strconv.Itoa(lintErr.SourceColumn+1))
+			params = append(params, "line="+strconv.Itoa(lintErr.SourceLine+1))
+		}
+
+		message := strings.ReplaceAll(lintErr.Text, "\n", "%0A")
+		if lintErr.Path != "" {
+			message = fmt.Sprintf("in API %s %s", strings.TrimSpace(lintErr.Operation+" "+lintErr.Path), message)
+		}
+
+		buf.WriteString(fmt.Sprintf("::%s %s::%s\n", lintErr.LevelString(), strings.Join(params, ","), message))
+	}
+
+	return buf.Bytes(), nil
+}
+
 func (f GitHubActionsFormatter) SupportedOutputs() []Output {
-	return []Output{OutputChangelog}
+	return []Output{OutputChangelog, OutputLint}
 }
 
 func writeGitHubActionsJobOutputParameters(params map[string]string) error {
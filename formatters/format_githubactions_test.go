@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/lint"
 	"github.com/tufin/oasdiff/load"
 )
 
@@ -184,6 +185,32 @@ func TestGitHubActionsFormatter_RenderChangelog_JobOutputParameters(t *testing.T
 	assert.Contains(t, string(outputFile), "info_count=1\n")
 }
 
+func TestGitHubActionsFormatter_RenderLint(t *testing.T) {
+	errs := lint.Errors{
+		{
+			Id:           "path-param-extra",
+			Level:        lint.LEVEL_ERROR,
+			Text:         "path parameter is defined but doesn't appear in the path",
+			Source:       "openapi.yaml",
+			Operation:    http.MethodGet,
+			Path:         "/api/test",
+			SourceLine:   9,
+			SourceColumn: 4,
+		},
+		{
+			Id:     "info-title-missing",
+			Level:  lint.LEVEL_WARN,
+			Text:   "the title of the API is missing",
+			Source: "openapi.yaml",
+		},
+	}
+
+	output, err := gitHubFormatter.RenderLint(errs, formatters.NewRenderOpts())
+	assert.NoError(t, err)
+	expectedOutput := "::error title=path-param-extra,file=openapi.yaml,col=5,line=10::in API GET /api/test path parameter is defined but doesn't appear in the path\n::warning title=info-title-missing::the title of the API is missing\n"
+	assert.Equal(t, expectedOutput, string(output))
+}
+
 func TestGitHubActionsFormatter_NotImplemented(t *testing.T) {
 	var err error
 	_, err = gitHubFormatter.RenderDiff(nil, formatters.NewRenderOpts())
@@ -197,4 +224,6 @@ func TestGitHubActionsFormatter_NotImplemented(t *testing.T) {
 
 	_, err = gitHubFormatter.RenderFlatten(nil, formatters.NewRenderOpts())
 	assert.Error(t, err)
+	_, err = gitHubFormatter.RenderHistory(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/history"
 	"github.com/tufin/oasdiff/load"
 	"github.com/tufin/oasdiff/report"
 )
@@ -55,6 +56,18 @@ func ExecuteHtmlTemplate(tmpl *template.Template, changes ChangesByEndpoint, spe
 	return out.Bytes(), nil
 }
 
+//go:embed templates/history.html
+var historyHtml string
+
+func (f HTMLFormatter) RenderHistory(h history.History, opts RenderOpts) ([]byte, error) {
+	tmpl := template.Must(template.New("history").Parse(historyHtml))
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, HistoryTemplateData{NewHistory(h, f.Localizer)}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
 func (f HTMLFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputChangelog}
+	return []Output{OutputDiff, OutputChangelog, OutputHistory}
 }
@@ -63,6 +63,14 @@ func TestHtmlFormatter_RenderChangelog(t *testing.T) {
 	require.NotEmpty(t, string(out))
 }
 
+func TestHtmlFormatter_RenderHistory(t *testing.T) {
+	out, err := htmlFormatter.RenderHistory(testHistory, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Contains(t, string(out), `<span class="path hash">0123456</span>`)
+	require.Contains(t, string(out), "Jane Doe, 2026-03-01")
+	require.Contains(t, string(out), "<b>GET /api/test</b> This is a breaking change.")
+}
+
 func TestHtmlFormatter_NotImplemented(t *testing.T) {
 	var err error
 
@@ -72,6 +80,9 @@ func TestHtmlFormatter_NotImplemented(t *testing.T) {
 	_, err = htmlFormatter.RenderFlatten(nil, formatters.NewRenderOpts())
 	assert.Error(t, err)
 
+	_, err = htmlFormatter.RenderLint(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
+
 	_, err = htmlFormatter.RenderSummary(nil, formatters.NewRenderOpts())
 	assert.Error(t, err)
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/lint"
 	"github.com/tufin/oasdiff/load"
 )
 
@@ -30,7 +32,7 @@ func (f JSONFormatter) RenderSummary(diff *diff.Diff, opts RenderOpts) ([]byte,
 }
 
 func (f JSONFormatter) RenderChangelog(changes checker.Changes, opts RenderOpts, specInfoPair *load.SpecInfoPair) ([]byte, error) {
-	return printJSON(NewChanges(changes, f.Localizer))
+	return printJSON(newChangelog(changes, f.Localizer, opts))
 }
 
 func (f JSONFormatter) RenderChecks(checks Checks, opts RenderOpts) ([]byte, error) {
@@ -41,8 +43,16 @@ func (f JSONFormatter) RenderFlatten(spec *openapi3.T, opts RenderOpts) ([]byte,
 	return printJSON(spec)
 }
 
+func (f JSONFormatter) RenderLint(errs lint.Errors, opts RenderOpts) ([]byte, error) {
+	return printJSON(errs)
+}
+
+func (f JSONFormatter) RenderHistory(history history.History, opts RenderOpts) ([]byte, error) {
+	return printJSON(NewHistory(history, f.Localizer))
+}
+
 func (f JSONFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten}
+	return []Output{OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten, OutputLint, OutputHistory}
 }
 
 func printJSON(output interface{}) ([]byte, error) {
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/lint"
 )
 
 var jsonFormatter = formatters.JSONFormatter{
@@ -31,6 +32,28 @@ func TestJsonFormatter_RenderChangelog(t *testing.T) {
 	require.Equal(t, "[{\"id\":\"change_id\",\"text\":\"This is a breaking change.\",\"level\":3,\"section\":\"components\"}]", string(out))
 }
 
+func TestJsonFormatter_RenderChangelogWithSemver(t *testing.T) {
+	testChanges := checker.Changes{
+		checker.ComponentChange{
+			Id:    "change_id",
+			Level: checker.ERR,
+		},
+	}
+
+	opts := formatters.NewRenderOpts()
+	opts.Semver = &checker.SemverCheck{
+		BaseVersion:        "1.0.0",
+		RevisionVersion:    "1.1.0",
+		RequiredBump:       checker.SemverMajor,
+		ActualBump:         checker.SemverMinor,
+		RecommendedVersion: "2.0.0",
+	}
+
+	out, err := jsonFormatter.RenderChangelog(testChanges, opts, nil)
+	require.NoError(t, err)
+	require.Equal(t, "{\"changes\":[{\"id\":\"change_id\",\"text\":\"This is a breaking change.\",\"level\":3,\"section\":\"components\"}],\"semver\":{\"baseVersion\":\"1.0.0\",\"revisionVersion\":\"1.1.0\",\"requiredBump\":\"major\",\"actualBump\":\"minor\",\"recommendedVersion\":\"2.0.0\"}}", string(out))
+}
+
 func TestJsonFormatter_RenderChecks(t *testing.T) {
 	checks := formatters.Checks{
 		{
@@ -62,3 +85,26 @@ func TestJsonFormatter_RenderSummary(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, `{"diff":false}`, string(out))
 }
+
+func TestJsonFormatter_RenderHistory(t *testing.T) {
+	out, err := jsonFormatter.RenderHistory(testHistory, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, `[{"commit":{"hash":"0123456789abcdef0123456789abcdef01234567","author":"Jane Doe","date":"2026-03-01T10:00:00Z","subject":"Require the id parameter"},"previous":"fedcba9876543210fedcba9876543210fedcba98","changes":[{"id":"change_id","text":"This is a breaking change.","level":3,"operation":"GET","path":"/api/test","source":"git:0123456789abcdef0123456789abcdef01234567:openapi.yaml","section":"paths"}]}]`, string(out))
+}
+
+func TestJsonFormatter_RenderLint(t *testing.T) {
+	errs := lint.Errors{
+		{
+			Id:        "path-param-missing",
+			Level:     lint.LEVEL_WARN,
+			Text:      "path parameter missing",
+			Source:    "openapi.yaml",
+			Operation: "GET",
+			Path:      "/api/{id}",
+		},
+	}
+
+	out, err := jsonFormatter.RenderLint(errs, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, `[{"id":"path-param-missing","text":"path parameter missing","level":1,"source":"openapi.yaml","operation":"GET","path":"/api/{id}"}]`, string(out))
+}
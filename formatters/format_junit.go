@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/lint"
 	"github.com/tufin/oasdiff/load"
 )
 
@@ -91,6 +92,49 @@ func (f JUnitFormatter) RenderChangelog(changes checker.Changes, opts RenderOpts
 	return []byte(xml.Header + string(output)), nil
 }
 
+func (f JUnitFormatter) RenderLint(errs lint.Errors, opts RenderOpts) ([]byte, error) {
+	var testSuite = JUnitTestSuite{
+		Package:   "com.oasdiff",
+		Time:      "0",
+		Tests:     len(errs),
+		Errors:    0,
+		Failures:  len(errs),
+		Name:      "OASDiff Lint",
+		TestCases: []JUnitTestCase{},
+	}
+
+	for _, err := range errs {
+		testCase := JUnitTestCase{
+			Name:      err.Id,
+			Classname: "OASDiff",
+			Time:      "0",
+			Failure: &JUnitFailure{
+				Message: "Lint " + err.LevelString() + " detected",
+				CDATA:   err.Text,
+			},
+		}
+		testSuite.TestCases = append(testSuite.TestCases, testCase)
+	}
+
+	// if there are no errors, add a dummy test case to the test suite as we need at least one test case
+	if len(errs) == 0 {
+		testCase := JUnitTestCase{
+			Name:      "no lint errors detected",
+			Classname: "OASDiff",
+			Time:      "0",
+		}
+		testSuite.TestCases = append(testSuite.TestCases, testCase)
+	}
+
+	testSuites := JUnitTestSuites{TestSuites: []JUnitTestSuite{testSuite}}
+	output, err := xml.MarshalIndent(testSuites, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal junit XML: %w", err)
+	}
+
+	return []byte(xml.Header + string(output)), nil
+}
+
 func (f JUnitFormatter) SupportedOutputs() []Output {
-	return []Output{OutputChangelog}
+	return []Output{OutputChangelog, OutputLint}
 }
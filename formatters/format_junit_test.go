@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/lint"
 )
 
 var jUnitFormatter = formatters.JUnitFormatter{
@@ -34,6 +35,28 @@ func TestJUnitFormatter_RenderChangelog_Success(t *testing.T) {
 	assert.Equal(t, expectedOutput, string(output))
 }
 
+func TestJUnitFormatter_RenderLint(t *testing.T) {
+	errs := lint.Errors{
+		{
+			Id:    "info-missing",
+			Level: lint.LEVEL_ERROR,
+			Text:  "info is missing",
+		},
+	}
+
+	output, err := jUnitFormatter.RenderLint(errs, formatters.NewRenderOpts())
+	assert.NoError(t, err)
+	expectedOutput := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite package="com.oasdiff" time="0" tests="1" errors="0" failures="1" name="OASDiff Lint">
+    <testcase name="info-missing" classname="OASDiff" time="0">
+      <failure message="Lint error detected">info is missing</failure>
+    </testcase>
+  </testsuite>
+</testsuites>`
+	assert.Equal(t, expectedOutput, string(output))
+}
+
 func TestJUnitFormatter_NotImplemented(t *testing.T) {
 
 	var err error
@@ -48,4 +71,6 @@ func TestJUnitFormatter_NotImplemented(t *testing.T) {
 
 	_, err = jUnitFormatter.RenderFlatten(nil, formatters.NewRenderOpts())
 	assert.Error(t, err)
+	_, err = jUnitFormatter.RenderHistory(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/history"
 	"github.com/tufin/oasdiff/load"
 	"github.com/tufin/oasdiff/report"
 )
@@ -43,6 +44,23 @@ func ExecuteTextTemplate(tmpl *template.Template, changes ChangesByEndpoint, spe
 	return out.Bytes(), nil
 }
 
+//go:embed templates/history.md
+var historyMarkdown string
+
+// HistoryTemplateData is the data of the history templates
+type HistoryTemplateData struct {
+	Entries []HistoryEntry
+}
+
+func (f MarkupFormatter) RenderHistory(h history.History, opts RenderOpts) ([]byte, error) {
+	tmpl := template.Must(template.New("history").Parse(historyMarkdown))
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, HistoryTemplateData{NewHistory(h, f.Localizer)}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
 func (f MarkupFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputChangelog}
+	return []Output{OutputDiff, OutputChangelog, OutputHistory}
 }
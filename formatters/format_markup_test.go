@@ -41,6 +41,27 @@ func TestMarkupFormatter_RenderChangelog(t *testing.T) {
 	require.NotEmpty(t, string(out))
 }
 
+func TestMarkupFormatter_RenderChangelog_Webhook(t *testing.T) {
+	testChanges := checker.Changes{
+		checker.WebhookChange{
+			Webhook:   "newPet",
+			Operation: "POST",
+			Id:        "change_id",
+			Level:     checker.ERR,
+		},
+	}
+
+	out, err := markupFormatter.RenderChangelog(testChanges, formatters.NewRenderOpts(), nil)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "## Webhook POST newPet\n")
+}
+
+func TestMarkupFormatter_RenderHistory(t *testing.T) {
+	out, err := markupFormatter.RenderHistory(testHistory, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, "# API History\n\n## 0123456 Require the id parameter\nJane Doe, 2026-03-01\n- :warning: GET /api/test: This is a breaking change.\n\n", string(out))
+}
+
 func TestMarkupFormatter_NotImplemented(t *testing.T) {
 	var err error
 
@@ -50,6 +71,9 @@ func TestMarkupFormatter_NotImplemented(t *testing.T) {
 	_, err = markupFormatter.RenderFlatten(nil, formatters.NewRenderOpts())
 	assert.Error(t, err)
 
+	_, err = markupFormatter.RenderLint(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
+
 	_, err = markupFormatter.RenderSummary(nil, formatters.NewRenderOpts())
 	assert.Error(t, err)
 }
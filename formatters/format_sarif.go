@@ -0,0 +1,226 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tufin/oasdiff/build"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/load"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+var sarifLevel = map[checker.Level]string{
+	checker.ERR:  "error",
+	checker.WARN: "warning",
+	checker.INFO: "note",
+}
+
+// SarifLog is the top-level object of a SARIF 2.1.0 document
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string                     `json:"name"`
+	InformationUri string                     `json:"informationUri"`
+	Version        string                     `json:"version"`
+	Rules          []SarifReportingDescriptor `json:"rules"`
+}
+
+type SarifReportingDescriptor struct {
+	Id                   string             `json:"id"`
+	ShortDescription     SarifMessage       `json:"shortDescription"`
+	DefaultConfiguration SarifConfiguration `json:"defaultConfiguration"`
+}
+
+type SarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation *SarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type SarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+type SarifFormatter struct {
+	notImplementedFormatter
+	Localizer checker.Localizer
+}
+
+func newSarifFormatter(l checker.Localizer) SarifFormatter {
+	return SarifFormatter{
+		Localizer: l,
+	}
+}
+
+func (f SarifFormatter) RenderChangelog(changes checker.Changes, opts RenderOpts, specInfoPair *load.SpecInfoPair) ([]byte, error) {
+
+	rules, ruleIndex := f.getRules(checker.GetAllRules())
+
+	results := make([]SarifResult, 0, len(changes))
+	for _, change := range changes {
+		result := SarifResult{
+			RuleId:    change.GetId(),
+			Level:     sarifLevel[change.GetLevel()],
+			Message:   SarifMessage{Text: change.GetUncolorizedText(f.Localizer)},
+			Locations: getSarifLocations(change),
+		}
+		if index, ok := ruleIndex[change.GetId()]; ok {
+			result.RuleIndex = &index
+		}
+		if result.Level == "" {
+			result.Level = "none"
+		}
+		results = append(results, result)
+	}
+
+	sarifLog := SarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []SarifRun{{
+			Tool: SarifTool{
+				Driver: SarifDriver{
+					Name:           "oasdiff",
+					InformationUri: "https://github.com/Tufin/oasdiff",
+					Version:        build.Version,
+					Rules:          rules,
+				},
+			},
+			Results: results,
+		}},
+	}
+
+	bytes, err := json.MarshalIndent(sarifLog, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SARIF: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// getRules converts the checker rules into SARIF reporting descriptors and returns an index of rule positions by id
+func (f SarifFormatter) getRules(rules checker.BackwardCompatibilityRules) ([]SarifReportingDescriptor, map[string]int) {
+	descriptors := make([]SarifReportingDescriptor, 0, len(rules))
+	index := make(map[string]int, len(rules))
+
+	for _, rule := range rules {
+		if _, ok := index[rule.Id]; ok {
+			continue
+		}
+		index[rule.Id] = len(descriptors)
+		descriptors = append(descriptors, SarifReportingDescriptor{
+			Id:               rule.Id,
+			ShortDescription: SarifMessage{Text: f.Localizer(rule.Description)},
+			DefaultConfiguration: SarifConfiguration{
+				Level: sarifLevel[rule.Level],
+			},
+		})
+	}
+
+	return descriptors, index
+}
+
+func getSarifLocations(change checker.Change) []SarifLocation {
+	location := SarifLocation{}
+
+	if file := change.GetSourceFile(); file != "" {
+		location.PhysicalLocation = &SarifPhysicalLocation{
+			ArtifactLocation: SarifArtifactLocation{Uri: toSarifUri(file)},
+			Region:           getSarifRegion(change),
+		}
+	}
+
+	if change.GetPath() != "" {
+		location.LogicalLocations = []SarifLogicalLocation{{
+			FullyQualifiedName: strings.TrimSpace(change.GetOperation() + " " + change.GetPath()),
+			Kind:               "member",
+		}}
+	}
+
+	if location.PhysicalLocation == nil && location.LogicalLocations == nil {
+		return nil
+	}
+
+	return []SarifLocation{location}
+}
+
+// getSarifRegion converts the zero-based source position of a change into a one-based SARIF region
+func getSarifRegion(change checker.Change) *SarifRegion {
+	if change.GetSourceLine() == 0 {
+		return nil
+	}
+
+	region := SarifRegion{
+		StartLine: change.GetSourceLine() + 1,
+	}
+	if change.GetSourceColumn() != 0 {
+		region.StartColumn = change.GetSourceColumn() + 1
+	}
+	if change.GetSourceLineEnd() != 0 {
+		region.EndLine = change.GetSourceLineEnd() + 1
+	}
+	if change.GetSourceColumnEnd() != 0 {
+		region.EndColumn = change.GetSourceColumnEnd() + 1
+	}
+
+	return &region
+}
+
+// toSarifUri converts windows path separators since SARIF artifact locations are URI references
+func toSarifUri(file string) string {
+	return strings.ReplaceAll(file, "\\", "/")
+}
+
+func (f SarifFormatter) SupportedOutputs() []Output {
+	return []Output{OutputChangelog}
+}
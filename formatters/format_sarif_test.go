@@ -0,0 +1,99 @@
+package formatters_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/load"
+)
+
+var sarifFormatter = formatters.SarifFormatter{
+	Localizer: MockLocalizer,
+}
+
+func TestSarifLookup(t *testing.T) {
+	f, err := formatters.Lookup(string(formatters.FormatSarif), formatters.DefaultFormatterOpts())
+	require.NoError(t, err)
+	require.IsType(t, formatters.SarifFormatter{}, f)
+}
+
+func TestSarifFormatter_RenderChangelog(t *testing.T) {
+	testChanges := checker.Changes{
+		checker.ApiChange{
+			Id:           checker.APIRemovedWithoutDeprecationId,
+			Level:        checker.ERR,
+			Operation:    http.MethodGet,
+			Path:         "/api/test",
+			Source:       load.NewSource("openapi.yaml"),
+			SourceLine:   9,
+			SourceColumn: 4,
+		},
+		checker.ApiChange{
+			Id:        "notice_id",
+			Level:     checker.INFO,
+			Operation: http.MethodGet,
+			Path:      "/api/test",
+			Source:    load.NewSource("http://example.com/openapi.yaml"),
+		},
+	}
+
+	output, err := sarifFormatter.RenderChangelog(testChanges, formatters.NewRenderOpts(), nil)
+	require.NoError(t, err)
+
+	var log formatters.SarifLog
+	require.NoError(t, json.Unmarshal(output, &log))
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	require.Equal(t, "oasdiff", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, len(checker.GetAllRules()))
+	require.Len(t, run.Results, 2)
+
+	result := run.Results[0]
+	require.Equal(t, checker.APIRemovedWithoutDeprecationId, result.RuleId)
+	require.NotNil(t, result.RuleIndex)
+	require.Equal(t, checker.APIRemovedWithoutDeprecationId, run.Tool.Driver.Rules[*result.RuleIndex].Id)
+	require.Equal(t, "error", result.Level)
+	require.Equal(t, "openapi.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.Uri)
+	require.Equal(t, 10, result.Locations[0].PhysicalLocation.Region.StartLine)
+	require.Equal(t, 5, result.Locations[0].PhysicalLocation.Region.StartColumn)
+	require.Equal(t, "GET /api/test", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+
+	result = run.Results[1]
+	require.Equal(t, "note", result.Level)
+	require.Equal(t, "This is a notice.", result.Message.Text)
+	require.Nil(t, result.RuleIndex)
+	require.Nil(t, result.Locations[0].PhysicalLocation)
+}
+
+func TestSarifFormatter_RenderChangelog_Empty(t *testing.T) {
+	output, err := sarifFormatter.RenderChangelog(checker.Changes{}, formatters.NewRenderOpts(), nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `"results": []`)
+}
+
+func TestSarifFormatter_NotImplemented(t *testing.T) {
+	var err error
+	_, err = sarifFormatter.RenderDiff(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
+
+	_, err = sarifFormatter.RenderSummary(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
+
+	_, err = sarifFormatter.RenderChecks(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
+
+	_, err = sarifFormatter.RenderFlatten(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
+
+	_, err = sarifFormatter.RenderLint(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
+	_, err = sarifFormatter.RenderHistory(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
+}
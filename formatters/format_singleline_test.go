@@ -45,6 +45,11 @@ func TestSingleLineFormatter_NotImplemented(t *testing.T) {
 	_, err = singleLineFormatter.RenderFlatten(nil, formatters.NewRenderOpts())
 	assert.Error(t, err)
 
+	_, err = singleLineFormatter.RenderLint(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
+
 	_, err = singleLineFormatter.RenderSummary(nil, formatters.NewRenderOpts())
 	assert.Error(t, err)
+	_, err = singleLineFormatter.RenderHistory(nil, formatters.NewRenderOpts())
+	assert.Error(t, err)
 }
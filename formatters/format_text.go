@@ -3,10 +3,14 @@ package formatters
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/lint"
 	"github.com/tufin/oasdiff/load"
 	"github.com/tufin/oasdiff/report"
 )
@@ -34,7 +38,19 @@ func (f TEXTFormatter) RenderChangelog(changes checker.Changes, opts RenderOpts,
 	}
 
 	for _, c := range changes {
-		_, _ = fmt.Fprintf(result, "%s\n\n", c.MultiLineError(f.Localizer, opts.ColorMode))
+		_, _ = fmt.Fprintf(result, "%s\n", c.MultiLineError(f.Localizer, opts.ColorMode))
+		if calls, ok := c.GetObservedCalls(); ok {
+			_, _ = fmt.Fprintf(result, "\t\tobserved calls: %d\n", calls)
+		}
+		if ce := c.GetCounterexample(); ce != nil {
+			_, _ = fmt.Fprintf(result, "\t\tcounterexample: %s\n", ce)
+		}
+		_, _ = fmt.Fprintln(result)
+	}
+
+	if opts.Semver != nil {
+		_, _ = fmt.Fprintf(result, "Semantic versioning: the changes require a %s version bump, info.version changed from %s to %s (%s), recommended version: %s\n",
+			opts.Semver.RequiredBump, opts.Semver.BaseVersion, opts.Semver.RevisionVersion, opts.Semver.ActualBump, opts.Semver.RecommendedVersion)
 	}
 
 	return result.Bytes(), nil
@@ -53,6 +69,47 @@ func (f TEXTFormatter) RenderChecks(checks Checks, opts RenderOpts) ([]byte, err
 	return result.Bytes(), nil
 }
 
+func (f TEXTFormatter) RenderLint(errs lint.Errors, opts RenderOpts) ([]byte, error) {
+	result := bytes.NewBuffer(nil)
+
+	if len(errs) > 0 {
+		counts := errs.GetLevelCount()
+		_, _ = fmt.Fprintf(result, "%d lint issues: %d error, %d warning\n\n", len(errs), counts[lint.LEVEL_ERROR], counts[lint.LEVEL_WARN])
+	}
+
+	for _, err := range errs {
+		_, _ = fmt.Fprintf(result, "%s\t[%s] at %s\t\n", err.LevelString(), err.Id, getLintLocation(err))
+		if err.Path != "" {
+			_, _ = fmt.Fprintf(result, "\tin API %s\n", strings.TrimSpace(err.Operation+" "+err.Path))
+		}
+		_, _ = fmt.Fprintf(result, "\t\t%s\n\n", err.Text)
+	}
+
+	return result.Bytes(), nil
+}
+
+func (f TEXTFormatter) RenderHistory(h history.History, opts RenderOpts) ([]byte, error) {
+	result := bytes.NewBuffer(nil)
+
+	for _, entry := range h {
+		_, _ = fmt.Fprintf(result, "commit %s %s %s: %s\n", entry.Commit.ShortHash(), entry.Commit.Date.Format(time.DateOnly), entry.Commit.Author, entry.Commit.Subject)
+		_, _ = fmt.Fprint(result, getChangelogTitle(entry.Changes, f.Localizer, opts.ColorMode))
+		for _, c := range entry.Changes {
+			_, _ = fmt.Fprintf(result, "%s\n\n", c.MultiLineError(f.Localizer, opts.ColorMode))
+		}
+	}
+
+	return result.Bytes(), nil
+}
+
+// getLintLocation returns the source of a lint error, with the one-based line and column when available
+func getLintLocation(err *lint.Error) string {
+	if err.SourceLine == 0 {
+		return err.Source
+	}
+	return fmt.Sprintf("%s:%d:%d", err.Source, err.SourceLine+1, err.SourceColumn+1)
+}
+
 func (f TEXTFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputChangelog, OutputChecks}
+	return []Output{OutputDiff, OutputChangelog, OutputChecks, OutputLint, OutputHistory}
 }
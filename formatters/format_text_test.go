@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/lint"
 )
 
 var textFormatter = formatters.TEXTFormatter{
@@ -33,6 +34,39 @@ func TestTextFormatter_RenderChangelog(t *testing.T) {
 	require.Equal(t, "1 changes: 1 error, 0 warning, 0 info\nerror\t[change_id] \t\n\tin components/test\n\t\tThis is a breaking change.\n\n", string(out))
 }
 
+func TestTextFormatter_RenderChangelogWithObservedCalls(t *testing.T) {
+	testChanges := checker.Changes{
+		checker.WithObservedCalls(checker.ComponentChange{
+			Id:        "change_id",
+			Level:     checker.ERR,
+			Component: "test",
+		}, 3),
+	}
+
+	out, err := textFormatter.RenderChangelog(testChanges, formatters.NewRenderOpts(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "1 changes: 1 error, 0 warning, 0 info\nerror\t[change_id] \t\n\tin components/test\n\t\tThis is a breaking change.\n\t\tobserved calls: 3\n\n", string(out))
+}
+
+func TestTextFormatter_RenderChangelogWithCounterexample(t *testing.T) {
+	testChanges := checker.Changes{
+		checker.WithCounterexample(checker.ComponentChange{
+			Id:        "change_id",
+			Level:     checker.ERR,
+			Component: "test",
+		}, &checker.Counterexample{
+			Method: "PUT",
+			Path:   "/users/a",
+			Query:  "limit=101",
+			Body:   map[string]any{"name": "a"},
+		}),
+	}
+
+	out, err := textFormatter.RenderChangelog(testChanges, formatters.NewRenderOpts(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "1 changes: 1 error, 0 warning, 0 info\nerror\t[change_id] \t\n\tin components/test\n\t\tThis is a breaking change.\n\t\tcounterexample: PUT /users/a?limit=101 {\"name\":\"a\"}\n\n", string(out))
+}
+
 func TestTextFormatter_RenderChecks(t *testing.T) {
 	checks := formatters.Checks{
 		{
@@ -53,6 +87,37 @@ func TestTextFormatter_RenderDiff(t *testing.T) {
 	require.Equal(t, string(out), "No changes\n")
 }
 
+func TestTextFormatter_RenderLint(t *testing.T) {
+	errs := lint.Errors{
+		{
+			Id:           "invalid-regex-pattern",
+			Level:        lint.LEVEL_ERROR,
+			Text:         "error parsing regexp",
+			Source:       "openapi.yaml",
+			Operation:    "GET",
+			Path:         "/api/test",
+			SourceLine:   11,
+			SourceColumn: 6,
+		},
+		{
+			Id:     "info-missing",
+			Level:  lint.LEVEL_WARN,
+			Text:   "info is missing",
+			Source: "openapi.yaml",
+		},
+	}
+
+	out, err := textFormatter.RenderLint(errs, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, "2 lint issues: 1 error, 1 warning\n\nerror\t[invalid-regex-pattern] at openapi.yaml:12:7\t\n\tin API GET /api/test\n\t\terror parsing regexp\n\nwarning\t[info-missing] at openapi.yaml\t\n\t\tinfo is missing\n\n", string(out))
+}
+
+func TestTextFormatter_RenderHistory(t *testing.T) {
+	out, err := textFormatter.RenderHistory(testHistory, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, "commit 0123456 2026-03-01 Jane Doe: Require the id parameter\n1 changes: 1 error, 0 warning, 0 info\nerror\t[change_id] at git:0123456789abcdef0123456789abcdef01234567:openapi.yaml\t\n\tin API GET /api/test\n\t\tThis is a breaking change.\n\n", string(out))
+}
+
 func TestTextFormatter_NotImplemented(t *testing.T) {
 	var err error
 
@@ -7,6 +7,8 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/lint"
 	"github.com/tufin/oasdiff/load"
 	"gopkg.in/yaml.v3"
 )
@@ -30,7 +32,7 @@ func (f YAMLFormatter) RenderSummary(diff *diff.Diff, opts RenderOpts) ([]byte,
 }
 
 func (f YAMLFormatter) RenderChangelog(changes checker.Changes, opts RenderOpts, specInfoPair *load.SpecInfoPair) ([]byte, error) {
-	return printYAML(NewChanges(changes, f.Localizer))
+	return printYAML(newChangelog(changes, f.Localizer, opts))
 }
 
 func (f YAMLFormatter) RenderChecks(checks Checks, opts RenderOpts) ([]byte, error) {
@@ -41,8 +43,16 @@ func (f YAMLFormatter) RenderFlatten(spec *openapi3.T, opts RenderOpts) ([]byte,
 	return printYAML(spec)
 }
 
+func (f YAMLFormatter) RenderLint(errs lint.Errors, opts RenderOpts) ([]byte, error) {
+	return printYAML(errs)
+}
+
+func (f YAMLFormatter) RenderHistory(history history.History, opts RenderOpts) ([]byte, error) {
+	return printYAML(NewHistory(history, f.Localizer))
+}
+
 func (f YAMLFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten}
+	return []Output{OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten, OutputLint, OutputHistory}
 }
 
 func printYAML(output interface{}) ([]byte, error) {
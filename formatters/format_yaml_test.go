@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/lint"
 )
 
 var yamlFormatter = formatters.YAMLFormatter{
@@ -31,6 +32,21 @@ func TestYamlFormatter_RenderChangelog(t *testing.T) {
 	require.Equal(t, "- id: change_id\n  text: This is a breaking change.\n  level: 3\n  section: components\n", string(out))
 }
 
+func TestYamlFormatter_RenderChangelogWithSemver(t *testing.T) {
+	opts := formatters.NewRenderOpts()
+	opts.Semver = &checker.SemverCheck{
+		BaseVersion:        "1.0.0",
+		RevisionVersion:    "1.0.1",
+		RequiredBump:       checker.SemverPatch,
+		ActualBump:         checker.SemverPatch,
+		RecommendedVersion: "1.0.1",
+	}
+
+	out, err := yamlFormatter.RenderChangelog(checker.Changes{}, opts, nil)
+	require.NoError(t, err)
+	require.Equal(t, "changes: []\nsemver:\n    baseVersion: 1.0.0\n    revisionVersion: 1.0.1\n    requiredBump: patch\n    actualBump: patch\n    recommendedVersion: 1.0.1\n", string(out))
+}
+
 func TestYamlFormatter_RenderChecks(t *testing.T) {
 	checks := formatters.Checks{
 		{
@@ -62,3 +78,25 @@ func TestYamlFormatter_RenderSummary(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, string(out), "diff: false\n")
 }
+
+func TestYamlFormatter_RenderHistory(t *testing.T) {
+	out, err := yamlFormatter.RenderHistory(testHistory, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Contains(t, string(out), "- commit:\n    hash: 0123456789abcdef0123456789abcdef01234567\n    author: Jane Doe\n")
+	require.Contains(t, string(out), "  changes:\n    - id: change_id\n")
+}
+
+func TestYamlFormatter_RenderLint(t *testing.T) {
+	errs := lint.Errors{
+		{
+			Id:     "info-missing",
+			Level:  lint.LEVEL_ERROR,
+			Text:   "info is missing",
+			Source: "openapi.yaml",
+		},
+	}
+
+	out, err := yamlFormatter.RenderLint(errs, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, "- id: info-missing\n  text: info is missing\n  level: 0\n  source: openapi.yaml\n", string(out))
+}
@@ -0,0 +1,26 @@
+package formatters
+
+import (
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/load"
+)
+
+// HistoryEntry is a commit which changed the spec, along with its changes
+type HistoryEntry struct {
+	Commit   *load.GitCommit `json:"commit" yaml:"commit"`
+	Previous string          `json:"previous,omitempty" yaml:"previous,omitempty"` // the revision that the commit is compared with
+	Changes  Changes         `json:"changes" yaml:"changes"`
+}
+
+func NewHistory(h history.History, l checker.Localizer) []HistoryEntry {
+	result := make([]HistoryEntry, len(h))
+	for i, entry := range h {
+		result[i] = HistoryEntry{
+			Commit:   entry.Commit,
+			Previous: entry.Previous,
+			Changes:  NewChanges(entry.Changes, l),
+		}
+	}
+	return result
+}
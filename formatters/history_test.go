@@ -0,0 +1,42 @@
+package formatters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/load"
+)
+
+var testHistory = history.History{
+	{
+		Commit: &load.GitCommit{
+			Hash:    "0123456789abcdef0123456789abcdef01234567",
+			Author:  "Jane Doe",
+			Date:    time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+			Subject: "Require the id parameter",
+		},
+		Previous: "fedcba9876543210fedcba9876543210fedcba98",
+		Changes: checker.Changes{
+			checker.ApiChange{
+				Id:        "change_id",
+				Level:     checker.ERR,
+				Operation: "GET",
+				Path:      "/api/test",
+				Source:    load.NewSource("git:0123456789abcdef0123456789abcdef01234567:openapi.yaml"),
+			},
+		},
+	},
+}
+
+func TestNewHistory(t *testing.T) {
+	result := formatters.NewHistory(testHistory, MockLocalizer)
+	require.Len(t, result, 1)
+	require.Equal(t, testHistory[0].Commit, result[0].Commit)
+	require.Equal(t, "fedcba9876543210fedcba9876543210fedcba98", result[0].Previous)
+	require.Len(t, result[0].Changes, 1)
+	require.True(t, result[0].Changes[0].IsBreaking)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/checker/localizations"
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/lint"
 	"github.com/tufin/oasdiff/load"
 	"golang.org/x/exp/slices"
 )
@@ -19,6 +21,8 @@ type Formatter interface {
 	RenderChangelog(changes checker.Changes, opts RenderOpts, specInfoPair *load.SpecInfoPair) ([]byte, error)
 	RenderChecks(checks Checks, opts RenderOpts) ([]byte, error)
 	RenderFlatten(spec *openapi3.T, opts RenderOpts) ([]byte, error)
+	RenderLint(errs lint.Errors, opts RenderOpts) ([]byte, error)
+	RenderHistory(history history.History, opts RenderOpts) ([]byte, error)
 	SupportedOutputs() []Output
 }
 
@@ -32,12 +36,16 @@ var formatters = map[Format]Formatter{
 	FormatHTML:          HTMLFormatter{},
 	FormatGithubActions: GitHubActionsFormatter{},
 	FormatJUnit:         JUnitFormatter{},
+	FormatSarif:         SarifFormatter{},
 }
 
 // Lookup returns a formatter by its name
 func Lookup(format string, opts FormatterOpts) (Formatter, error) {
 	f := Format(format)
-	l := checker.NewLocalizer(opts.Language)
+	l := opts.Localizer
+	if l == nil {
+		l = checker.NewLocalizer(opts.Language)
+	}
 
 	switch f {
 	case FormatYAML:
@@ -56,6 +64,8 @@ func Lookup(format string, opts FormatterOpts) (Formatter, error) {
 		return newGitHubActionsFormatter(l), nil
 	case FormatJUnit:
 		return newJUnitFormatter(l), nil
+	case FormatSarif:
+		return newSarifFormatter(l), nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", f)
 	}
@@ -6,6 +6,8 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/lint"
 	"github.com/tufin/oasdiff/load"
 )
 
@@ -31,6 +33,14 @@ func (f notImplementedFormatter) RenderFlatten(*openapi3.T, RenderOpts) ([]byte,
 	return notImplemented()
 }
 
+func (f notImplementedFormatter) RenderLint(lint.Errors, RenderOpts) ([]byte, error) {
+	return notImplemented()
+}
+
+func (f notImplementedFormatter) RenderHistory(history.History, RenderOpts) ([]byte, error) {
+	return notImplemented()
+}
+
 func notImplemented() ([]byte, error) {
 	return nil, fmt.Errorf("not implemented")
 }
@@ -8,4 +8,6 @@ const (
 	OutputChangelog
 	OutputChecks
 	OutputFlatten
+	OutputLint
+	OutputHistory
 )
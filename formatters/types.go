@@ -34,12 +34,14 @@ func GetSupportedFormats() []string {
 
 // FormatterOpts can be used to pass properties to the formatter (e.g. colors)
 type FormatterOpts struct {
-	Language string
+	Language  string
+	Localizer checker.Localizer // optional, a localizer to reuse instead of creating one for the language
 }
 
 // RenderOpts can be used to pass properties to the renderer method
 type RenderOpts struct {
 	ColorMode checker.ColorMode
+	Semver    *checker.SemverCheck // optional, the semantic versioning check of the changelog
 }
 
 func NewRenderOpts() RenderOpts {
@@ -47,3 +49,22 @@ func NewRenderOpts() RenderOpts {
 		ColorMode: checker.ColorAuto,
 	}
 }
+
+// GetContentType returns the media type of the output of a format, for example, to serve it over HTTP
+func GetContentType(format Format) string {
+	switch format {
+	case FormatYAML:
+		return "application/yaml"
+	case FormatJSON:
+		return "application/json"
+	case FormatMarkup, FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	case FormatJUnit:
+		return "application/xml"
+	case FormatSarif:
+		return "application/sarif+json"
+	}
+	return "text/plain; charset=utf-8"
+}
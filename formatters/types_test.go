@@ -10,3 +10,9 @@ import (
 func TestTypes(t *testing.T) {
 	require.Equal(t, formatters.GetSupportedFormats(), []string{"yaml", "json", "text", "markup", "markdown", "singleline", "html", "githubactions", "junit", "sarif"})
 }
+
+func TestGetContentType(t *testing.T) {
+	require.Equal(t, "application/json", formatters.GetContentType(formatters.FormatJSON))
+	require.Equal(t, "text/markdown; charset=utf-8", formatters.GetContentType(formatters.FormatMarkdown))
+	require.Equal(t, "text/plain; charset=utf-8", formatters.GetContentType(formatters.FormatGithubActions))
+}
@@ -0,0 +1,149 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/load"
+)
+
+// ErrNoBreakingChanges is returned by Bisect when the spec at the bad revision has no matching breaking changes
+var ErrNoBreakingChanges = errors.New("no matching breaking changes at the bad revision")
+
+// BisectResult is the first commit in which the spec has matching breaking changes compared with the good revision
+type BisectResult struct {
+	Commit  *load.GitCommit
+	Changes checker.Changes // the matching breaking changes, compared with the good revision
+	Tested  int             // the number of commits whose spec was compared with the good revision
+	Skipped []*Skipped      // commits whose spec couldn't be loaded or compared
+}
+
+// Bisect binary-searches the commits between a good and a bad revision for the first commit in which the spec has breaking changes compared with the good revision
+// only changes of config.Checks, in paths selected by config.Diff, are considered, config.Level is ignored
+// like git bisect, it assumes that once a breaking change appears, it appears in all the following commits
+func Bisect(config *Config, path, good, bad string) (*BisectResult, error) {
+	goodSpecs, err := config.load(good, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spec at the good revision %s: %w", good, err)
+	}
+
+	commits, err := config.getCommits(path, good+".."+bad, bad)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits modified the spec between %s and %s", good, bad)
+	}
+
+	b := &bisector{
+		config:  config,
+		path:    path,
+		good:    goodSpecs,
+		commits: commits,
+		results: map[int]checker.Changes{},
+		failed:  map[int]bool{},
+		result:  &BisectResult{Skipped: []*Skipped{}},
+	}
+
+	// the newest commit which modified the spec has the spec of the bad revision
+	last := len(commits) - 1
+	changes, ok := b.test(last)
+	if b.err != nil {
+		return nil, b.err
+	}
+	if !ok {
+		return nil, fmt.Errorf("failed to load spec at the bad revision %s: %w", bad, b.result.Skipped[len(b.result.Skipped)-1].Err)
+	}
+	if len(changes) == 0 {
+		return nil, ErrNoBreakingChanges
+	}
+
+	// invariant: the commit at hi is bad, and all the loadable commits before lo are good
+	lo, hi := 0, last
+	for lo < hi {
+		i, ok := b.testInRange((lo+hi)/2, lo, hi)
+		if b.err != nil {
+			return nil, b.err
+		}
+		if !ok {
+			// all the commits between lo and hi are skipped, so the first bad commit is hi or one of them
+			break
+		}
+		if len(b.results[i]) > 0 {
+			hi = i
+		} else {
+			lo = i + 1
+		}
+	}
+
+	b.result.Commit = commits[hi]
+	b.result.Changes = b.results[hi]
+	return b.result, nil
+}
+
+type bisector struct {
+	config  *Config
+	path    string
+	good    []*load.SpecInfo
+	commits []*load.GitCommit
+	results map[int]checker.Changes // the matching breaking changes of the tested commits
+	failed  map[int]bool            // the commits whose spec couldn't be loaded or compared
+	result  *BisectResult
+	err     error // a limit which was exceeded, this stops the search rather than skipping the commit
+}
+
+// testInRange tests the commit at i, or the nearest commit in [lo, hi) which can be loaded, looking forward first like git bisect skip
+func (b *bisector) testInRange(i, lo, hi int) (int, bool) {
+	for j := i; j < hi && b.err == nil; j++ {
+		if _, ok := b.test(j); ok {
+			return j, true
+		}
+	}
+	for j := i - 1; j >= lo && b.err == nil; j-- {
+		if _, ok := b.test(j); ok {
+			return j, true
+		}
+	}
+	return 0, false
+}
+
+// test compares the spec at a commit with the good revision, and returns the matching breaking changes
+func (b *bisector) test(i int) (checker.Changes, bool) {
+	if changes, ok := b.results[i]; ok {
+		return changes, true
+	}
+	if b.failed[i] {
+		return nil, false
+	}
+
+	changes, err := b.getBreakingChanges(b.commits[i])
+	if errors.Is(err, limits.ErrLimitExceeded) {
+		b.err = err
+		return nil, false
+	}
+	if err != nil {
+		b.failed[i] = true
+		b.result.Skipped = append(b.result.Skipped, &Skipped{Commit: b.commits[i], Err: err})
+		return nil, false
+	}
+
+	b.result.Tested++
+	b.results[i] = changes
+	return changes, true
+}
+
+func (b *bisector) getBreakingChanges(commit *load.GitCommit) (checker.Changes, error) {
+	specs, err := b.config.load(commit.Hash, b.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spec: %w", err)
+	}
+
+	diffReport, operationsSources, err := b.config.diff(b.good, specs)
+	if err != nil {
+		return nil, fmt.Errorf("diff failed: %w", err)
+	}
+
+	return b.config.filterChecks(checker.CheckBackwardCompatibility(b.config.Checker, diffReport, operationsSources)), nil
+}
@@ -0,0 +1,74 @@
+package history_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/load"
+)
+
+func TestBisect(t *testing.T) {
+	initHistoryRepo(t)
+
+	result, err := history.Bisect(newConfig(), "openapi.yaml", "HEAD~4", "HEAD")
+	require.NoError(t, err)
+	require.Equal(t, "make pet an object", result.Commit.Subject)
+	require.Equal(t, "response-body-type-changed", result.Changes[0].GetId())
+}
+
+func TestBisect_Checks(t *testing.T) {
+	initHistoryRepo(t)
+
+	config := newConfig()
+	config.Checks = []string{"new-required-request-parameter"}
+	result, err := history.Bisect(config, "openapi.yaml", "HEAD~4", "HEAD")
+	require.NoError(t, err)
+	require.Equal(t, "require a limit", result.Commit.Subject)
+	require.Len(t, result.Changes, 1)
+
+	// the invalid revision is skipped
+	require.Len(t, result.Skipped, 1)
+	require.Equal(t, "break the spec", result.Skipped[0].Commit.Subject)
+}
+
+func TestBisect_MatchPath(t *testing.T) {
+	initHistoryRepo(t)
+
+	config := newConfig()
+	config.Diff.MatchPath = "^/users"
+	_, err := history.Bisect(config, "openapi.yaml", "HEAD~4", "HEAD")
+	require.ErrorIs(t, err, history.ErrNoBreakingChanges)
+}
+
+func TestBisect_NoCommits(t *testing.T) {
+	initHistoryRepo(t)
+
+	_, err := history.Bisect(newConfig(), "openapi.yaml", "HEAD", "HEAD")
+	require.EqualError(t, err, "no commits modified the spec between HEAD and HEAD")
+}
+
+func TestBisect_InvalidGood(t *testing.T) {
+	initHistoryRepo(t)
+
+	_, err := history.Bisect(newConfig(), "openapi.yaml", "no-such-rev", "HEAD")
+	require.Error(t, err)
+}
+
+func TestBisect_LimitExceeded(t *testing.T) {
+	initHistoryRepo(t)
+
+	// the spec at the good revision is within the limits, and the spec at the bad revision exceeds them
+	config := newConfig()
+	config.NewLoader = func() *openapi3.Loader {
+		loader := openapi3.NewLoader()
+		loader.IsExternalRefsAllowed = true
+		load.WithLimits(loader, &limits.Limits{MaxInputBytes: int64(len(fmtSpec("")))})
+		return loader
+	}
+
+	_, err := history.Bisect(config, "openapi.yaml", "HEAD~4", "HEAD")
+	require.ErrorIs(t, err, limits.ErrLimitExceeded)
+}
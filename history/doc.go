@@ -0,0 +1,2 @@
+// Package history walks the git history of a spec and reports the changes introduced by each commit
+package history
@@ -0,0 +1,189 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/load"
+)
+
+// Config configures how the revisions of a spec are compared
+type Config struct {
+	Diff     *diff.Config
+	Checker  *checker.Config
+	Level    checker.Level // only changes with this level or higher are reported
+	Checks   []string      // only changes of these checks are reported, all checks if empty
+	Composed bool          // the spec is a glob of specs, which are compared in composed mode
+	RevRange string        // the commits to walk, like v1.0..main, HEAD if empty
+	Options  []load.Option
+	// NewLoader creates the loaders of the revisions, for example, with a ref policy and limits
+	// if nil, the revisions are loaded with external refs allowed and without restrictions
+	NewLoader func() *openapi3.Loader
+}
+
+// Entry is a commit which changed the spec, along with the changes compared with the previous revision of the spec
+type Entry struct {
+	Commit   *load.GitCommit
+	Previous string // the revision that the commit is compared with
+	Changes  checker.Changes
+}
+
+// History is the changes of a spec, from the newest commit to the oldest
+type History []*Entry
+
+// Skipped is a commit whose spec couldn't be loaded or compared
+// the next commit is compared with the last revision that was loaded successfully instead
+type Skipped struct {
+	Commit *load.GitCommit
+	Err    error
+}
+
+// Get walks the commits of the local git repository which modified a spec, and compares each revision of the spec with the previous one
+// commits which modified the local files referenced by the spec at the newest revision are walked too
+// commits without changes are omitted
+func Get(config *Config, path string) (History, []*Skipped, error) {
+	start, end := parseRevRange(config.RevRange)
+
+	commits, err := config.getCommits(path, config.RevRange, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var previous []*load.SpecInfo
+	previousRev := ""
+	if start != "" {
+		// a range like v1.0..main excludes its start, which is the baseline of the first commit
+		if previous, err = config.load(start, path); err == nil {
+			previousRev = start
+		}
+	}
+
+	result := History{}
+	skipped := []*Skipped{}
+	for _, commit := range commits {
+		current, err := config.load(commit.Hash, path)
+		if errors.Is(err, limits.ErrLimitExceeded) {
+			return nil, nil, err
+		}
+		if err != nil {
+			skipped = append(skipped, &Skipped{Commit: commit, Err: fmt.Errorf("failed to load spec: %w", err)})
+			continue
+		}
+
+		if previous != nil {
+			changes, err := config.compare(previous, current)
+			if errors.Is(err, limits.ErrLimitExceeded) {
+				return nil, nil, err
+			}
+			if err != nil {
+				skipped = append(skipped, &Skipped{Commit: commit, Err: err})
+				continue
+			}
+			if len(changes) > 0 {
+				result = append(result, &Entry{
+					Commit:   commit,
+					Previous: previousRev,
+					Changes:  changes,
+				})
+			}
+		}
+
+		previous, previousRev = current, commit.Hash
+	}
+
+	slices.Reverse(result)
+	return result, skipped, nil
+}
+
+// getCommits returns the commits in a revision range which modified the spec, or one of the files that it references at the newest revision
+func (config *Config) getCommits(path, revRange, newest string) ([]*load.GitCommit, error) {
+	paths, err := load.GetGitSpecFiles(config.newLoader(), newest, path, config.Composed)
+	if err != nil {
+		// the spec doesn't exist at the newest revision, for example, because it was deleted
+		paths = []string{path}
+	}
+	if config.Composed && !slices.Contains(paths, path) {
+		// specs which were added or deleted over time match the glob too
+		paths = append(paths, path)
+	}
+
+	return load.GetGitLog(paths, revRange)
+}
+
+// parseRevRange returns the start and the end of a revision range like v1.0..main, the start of a single revision is empty
+func parseRevRange(revRange string) (string, string) {
+	start, end, found := strings.Cut(revRange, "..")
+	if !found {
+		start, end = "", revRange
+	}
+	// a symmetric difference, like v1.0...main
+	end = strings.TrimPrefix(end, ".")
+	if end == "" {
+		end = "HEAD"
+	}
+	return start, end
+}
+
+// newLoader returns a loader for a revision of the spec
+func (config *Config) newLoader() *openapi3.Loader {
+	if config.NewLoader != nil {
+		return config.NewLoader()
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	return loader
+}
+
+func (config *Config) load(rev, path string) ([]*load.SpecInfo, error) {
+	loader := config.newLoader()
+
+	source := load.GitPrefix + rev + ":" + path
+	if config.Composed {
+		return load.NewSpecInfoFromGlob(loader, source, config.Options...)
+	}
+
+	specInfo, err := load.NewSpecInfo(loader, load.NewSource(source), config.Options...)
+	if err != nil {
+		return nil, err
+	}
+	return []*load.SpecInfo{specInfo}, nil
+}
+
+func (config *Config) compare(base, revision []*load.SpecInfo) (checker.Changes, error) {
+	diffReport, operationsSources, err := config.diff(base, revision)
+	if err != nil {
+		return nil, fmt.Errorf("diff failed: %w", err)
+	}
+
+	return config.filterChecks(checker.CheckBackwardCompatibilityUntilLevel(config.Checker, diffReport, operationsSources, config.Level)), nil
+}
+
+// diff compares two revisions of the spec
+func (config *Config) diff(base, revision []*load.SpecInfo) (*diff.Diff, *diff.OperationsSourcesMap, error) {
+	if config.Composed {
+		return diff.GetPathsDiff(config.Diff, base, revision)
+	}
+	return diff.GetWithOperationsSourcesMap(config.Diff, base[0], revision[0])
+}
+
+// filterChecks keeps the changes of the selected checks
+func (config *Config) filterChecks(changes checker.Changes) checker.Changes {
+	if len(config.Checks) == 0 {
+		return changes
+	}
+
+	result := checker.Changes{}
+	for _, change := range changes {
+		if slices.Contains(config.Checks, change.GetId()) {
+			result = append(result, change)
+		}
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+package history_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/load"
+)
+
+const spec = `openapi: 3.0.1
+info:
+  title: Test API
+  version: v1
+paths:
+  /pets:
+    get:
+%s      responses:
+        "200":
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: "schemas.yaml#/Pet"
+`
+
+const limitParam = `      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: integer
+`
+
+type commit struct {
+	files   map[string]string
+	message string
+}
+
+// initHistoryRepo creates a repository with a spec whose revisions are committed one after the other
+func initHistoryRepo(t *testing.T) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	runGit(t, "init", "-q")
+
+	for _, c := range []commit{
+		{map[string]string{"openapi.yaml": fmtSpec(""), "schemas.yaml": "Pet:\n  type: string\n"}, "add pets"},
+		{map[string]string{"schemas.yaml": "Pet:\n  type: object\n"}, "make pet an object"},
+		{map[string]string{"README.md": "pets"}, "add readme"},
+		{map[string]string{"openapi.yaml": "invalid: [\n"}, "break the spec"},
+		{map[string]string{"openapi.yaml": fmtSpec(limitParam)}, "require a limit"},
+	} {
+		for file, content := range c.files {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+		}
+		runGit(t, "add", ".")
+		runGit(t, "-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com", "commit", "-q", "-m", c.message)
+	}
+}
+
+func fmtSpec(params string) string {
+	return strings.Replace(spec, "%s", params, 1)
+}
+
+func runGit(t *testing.T, args ...string) {
+	t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func newConfig() *history.Config {
+	return &history.Config{
+		Diff:    diff.NewConfig(),
+		Checker: checker.NewConfig(checker.GetAllChecks()),
+		Level:   checker.INFO,
+	}
+}
+
+func TestGet(t *testing.T) {
+	initHistoryRepo(t)
+
+	h, skipped, err := history.Get(newConfig(), "openapi.yaml")
+	require.NoError(t, err)
+
+	// the newest commit comes first, commits without changes are omitted
+	require.Len(t, h, 2)
+	require.Equal(t, "require a limit", h[0].Commit.Subject)
+	require.Equal(t, "Jane Doe", h[0].Commit.Author)
+	require.Equal(t, "new-required-request-parameter", h[0].Changes[0].GetId())
+
+	// a commit which only modified a referenced file
+	require.Equal(t, "make pet an object", h[1].Commit.Subject)
+	require.Equal(t, "response-body-type-changed", h[1].Changes[0].GetId())
+
+	// the invalid revision is skipped, and the next commit is compared with the last valid one
+	require.Len(t, skipped, 1)
+	require.Equal(t, "break the spec", skipped[0].Commit.Subject)
+	require.Equal(t, h[1].Commit.Hash, h[0].Previous)
+}
+
+func TestGet_NewLoader(t *testing.T) {
+	initHistoryRepo(t)
+
+	// the revisions can't be loaded without their external refs, so only the commits which modified the spec itself are walked, and skipped
+	config := newConfig()
+	config.NewLoader = openapi3.NewLoader
+
+	h, skipped, err := history.Get(config, "openapi.yaml")
+	require.NoError(t, err)
+	require.Empty(t, h)
+	require.Len(t, skipped, 3)
+}
+
+func TestGet_LimitExceeded(t *testing.T) {
+	initHistoryRepo(t)
+
+	config := newConfig()
+	config.NewLoader = func() *openapi3.Loader {
+		loader := openapi3.NewLoader()
+		loader.IsExternalRefsAllowed = true
+		load.WithLimits(loader, &limits.Limits{MaxInputBytes: 100})
+		return loader
+	}
+
+	_, _, err := history.Get(config, "openapi.yaml")
+	require.ErrorIs(t, err, limits.ErrLimitExceeded)
+}
+
+func TestGet_Checks(t *testing.T) {
+	initHistoryRepo(t)
+
+	config := newConfig()
+	config.Checks = []string{"response-body-type-changed"}
+	h, _, err := history.Get(config, "openapi.yaml")
+	require.NoError(t, err)
+	require.Len(t, h, 1)
+	require.Equal(t, "make pet an object", h[0].Commit.Subject)
+}
+
+func TestGet_Level(t *testing.T) {
+	initHistoryRepo(t)
+
+	config := newConfig()
+	config.Level = checker.ERR
+	h, _, err := history.Get(config, "openapi.yaml")
+	require.NoError(t, err)
+	for _, entry := range h {
+		for _, change := range entry.Changes {
+			require.Equal(t, checker.ERR, change.GetLevel())
+		}
+	}
+}
+
+func TestGet_MatchPath(t *testing.T) {
+	initHistoryRepo(t)
+
+	config := newConfig()
+	config.Diff.MatchPath = "^/users"
+	h, _, err := history.Get(config, "openapi.yaml")
+	require.NoError(t, err)
+	require.Empty(t, h)
+}
+
+func TestGet_RevRange(t *testing.T) {
+	initHistoryRepo(t)
+
+	config := newConfig()
+	config.RevRange = "HEAD~2..HEAD"
+	h, _, err := history.Get(config, "openapi.yaml")
+	require.NoError(t, err)
+
+	// the start of the range is the baseline of the first commit in the range
+	require.Len(t, h, 1)
+	require.Equal(t, "require a limit", h[0].Commit.Subject)
+	require.Equal(t, "HEAD~2", h[0].Previous)
+}
+
+func TestGet_Composed(t *testing.T) {
+	initHistoryRepo(t)
+
+	config := newConfig()
+	config.Composed = true
+	h, _, err := history.Get(config, "openapi*.yaml")
+	require.NoError(t, err)
+	require.Len(t, h, 2)
+}
+
+func TestGet_InvalidRevRange(t *testing.T) {
+	initHistoryRepo(t)
+
+	config := newConfig()
+	config.RevRange = "no-such-rev"
+	_, _, err := history.Get(config, "openapi.yaml")
+	require.Error(t, err)
+}
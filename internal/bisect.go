@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/history"
+)
+
+const bisectCmd = "bisect"
+
+func getBisectCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "bisect spec --good <rev> [--bad <rev>] [flags]",
+		Short: "Find the commit that introduced a breaking change to a spec",
+		Long: `Binary-search the commits of the local git repository between a good and a bad revision for the first commit in which the spec has breaking changes compared with the good revision.
+The spec is loaded at each tested commit, along with its external refs.
+Use --checks and --match-path to look for a specific breaking change.
+Spec is a path to a file in the repository, or a glob in 'composed' mode.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: getRun(runBisect),
+	}
+
+	addCommonGitHistoryFlags(&cmd)
+	cmd.PersistentFlags().String("good", "", "a revision without the breaking change, for example: v1.0")
+	cmd.PersistentFlags().String("bad", "HEAD", "a revision with the breaking change")
+	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputHistory), string(formatters.FormatText)), "format", "f", "output format")
+
+	return &cmd
+}
+
+func runBisect(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+
+	if flags.getGood() == "" {
+		return false, getErrInvalidFlags(errors.New("bisect requires a good revision, set it with --good"))
+	}
+
+	config, colorMode, returnErr := getGitHistoryConfig(bisectCmd, flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	result, err := history.Bisect(config, flags.getBase().Path, flags.getGood(), flags.getBad())
+	if err != nil {
+		return false, getErrBisectFailed(err)
+	}
+
+	for _, s := range result.Skipped {
+		_, _ = fmt.Fprintf(stderr, "warning: skipped commit %s: %v\n", s.Commit.ShortHash(), s.Err)
+	}
+	_, _ = fmt.Fprintf(stderr, "the first commit with breaking changes compared with %s is %s, commits tested: %d\n", flags.getGood(), result.Commit.ShortHash(), result.Tested)
+
+	// the result is displayed like a history with a single commit, which is compared with the good revision
+	h := history.History{{Commit: result.Commit, Previous: flags.getGood(), Changes: result.Changes}}
+	return false, outputHistory(bisectCmd, flags, stdout, h, colorMode)
+}
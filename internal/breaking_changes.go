@@ -14,16 +14,17 @@ func getBreakingChangesCmd() *cobra.Command {
 		Short: "Display breaking changes",
 		Long:  "Display breaking changes between base and revision specs." + specHelp,
 		Args:  getParseArgs(),
-		RunE:  getRun(runBreakingChanges),
+		RunE:  getRun(withWatch(runBreakingChanges)),
 	}
 
 	addCommonDiffFlags(&cmd)
+	addWatchFlags(&cmd)
 	addCommonBreakingFlags(&cmd)
 	enumWithOptions(&cmd, newEnumValue(GetBreakingLevels(), ""), "fail-on", "o", "exit with return code 1 when output includes errors with this level or higher")
 
 	return &cmd
 }
 
-func runBreakingChanges(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
-	return getChangelog(flags, stdout, checker.WARN)
+func runBreakingChanges(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+	return getChangelog(flags, stdout, stderr, checker.WARN)
 }
@@ -1,13 +1,20 @@
 package internal
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/spf13/cobra"
 	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/counterexample"
 	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/limits"
 	"github.com/tufin/oasdiff/load"
+	"github.com/tufin/oasdiff/traffic"
 )
 
 const changelogCmd = "changelog"
@@ -19,10 +26,11 @@ func getChangelogCmd() *cobra.Command {
 		Short: "Display changelog",
 		Long:  "Display changes between base and revision specs." + specHelp,
 		Args:  getParseArgs(),
-		RunE:  getRun(runChangelog),
+		RunE:  getRun(withWatch(runChangelog)),
 	}
 
 	addCommonDiffFlags(&cmd)
+	addWatchFlags(&cmd)
 	addCommonBreakingFlags(&cmd)
 	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), ""), "fail-on", "o", "exit with return code 1 when output includes errors with this level or higher")
 	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), LevelInfo), "level", "", "output errors with this level or higher")
@@ -34,46 +42,96 @@ func enumWithOptions(cmd *cobra.Command, value enumVal, name, shorthand, usage s
 	cmd.PersistentFlags().VarP(value, name, shorthand, usage+": "+value.listOf())
 }
 
-func runChangelog(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
+func runChangelog(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
 
 	level, err := checker.NewLevel(flags.getLevel())
 	if err != nil {
 		return false, getErrInvalidFlags(fmt.Errorf("invalid level value: %q", flags.getLevel()))
 	}
 
-	return getChangelog(flags, stdout, level)
+	return getChangelog(flags, stdout, stderr, level)
 }
 
-func getChangelog(flags *Flags, stdout io.Writer, level checker.Level) (bool, *ReturnError) {
+func getChangelog(flags *Flags, stdout io.Writer, stderr io.Writer, level checker.Level) (bool, *ReturnError) {
 
 	diffResult, returnErr := calcDiff(flags)
 	if returnErr != nil {
 		return false, returnErr
 	}
 
-	severityLevels, returnErr := getCustomSeverityLevels(flags.getSeverityLevelsFile())
+	customRules, returnErr := getCustomRules(flags.getCustomRulesFile())
 	if returnErr != nil {
 		return false, returnErr
 	}
 
+	config := checker.NewConfig(checker.GetAllChecks()).WithOptionalChecks(flags.getIncludeChecks()).WithCustomRules(customRules)
+
+	if returnErr := runPlugins(flags.getPlugins(), diffResult, config); returnErr != nil {
+		return false, returnErr
+	}
+
+	severityLevels, returnErr := getCustomSeverityLevels(flags.getSeverityLevelsFile(), customRules, config.PluginChanges)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	// the semantic versioning check needs all changes, including those below the requested level
+	checkLevel := level
+	if flags.getCheckSemver() {
+		checkLevel = checker.INFO
+	}
+
 	errs, returnErr := filterIgnored(
 		checker.CheckBackwardCompatibilityUntilLevel(
-			checker.NewConfig(checker.GetAllChecks()).WithOptionalChecks(flags.getIncludeChecks()).WithSeverityLevels(severityLevels).WithDeprecation(flags.getDeprecationDaysBeta(), flags.getDeprecationDaysStable()).WithAttributes(flags.getAttributes()),
+			config.WithSeverityLevels(severityLevels).WithDeprecation(flags.getDeprecationDaysBeta(), flags.getDeprecationDaysStable()).WithAttributes(flags.getAttributes()).WithSourceMaps(diffResult.baseSourceMaps, diffResult.revisionSourceMaps),
 			diffResult.diffReport,
 			diffResult.operationsSources,
-			level),
+			checkLevel),
 		flags.getWarnIgnoreFile(),
 		flags.getErrIgnoreFile(),
-		checker.NewLocalizer(flags.getLang()))
+		checker.NewLocalizer(flags.getLang()),
+		stderr)
+
+	if returnErr != nil {
+		return false, returnErr
+	}
 
+	var semverCheck *checker.SemverCheck
+	if flags.getCheckSemver() {
+		var err error
+		semverCheck, err = checker.NewSemverCheck(errs, diffResult.diffReport, diffResult.specInfoPair.GetBaseVersion(), diffResult.specInfoPair.GetRevisionVersion())
+		if err != nil {
+			return false, getErrInvalidSemver(err)
+		}
+		errs = filterLevel(errs, level)
+	}
+
+	errs, returnErr = analyzeTraffic(errs, flags.getTrafficFiles(), flags.getDropUnused(), diffResult, stderr)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	if flags.getCounterexamples() {
+		errs = counterexample.Annotate(diffResult.diffReport, errs)
+	}
+
+	errs, returnErr = applyBaseline(errs, flags.getWriteBaselineFile(), flags.getBaselineFile(), stderr)
 	if returnErr != nil {
 		return false, returnErr
 	}
 
-	if returnErr := outputChangelog(flags, stdout, errs, diffResult.specInfoPair); returnErr != nil {
+	if flags.watch != nil {
+		flags.watch.recordChanges(errs)
+	}
+
+	if returnErr := outputChangelog(flags, stdout, errs, diffResult.specInfoPair, semverCheck); returnErr != nil {
 		return false, returnErr
 	}
 
+	if semverCheck != nil && !semverCheck.IsSufficient() {
+		return false, getErrSemverBumpTooSmall(semverCheck)
+	}
+
 	if flags.getFailOn() != "" {
 		level, err := checker.NewLevel(flags.getFailOn())
 		if err != nil {
@@ -85,28 +143,141 @@ func getChangelog(flags *Flags, stdout io.Writer, level checker.Level) (bool, *R
 	return false, nil
 }
 
-func filterIgnored(errs checker.Changes, warnIgnoreFile string, errIgnoreFile string, l checker.Localizer) (checker.Changes, *ReturnError) {
+func filterIgnored(errs checker.Changes, warnIgnoreFile string, errIgnoreFile string, l checker.Localizer, stderr io.Writer) (checker.Changes, *ReturnError) {
 
 	if warnIgnoreFile != "" {
-		var err error
-		errs, err = checker.ProcessIgnoredBackwardCompatibilityErrors(checker.WARN, errs, warnIgnoreFile, l)
-		if err != nil {
-			return nil, getErrCantProcessIgnoreFile("warn", err)
+		var returnErr *ReturnError
+		errs, returnErr = processIgnoreFile("warn", checker.WARN, errs, warnIgnoreFile, l, stderr)
+		if returnErr != nil {
+			return nil, returnErr
 		}
 	}
 
 	if errIgnoreFile != "" {
-		var err error
-		errs, err = checker.ProcessIgnoredBackwardCompatibilityErrors(checker.ERR, errs, errIgnoreFile, l)
-		if err != nil {
-			return nil, getErrCantProcessIgnoreFile("err", err)
+		var returnErr *ReturnError
+		errs, returnErr = processIgnoreFile("err", checker.ERR, errs, errIgnoreFile, l, stderr)
+		if returnErr != nil {
+			return nil, returnErr
 		}
 	}
 
 	return errs, nil
 }
 
-func outputChangelog(flags *Flags, stdout io.Writer, errs checker.Changes, specInfoPair *load.SpecInfoPair) *ReturnError {
+// processIgnoreFile removes the ignored changes of the given level, using either a textual or a structured ignore file
+// expired entries of a structured ignore file fail the run, and entries which didn't match any change are reported as stale
+func processIgnoreFile(what string, level checker.Level, errs checker.Changes, ignoreFile string, l checker.Localizer, stderr io.Writer) (checker.Changes, *ReturnError) {
+	if !checker.IsStructuredIgnoreFile(ignoreFile) {
+		result, err := checker.ProcessIgnoredBackwardCompatibilityErrors(level, errs, ignoreFile, l)
+		if err != nil {
+			return nil, getErrCantProcessIgnoreFile(what, err)
+		}
+		return result, nil
+	}
+
+	entries, err := checker.LoadIgnoreEntries(ignoreFile)
+	if err != nil {
+		return nil, getErrCantProcessIgnoreFile(what, err)
+	}
+
+	result, ignoreResult := entries.Apply(level, errs, time.Now())
+
+	for _, entry := range ignoreResult.Stale {
+		_, _ = fmt.Fprintf(stderr, "warning: stale entry in %s ignore file %s: %s didn't match any change\n", what, ignoreFile, entry)
+	}
+
+	if len(ignoreResult.Expired) > 0 {
+		return nil, getErrExpiredIgnoreEntries(what, ignoreFile, ignoreResult.Expired)
+	}
+
+	return result, nil
+}
+
+// analyzeTraffic annotates the changes with the number of recorded calls that they affect, and optionally drops the breaking changes which don't affect any call
+func analyzeTraffic(errs checker.Changes, trafficFiles []string, dropUnused bool, diffResult *diffResult, stderr io.Writer) (checker.Changes, *ReturnError) {
+	if len(trafficFiles) == 0 {
+		if dropUnused {
+			return nil, getErrInvalidFlags(errors.New("--drop-unused requires --traffic"))
+		}
+		return errs, nil
+	}
+
+	samples := traffic.Samples{}
+	for _, file := range trafficFiles {
+		fileSamples, err := traffic.Load(file)
+		if err != nil {
+			return nil, getErrFailedToLoadTraffic(file, err)
+		}
+		samples = append(samples, fileSamples...)
+	}
+
+	usage := traffic.NewUsage(samples, getTrafficSpecs(diffResult)...)
+	if usage.Unmatched > 0 {
+		_, _ = fmt.Fprintf(stderr, "warning: %d of %d recorded calls didn't match any endpoint\n", usage.Unmatched, len(samples))
+	}
+
+	result := usage.Annotate(errs)
+	if dropUnused {
+		result = usage.DropUnused(result)
+	}
+	return result, nil
+}
+
+// getTrafficSpecs returns the specs that recorded calls are mapped onto
+// when comparing collections of specs, the merged paths of the collections are used
+func getTrafficSpecs(diffResult *diffResult) []*openapi3.T {
+	if diffResult.specInfoPair != nil && diffResult.specInfoPair.Base != nil && diffResult.specInfoPair.Revision != nil {
+		return []*openapi3.T{diffResult.specInfoPair.Base.Spec, diffResult.specInfoPair.Revision.Spec}
+	}
+
+	if diffResult.diffReport.PathsDiff == nil {
+		return nil
+	}
+
+	return []*openapi3.T{
+		{Paths: diffResult.diffReport.PathsDiff.Base},
+		{Paths: diffResult.diffReport.PathsDiff.Revision},
+	}
+}
+
+// applyBaseline writes the changes to a new baseline file, and removes the changes which are already in an existing baseline file
+// the texts in the baseline file are always in English so that the file doesn't change with the language of the output
+// baseline entries which no longer occur are reported so that they can be removed from the baseline
+func applyBaseline(errs checker.Changes, writeBaselineFile string, baselineFile string, stderr io.Writer) (checker.Changes, *ReturnError) {
+	if writeBaselineFile != "" {
+		if err := checker.NewBaseline(errs, checker.NewLocalizer("en")).SaveBaseline(writeBaselineFile); err != nil {
+			return nil, getErrFailedToWriteBaseline(writeBaselineFile, err)
+		}
+	}
+
+	if baselineFile == "" {
+		return errs, nil
+	}
+
+	baseline, err := checker.LoadBaseline(baselineFile)
+	if err != nil {
+		return nil, getErrFailedToLoadBaseline(baselineFile, err)
+	}
+
+	result, resolved := baseline.Filter(errs)
+	for _, entry := range resolved {
+		_, _ = fmt.Fprintf(stderr, "warning: baseline entry no longer occurs in %s: %s %s\n", baselineFile, entry.Fingerprint, entry.Text)
+	}
+
+	return result, nil
+}
+
+func filterLevel(errs checker.Changes, level checker.Level) checker.Changes {
+	result := make(checker.Changes, 0, len(errs))
+	for _, err := range errs {
+		if err.GetLevel() >= level {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
+func outputChangelog(flags *Flags, stdout io.Writer, errs checker.Changes, specInfoPair *load.SpecInfoPair, semverCheck *checker.SemverCheck) *ReturnError {
 
 	// formatter lookup
 	formatter, err := formatters.Lookup(flags.getFormat(), formatters.FormatterOpts{
@@ -122,7 +293,7 @@ func outputChangelog(flags *Flags, stdout io.Writer, errs checker.Changes, specI
 		return getErrInvalidColorMode(err)
 	}
 
-	bytes, err := formatter.RenderChangelog(errs, formatters.RenderOpts{ColorMode: colorMode}, specInfoPair)
+	bytes, err := formatter.RenderChangelog(errs, formatters.RenderOpts{ColorMode: colorMode, Semver: semverCheck}, specInfoPair)
 	if err != nil {
 		return getErrFailedPrint(changelogCmd+" "+flags.getFormat(), err)
 	}
@@ -133,15 +304,74 @@ func outputChangelog(flags *Flags, stdout io.Writer, errs checker.Changes, specI
 	return nil
 }
 
-func getCustomSeverityLevels(severityLevelsFile string) (map[string]checker.Level, *ReturnError) {
+// getCustomSeverityLevels reads the severity levels file
+// the ids may refer to built-in rules, custom rules and the changes reported by plugins
+func getCustomSeverityLevels(severityLevelsFile string, customRules checker.CustomRules, pluginChanges checker.PluginChanges) (map[string]checker.Level, *ReturnError) {
 	if severityLevelsFile == "" {
 		return nil, nil
 	}
 
-	m, err := checker.ProcessSeverityLevels(severityLevelsFile)
+	m, err := checker.ProcessPluginSeverityLevels(severityLevelsFile, customRules, pluginChanges)
 	if err != nil {
 		return nil, getErrFailedToLoadSeverityLevels(severityLevelsFile, err)
 	}
 
 	return m, nil
 }
+
+func getCustomRules(customRulesFile string) (checker.CustomRules, *ReturnError) {
+	if customRulesFile == "" {
+		return nil, nil
+	}
+
+	rules, err := checker.LoadCustomRules(customRulesFile)
+	if err != nil {
+		return nil, getErrFailedToLoadCustomRules(customRulesFile, err)
+	}
+
+	return rules, nil
+}
+
+// runPlugins runs the check plugins and adds their changes to the config, a plugin fails if its ids clash with those of built-in checks or custom rules
+func runPlugins(plugins []string, diffResult *diffResult, config *checker.Config) *ReturnError {
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	var base, revision *openapi3.T
+	if diffResult.specInfoPair != nil {
+		base, revision = diffResult.specInfoPair.Base.Spec, diffResult.specInfoPair.Revision.Spec
+	}
+	request := checker.NewPluginRequest(diffResult.diffReport, diffResult.operationsSources, base, revision)
+
+	for _, plugin := range plugins {
+		changes, err := runPlugin(plugin, request, diffResult.limits)
+		if err != nil {
+			return getErrPluginFailed(plugin, err)
+		}
+		if _, err := config.WithPluginChanges(changes); err != nil {
+			return getErrPluginFailed(plugin, err)
+		}
+	}
+
+	return nil
+}
+
+// runPlugin runs a plugin until the timeout of the run, if there is one
+func runPlugin(plugin string, request *checker.PluginRequest, runLimits *limits.Limits) (checker.PluginChanges, error) {
+	ctx := context.Background()
+	if deadline, ok := runLimits.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	changes, err := checker.RunPlugin(ctx, plugin, request)
+	if err != nil {
+		if limitErr := runLimits.CheckTime("plugin " + plugin); limitErr != nil {
+			return nil, limitErr
+		}
+		return nil, err
+	}
+	return changes, nil
+}
@@ -29,12 +29,18 @@ func getChecksCmd() *cobra.Command {
 	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputChecks), string(formatters.FormatText)), "format", "f", "output format")
 	enumWithOptions(&cmd, newEnumSliceValue([]string{"info", "warn", "error"}, nil), "severity", "s", "include only checks with any of specified severities")
 	enumWithOptions(&cmd, newEnumSliceValue(getAllTags(), nil), "tags", "t", "include only checks with all specified tags")
+	cmd.PersistentFlags().String("custom-rules", "", "configuration file with custom rules to include in the list")
 
 	return &cmd
 }
 
-func runChecks(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
-	return false, outputChecks(stdout, flags, checker.GetAllRules())
+func runChecks(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+	customRules, returnErr := getCustomRules(flags.getCustomRulesFile())
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	return false, outputChecks(stdout, flags, append(checker.GetAllRules(), customRules.Rules()...))
 }
 
 func outputChecks(stdout io.Writer, flags *Flags, rules []checker.BackwardCompatibilityRule) *ReturnError {
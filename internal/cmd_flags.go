@@ -1,28 +1,61 @@
 package internal
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/checker/localizations"
 	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/load"
 )
 
 func addCommonDiffFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolP("composed", "c", false, "work in 'composed' mode, compare paths in all specs matching base and revision globs")
-	cmd.PersistentFlags().StringP("match-path", "p", "", "include only paths that match this regular expression")
-	cmd.PersistentFlags().StringP("unmatch-path", "q", "", "exclude paths that match this regular expression")
-	cmd.PersistentFlags().String("filter-extension", "", "exclude paths and operations with an OpenAPI Extension matching this regular expression")
 	cmd.PersistentFlags().String("prefix-base", "", "add this prefix to paths in base-spec before comparison")
 	cmd.PersistentFlags().String("prefix-revision", "", "add this prefix to paths in revised-spec before comparison")
 	cmd.PersistentFlags().String("strip-prefix-base", "", "strip this prefix from paths in base-spec before comparison")
 	cmd.PersistentFlags().String("strip-prefix-revision", "", "strip this prefix from paths in revised-spec before comparison")
+	addCommonCompareFlags(cmd)
+
+	addHiddenFlattenFlag(cmd)
+	addHiddenCircularDepFlag(cmd)
+}
+
+// addCommonCompareFlags adds the flags which control how specs are loaded, matched and compared
+// they are shared by the commands which compare a pair of specs and the commands which compare the revisions of a spec in git history
+func addCommonCompareFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP("match-path", "p", "", "include only paths that match this regular expression")
+	cmd.PersistentFlags().StringP("unmatch-path", "q", "", "exclude paths that match this regular expression")
+	cmd.PersistentFlags().String("filter-extension", "", "exclude paths and operations with an OpenAPI Extension matching this regular expression")
 	cmd.PersistentFlags().Bool("include-path-params", false, "include path parameter names in endpoint matching")
+	cmd.PersistentFlags().Bool("match-moved-endpoints", false, "match deleted and added endpoints by operationId or by the x-previous-path extension and compare them as moved endpoints")
 	cmd.PersistentFlags().Bool("flatten-allof", false, "merge subschemas under allOf before diff")
 	cmd.PersistentFlags().Bool("flatten-params", false, "merge common parameters at path level with operation parameters")
 	cmd.PersistentFlags().Bool("case-insensitive-headers", false, "case-insensitive header name comparison")
+	cmd.PersistentFlags().Bool("normalize-dialect", false, "map OpenAPI 3.0 and 3.1 forms of nullable schemas onto a common model before diff")
+	addRefFlags(cmd)
+	addLimitsFlag(cmd)
+}
 
-	addHiddenFlattenFlag(cmd)
-	addHiddenCircularDepFlag(cmd)
+// addRefFlags adds the flags which control how external refs are read
+// --ref-policy restricts the documents that are read through external refs, for example, in specs from untrusted sources
+// --ref-mirror and --ref-lock read remote documents from local copies, for example, to load specs without network access
+func addRefFlags(cmd *cobra.Command) {
+	addRefPolicyFlags(cmd)
+	cmd.PersistentFlags().String("ref-lock", "", "lockfile created by 'oasdiff refs lock', read remote refs from its cache only")
+}
+
+// addRefPolicyFlags adds the flags which control how external refs are read, except for --ref-lock
+func addRefPolicyFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSlice("ref-policy", nil, "restrict the external refs of the specs, one or more of: "+strings.Join(load.GetRefPolicyOptions(), ", "))
+	cmd.PersistentFlags().StringSlice("ref-mirror", nil, "read remote refs under a URL prefix from a local directory: <url-prefix>=<dir>, can be repeated")
+}
+
+// addLimitsFlag adds --limits which protects the run from specs that would exhaust its resources, like YAML alias bombs and very deep schema nesting
+func addLimitsFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSlice("limits", nil, "fail when the specs exceed resource limits, one or more of: "+strings.Join(limits.GetLimitsOptions(), ", "))
 }
 
 // addHiddenFlattenFlag adds --flatten as a hidden flag
@@ -52,15 +85,29 @@ func hideFlag(cmd *cobra.Command, flag string) {
 }
 
 func addCommonBreakingFlags(cmd *cobra.Command) {
-	enumWithOptions(cmd, newEnumValue(localizations.GetSupportedLanguages(), localizations.LangDefault), "lang", "l", "language for localized output")
+	addCommonCheckFlags(cmd)
 	cmd.PersistentFlags().String("err-ignore", "", "configuration file for ignoring errors")
 	cmd.PersistentFlags().String("warn-ignore", "", "configuration file for ignoring warnings")
+	cmd.PersistentFlags().String("baseline", "", "baseline file of accepted changes, only changes which aren't in the baseline are reported")
+	cmd.PersistentFlags().String("write-baseline", "", "write all changes to a baseline file")
+	cmd.PersistentFlags().StringSlice("traffic", nil, "recorded traffic, a HAR file or a JSON-lines access log, to annotate changes with the number of calls they affect, can be repeated")
+	cmd.PersistentFlags().Bool("drop-unused", false, "drop breaking changes which don't affect any call in the recorded traffic")
+	cmd.PersistentFlags().Bool("counterexamples", false, "attach an example request which is accepted by base and rejected by revision to breaking request changes")
+	cmd.PersistentFlags().Bool("check-semver", false, "fail when the change of info.version is smaller than the semantic versioning bump required by the changes")
+	enumWithOptions(cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputChangelog), string(formatters.FormatText)), "format", "f", "output format")
+	cmd.PersistentFlags().String("severity-levels", "", "configuration file for custom severity levels")
+	cmd.PersistentFlags().String("custom-rules", "", "configuration file with custom rules")
+	cmd.PersistentFlags().StringSlice("plugin", nil, "executable which receives the diff as JSON on stdin and returns additional changes as JSON on stdout, can be repeated")
+	cmd.PersistentFlags().StringSlice("attributes", nil, "OpenAPI Extensions to include in json or yaml output")
+}
+
+// addCommonCheckFlags adds the flags which control how changes are checked and shown
+// they are shared by breaking and changelog and by the commands which check the revisions of a spec in git history
+func addCommonCheckFlags(cmd *cobra.Command) {
+	enumWithOptions(cmd, newEnumValue(localizations.GetSupportedLanguages(), localizations.LangDefault), "lang", "l", "language for localized output")
 	cmd.PersistentFlags().VarPF(newEnumSliceValue(checker.GetOptionalRuleIds(), nil), "include-checks", "i", "optional checks")
 	hideFlag(cmd, "include-checks")
 	cmd.PersistentFlags().Uint("deprecation-days-beta", checker.DefaultBetaDeprecationDays, "min days required between deprecating a beta resource and removing it")
 	cmd.PersistentFlags().Uint("deprecation-days-stable", checker.DefaultStableDeprecationDays, "min days required between deprecating a stable resource and removing it")
 	enumWithOptions(cmd, newEnumValue(checker.GetSupportedColorValues(), "auto"), "color", "", "when to colorize textual output")
-	enumWithOptions(cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputChangelog), string(formatters.FormatText)), "format", "f", "output format")
-	cmd.PersistentFlags().String("severity-levels", "", "configuration file for custom severity levels")
-	cmd.PersistentFlags().StringSlice("attributes", nil, "OpenAPI Extensions to include in json or yaml output")
 }
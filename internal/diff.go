@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/spf13/cobra"
 	"github.com/tufin/oasdiff/diff"
 	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/limits"
 	"github.com/tufin/oasdiff/load"
 )
 
@@ -20,10 +20,11 @@ func getDiffCmd() *cobra.Command {
 		Short: "Generate a diff report",
 		Long:  "Generate a diff report between base and revision specs." + specHelp,
 		Args:  getParseArgs(),
-		RunE:  getRun(runDiff),
+		RunE:  getRun(withWatch(runDiff)),
 	}
 
 	addCommonDiffFlags(&cmd)
+	addWatchFlags(&cmd)
 	enumWithOptions(&cmd, newEnumSliceValue(diff.GetExcludeDiffOptions(), nil), "exclude-elements", "e", "elements to exclude")
 	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputDiff), string(formatters.FormatYAML)), "format", "f", "output format")
 	cmd.PersistentFlags().BoolP("fail-on-diff", "o", false, "exit with return code 1 when any change is found")
@@ -31,7 +32,7 @@ func getDiffCmd() *cobra.Command {
 	return &cmd
 }
 
-func runDiff(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
+func runDiff(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
 
 	if flags.getFormat() == string(formatters.FormatJSON) {
 		flags.addExcludeElements(diff.ExcludeEndpointsOption)
@@ -70,79 +71,114 @@ func outputDiff(stdout io.Writer, diffReport *diff.Diff, format string) *ReturnE
 
 func calcDiff(flags *Flags) (*diffResult, *ReturnError) {
 
-	loader := openapi3.NewLoader()
-	loader.IsExternalRefsAllowed = true
+	refs, err := getRefConfig(flags)
+	if err != nil {
+		return nil, err
+	}
 
 	if flags.getComposed() {
-		return composedDiff(loader, flags)
+		return composedDiff(flags, refs)
 	}
 
-	return normalDiff(loader, flags)
+	return normalDiff(flags, refs)
+}
+
+// loadSpecs loads the specs of one side of the comparison
+// in watch mode, the specs of the previous run are reused if their files didn't change
+func loadSpecs(flags *Flags, refs *refConfig, what string, source string, composed bool, load func(loader load.Loader) ([]*load.SpecInfo, error)) ([]*load.SpecInfo, error) {
+	if flags.watch != nil {
+		return flags.watch.loadSpecs(what, source, composed, refs, load)
+	}
+	return load(newLoader(refs))
 }
 
 type diffResult struct {
-	diffReport        *diff.Diff
-	operationsSources *diff.OperationsSourcesMap
-	specInfoPair      *load.SpecInfoPair
+	diffReport         *diff.Diff
+	operationsSources  *diff.OperationsSourcesMap
+	specInfoPair       *load.SpecInfoPair
+	baseSourceMaps     load.SourceMaps
+	revisionSourceMaps load.SourceMaps
+	limits             *limits.Limits // the limits of the run, they also apply to the steps after the diff, like running plugins
 }
 
-func newDiffResult(d *diff.Diff, o *diff.OperationsSourcesMap, s *load.SpecInfoPair) *diffResult {
+func newDiffResult(d *diff.Diff, o *diff.OperationsSourcesMap, s *load.SpecInfoPair, baseSourceMaps, revisionSourceMaps load.SourceMaps, runLimits *limits.Limits) *diffResult {
 	return &diffResult{
-		diffReport:        d,
-		operationsSources: o,
-		specInfoPair:      s,
+		diffReport:         d,
+		operationsSources:  o,
+		specInfoPair:       s,
+		baseSourceMaps:     baseSourceMaps,
+		revisionSourceMaps: revisionSourceMaps,
+		limits:             runLimits,
 	}
 }
 
-func normalDiff(loader load.Loader, flags *Flags) (*diffResult, *ReturnError) {
+func normalDiff(flags *Flags, refs *refConfig) (*diffResult, *ReturnError) {
 
 	flattenAllOf := load.GetOption(load.WithFlattenAllOf(), flags.getFlattenAllOf())
 	flattenParams := load.GetOption(load.WithFlattenParams(), flags.getFlattenParams())
 	lowerHeaderNames := load.GetOption(load.WithLowercaseHeaders(), flags.getCaseInsensitiveHeaders())
+	normalizeDialect := load.GetOption(load.WithNormalizeDialect(), flags.getNormalizeDialect())
+
+	loadSpec := func(source *load.Source) func(loader load.Loader) ([]*load.SpecInfo, error) {
+		return func(loader load.Loader) ([]*load.SpecInfo, error) {
+			specInfo, err := load.NewSpecInfo(loader, source, flattenAllOf, flattenParams, lowerHeaderNames, normalizeDialect)
+			if err != nil {
+				return nil, err
+			}
+			return []*load.SpecInfo{specInfo}, nil
+		}
+	}
 
-	s1, err := load.NewSpecInfo(loader, flags.getBase(), flattenAllOf, flattenParams, lowerHeaderNames)
+	s1, err := loadSpecs(flags, refs, "base", flags.getBase().Path, false, loadSpec(flags.getBase()))
 	if err != nil {
 		return nil, getErrFailedToLoadSpec("base", flags.getBase(), err)
 	}
 
-	s2, err := load.NewSpecInfo(loader, flags.getRevision(), flattenAllOf, flattenParams, lowerHeaderNames)
+	s2, err := loadSpecs(flags, refs, "revision", flags.getRevision().Path, false, loadSpec(flags.getRevision()))
 	if err != nil {
 		return nil, getErrFailedToLoadSpec("revision", flags.getRevision(), err)
 	}
 
 	if flags.getBase().IsStdin() && flags.getRevision().IsStdin() {
 		// io.ReadAll can only read stdin once, so in this edge case, we copy base into revision
-		s2.Spec = s1.Spec
+		s2[0].Spec = s1[0].Spec
 	}
 
-	diffReport, operationsSources, err := diff.GetWithOperationsSourcesMap(flags.toConfig(), s1, s2)
+	diffReport, operationsSources, err := diff.GetWithOperationsSourcesMap(flags.toConfig().WithLimits(refs.limits), s1[0], s2[0])
 	if err != nil {
 		return nil, getErrDiffFailed(err)
 	}
 
-	return newDiffResult(diffReport, operationsSources, load.NewSpecInfoPair(s1, s2)), nil
+	return newDiffResult(diffReport, operationsSources, load.NewSpecInfoPair(s1[0], s2[0]), load.NewSourceMaps(s1...), load.NewSourceMaps(s2...), refs.limits), nil
 }
 
-func composedDiff(loader load.Loader, flags *Flags) (*diffResult, *ReturnError) {
+func composedDiff(flags *Flags, refs *refConfig) (*diffResult, *ReturnError) {
 
 	flattenAllOf := load.GetOption(load.WithFlattenAllOf(), flags.getFlattenAllOf())
 	flattenParams := load.GetOption(load.WithFlattenParams(), flags.getFlattenParams())
 	lowerHeaderNames := load.GetOption(load.WithLowercaseHeaders(), flags.getCaseInsensitiveHeaders())
+	normalizeDialect := load.GetOption(load.WithNormalizeDialect(), flags.getNormalizeDialect())
+
+	loadGlob := func(glob string) func(loader load.Loader) ([]*load.SpecInfo, error) {
+		return func(loader load.Loader) ([]*load.SpecInfo, error) {
+			return load.NewSpecInfoFromGlob(loader, glob, flattenAllOf, flattenParams, lowerHeaderNames, normalizeDialect)
+		}
+	}
 
-	s1, err := load.NewSpecInfoFromGlob(loader, flags.getBase().Path, flattenAllOf, flattenParams, lowerHeaderNames)
+	s1, err := loadSpecs(flags, refs, "base", flags.getBase().Path, true, loadGlob(flags.getBase().Path))
 	if err != nil {
 		return nil, getErrFailedToLoadSpecs("base", flags.getBase().Path, err)
 	}
 
-	s2, err := load.NewSpecInfoFromGlob(loader, flags.getRevision().Path, flattenAllOf, flattenParams, lowerHeaderNames)
+	s2, err := loadSpecs(flags, refs, "revision", flags.getRevision().Path, true, loadGlob(flags.getRevision().Path))
 	if err != nil {
 		return nil, getErrFailedToLoadSpecs("revision", flags.getRevision().Path, err)
 	}
 
-	diffReport, operationsSources, err := diff.GetPathsDiff(flags.toConfig(), s1, s2)
+	diffReport, operationsSources, err := diff.GetPathsDiff(flags.toConfig().WithLimits(refs.limits), s1, s2)
 	if err != nil {
 		return nil, getErrDiffFailed(err)
 	}
 
-	return newDiffResult(diffReport, operationsSources, nil), nil
+	return newDiffResult(diffReport, operationsSources, nil, load.NewSourceMaps(s1...), load.NewSourceMaps(s2...), refs.limits), nil
 }
@@ -1,8 +1,13 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/limits"
 	"github.com/tufin/oasdiff/load"
 )
 
@@ -13,6 +18,9 @@ type ReturnError struct {
 
 const generalExecutionErr = 100
 
+// limitExceededErr is returned when a spec exceeds the limits of the run, regardless of the step that failed, like loading or comparing the specs
+const limitExceededErr = 133
+
 func getErrInvalidFlags(err error) *ReturnError {
 	return getError(
 		err,
@@ -55,6 +63,20 @@ func getErrFailedToLoadSeverityLevels(source string, err error) *ReturnError {
 	)
 }
 
+func getErrFailedToLoadCustomRules(source string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to load custom rules from %s: %w", source, err),
+		108,
+	)
+}
+
+func getErrPluginFailed(plugin string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("plugin %s failed: %w", plugin, err),
+		109,
+	)
+}
+
 func getErrConfigFileProblem(err error) *ReturnError {
 	return getError(
 		fmt.Errorf("failed to load config file: %w", err),
@@ -83,6 +105,91 @@ func getErrCantProcessIgnoreFile(what string, err error) *ReturnError {
 	)
 }
 
+func getErrExpiredIgnoreEntries(what string, ignoreFile string, entries checker.IgnoreEntries) *ReturnError {
+	descriptions := make([]string, len(entries))
+	for i, entry := range entries {
+		descriptions[i] = fmt.Sprintf("%s expired on %s, reason: %s, owner: %s", entry, entry.Expires.Format(time.DateOnly), entry.Reason, entry.Owner)
+	}
+
+	return getError(
+		fmt.Errorf("%s ignore file %s has expired entries:\n%s", what, ignoreFile, strings.Join(descriptions, "\n")),
+		122,
+	)
+}
+
+func getErrFailedToWriteBaseline(file string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to write baseline %s: %w", file, err),
+		123,
+	)
+}
+
+func getErrFailedToLoadBaseline(file string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to load baseline %s: %w", file, err),
+		124,
+	)
+}
+
+func getErrInvalidSemver(err error) *ReturnError {
+	return getError(
+		fmt.Errorf("can't check semantic versioning: %w", err),
+		125,
+	)
+}
+
+func getErrSemverBumpTooSmall(semverCheck *checker.SemverCheck) *ReturnError {
+	return getError(
+		fmt.Errorf("the changes require a %s version bump but info.version changed from %s to %s (%s), recommended version: %s", semverCheck.RequiredBump, semverCheck.BaseVersion, semverCheck.RevisionVersion, semverCheck.ActualBump, semverCheck.RecommendedVersion),
+		126,
+	)
+}
+
+func getErrFailedToLoadTraffic(file string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to load recorded traffic from %s: %w", file, err),
+		127,
+	)
+}
+
+func getErrServerFailed(err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to run server: %w", err),
+		128,
+	)
+}
+
+func getErrLanguageServerFailed(err error) *ReturnError {
+	return getError(
+		fmt.Errorf("language server failed: %w", err),
+		129,
+	)
+}
+
+func getErrHistoryFailed(err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to walk the git history: %w", err),
+		130,
+	)
+}
+
+func getErrBisectFailed(err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to bisect the git history: %w", err),
+		131,
+	)
+}
+
+func getErrRefsLockFailed(err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to lock refs: %w", err),
+		132,
+	)
+}
+
 func getError(err error, code int) *ReturnError {
+	if errors.Is(err, limits.ErrLimitExceeded) {
+		code = limitExceededErr
+	}
 	return &ReturnError{err, code}
 }
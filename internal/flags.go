@@ -1,8 +1,11 @@
 package internal
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/limits"
 	"github.com/tufin/oasdiff/load"
 )
 
@@ -10,6 +13,7 @@ type Flags struct {
 	v        *viper.Viper
 	base     *load.Source
 	revision *load.Source
+	watch    *watchState // set in watch mode, to reuse the specs of the previous run
 }
 
 func NewFlags() *Flags {
@@ -28,6 +32,7 @@ func (flags *Flags) toConfig() *diff.Config {
 	config.PathStripPrefixBase = flags.v.GetString("strip-prefix-base")
 	config.PathStripPrefixRevision = flags.v.GetString("strip-prefix-revision")
 	config.IncludePathParams = flags.v.GetBool("include-path-params")
+	config.MatchMovedEndpoints = flags.v.GetBool("match-moved-endpoints")
 
 	return config
 }
@@ -64,6 +69,10 @@ func (flags *Flags) getCaseInsensitiveHeaders() bool {
 	return flags.v.GetBool("case-insensitive-headers")
 }
 
+func (flags *Flags) getNormalizeDialect() bool {
+	return flags.v.GetBool("normalize-dialect")
+}
+
 func (flags *Flags) getIncludeChecks() []string {
 	return fixViperStringSlice(flags.v.GetStringSlice("include-checks"))
 }
@@ -92,6 +101,46 @@ func (flags *Flags) getErrIgnoreFile() string {
 	return flags.v.GetString("err-ignore")
 }
 
+func (flags *Flags) getBaselineFile() string {
+	return flags.v.GetString("baseline")
+}
+
+func (flags *Flags) getWriteBaselineFile() string {
+	return flags.v.GetString("write-baseline")
+}
+
+func (flags *Flags) getTrafficFiles() []string {
+	return fixViperStringSlice(flags.v.GetStringSlice("traffic"))
+}
+
+func (flags *Flags) getDropUnused() bool {
+	return flags.v.GetBool("drop-unused")
+}
+
+func (flags *Flags) getCounterexamples() bool {
+	return flags.v.GetBool("counterexamples")
+}
+
+func (flags *Flags) getAddr() string {
+	return flags.v.GetString("addr")
+}
+
+func (flags *Flags) getMaxRequestSize() int64 {
+	return flags.v.GetInt64("max-request-size")
+}
+
+func (flags *Flags) getTimeout() time.Duration {
+	return flags.v.GetDuration("timeout")
+}
+
+func (flags *Flags) getMaxConcurrent() int {
+	return flags.v.GetInt("max-concurrent")
+}
+
+func (flags *Flags) getCheckSemver() bool {
+	return flags.v.GetBool("check-semver")
+}
+
 func (flags *Flags) getFormat() string {
 	return flags.v.GetString("format")
 }
@@ -104,6 +153,38 @@ func (flags *Flags) getLevel() string {
 	return flags.v.GetString("level")
 }
 
+func (flags *Flags) getBaseSource() string {
+	return flags.v.GetString("base")
+}
+
+func (flags *Flags) getBaseRev() string {
+	return flags.v.GetString("base-rev")
+}
+
+func (flags *Flags) getLint() bool {
+	return flags.v.GetBool("lint")
+}
+
+func (flags *Flags) getRevRange() string {
+	return flags.v.GetString("rev-range")
+}
+
+func (flags *Flags) getGood() string {
+	return flags.v.GetString("good")
+}
+
+func (flags *Flags) getBad() string {
+	return flags.v.GetString("bad")
+}
+
+func (flags *Flags) getWatch() bool {
+	return flags.v.GetBool("watch")
+}
+
+func (flags *Flags) getWatchInterval() time.Duration {
+	return flags.v.GetDuration("watch-interval")
+}
+
 func (flags *Flags) getFailOnDiff() bool {
 	return flags.v.GetBool("fail-on-diff")
 }
@@ -112,6 +193,14 @@ func (flags *Flags) getSeverityLevelsFile() string {
 	return flags.v.GetString("severity-levels")
 }
 
+func (flags *Flags) getCustomRulesFile() string {
+	return flags.v.GetString("custom-rules")
+}
+
+func (flags *Flags) getPlugins() []string {
+	return fixViperStringSlice(flags.v.GetStringSlice("plugin"))
+}
+
 func (flags *Flags) getExcludeElements() []string {
 	return fixViperStringSlice(flags.v.GetStringSlice("exclude-elements"))
 }
@@ -135,3 +224,31 @@ func (flags *Flags) getSeverity() []string {
 func (flags *Flags) getTags() []string {
 	return fixViperStringSlice(flags.v.GetStringSlice("tags"))
 }
+
+func (flags *Flags) getChecks() []string {
+	return fixViperStringSlice(flags.v.GetStringSlice("checks"))
+}
+
+func (flags *Flags) getRefPolicy() (*load.RefPolicy, error) {
+	return load.ParseRefPolicy(fixViperStringSlice(flags.v.GetStringSlice("ref-policy")))
+}
+
+func (flags *Flags) getRefMirrors() ([]*load.RefMirror, error) {
+	return load.ParseRefMirrors(fixViperStringSlice(flags.v.GetStringSlice("ref-mirror")))
+}
+
+func (flags *Flags) getLimits() (*limits.Limits, error) {
+	return limits.ParseLimits(fixViperStringSlice(flags.v.GetStringSlice("limits")))
+}
+
+func (flags *Flags) getRefLock() string {
+	return flags.v.GetString("ref-lock")
+}
+
+func (flags *Flags) getLockfile() string {
+	return flags.v.GetString("lockfile")
+}
+
+func (flags *Flags) getCacheDir() string {
+	return flags.v.GetString("cache-dir")
+}
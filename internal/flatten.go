@@ -25,16 +25,21 @@ Spec can be a path to a file, a URL or '-' to read standard input.
 	}
 
 	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputFlatten), string(formatters.FormatJSON)), "format", "f", "output format")
+	addRefFlags(&cmd)
+	addLimitsFlag(&cmd)
 	addHiddenCircularDepFlag(&cmd)
 
 	return &cmd
 }
 
-func runFlatten(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
+func runFlatten(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
 
-	loader := openapi3.NewLoader()
-	loader.IsExternalRefsAllowed = true
-	spec, err := load.NewSpecInfo(loader, flags.getBase(), load.WithFlattenAllOf())
+	refs, returnErr := getRefConfig(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	spec, err := load.NewSpecInfo(newLoader(refs), flags.getBase(), load.WithFlattenAllOf())
 	if err != nil {
 		return false, getErrFailedToLoadSpec("original", flags.getBase(), err)
 	}
@@ -9,13 +9,13 @@ import (
 )
 
 const specHelp = `
-Base and revision can be a path to a file, a URL, or '-' to read standard input.
+Base and revision can be a path to a file, a URL, a file in a git revision (git:<rev>:<path>), or '-' to read standard input.
 In 'composed' mode, base and revision can be a glob and oasdiff will compare matching endpoints between the two sets of files.`
 
 func getParseArgs() cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
 		if len(args) < 2 {
-			return errors.New("please specify base and revision arguments as a path to a file, a glob (in composed mode), a URL, or '-' to read standard input")
+			return errors.New("please specify base and revision arguments as a path to a file, a glob (in composed mode), a URL, a git revision (git:<rev>:<path>), or '-' to read standard input")
 		}
 		if len(args) > 2 {
 			return errors.New("invalid arguments after base and revision")
@@ -31,7 +31,7 @@ func getParseArgs() cobra.PositionalArgs {
 	}
 }
 
-type runner func(flags *Flags, stdout io.Writer) (bool, *ReturnError)
+type runner func(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError)
 
 func getRun(runner runner) cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
@@ -54,7 +54,7 @@ func getRun(runner runner) cobra.PositionalArgs {
 		// by now flags have been parsed successfully so we don't need to show usage on any errors
 		cmd.Root().SilenceUsage = true
 
-		failEmpty, err := runner(flags, cmd.OutOrStdout())
+		failEmpty, err := runner(flags, cmd.OutOrStdout(), cmd.ErrOrStderr())
 		if err != nil {
 			setReturnValue(cmd, err.Code)
 			return err
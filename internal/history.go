@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/spf13/cobra"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/history"
+	"github.com/tufin/oasdiff/load"
+)
+
+const historyCmd = "history"
+
+func getHistoryCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "history spec [flags]",
+		Short: "Display the changes across the git history of a spec",
+		Long: `Display a timeline of the changes introduced by each commit of the local git repository which modified the spec.
+Each revision of the spec is compared with the previous one, and the changes are annotated with the commit hash, author and date.
+Spec is a path to a file in the repository, or a glob in 'composed' mode.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: getRun(runHistory),
+	}
+
+	addCommonGitHistoryFlags(&cmd)
+	cmd.PersistentFlags().String("rev-range", "", "walk only the commits in this revision range, for example: v1.0..main (default: all commits up to HEAD)")
+	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), LevelInfo), "level", "", "output changes with this level or higher")
+	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputHistory), string(formatters.FormatText)), "format", "f", "output format")
+
+	return &cmd
+}
+
+// addCommonGitHistoryFlags adds the flags of the commands which compare the revisions of a spec in the local git repository
+func addCommonGitHistoryFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolP("composed", "c", false, "work in 'composed' mode, compare paths in all specs matching the glob")
+	addCommonCompareFlags(cmd)
+	enumWithOptions(cmd, newEnumSliceValue(checker.GetAllRuleIds(), nil), "checks", "k", "consider only changes of these checks (default: all)")
+	addCommonCheckFlags(cmd)
+}
+
+func runHistory(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+
+	level, err := checker.NewLevel(flags.getLevel())
+	if err != nil {
+		return false, getErrInvalidFlags(fmt.Errorf("invalid level value: %q", flags.getLevel()))
+	}
+
+	config, colorMode, returnErr := getGitHistoryConfig(historyCmd, flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+	config.Level = level
+	config.RevRange = flags.getRevRange()
+
+	h, skipped, err := history.Get(config, flags.getBase().Path)
+	if err != nil {
+		return false, getErrHistoryFailed(err)
+	}
+
+	for _, s := range skipped {
+		_, _ = fmt.Fprintf(stderr, "warning: skipped commit %s: %v\n", s.Commit.ShortHash(), s.Err)
+	}
+
+	return false, outputHistory(historyCmd, flags, stdout, h, colorMode)
+}
+
+func outputHistory(cmdName string, flags *Flags, stdout io.Writer, h history.History, colorMode checker.ColorMode) *ReturnError {
+
+	// formatter lookup
+	formatter, err := formatters.Lookup(flags.getFormat(), formatters.FormatterOpts{
+		Language: flags.getLang(),
+	})
+	if err != nil {
+		return getErrUnsupportedFormat(flags.getFormat(), cmdName)
+	}
+
+	// render
+	bytes, err := formatter.RenderHistory(h, formatters.RenderOpts{ColorMode: colorMode})
+	if err != nil {
+		return getErrFailedPrint(cmdName+" "+flags.getFormat(), err)
+	}
+
+	// print output
+	_, _ = fmt.Fprintf(stdout, "%s\n", bytes)
+
+	return nil
+}
+
+// getGitHistoryConfig returns the configuration of the commands which compare the revisions of a spec in the local git repository
+func getGitHistoryConfig(cmdName string, flags *Flags) (*history.Config, checker.ColorMode, *ReturnError) {
+	if !flags.getBase().IsFile() {
+		return nil, checker.ColorInvalid, getErrInvalidFlags(fmt.Errorf("%s requires a path to a spec in the local git repository, got %s", cmdName, flags.getBase().Out()))
+	}
+
+	colorMode, err := checker.NewColorMode(flags.getColor())
+	if err != nil {
+		return nil, checker.ColorInvalid, getErrInvalidColorMode(err)
+	}
+
+	refs, returnErr := getRefConfig(flags)
+	if returnErr != nil {
+		return nil, checker.ColorInvalid, returnErr
+	}
+
+	return &history.Config{
+		Diff:     flags.toConfig().WithLimits(refs.limits),
+		Checker:  checker.NewConfig(checker.GetAllChecks()).WithOptionalChecks(flags.getIncludeChecks()).WithDeprecation(flags.getDeprecationDaysBeta(), flags.getDeprecationDaysStable()),
+		Level:    checker.INFO,
+		Checks:   flags.getChecks(),
+		Composed: flags.getComposed(),
+		Options: []load.Option{
+			load.GetOption(load.WithFlattenAllOf(), flags.getFlattenAllOf()),
+			load.GetOption(load.WithFlattenParams(), flags.getFlattenParams()),
+			load.GetOption(load.WithLowercaseHeaders(), flags.getCaseInsensitiveHeaders()),
+			load.GetOption(load.WithNormalizeDialect(), flags.getNormalizeDialect()),
+		},
+		NewLoader: func() *openapi3.Loader { return newLoader(refs) },
+	}, colorMode, nil
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/lint"
+	"github.com/tufin/oasdiff/load"
+)
+
+const lintCmd = "lint"
+
+func getLintCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "lint spec [flags]",
+		Short: "Lint a spec",
+		Long: `Display lint errors in the given OpenAPI spec.
+Spec can be a path to a file, a URL, a file in a git revision (git:<rev>:<path>), or '-' to read standard input.
+With --base, only errors in endpoints that were added or modified since the base spec are reported.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: getRun(runLint),
+	}
+
+	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputLint), string(formatters.FormatText)), "format", "f", "output format")
+	enumWithOptions(&cmd, newEnumValue(GetBreakingLevels(), ""), "fail-on", "o", "exit with return code 1 when output includes errors with this level or higher")
+	enumWithOptions(&cmd, newEnumSliceValue(lint.GetCheckIds(), nil), "checks", "k", "run only these lint checks (default: all)")
+	cmd.PersistentFlags().String("base", "", "base spec; only report errors in endpoints that were added or modified since the base")
+	addRefFlags(&cmd)
+	addLimitsFlag(&cmd)
+
+	return &cmd
+}
+
+func runLint(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+
+	refs, returnErr := getRefConfig(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	loader := newLoader(refs)
+	spec, err := load.NewSpecInfo(loader, flags.getBase())
+	if err != nil {
+		return false, getErrFailedToLoadSpec("lint", flags.getBase(), err)
+	}
+
+	config := lint.DefaultConfig()
+	if ids := flags.getChecks(); len(ids) > 0 {
+		if config, err = lint.NewConfigFromIds(ids); err != nil {
+			return false, getErrInvalidFlags(err)
+		}
+	}
+
+	errs := lint.Run(config, spec.Url, spec)
+
+	if base := flags.getBaseSource(); base != "" {
+		var returnErr *ReturnError
+		if errs, returnErr = filterChangedEndpoints(loader, load.NewSource(base), spec, errs); returnErr != nil {
+			return false, returnErr
+		}
+	}
+
+	if returnErr := outputLint(stdout, errs, flags.getFormat()); returnErr != nil {
+		return false, returnErr
+	}
+
+	switch flags.getFailOn() {
+	case LevelErr:
+		return errs.HasLevelOrHigher(lint.LEVEL_ERROR), nil
+	case LevelWarn:
+		return errs.HasLevelOrHigher(lint.LEVEL_WARN), nil
+	}
+
+	return false, nil
+}
+
+// filterChangedEndpoints keeps only the errors in endpoints that were added or modified since the base spec
+func filterChangedEndpoints(loader load.Loader, baseSource *load.Source, spec *load.SpecInfo, errs lint.Errors) (lint.Errors, *ReturnError) {
+	base, err := load.NewSpecInfo(loader, baseSource)
+	if err != nil {
+		return nil, getErrFailedToLoadSpec("base", baseSource, err)
+	}
+
+	diffReport, err := diff.Get(diff.NewConfig(), base.Spec, spec.Spec)
+	if err != nil {
+		return nil, getErrDiffFailed(err)
+	}
+
+	if diffReport == nil {
+		return lint.Errors{}, nil
+	}
+
+	return errs.FilterEndpoints(lint.GetChangedEndpoints(diffReport.EndpointsDiff)), nil
+}
+
+func outputLint(stdout io.Writer, errs lint.Errors, format string) *ReturnError {
+	// formatter lookup
+	formatter, err := formatters.Lookup(format, formatters.DefaultFormatterOpts())
+	if err != nil {
+		return getErrUnsupportedFormat(format, lintCmd)
+	}
+
+	// render
+	bytes, err := formatter.RenderLint(errs, formatters.NewRenderOpts())
+	if err != nil {
+		return getErrFailedPrint("lint "+format, err)
+	}
+
+	// print output
+	_, _ = fmt.Fprintf(stdout, "%s\n", bytes)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/spf13/cobra"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/checker/localizations"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/lsp"
+)
+
+func getLSPCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:               "lsp [flags]",
+		Short:             "Run a language server",
+		Long:              "Run a Language Server Protocol server over stdio, which compares the open specs with a baseline as they are edited, and shows the changes as diagnostics in the editor.",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions, // see https://github.com/spf13/cobra/issues/1969
+		RunE:              getRun(runLSP),
+	}
+
+	cmd.PersistentFlags().String("base", "", "baseline spec of all documents: a path, a URL, or a git revision like git:main:openapi.yaml")
+	cmd.PersistentFlags().String("base-rev", "", "git revision to compare each document with its own version at, for example: main")
+	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), LevelWarn), "level", "", "show changes with this level or higher")
+	enumWithOptions(&cmd, newEnumValue(localizations.GetSupportedLanguages(), localizations.LangDefault), "lang", "l", "language for localized output")
+	cmd.PersistentFlags().String("err-ignore", "", "configuration file for ignoring errors, code actions add entries to it")
+	cmd.PersistentFlags().String("warn-ignore", "", "configuration file for ignoring warnings, code actions add entries to it")
+	cmd.PersistentFlags().VarP(newEnumSliceValue(checker.GetOptionalRuleIds(), nil), "include-checks", "i", "optional checks")
+	cmd.PersistentFlags().Uint("deprecation-days-beta", checker.DefaultBetaDeprecationDays, "min days required between deprecating a beta resource and removing it")
+	cmd.PersistentFlags().Uint("deprecation-days-stable", checker.DefaultStableDeprecationDays, "min days required between deprecating a stable resource and removing it")
+	cmd.PersistentFlags().Bool("lint", true, "show lint errors along with the changes")
+	addRefFlags(&cmd)
+	addLimitsFlag(&cmd)
+
+	return &cmd
+}
+
+func runLSP(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+
+	if (flags.getBaseSource() == "") == (flags.getBaseRev() == "") {
+		return false, getErrInvalidFlags(errors.New("exactly one of --base and --base-rev is required"))
+	}
+
+	level, err := checker.NewLevel(flags.getLevel())
+	if err != nil {
+		return false, getErrInvalidFlags(err)
+	}
+
+	refs, returnErr := getRefConfig(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	config := &lsp.Config{
+		Base:                  flags.getBaseSource(),
+		BaseRev:               flags.getBaseRev(),
+		Level:                 level,
+		Lang:                  flags.getLang(),
+		ErrIgnoreFile:         flags.getErrIgnoreFile(),
+		WarnIgnoreFile:        flags.getWarnIgnoreFile(),
+		IncludeChecks:         flags.getIncludeChecks(),
+		DeprecationDaysBeta:   flags.getDeprecationDaysBeta(),
+		DeprecationDaysStable: flags.getDeprecationDaysStable(),
+		Lint:                  flags.getLint(),
+		// the timeout applies to each analysis, rather than to the whole session
+		Limits: refs.limits,
+		NewLoader: func(analysisLimits *limits.Limits) *openapi3.Loader {
+			analysisRefs := *refs
+			analysisRefs.limits = analysisLimits
+			return newUncachedLoader(&analysisRefs)
+		},
+	}
+
+	// stdout carries the protocol, so nothing else may be written to it
+	if err := lsp.New(config).Run(os.Stdin, stdout); err != nil {
+		return false, getErrLanguageServerFailed(err)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/spf13/cobra"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/load"
+)
+
+const refsLockCmd = "refs lock"
+
+// refConfig configures how the specs and their external refs are read
+type refConfig struct {
+	policy  *load.RefPolicy
+	mirrors []*load.RefMirror
+	lock    *load.RefLock
+	limits  *limits.Limits // the limits of the run, they also apply to comparing the specs
+}
+
+func getRefConfig(flags *Flags) (*refConfig, *ReturnError) {
+	policy, err := flags.getRefPolicy()
+	if err != nil {
+		return nil, getErrInvalidFlags(err)
+	}
+
+	mirrors, err := flags.getRefMirrors()
+	if err != nil {
+		return nil, getErrInvalidFlags(err)
+	}
+
+	var lock *load.RefLock
+	if file := flags.getRefLock(); file != "" {
+		if lock, err = load.LoadRefLock(file); err != nil {
+			return nil, getErrInvalidFlags(fmt.Errorf("failed to load lockfile: %w", err))
+		}
+	}
+
+	runLimits, err := flags.getLimits()
+	if err != nil {
+		return nil, getErrInvalidFlags(err)
+	}
+	// the timeout starts with the run, before the specs are loaded
+	runLimits.Start()
+
+	return &refConfig{
+		policy:  policy,
+		mirrors: mirrors,
+		lock:    lock,
+		limits:  runLimits,
+	}, nil
+}
+
+// newLoader returns a loader which resolves external refs as configured by the ref flags, and enforces the limits
+func newLoader(refs *refConfig) *openapi3.Loader {
+	return withRefConfig(openapi3.NewLoader(), refs)
+}
+
+// newUncachedLoader is like newLoader but reads the documents again rather than from the cache of the process, for long-running commands like lsp
+func newUncachedLoader(refs *refConfig) *openapi3.Loader {
+	loader := openapi3.NewLoader()
+	load.WithoutCache(loader)
+	return withRefConfig(loader, refs)
+}
+
+func withRefConfig(loader *openapi3.Loader, refs *refConfig) *openapi3.Loader {
+	loader.IsExternalRefsAllowed = true
+	load.WithRefPolicy(loader, refs.policy)
+	load.WithRefMirrors(loader, refs.mirrors)
+	load.WithRefLock(loader, refs.lock)
+	load.WithLimits(loader, refs.limits)
+	return loader
+}
+
+func getRefsCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "refs",
+		Short: "Manage the remote refs of specs",
+	}
+
+	cmd.AddCommand(getRefsLockCmd())
+
+	return &cmd
+}
+
+func getRefsLockCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "lock spec [spec] [flags]",
+		Short: "Download the remote refs of specs into a local cache",
+		Long: `Download every remote document that is reachable through the refs of the specs into a local cache, and write a lockfile with their hashes.
+Specs which are loaded with --ref-lock read remote refs from the cache only, and fail if a cached document doesn't match its hash.
+Spec can be a path to a file, a URL, a file in a git revision (git:<rev>:<path>), or '-' to read standard input.
+In 'composed' mode, spec can be a glob.
+`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: getRun(runRefsLock),
+	}
+
+	cmd.PersistentFlags().String("lockfile", "oasdiff-refs.lock", "the lockfile to write")
+	cmd.PersistentFlags().String("cache-dir", ".oasdiff-refs", "the directory to download the remote documents into")
+	cmd.PersistentFlags().BoolP("composed", "c", false, "work in 'composed' mode, lock the refs of all specs matching the globs")
+	addRefPolicyFlags(&cmd)
+	addLimitsFlag(&cmd)
+
+	return &cmd
+}
+
+func runRefsLock(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+
+	refs, returnErr := getRefConfig(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	loader := newLoader(refs)
+	recorder := load.NewRefRecorder()
+	recorder.Track(loader)
+
+	for _, source := range []*load.Source{flags.getBase(), flags.getRevision()} {
+		if source == nil {
+			continue
+		}
+
+		if flags.getComposed() {
+			if _, err := load.NewSpecInfoFromGlob(loader, source.Path); err != nil {
+				return false, getErrFailedToLoadSpecs("the", source.Path, err)
+			}
+			continue
+		}
+
+		if _, err := load.NewSpecInfo(loader, source); err != nil {
+			return false, getErrFailedToLoadSpec("the", source, err)
+		}
+	}
+
+	lock, err := recorder.Lock(flags.getLockfile(), flags.getCacheDir())
+	if err != nil {
+		return false, getErrRefsLockFailed(err)
+	}
+
+	if err := lock.SaveRefLock(flags.getLockfile()); err != nil {
+		return false, getErrRefsLockFailed(err)
+	}
+
+	_, _ = fmt.Fprintf(stdout, "locked %d remote documents in %s\n", len(lock.Documents), flags.getLockfile())
+
+	return false, nil
+}
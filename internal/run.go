@@ -27,7 +27,13 @@ func Run(args []string, stdout io.Writer, stderr io.Writer) int {
 		getChangelogCmd(),
 		getFlattenCmd(),
 		getChecksCmd(),
+		getLintCmd(),
 		getQRCodeCmd(),
+		getServeCmd(),
+		getLSPCmd(),
+		getHistoryCmd(),
+		getBisectCmd(),
+		getRefsCmd(),
 	)
 
 	return run(rootCmd)
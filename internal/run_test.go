@@ -12,6 +12,7 @@ import (
 	"github.com/tufin/oasdiff/checker"
 	"github.com/tufin/oasdiff/formatters"
 	"github.com/tufin/oasdiff/internal"
+	"github.com/tufin/oasdiff/lint"
 	"gopkg.in/yaml.v3"
 )
 
@@ -133,6 +134,110 @@ func Test_BreakingChangesText(t *testing.T) {
 	require.Error(t, yaml.Unmarshal(stdout.Bytes(), &bc))
 }
 
+func Test_BreakingChangesSwagger2(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/swagger2/petstore-v2.yaml ../data/swagger2/petstore-v3.yaml --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 1)
+	require.Equal(t, "request-parameter-became-required", bc[0].Id)
+}
+
+func Test_RefPolicy(t *testing.T) {
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/ref-policy/spec/openapi.yaml ../data/ref-policy/spec/openapi.yaml --ref-policy spec-dir,allow-dir=../data/ref-policy/shared"), io.Discard, io.Discard))
+}
+
+func Test_RefPolicyViolation(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 102, internal.Run(cmdToArgs("oasdiff breaking ../data/ref-policy/spec/openapi.yaml ../data/ref-policy/spec/openapi.yaml --ref-policy spec-dir"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), `error resolving reference "../shared/pet.yaml#/Pet": ref policy violation`)
+}
+
+func Test_RefPolicyInvalid(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff diff ../data/ref-policy/spec/openapi.yaml ../data/ref-policy/spec/openapi.yaml --ref-policy no-such-option"), io.Discard, io.Discard))
+}
+
+func Test_FlattenRefPolicy(t *testing.T) {
+	require.Equal(t, 102, internal.Run(cmdToArgs("oasdiff flatten ../data/ref-policy/spec/openapi.yaml --ref-policy max-documents=1"), io.Discard, io.Discard))
+}
+
+const refMirror = "https://schemas.example.com/shared/=../data/ref-mirror/schemas"
+
+func Test_RefMirror(t *testing.T) {
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/ref-mirror/openapi.yaml ../data/ref-mirror/openapi.yaml --ref-mirror "+refMirror), io.Discard, io.Discard))
+}
+
+func Test_RefMirrorInvalid(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff breaking ../data/ref-mirror/openapi.yaml ../data/ref-mirror/openapi.yaml --ref-mirror ../data/ref-mirror/schemas"), io.Discard, io.Discard))
+}
+
+func Test_RefsLock(t *testing.T) {
+	dir := t.TempDir()
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff refs lock ../data/ref-mirror/openapi.yaml --ref-mirror "+refMirror+" --lockfile "+dir+"/oasdiff-refs.lock --cache-dir "+dir+"/cache"), &stdout, io.Discard))
+	require.Equal(t, "locked 2 remote documents in "+dir+"/oasdiff-refs.lock\n", stdout.String())
+
+	// the lockfile replaces the mirror
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/ref-mirror/openapi.yaml ../data/ref-mirror/openapi.yaml --ref-lock "+dir+"/oasdiff-refs.lock"), io.Discard, io.Discard))
+
+	// a modified copy in the cache fails loading
+	require.NoError(t, os.WriteFile(dir+"/cache/schemas.example.com/shared/tag.yaml", []byte("Tag:\n  type: integer\n"), 0644))
+	var stderr bytes.Buffer
+	require.Equal(t, 102, internal.Run(cmdToArgs("oasdiff breaking ../data/ref-mirror/openapi.yaml ../data/ref-mirror/openapi.yaml --ref-lock "+dir+"/oasdiff-refs.lock"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "doesn't match the hash in the lockfile")
+}
+
+func Test_RefLockMissing(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff breaking ../data/ref-mirror/openapi.yaml ../data/ref-mirror/openapi.yaml --ref-lock "+t.TempDir()+"/oasdiff-refs.lock"), io.Discard, io.Discard))
+}
+
+func Test_Limits(t *testing.T) {
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/limits/deep.yaml ../data/limits/deep.yaml --limits max-input-bytes=100000,max-schema-depth=100,max-schemas=1000,timeout=1m"), io.Discard, io.Discard))
+}
+
+func Test_LimitsSchemaDepth(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 133, internal.Run(cmdToArgs("oasdiff breaking ../data/limits/deep.yaml ../data/limits/deep.yaml --limits max-schema-depth=20"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), `resource limit exceeded: an inline schema under "#/components/schemas/Deep" is nested deeper than max-schema-depth=20`)
+}
+
+func Test_LimitsAliasBomb(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 133, internal.Run(cmdToArgs("oasdiff diff ../data/limits/alias-bomb.yaml ../data/limits/alias-bomb.yaml --limits max-alias-expansion=1000"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "expand to more than max-alias-expansion=1000 nodes, at alias *b on line 8")
+}
+
+func Test_LimitsInputBytes(t *testing.T) {
+	require.Equal(t, 133, internal.Run(cmdToArgs("oasdiff diff ../data/limits/deep.yaml ../data/limits/deep.yaml --limits max-input-bytes=100"), io.Discard, io.Discard))
+}
+
+func Test_FlattenLimits(t *testing.T) {
+	require.Equal(t, 133, internal.Run(cmdToArgs("oasdiff flatten ../data/limits/combinations.yaml --limits max-schemas=10000"), io.Discard, io.Discard))
+}
+
+func Test_LimitsInvalid(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff diff ../data/limits/deep.yaml ../data/limits/deep.yaml --limits max-schema-depth=-1"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesWebhooks(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/webhooks/base.yaml ../data/webhooks/revision.yaml --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 5)
+	for _, c := range bc {
+		require.Equal(t, "webhooks", c.Section)
+	}
+}
+
+func Test_BreakingChangesWebhooksIgnore(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/webhooks/base.yaml ../data/webhooks/revision.yaml --format json --err-ignore ../data/webhooks/ignore-err.txt"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 4)
+}
+
 func Test_BreakingChangesFailOnErr(t *testing.T) {
 	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --fail-on ERR"), io.Discard, io.Discard))
 }
@@ -241,6 +346,15 @@ func Test_Changelog(t *testing.T) {
 	require.Len(t, cl, 1)
 }
 
+func Test_BreakingChangesSarif(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --format sarif"), &stdout, io.Discard))
+	sarif := formatters.SarifLog{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &sarif))
+	require.Len(t, sarif.Runs, 1)
+	require.NotEmpty(t, sarif.Runs[0].Results)
+}
+
 func Test_ChangelogWithAttributes(t *testing.T) {
 	var stdout bytes.Buffer
 	require.Zero(t, internal.Run(cmdToArgs("oasdiff changelog ../data/openapi-test1.yaml ../data/openapi-test3.yaml --attributes x-beta,x-extension-test -f yaml"), &stdout, io.Discard))
@@ -294,6 +408,238 @@ func Test_BreakingChangesCaseInsensitiveHeaders(t *testing.T) {
 	require.Zero(t, internal.Run(cmdToArgs("oasdiff diff ../data/header-case/base.yaml ../data/header-case/revision.yaml --case-insensitive-headers --fail-on-diff"), io.Discard, io.Discard))
 }
 
+func Test_BreakingChangesNormalizeDialect(t *testing.T) {
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/dialect/openapi-3.0.yaml ../data/dialect/openapi-3.1.yaml --normalize-dialect --fail-on WARN"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesWithoutNormalizeDialect(t *testing.T) {
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/dialect/openapi-3.0.yaml ../data/dialect/openapi-3.1.yaml --fail-on ERR"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesNormalizeDialectChanged(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/dialect/openapi-3.0.yaml ../data/dialect/openapi-3.1-changed.yaml --normalize-dialect --fail-on ERR --format json"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), "request-property-min-increased")
+}
+
+func Test_BreakingChangesMatchMovedEndpoints(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/moved-endpoints/base.yaml ../data/moved-endpoints/revision.yaml --match-moved-endpoints --fail-on ERR --format json"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), "endpoint-path-changed")
+	require.NotContains(t, stdout.String(), "api-path-removed-without-deprecation")
+}
+
+func Test_BreakingChangesCustomRules(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --custom-rules ../data/custom-rules/rules.yaml --fail-on ERR --format json"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), "public-endpoint-audience-changed")
+	require.Contains(t, stdout.String(), "removed the response header 'X-Rate-Limit'")
+}
+
+func Test_BreakingChangesCustomRulesSeverityLevelsAndIgnore(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --custom-rules ../data/custom-rules/rules.yaml --severity-levels ../data/custom-rules/severity-levels.txt --err-ignore ../data/custom-rules/ignore-err.txt --fail-on ERR --format json"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), "public-endpoint-audience-changed")
+	require.NotContains(t, stdout.String(), "billing-response-header-removed")
+}
+
+func Test_BreakingChangesCustomRulesInvalidFile(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 108, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --custom-rules ../data/custom-rules/base.yaml"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "failed to load custom rules from ../data/custom-rules/base.yaml")
+}
+
+func Test_SeverityLevelsWithCustomRuleIdWithoutCustomRules(t *testing.T) {
+	require.Equal(t, 106, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --severity-levels ../data/custom-rules/severity-levels.txt"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesStructuredIgnoreFile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --custom-rules ../data/custom-rules/rules.yaml --err-ignore ../data/ignore/ignore-err.yaml --warn-ignore ../data/ignore/ignore-warn.yaml --fail-on ERR --format json"), &stdout, &stderr))
+	require.NotContains(t, stdout.String(), "billing-response-header-removed")
+	require.NotContains(t, stdout.String(), `"path":"/billing/payments"`)
+	require.NotContains(t, stdout.String(), `"path":"/users"`)
+	require.Contains(t, stdout.String(), `"path":"/billing/invoices"`)
+	require.Equal(t, "warning: stale entry in err ignore file ../data/ignore/ignore-err.yaml: line 13 (public-endpoint-audience-changed /users) didn't match any change\n", stderr.String())
+}
+
+func Test_BreakingChangesStructuredIgnoreFileExpired(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 122, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --custom-rules ../data/custom-rules/rules.yaml --err-ignore ../data/ignore/ignore-err-expired.yaml"), io.Discard, &stderr))
+	require.Equal(t, `Error: err ignore file ../data/ignore/ignore-err-expired.yaml has expired entries:
+line 2 (billing-response-header-removed GET /billing/invoices) expired on 2020-01-31, reason: the header was renamed, clients were notified, owner: billing-team
+`, stderr.String())
+}
+
+func Test_BreakingChangesStructuredIgnoreFileInvalid(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 121, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --warn-ignore ../data/custom-rules/rules.yaml"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "can't process warn ignore file")
+}
+
+func Test_BreakingChangesBaseline(t *testing.T) {
+	baseline := t.TempDir() + "/baseline.yaml"
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --fail-on ERR --write-baseline "+baseline), io.Discard, io.Discard))
+
+	var stdout, stderr bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --fail-on ERR --format json --baseline "+baseline), &stdout, &stderr))
+	require.Equal(t, "[]\n", stdout.String())
+	require.Empty(t, stderr.String())
+}
+
+func Test_BreakingChangesBaselineResolved(t *testing.T) {
+	baseline := t.TempDir() + "/baseline.yaml"
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --write-baseline "+baseline), io.Discard, io.Discard))
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --custom-rules ../data/custom-rules/rules.yaml --fail-on ERR --format json --baseline "+baseline), &stdout, &stderr))
+	require.Contains(t, stdout.String(), "billing-response-header-removed")
+	require.Contains(t, stderr.String(), "warning: baseline entry no longer occurs in "+baseline)
+}
+
+func Test_BreakingChangesBaselineInvalid(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 124, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --baseline ../data/custom-rules/rules.yaml"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "failed to load baseline ../data/custom-rules/rules.yaml")
+}
+
+func Test_BreakingChangesCheckSemver(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff changelog ../data/semver/base.yaml ../data/semver/revision-docs.yaml --check-semver --format json"), &stdout, io.Discard))
+	require.Equal(t, `{"changes":[],"semver":{"baseVersion":"1.2.3","revisionVersion":"1.2.4","requiredBump":"patch","actualBump":"patch","recommendedVersion":"1.2.4"}}`+"\n", stdout.String())
+}
+
+func Test_BreakingChangesCheckSemverTooSmall(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 126, internal.Run(cmdToArgs("oasdiff breaking ../data/semver/base.yaml ../data/semver/revision-added.yaml --check-semver --format yaml"), &stdout, &stderr))
+	require.Contains(t, stdout.String(), "recommendedVersion: 1.3.0")
+	require.NotContains(t, stdout.String(), "endpoint-added")
+	require.Equal(t, "Error: the changes require a minor version bump but info.version changed from 1.2.3 to 1.2.4 (patch), recommended version: 1.3.0\n", stderr.String())
+}
+
+func Test_BreakingChangesCheckSemverInvalid(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 125, internal.Run(cmdToArgs("oasdiff breaking ../data/semver/base.yaml ../data/semver/revision-invalid-version.yaml --check-semver"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "can't check semantic versioning: invalid revision version: \"latest\" isn't a semantic version")
+}
+
+func Test_BreakingChangesTraffic(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/traffic/base.yaml ../data/traffic/revision.yaml --traffic ../data/traffic/access.jsonl --traffic ../data/traffic/calls.har --format json"), &stdout, &stderr))
+	require.Contains(t, stdout.String(), `"path":"/groups/{id}","source":"../data/traffic/base.yaml","section":"paths","observedCalls":0`)
+	require.Contains(t, stdout.String(), `"observedCalls":2`)
+	require.Equal(t, "warning: 1 of 9 recorded calls didn't match any endpoint\n", stderr.String())
+}
+
+func Test_BreakingChangesTrafficDropUnused(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/traffic/base.yaml ../data/traffic/revision.yaml --traffic ../data/traffic/access.jsonl --drop-unused --fail-on ERR --format json"), &stdout, io.Discard))
+	require.NotContains(t, stdout.String(), "api-path-removed-without-deprecation")
+	require.Contains(t, stdout.String(), "request-property-enum-value-removed")
+}
+
+func Test_BreakingChangesDropUnusedWithoutTraffic(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff breaking ../data/traffic/base.yaml ../data/traffic/revision.yaml --drop-unused"), io.Discard, &stderr))
+	require.Equal(t, "Error: --drop-unused requires --traffic\n", stderr.String())
+}
+
+func Test_BreakingChangesTrafficInvalid(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 127, internal.Run(cmdToArgs("oasdiff breaking ../data/traffic/base.yaml ../data/traffic/revision.yaml --traffic ../data/traffic/base.yaml"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "failed to load recorded traffic from ../data/traffic/base.yaml")
+}
+
+func Test_BreakingChangesCounterexamples(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/counterexample/base.yaml ../data/counterexample/revision.yaml --counterexamples --format json"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), `"counterexample":{"method":"PUT","path":"/users/a","query":"limit=101","headers":{"Content-Type":"application/json"},"body":{"email":"a","name":"a"}}`)
+}
+
+func Test_BreakingChangesWithoutCounterexamples(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/counterexample/base.yaml ../data/counterexample/revision.yaml --format json"), &stdout, io.Discard))
+	require.NotContains(t, stdout.String(), `"counterexample":`)
+}
+
+func Test_ServeInvalidAddr(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 128, internal.Run(cmdToArgs("oasdiff serve --addr no-such-host:-1"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "failed to run server")
+}
+
+func Test_ServeInvalidTimeout(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff serve --timeout 0s"), io.Discard, &stderr))
+	require.Equal(t, "Error: --timeout must be positive\n", stderr.String())
+}
+
+func Test_ServeInvalidLimits(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff serve --limits max-schema-depth=-1"), io.Discard, io.Discard))
+}
+
+func Test_ServeLimitsTimeout(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff serve --limits timeout=1m"), io.Discard, &stderr))
+	require.Equal(t, "Error: --limits timeout isn't supported by serve, use --timeout\n", stderr.String())
+}
+
+func Test_ServeArgs(t *testing.T) {
+	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff serve base.yaml"), io.Discard, io.Discard))
+}
+
+func Test_LSPMissingBase(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff lsp"), io.Discard, &stderr))
+	require.Equal(t, "Error: exactly one of --base and --base-rev is required\n", stderr.String())
+}
+
+func Test_LSPBaseAndBaseRev(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff lsp --base ../data/openapi-test1.yaml --base-rev main"), io.Discard, io.Discard))
+}
+
+func Test_LSPInvalidIncludeChecks(t *testing.T) {
+	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff lsp --base ../data/openapi-test1.yaml --include-checks no-such-check"), io.Discard, io.Discard))
+}
+
+func Test_LSPArgs(t *testing.T) {
+	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff lsp base.yaml"), io.Discard, io.Discard))
+}
+
+func Test_HistoryURL(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff history https://example.com/openapi.yaml"), io.Discard, &stderr))
+	require.Equal(t, "Error: history requires a path to a spec in the local git repository, got \"https://example.com/openapi.yaml\"\n", stderr.String())
+}
+
+func Test_HistoryArgs(t *testing.T) {
+	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff history"), io.Discard, io.Discard))
+}
+
+func Test_HistoryInvalidCheck(t *testing.T) {
+	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff history ../data/openapi-test1.yaml --checks no-such-check"), io.Discard, io.Discard))
+}
+
+func Test_HistoryInvalidRevRange(t *testing.T) {
+	require.Equal(t, 130, internal.Run(cmdToArgs("oasdiff history ../data/openapi-test1.yaml --rev-range no-such-rev"), io.Discard, io.Discard))
+}
+
+func Test_BisectURL(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff bisect https://example.com/openapi.yaml --good v1.0"), io.Discard, &stderr))
+	require.Equal(t, "Error: bisect requires a path to a spec in the local git repository, got \"https://example.com/openapi.yaml\"\n", stderr.String())
+}
+
+func Test_BisectNoGood(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff bisect ../data/openapi-test1.yaml"), io.Discard, &stderr))
+	require.Equal(t, "Error: bisect requires a good revision, set it with --good\n", stderr.String())
+}
+
+func Test_BisectInvalidGood(t *testing.T) {
+	require.Equal(t, 131, internal.Run(cmdToArgs("oasdiff bisect ../data/openapi-test1.yaml --good no-such-rev"), io.Discard, io.Discard))
+}
+
 func Test_FlattenCmdOK(t *testing.T) {
 	require.Zero(t, internal.Run(cmdToArgs("oasdiff flatten ../data/allof/simple.yaml"), io.Discard, io.Discard))
 }
@@ -309,6 +655,13 @@ func Test_Checks(t *testing.T) {
 	require.Zero(t, internal.Run(cmdToArgs("oasdiff checks -l ru --tags decrease,parameters --severity info,warn,error"), io.Discard, io.Discard))
 }
 
+func Test_ChecksCustomRules(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff checks --custom-rules ../data/custom-rules/rules.yaml --tags headers,remove"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), "billing-response-header-removed")
+	require.NotContains(t, stdout.String(), "public-endpoint-audience-changed")
+}
+
 func Test_Color(t *testing.T) {
 	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/allof/simple.yaml ../data/allof/revision.yaml --color always"), io.Discard, io.Discard))
 }
@@ -361,3 +714,59 @@ func Test_Changelog_WithUnmatchPath(t *testing.T) {
 	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
 	require.Len(t, bc, 1)
 }
+
+func Test_Lint(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff lint ../data/lint/regex/openapi-invalid-regex.yaml -f json"), &stdout, io.Discard))
+	errs := lint.Errors{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &errs))
+	require.Len(t, errs, 2)
+	require.Equal(t, "invalid-regex-pattern", errs[0].Id)
+	require.Equal(t, "path-param-duplicate", errs[1].Id)
+}
+
+func Test_LintFailOn(t *testing.T) {
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff lint ../data/lint/path-params/path-missing.yaml --fail-on WARN"), io.Discard, io.Discard))
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff lint ../data/lint/path-params/path-missing.yaml --fail-on ERR"), io.Discard, io.Discard))
+}
+
+func Test_LintChecks(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff lint ../data/lint/regex/openapi-invalid-regex.yaml --checks path-params -f json"), &stdout, io.Discard))
+	errs := lint.Errors{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &errs))
+	require.Len(t, errs, 1)
+	require.Equal(t, "path-param-duplicate", errs[0].Id)
+}
+
+func Test_LintChangedEndpoints(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff lint ../data/lint/changed/revision.yaml --base ../data/lint/changed/base.yaml -f json"), &stdout, io.Discard))
+	errs := lint.Errors{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &errs))
+	require.Len(t, errs, 1)
+	require.Equal(t, "path-param-missing", errs[0].Id)
+	require.Equal(t, "/authors/{authorId}", errs[0].Path)
+}
+
+func Test_LintInvalidFormat(t *testing.T) {
+	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff lint ../data/lint/openapi.yaml -f html"), io.Discard, io.Discard))
+}
+
+func Test_HistoryInvalidRefPolicy(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff history ../data/openapi-test1.yaml --ref-policy no-such-policy"), io.Discard, io.Discard))
+}
+
+func Test_HistoryLimitExceeded(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 133, internal.Run(cmdToArgs("oasdiff history ../data/openapi-test1.yaml --limits max-input-bytes=100"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "resource limit exceeded")
+}
+
+func Test_BisectLimitExceeded(t *testing.T) {
+	require.Equal(t, 133, internal.Run(cmdToArgs("oasdiff bisect ../data/openapi-test1.yaml --good HEAD --limits max-input-bytes=100"), io.Discard, io.Discard))
+}
+
+func Test_LSPInvalidLimits(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff lsp --base ../data/openapi-test1.yaml --limits no-such-limit=1"), io.Discard, io.Discard))
+}
@@ -24,3 +24,46 @@ func Test_ComposedModeInvalidFileUnix(t *testing.T) {
 	require.Equal(t, `Error: failed to load base specs from glob "../data/allof/*": failed to flatten allOf in "../data/allof/invalid.yaml": unable to resolve Type conflict: all Type values must be identical
 `, stderr.String())
 }
+
+func Test_BreakingChangesPlugin(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --plugin ../data/plugins/audience.sh --fail-on ERR --format json"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), `"id":"audience-changed"`)
+	require.Contains(t, stdout.String(), "the audience of the endpoint changed, 100% breaking")
+}
+
+func Test_BreakingChangesPluginSeverityLevels(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --plugin ../data/plugins/audience.sh --severity-levels ../data/plugins/severity-levels.txt --fail-on ERR --format json"), &stdout, io.Discard))
+	require.NotContains(t, stdout.String(), "audience-changed")
+}
+
+func Test_BreakingChangesPluginIgnore(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --plugin ../data/plugins/audience.sh --err-ignore ../data/plugins/ignore-err.txt --fail-on ERR --format json"), &stdout, io.Discard))
+	require.NotContains(t, stdout.String(), "audience-changed")
+}
+
+func Test_BreakingChangesPluginFailed(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 109, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --plugin ../data/plugins/fail.sh"), io.Discard, &stderr))
+	require.Equal(t, "Error: plugin ../data/plugins/fail.sh failed: exit status 3: something went wrong\n", stderr.String())
+}
+
+func Test_BreakingChangesPluginSeverityLevelsInvalidId(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 106, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --plugin ../data/plugins/audience.sh --severity-levels ../data/plugins/severity-levels-invalid.txt"), io.Discard, &stderr))
+	require.Equal(t, "Error: failed to load custom severity levels from ../data/plugins/severity-levels-invalid.txt: invalid rule id \"audience-changd\" on line 1\n", stderr.String())
+}
+
+func Test_BreakingChangesPluginCustomRuleId(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 109, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --custom-rules ../data/custom-rules/rules.yaml --plugin ../data/plugins/custom-rule-id.sh"), io.Discard, &stderr))
+	require.Equal(t, "Error: plugin ../data/plugins/custom-rule-id.sh failed: invalid change \"schema-property-removed\": id is already used by a custom rule\n", stderr.String())
+}
+
+func Test_BreakingChangesPluginTimeout(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 133, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --plugin ../data/plugins/hang.sh --limits timeout=500ms"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "while processing plugin ../data/plugins/hang.sh")
+}
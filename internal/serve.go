@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tufin/oasdiff/server"
+)
+
+func getServeCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:               "serve [flags]",
+		Short:             "Run an HTTP server",
+		Long:              "Run an HTTP server which exposes the diff, summary, breaking and changelog commands. The server describes its API at /openapi.yaml.",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions, // see https://github.com/spf13/cobra/issues/1969
+		RunE:              getRun(runServe),
+	}
+
+	cmd.PersistentFlags().String("addr", ":8080", "address to listen on")
+	cmd.PersistentFlags().Int64("max-request-size", server.DefaultMaxRequestSize, "max size of a request body in bytes")
+	cmd.PersistentFlags().Duration("timeout", server.DefaultTimeout, "max duration of a comparison")
+	cmd.PersistentFlags().Int("max-concurrent", 0, "max number of comparisons that run at the same time (default: number of CPUs)")
+	addLimitsFlag(&cmd)
+
+	return &cmd
+}
+
+func runServe(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+
+	config, returnErr := getServeConfig(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	listener, err := net.Listen("tcp", flags.getAddr())
+	if err != nil {
+		return false, getErrServerFailed(err)
+	}
+
+	httpServer := &http.Server{
+		Handler:           server.New(config).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       config.Timeout,
+	}
+
+	// shut down gracefully on interrupt, letting running comparisons complete
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	_, _ = fmt.Fprintf(stderr, "listening on %s\n", listener.Addr())
+
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return false, getErrServerFailed(err)
+	}
+	<-shutdown
+
+	return false, nil
+}
+
+// getServeConfig returns the configuration of the server from the flags
+// --limits replaces the default limits on the specs of each comparison, while the timeout of each comparison is set by --timeout
+func getServeConfig(flags *Flags) (*server.Config, *ReturnError) {
+	config := server.NewConfig()
+	config.MaxRequestSize = flags.getMaxRequestSize()
+	config.Timeout = flags.getTimeout()
+	if flags.getMaxConcurrent() != 0 {
+		config.MaxConcurrent = flags.getMaxConcurrent()
+	}
+
+	if config.MaxRequestSize <= 0 {
+		return nil, getErrInvalidFlags(errors.New("--max-request-size must be positive"))
+	}
+	if config.Timeout <= 0 {
+		return nil, getErrInvalidFlags(errors.New("--timeout must be positive"))
+	}
+	if config.MaxConcurrent < 0 {
+		return nil, getErrInvalidFlags(errors.New("--max-concurrent can't be negative"))
+	}
+
+	serveLimits, err := flags.getLimits()
+	if err != nil {
+		return nil, getErrInvalidFlags(err)
+	}
+	if serveLimits != nil {
+		if serveLimits.Timeout > 0 {
+			return nil, getErrInvalidFlags(errors.New("--limits timeout isn't supported by serve, use --timeout"))
+		}
+		config.Limits = serveLimits
+	}
+
+	return config, nil
+}
@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/server"
+)
+
+func getTestServeConfig(t *testing.T, args ...string) *server.Config {
+	t.Helper()
+
+	cmd := getServeCmd()
+	require.NoError(t, cmd.ParseFlags(args))
+	flags := NewFlags()
+	require.Nil(t, RunViper(cmd, flags.getViper()))
+
+	config, err := getServeConfig(flags)
+	require.Nil(t, err)
+	return config
+}
+
+func TestGetServeConfig_DefaultLimits(t *testing.T) {
+	require.Equal(t, server.NewLimits(), getTestServeConfig(t).Limits)
+}
+
+func TestGetServeConfig_Limits(t *testing.T) {
+	config := getTestServeConfig(t, "--limits", "max-schema-depth=10,max-schemas=1000", "--timeout", "5s")
+	require.Equal(t, &limits.Limits{MaxSchemaDepth: 10, MaxSchemas: 1000}, config.Limits)
+	require.Equal(t, 5*time.Second, config.Timeout)
+}
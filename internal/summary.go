@@ -18,10 +18,11 @@ func getSummaryCmd() *cobra.Command {
 		Short: "Generate a diff summary",
 		Long:  "Display a summary of changes between base and revision specs." + specHelp,
 		Args:  getParseArgs(),
-		RunE:  getRun(runSummary),
+		RunE:  getRun(withWatch(runSummary)),
 	}
 
 	addCommonDiffFlags(&cmd)
+	addWatchFlags(&cmd)
 	enumWithOptions(&cmd, newEnumSliceValue(diff.GetExcludeDiffOptions(), nil), "exclude-elements", "e", "elements to exclude")
 	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputSummary), string(formatters.FormatYAML)), "format", "f", "output format")
 	cmd.PersistentFlags().BoolP("fail-on-diff", "", false, "exit with return code 1 when any change is found")
@@ -29,7 +30,7 @@ func getSummaryCmd() *cobra.Command {
 	return &cmd
 }
 
-func runSummary(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
+func runSummary(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
 
 	diffResult, err := calcDiff(flags)
 	if err != nil {
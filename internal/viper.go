@@ -3,6 +3,7 @@ package internal
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -80,33 +81,61 @@ func fixViperStringSlice(viperString []string) []string {
 }
 
 type Config struct {
-	Attributes             []string `mapstructure:"attributes"`
-	Composed               bool     `mapstructure:"composed"`
-	FlattenAllof           bool     `mapstructure:"flatten-allof"`
-	FlattenParams          bool     `mapstructure:"flatten-params"`
-	CaseInsensitiveHeaders bool     `mapstructure:"case-insensitive-headers"`
-	DeprecationDaysBeta    uint     `mapstructure:"deprecation-days-beta"`
-	DeprecationDaysStable  uint     `mapstructure:"deprecation-days-stable"`
-	Lang                   string   `mapstructure:"lang"`
-	Color                  string   `mapstructure:"color"`
-	WarnIgnore             string   `mapstructure:"warn-ignore"`
-	ErrIgnore              string   `mapstructure:"err-ignore"`
-	Format                 string   `mapstructure:"format"`
-	FailOn                 string   `mapstructure:"fail-on"`
-	Level                  string   `mapstructure:"level"`
-	FailOnDiff             bool     `mapstructure:"fail-on-diff"`
-	SeverityLevels         string   `mapstructure:"severity-levels"`
-	ExcludeElements        []string `mapstructure:"exclude-elements"`
-	Severity               []string `mapstructure:"severity"`
-	Tags                   []string `mapstructure:"tags"`
-	MatchPath              string   `mapstructure:"match-path"`
-	UnmatchPath            string   `mapstructure:"unmatch-path"`
-	FilterExtension        string   `mapstructure:"filter-extension"`
-	PrefixBase             string   `mapstructure:"prefix-base"`
-	PrefixRevision         string   `mapstructure:"prefix-revision"`
-	StripPrefixBase        string   `mapstructure:"strip-prefix-base"`
-	StripPrefixRevision    string   `mapstructure:"strip-prefix-revision"`
-	IncludePathParams      bool     `mapstructure:"include-path-params"`
+	Attributes             []string      `mapstructure:"attributes"`
+	Composed               bool          `mapstructure:"composed"`
+	FlattenAllof           bool          `mapstructure:"flatten-allof"`
+	FlattenParams          bool          `mapstructure:"flatten-params"`
+	CaseInsensitiveHeaders bool          `mapstructure:"case-insensitive-headers"`
+	NormalizeDialect       bool          `mapstructure:"normalize-dialect"`
+	DeprecationDaysBeta    uint          `mapstructure:"deprecation-days-beta"`
+	DeprecationDaysStable  uint          `mapstructure:"deprecation-days-stable"`
+	Lang                   string        `mapstructure:"lang"`
+	Color                  string        `mapstructure:"color"`
+	WarnIgnore             string        `mapstructure:"warn-ignore"`
+	ErrIgnore              string        `mapstructure:"err-ignore"`
+	Baseline               string        `mapstructure:"baseline"`
+	WriteBaseline          string        `mapstructure:"write-baseline"`
+	Traffic                []string      `mapstructure:"traffic"`
+	DropUnused             bool          `mapstructure:"drop-unused"`
+	Counterexamples        bool          `mapstructure:"counterexamples"`
+	CheckSemver            bool          `mapstructure:"check-semver"`
+	Format                 string        `mapstructure:"format"`
+	FailOn                 string        `mapstructure:"fail-on"`
+	Level                  string        `mapstructure:"level"`
+	FailOnDiff             bool          `mapstructure:"fail-on-diff"`
+	SeverityLevels         string        `mapstructure:"severity-levels"`
+	CustomRules            string        `mapstructure:"custom-rules"`
+	Plugin                 []string      `mapstructure:"plugin"`
+	RefPolicy              []string      `mapstructure:"ref-policy"`
+	RefMirror              []string      `mapstructure:"ref-mirror"`
+	RefLock                string        `mapstructure:"ref-lock"`
+	Limits                 []string      `mapstructure:"limits"`
+	Lockfile               string        `mapstructure:"lockfile"`
+	CacheDir               string        `mapstructure:"cache-dir"`
+	ExcludeElements        []string      `mapstructure:"exclude-elements"`
+	Severity               []string      `mapstructure:"severity"`
+	Tags                   []string      `mapstructure:"tags"`
+	MatchPath              string        `mapstructure:"match-path"`
+	UnmatchPath            string        `mapstructure:"unmatch-path"`
+	FilterExtension        string        `mapstructure:"filter-extension"`
+	PrefixBase             string        `mapstructure:"prefix-base"`
+	PrefixRevision         string        `mapstructure:"prefix-revision"`
+	StripPrefixBase        string        `mapstructure:"strip-prefix-base"`
+	StripPrefixRevision    string        `mapstructure:"strip-prefix-revision"`
+	IncludePathParams      bool          `mapstructure:"include-path-params"`
+	MatchMovedEndpoints    bool          `mapstructure:"match-moved-endpoints"`
+	Addr                   string        `mapstructure:"addr"`
+	MaxRequestSize         int64         `mapstructure:"max-request-size"`
+	Timeout                time.Duration `mapstructure:"timeout"`
+	MaxConcurrent          int           `mapstructure:"max-concurrent"`
+	Base                   string        `mapstructure:"base"`
+	BaseRev                string        `mapstructure:"base-rev"`
+	Lint                   bool          `mapstructure:"lint"`
+	Watch                  bool          `mapstructure:"watch"`
+	WatchInterval          time.Duration `mapstructure:"watch-interval"`
+	RevRange               string        `mapstructure:"rev-range"`
+	Good                   string        `mapstructure:"good"`
+	Bad                    string        `mapstructure:"bad"`
 }
 
 // validate checks that each of the provided configuration values is one of the generally accepted values
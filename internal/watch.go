@@ -0,0 +1,478 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/load"
+	"github.com/yargevad/filepathx"
+)
+
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool("watch", false, "keep running and print the changes in the output whenever the revision, the base or the files that they reference change")
+	cmd.PersistentFlags().Duration("watch-interval", time.Second, "how often to check the watched files for changes")
+}
+
+// withWatch runs the command once, or repeatedly with --watch
+func withWatch(runner runner) runner {
+	return func(flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+		if !flags.getWatch() {
+			return runner(flags, stdout, stderr)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		return watch(ctx, runner, flags, stdout, stderr)
+	}
+}
+
+// watch runs the command and then re-runs it whenever one of the spec files changes, until the context is done
+// the output of the first run is printed as is, and the following runs print the delta relative to the previous run
+func watch(ctx context.Context, runner runner, flags *Flags, stdout io.Writer, stderr io.Writer) (bool, *ReturnError) {
+	if flags.getBase().IsStdin() || flags.getRevision().IsStdin() {
+		return false, getErrInvalidFlags(errors.New("--watch can't be used with specs from standard input"))
+	}
+	if flags.getWatchInterval() <= 0 {
+		return false, getErrInvalidFlags(fmt.Errorf("invalid watch-interval value: %s", flags.getWatchInterval()))
+	}
+
+	state := newWatchState()
+	flags.watch = state
+
+	var previous *watchRun
+	ticker := time.NewTicker(flags.getWatchInterval())
+	defer ticker.Stop()
+
+	for {
+		current := state.run(runner, flags, stderr)
+		if current.err != nil {
+			if previous == nil && !isLoadError(current.err) {
+				return false, current.err
+			}
+			_, _ = fmt.Fprintf(stderr, "Error: %v\n", current.err)
+		} else {
+			if previous == nil {
+				_, _ = stdout.Write(current.output)
+			} else {
+				printWatchDelta(stdout, previous, current, flags)
+			}
+			previous = current
+		}
+		_, _ = fmt.Fprintf(stderr, "watching %d files for changes\n", len(state.files()))
+
+		for changed := []string(nil); len(changed) == 0; {
+			select {
+			case <-ctx.Done():
+				return false, nil
+			case <-ticker.C:
+				changed = state.changed()
+			}
+			if len(changed) > 0 {
+				_, _ = fmt.Fprintf(stderr, "\n%s changed, re-running\n", strings.Join(changed, ", "))
+			}
+		}
+	}
+}
+
+// isLoadError returns true for errors which can be fixed by editing the specs, so watch mode keeps running after them
+func isLoadError(err *ReturnError) bool {
+	switch err.Code {
+	case 102, 103, 104:
+		return true
+	}
+	return false
+}
+
+// watchRun is the result of a single run in watch mode
+type watchRun struct {
+	output  []byte
+	changes checker.Changes // the reported changes of changelog and breaking, nil for diff and summary
+	err     *ReturnError
+}
+
+// watchState keeps the loaded specs between runs, so that only the specs whose files changed are reloaded
+type watchState struct {
+	specs   map[string]*watchedSpecs
+	changes checker.Changes
+	hasRun  bool // true if the current run recorded its changes
+}
+
+func newWatchState() *watchState {
+	return &watchState{
+		specs: map[string]*watchedSpecs{},
+	}
+}
+
+func (state *watchState) run(runner runner, flags *Flags, stderr io.Writer) *watchRun {
+	state.changes, state.hasRun = nil, false
+
+	output := bytes.Buffer{}
+	_, err := runner(flags, &output, stderr)
+
+	result := &watchRun{
+		output: output.Bytes(),
+		err:    err,
+	}
+	if state.hasRun {
+		// changes are never nil for changelog and breaking, even if there are none
+		result.changes = append(checker.Changes{}, state.changes...)
+	}
+	return result
+}
+
+// recordChanges keeps the changes reported by the current run, to print the new and resolved changes rather than a textual delta
+func (state *watchState) recordChanges(changes checker.Changes) {
+	state.changes, state.hasRun = changes, true
+}
+
+// loadSpecs returns the specs of the previous run, or reloads them if one of their files changed
+// specs which failed to load are cached too, so the error is repeated until the spec is fixed
+func (state *watchState) loadSpecs(what string, source string, composed bool, refs *refConfig, loadFunc func(loader load.Loader) ([]*load.SpecInfo, error)) ([]*load.SpecInfo, error) {
+	if specs, ok := state.specs[what]; ok && specs.source == source && specs.composed == composed && !specs.isStale() {
+		return specs.specInfos, specs.err
+	}
+
+	specs := &watchedSpecs{
+		source:   source,
+		composed: composed,
+		tracker:  load.NewFileTracker(),
+	}
+	if composed {
+		specs.matches = getGlobMatches(source)
+	}
+
+	loader := newLoader(refs)
+	specs.tracker.Track(loader)
+	specs.specInfos, specs.err = loadFunc(loader)
+
+	if !composed && load.NewSource(source).IsFile() && !slices.Contains(specs.tracker.Files(), filepath.Clean(source)) {
+		// a spec which couldn't be read, like a file which is being renamed, is watched until it appears
+		specs.tracker.Add(source)
+	}
+
+	state.specs[what] = specs
+	return specs.specInfos, specs.err
+}
+
+// files returns the watched files of all specs
+func (state *watchState) files() []string {
+	result := []string{}
+	for _, specs := range state.specs {
+		for _, file := range specs.tracker.Files() {
+			if !slices.Contains(result, file) {
+				result = append(result, file)
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// changed returns the watched files which changed since they were loaded
+// a glob which matches a different set of files is reported as changed too
+func (state *watchState) changed() []string {
+	result := []string{}
+	for _, specs := range state.specs {
+		for _, file := range specs.changed() {
+			if !slices.Contains(result, file) {
+				result = append(result, file)
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// watchedSpecs are the specs of one side of the comparison along with the files that they were loaded from
+type watchedSpecs struct {
+	source    string
+	composed  bool
+	matches   []string // the files matching the glob in composed mode
+	tracker   *load.FileTracker
+	specInfos []*load.SpecInfo
+	err       error
+}
+
+func (specs *watchedSpecs) isStale() bool {
+	return len(specs.changed()) > 0
+}
+
+func (specs *watchedSpecs) changed() []string {
+	result := specs.tracker.Changed()
+	if specs.composed && !slices.Equal(specs.matches, getGlobMatches(specs.source)) {
+		result = append(result, specs.source)
+	}
+	return result
+}
+
+// getGlobMatches returns the files matching a glob, globs in git revisions and invalid globs have no matches
+func getGlobMatches(glob string) []string {
+	if load.IsGitSource(glob) {
+		return nil
+	}
+	matches, err := filepathx.Glob(glob)
+	if err != nil {
+		return nil
+	}
+	slices.Sort(matches)
+	return matches
+}
+
+// printWatchDelta prints the difference between the outputs of two runs:
+// the new and resolved changes for changelog and breaking, or a line diff of the output for diff and summary
+func printWatchDelta(stdout io.Writer, previous, current *watchRun, flags *Flags) {
+	if previous.changes != nil && current.changes != nil {
+		// the color mode was already validated when the changes were printed
+		colorMode, _ := checker.NewColorMode(flags.getColor())
+		printChangesDelta(stdout, previous.changes, current.changes, checker.NewLocalizer(flags.getLang()), colorMode)
+		return
+	}
+
+	hunks := getLineDiff(splitLines(previous.output), splitLines(current.output), watchDiffContext)
+	if len(hunks) == 0 {
+		_, _ = fmt.Fprintln(stdout, "no changes in the output")
+		return
+	}
+	for _, hunk := range hunks {
+		_, _ = fmt.Fprint(stdout, hunk)
+	}
+}
+
+// printChangesDelta prints the changes which were added and removed since the previous run
+func printChangesDelta(stdout io.Writer, previous, current checker.Changes, l checker.Localizer, colorMode checker.ColorMode) {
+	added, resolved := getChangesDelta(previous, current)
+	if len(added) == 0 && len(resolved) == 0 {
+		_, _ = fmt.Fprintln(stdout, "no new or resolved changes")
+		return
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%d new, %d resolved\n", len(added), len(resolved))
+	for _, change := range added {
+		_, _ = fmt.Fprintf(stdout, "+ %s\n", change.SingleLineError(l, colorMode))
+	}
+	for _, change := range resolved {
+		_, _ = fmt.Fprintf(stdout, "- %s\n", change.SingleLineError(l, colorMode))
+	}
+}
+
+// getChangesDelta returns the changes which are only in current, and those which are only in previous
+// changes are compared by fingerprint, so a change which moved to another line isn't reported
+func getChangesDelta(previous, current checker.Changes) (checker.Changes, checker.Changes) {
+	count := map[string]int{}
+	for _, change := range previous {
+		count[checker.GetFingerprint(change)]++
+	}
+
+	added := checker.Changes{}
+	for _, change := range current {
+		fingerprint := checker.GetFingerprint(change)
+		if count[fingerprint] > 0 {
+			count[fingerprint]--
+			continue
+		}
+		added = append(added, change)
+	}
+
+	resolved := checker.Changes{}
+	for _, change := range previous {
+		fingerprint := checker.GetFingerprint(change)
+		if count[fingerprint] > 0 {
+			count[fingerprint]--
+			resolved = append(resolved, change)
+		}
+	}
+
+	return added, resolved
+}
+
+const watchDiffContext = 2
+
+func splitLines(data []byte) []string {
+	text := strings.TrimSuffix(string(data), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+type lineEdit struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// getLineDiff returns the unified diff hunks between two texts, with the given number of context lines
+func getLineDiff(a, b []string, context int) []string {
+	edits := getLineEdits(a, b)
+
+	result := []string{}
+	for start := 0; start < len(edits); {
+		// find the next edit
+		for start < len(edits) && edits[start].kind == ' ' {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+
+		// extend the hunk while the gap between edits is small enough to share context lines
+		end := start
+		for i := start; i < len(edits); i++ {
+			if edits[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*context {
+				break
+			}
+		}
+
+		from, to := max(start-context, 0), min(end+context, len(edits))
+		result = append(result, formatHunk(edits, from, to))
+		start = to
+	}
+	return result
+}
+
+func formatHunk(edits []lineEdit, from, to int) string {
+	aStart, bStart := 1, 1
+	for _, edit := range edits[:from] {
+		if edit.kind != '+' {
+			aStart++
+		}
+		if edit.kind != '-' {
+			bStart++
+		}
+	}
+
+	aLen, bLen := 0, 0
+	body := strings.Builder{}
+	for _, edit := range edits[from:to] {
+		if edit.kind != '+' {
+			aLen++
+		}
+		if edit.kind != '-' {
+			bLen++
+		}
+		body.WriteByte(edit.kind)
+		body.WriteString(edit.line)
+		body.WriteByte('\n')
+	}
+
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n%s", aStart, aLen, bStart, bLen, body.String())
+}
+
+// getLineEdits returns the shortest edit script between two texts, using the linear space variant of Myers' algorithm
+// the memory is linear in the size of the texts, rather than in the product of their size and the number of edits, since outputs may be large
+func getLineEdits(a, b []string) []lineEdit {
+	return appendLineEdits(make([]lineEdit, 0, len(a)+len(b)), a, b)
+}
+
+// appendLineEdits appends the shortest edit script between two texts, splitting them at the middle snake of the edit graph
+func appendLineEdits(result []lineEdit, a, b []string) []lineEdit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	result = appendEqualLines(result, a[:prefix])
+	a, b = a[prefix:], b[prefix:]
+
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	common := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			result = append(result, lineEdit{'+', line})
+		}
+	case len(b) == 0:
+		for _, line := range a {
+			result = append(result, lineEdit{'-', line})
+		}
+	default:
+		// both texts are left with at least two edits, so each half has fewer edits and the recursion ends
+		x, y, u, v := getMiddleSnake(a, b)
+		result = appendLineEdits(result, a[:x], b[:y])
+		result = appendEqualLines(result, a[x:u])
+		result = appendLineEdits(result, a[u:], b[v:])
+	}
+
+	return appendEqualLines(result, common)
+}
+
+func appendEqualLines(result []lineEdit, lines []string) []lineEdit {
+	for _, line := range lines {
+		result = append(result, lineEdit{' ', line})
+	}
+	return result
+}
+
+// getMiddleSnake returns the snake from (x, y) to (u, v) in the middle of a shortest edit script between two texts
+// it runs Myers' algorithm from the start and from the end of the texts at the same time until the paths overlap
+// forward[k] is the furthest x on diagonal k = x - y from the start, backward[k] is the furthest distance from the end on diagonal k of the reversed texts
+func getMiddleSnake(a, b []string) (x, y, u, v int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			x := getNextX(forward, offset, k, d)
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			forward[offset+k] = x
+
+			// the backward paths which were extended d-1 times are on the reversed diagonal delta-k
+			if reverseK := delta - k; odd && reverseK >= -(d-1) && reverseK <= d-1 && x+backward[offset+reverseK] >= n {
+				return startX, startY, x, y
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+			x := getNextX(backward, offset, k, d)
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x, y = x+1, y+1
+			}
+			backward[offset+k] = x
+
+			// the forward paths which were extended d times are on the diagonal delta-k
+			if forwardK := delta - k; !odd && forwardK >= -d && forwardK <= d && x+forward[offset+forwardK] >= n {
+				return n - x, m - y, n - startX, m - startY
+			}
+		}
+	}
+
+	// unreachable: the paths overlap after at most (n+m+1)/2 steps
+	return 0, 0, 0, 0
+}
+
+// getNextX returns the x on diagonal k where a path with d edits starts its snake: after an insertion from diagonal k+1, or after a deletion from diagonal k-1
+func getNextX(furthest []int, offset, k, d int) int {
+	if k == -d || (k != d && furthest[offset+k-1] < furthest[offset+k+1]) {
+		return furthest[offset+k+1]
+	}
+	return furthest[offset+k-1] + 1
+}
@@ -0,0 +1,208 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/load"
+)
+
+func TestGetLineDiff(t *testing.T) {
+	a := strings.Split("a b c d e f g h i j", " ")
+	b := strings.Split("a b x d e f g h j", " ")
+
+	require.Equal(t, []string{
+		"@@ -1,5 +1,5 @@\n a\n b\n-c\n+x\n d\n e\n",
+		"@@ -7,4 +7,3 @@\n g\n h\n-i\n j\n",
+	}, getLineDiff(a, b, 2))
+}
+
+func TestGetLineDiff_Merged(t *testing.T) {
+	a := strings.Split("a b c d e f", " ")
+	b := strings.Split("a x c d y f", " ")
+
+	require.Equal(t, []string{
+		"@@ -1,6 +1,6 @@\n a\n-b\n+x\n c\n d\n-e\n+y\n f\n",
+	}, getLineDiff(a, b, 2))
+}
+
+func TestGetLineDiff_Same(t *testing.T) {
+	a := strings.Split("a b c", " ")
+	require.Empty(t, getLineDiff(a, a, 2))
+}
+
+func TestGetLineDiff_Empty(t *testing.T) {
+	require.Equal(t, []string{"@@ -1,0 +1,2 @@\n+a\n+b\n"}, getLineDiff(nil, []string{"a", "b"}, 2))
+}
+
+func TestGetLineDiff_Minimal(t *testing.T) {
+	a := strings.Split("a b c a b b a", " ")
+	b := strings.Split("c b a b a c", " ")
+
+	edits := getLineEdits(a, b)
+	count := 0
+	for _, edit := range edits {
+		if edit.kind != ' ' {
+			count++
+		}
+	}
+	require.Equal(t, 5, count)
+}
+
+func TestGetLineDiff_Replaced(t *testing.T) {
+	// every line changed, which used to need memory in the product of the number of lines and the number of edits
+	a, b := make([]string, 5000), make([]string, 5000)
+	for i := range a {
+		a[i], b[i] = fmt.Sprintf("a%d", i), fmt.Sprintf("b%d", i)
+	}
+
+	hunks := getLineDiff(a, b, 2)
+	require.Len(t, hunks, 1)
+	require.True(t, strings.HasPrefix(hunks[0], "@@ -1,5000 +1,5000 @@\n"))
+}
+
+func TestGetChangesDelta(t *testing.T) {
+	removed := checker.ApiChange{Id: "api-removed-without-deprecation", Operation: "GET", Path: "/a"}
+	kept := checker.ApiChange{Id: "response-success-status-removed", Operation: "GET", Path: "/b", Args: []any{"200"}}
+	added := checker.ApiChange{Id: "request-parameter-removed", Operation: "POST", Path: "/b", Args: []any{"query", "id"}}
+
+	// a change which moved to another line is the same change
+	moved := kept
+	moved.SourceLine = 10
+
+	newChanges, resolved := getChangesDelta(checker.Changes{removed, kept}, checker.Changes{moved, added})
+	require.Equal(t, checker.Changes{added}, newChanges)
+	require.Equal(t, checker.Changes{removed}, resolved)
+}
+
+func TestWatchState_ReloadsOnlyChangedSpecs(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	revision := filepath.Join(dir, "revision.yaml")
+	schemas := filepath.Join(dir, "schemas.yaml")
+	writeWatchSpecs(t, base, revision, schemas, "string")
+
+	state := newWatchState()
+	loads := map[string]int{}
+	loadSpec := func(what, path string) {
+		_, err := state.loadSpecs(what, path, false, &refConfig{}, func(loader load.Loader) ([]*load.SpecInfo, error) {
+			loads[what]++
+			specInfo, err := load.NewSpecInfo(loader, load.NewSource(path))
+			return []*load.SpecInfo{specInfo}, err
+		})
+		require.NoError(t, err)
+	}
+
+	loadSpec("base", base)
+	loadSpec("revision", revision)
+	require.Equal(t, []string{base, revision, schemas}, state.files())
+	require.Empty(t, state.changed())
+
+	// a referenced file of the revision changed
+	require.NoError(t, os.WriteFile(schemas, []byte(getWatchSchemas("integer")), 0644))
+	require.Equal(t, []string{schemas}, state.changed())
+
+	loadSpec("base", base)
+	loadSpec("revision", revision)
+	require.Equal(t, map[string]int{"base": 1, "revision": 2}, loads)
+	require.Empty(t, state.changed())
+}
+
+func TestWatch_Breaking(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	revision := filepath.Join(dir, "revision.yaml")
+	schemas := filepath.Join(dir, "schemas.yaml")
+	writeWatchSpecs(t, base, revision, schemas, "string")
+
+	cmd := getBreakingChangesCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--watch-interval", "10ms", "--color", "never"}))
+	flags := NewFlags()
+	require.Nil(t, RunViper(cmd, flags.getViper()))
+	flags.setBase(load.NewSource(base))
+	flags.setRevision(load.NewSource(revision))
+
+	stdout, stderr := &syncBuffer{}, &syncBuffer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan *ReturnError)
+	go func() {
+		_, err := watch(ctx, runBreakingChanges, flags, stdout, stderr)
+		done <- err
+	}()
+
+	require.Eventually(t, func() bool { return strings.Contains(stderr.String(), "watching 3 files for changes") }, 5*time.Second, 10*time.Millisecond)
+	require.Empty(t, strings.TrimSpace(stdout.String()))
+
+	require.NoError(t, os.WriteFile(schemas, []byte(getWatchSchemas("integer")), 0644))
+	require.Eventually(t, func() bool { return strings.Contains(stdout.String(), "1 new, 0 resolved") }, 5*time.Second, 10*time.Millisecond)
+	require.Contains(t, stdout.String(), "+ error at "+revision+", in API GET /pets the response's body type/format changed")
+	require.Contains(t, stderr.String(), schemas+" changed, re-running")
+
+	require.NoError(t, os.WriteFile(schemas, []byte(getWatchSchemas("string")), 0644))
+	require.Eventually(t, func() bool { return strings.Contains(stdout.String(), "0 new, 1 resolved") }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.Nil(t, <-done)
+}
+
+func TestWatch_Stdin(t *testing.T) {
+	flags := NewFlags()
+	flags.setBase(load.NewSource("-"))
+	flags.setRevision(load.NewSource("../data/openapi-test1.yaml"))
+
+	_, err := watch(context.Background(), runDiff, flags, &bytes.Buffer{}, &bytes.Buffer{})
+	require.Equal(t, 101, err.Code)
+}
+
+func writeWatchSpecs(t *testing.T, base, revision, schemas, revisionType string) {
+	t.Helper()
+	spec := `openapi: 3.0.3
+info:
+  title: watch
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                %s
+`
+	require.NoError(t, os.WriteFile(base, []byte(strings.Replace(spec, "%s", "type: string", 1)), 0644))
+	require.NoError(t, os.WriteFile(revision, []byte(strings.Replace(spec, "%s", "$ref: schemas.yaml#/Pet", 1)), 0644))
+	require.NoError(t, os.WriteFile(schemas, []byte(getWatchSchemas(revisionType)), 0644))
+}
+
+func getWatchSchemas(petType string) string {
+	return "Pet:\n  type: " + petType + "\n"
+}
+
+// syncBuffer is a buffer which can be written by the watch loop while the test reads it
+type syncBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.String()
+}
@@ -0,0 +1,5 @@
+/*
+Package limits protects oasdiff from specs that would exhaust its resources, like YAML alias bombs, very deep schema nesting and combinatorial allOf/oneOf trees.
+A limit which is exceeded fails the run with an error that wraps ErrLimitExceeded and names the element that exceeded it.
+*/
+package limits
@@ -0,0 +1,113 @@
+package limits
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CheckDocument enforces MaxInputBytes, MaxAliasExpansion and Timeout on a document before it is parsed, name identifies the document in errors
+func (limits *Limits) CheckDocument(name string, data []byte) error {
+	if limits == nil {
+		return nil
+	}
+
+	if err := limits.checkTime(fmt.Sprintf("%q", name)); err != nil {
+		return err
+	}
+
+	if limits.MaxInputBytes > 0 && int64(len(data)) > limits.MaxInputBytes {
+		return fmt.Errorf("%w: %q is larger than %s=%d", ErrLimitExceeded, name, limitMaxInputBytes, limits.MaxInputBytes)
+	}
+
+	if limits.MaxAliasExpansion > 0 {
+		return limits.checkAliases(name, data)
+	}
+
+	return nil
+}
+
+// Reader returns a reader which stops after MaxInputBytes+1 bytes, so that a document which is too large isn't read in full before CheckDocument rejects it
+func (limits *Limits) Reader(r io.Reader) io.Reader {
+	if limits == nil || limits.MaxInputBytes == 0 {
+		return r
+	}
+	return io.LimitReader(r, limits.MaxInputBytes+1)
+}
+
+// checkAliases fails if expanding the aliases of a YAML document would add more than MaxAliasExpansion nodes to it
+// the document is parsed without expanding its aliases, the expansion of each anchored node is calculated once
+func (limits *Limits) checkAliases(name string, data []byte) error {
+	if !bytes.Contains(data, []byte("&")) || !bytes.Contains(data, []byte("*")) {
+		return nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		// leave the data as is and let the loader report the error
+		return nil
+	}
+
+	counter := aliasCounter{
+		max:   limits.MaxAliasExpansion,
+		sizes: map[*yaml.Node]int{},
+	}
+	if alias := counter.walk(&root); alias != nil {
+		return fmt.Errorf("%w: the aliases in %q expand to more than %s=%d nodes, at alias *%s on line %d", ErrLimitExceeded, name, limitMaxAliasExpansion, limits.MaxAliasExpansion, alias.Value, alias.Line)
+	}
+	return nil
+}
+
+type aliasCounter struct {
+	max   int
+	added int                // the number of nodes that the aliases walked so far add
+	sizes map[*yaml.Node]int // the expanded sizes of nodes, -1 while a node is being expanded
+}
+
+// walk walks the nodes of the document in order, and returns the alias whose expansion exceeds the limit, if there is one
+func (counter *aliasCounter) walk(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		// an alias replaces itself with the expanded anchored node
+		counter.added += counter.size(node.Alias) - 1
+		if counter.added > counter.max {
+			return node
+		}
+		return nil
+	}
+
+	for _, child := range node.Content {
+		if alias := counter.walk(child); alias != nil {
+			return alias
+		}
+	}
+	return nil
+}
+
+// size returns the number of nodes of a node with its aliases expanded, sizes above the limit are capped to keep them from overflowing
+func (counter *aliasCounter) size(node *yaml.Node) int {
+	if node == nil {
+		return 0
+	}
+
+	if size, ok := counter.sizes[node]; ok {
+		if size < 0 {
+			// a node which contains an alias to itself, the loader reports it
+			return 1
+		}
+		return size
+	}
+	counter.sizes[node] = -1
+
+	result := 1
+	if node.Kind == yaml.AliasNode {
+		result = counter.size(node.Alias)
+	}
+	for _, child := range node.Content {
+		result = min(result+counter.size(child), counter.max+2)
+	}
+
+	counter.sizes[node] = result
+	return result
+}
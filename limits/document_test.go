@@ -0,0 +1,57 @@
+package limits_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/limits"
+)
+
+// aliasBomb is a "billion laughs" document, each level multiplies the nodes of the previous one by ten
+const aliasBomb = `a: &a ["x","x","x","x","x","x","x","x","x","x"]
+b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a,*a]
+c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b,*b]
+d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c,*c]
+e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d,*d]
+f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e,*e]
+g: &g [*f,*f,*f,*f,*f,*f,*f,*f,*f,*f]
+h: &h [*g,*g,*g,*g,*g,*g,*g,*g,*g,*g]
+i: &i [*h,*h,*h,*h,*h,*h,*h,*h,*h,*h]
+`
+
+func TestCheckDocument_AliasBomb(t *testing.T) {
+	err := (&limits.Limits{MaxAliasExpansion: 10000}).CheckDocument("bomb.yaml", []byte(aliasBomb))
+	require.ErrorIs(t, err, limits.ErrLimitExceeded)
+	require.EqualError(t, err, `resource limit exceeded: the aliases in "bomb.yaml" expand to more than max-alias-expansion=10000 nodes, at alias *c on line 4`)
+}
+
+func TestCheckDocument_Aliases(t *testing.T) {
+	data := []byte("a: &a {type: string}\nb: *a\nc: *a\n")
+
+	// each alias adds the two nodes of the pair and the mapping, less the alias itself
+	require.NoError(t, (&limits.Limits{MaxAliasExpansion: 4}).CheckDocument("spec.yaml", data))
+	require.EqualError(t, (&limits.Limits{MaxAliasExpansion: 3}).CheckDocument("spec.yaml", data), `resource limit exceeded: the aliases in "spec.yaml" expand to more than max-alias-expansion=3 nodes, at alias *a on line 3`)
+}
+
+func TestCheckDocument_RecursiveAlias(t *testing.T) {
+	require.NoError(t, (&limits.Limits{MaxAliasExpansion: 10}).CheckDocument("spec.yaml", []byte("a: &a [*a]\n")))
+}
+
+func TestCheckDocument_InputBytes(t *testing.T) {
+	l := &limits.Limits{MaxInputBytes: 10}
+	require.NoError(t, l.CheckDocument("spec.yaml", []byte("openapi: 3")))
+	require.EqualError(t, l.CheckDocument("spec.yaml", []byte("openapi: 3.0")), `resource limit exceeded: "spec.yaml" is larger than max-input-bytes=10`)
+}
+
+func TestReader(t *testing.T) {
+	data, err := io.ReadAll((&limits.Limits{MaxInputBytes: 10}).Reader(strings.NewReader(aliasBomb)))
+	require.NoError(t, err)
+	require.Len(t, data, 11)
+}
+
+func TestCheckDocument_Unlimited(t *testing.T) {
+	var l *limits.Limits
+	require.NoError(t, l.CheckDocument("bomb.yaml", []byte(aliasBomb)))
+}
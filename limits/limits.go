@@ -0,0 +1,158 @@
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits restrict the resources that loading, flattening and comparing specs may use
+type Limits struct {
+	MaxInputBytes     int64         // the size of each document, unlimited if 0
+	MaxAliasExpansion int           // the number of YAML nodes that the aliases of each document add when they are expanded, unlimited if 0
+	MaxSchemaDepth    int           // the nesting depth of schemas, unlimited if 0
+	MaxSchemas        int           // the number of schemas that are visited while flattening allOf in a spec, or while comparing a pair of specs, unlimited if 0
+	Timeout           time.Duration // the total time of the run, measured from Start, unlimited if 0
+
+	start    sync.Once
+	deadline time.Time
+}
+
+// ErrLimitExceeded is returned when a spec exceeds one of the limits
+var ErrLimitExceeded = errors.New("resource limit exceeded")
+
+const (
+	limitMaxInputBytes     = "max-input-bytes"
+	limitMaxAliasExpansion = "max-alias-expansion"
+	limitMaxSchemaDepth    = "max-schema-depth"
+	limitMaxSchemas        = "max-schemas"
+	limitTimeout           = "timeout"
+)
+
+// GetLimitsOptions returns the options of ParseLimits
+func GetLimitsOptions() []string {
+	return []string{
+		limitMaxInputBytes + "=<n>",
+		limitMaxAliasExpansion + "=<n>",
+		limitMaxSchemaDepth + "=<n>",
+		limitMaxSchemas + "=<n>",
+		limitTimeout + "=<duration>",
+	}
+}
+
+// ParseLimits creates Limits from a list of options like max-input-bytes=<n>, max-alias-expansion=<n>, max-schema-depth=<n>, max-schemas=<n> and timeout=<duration>
+// an empty list returns nil, which is unlimited
+func ParseLimits(options []string) (*Limits, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+
+	result := &Limits{}
+	for _, option := range options {
+		name, value, _ := strings.Cut(option, "=")
+
+		switch name {
+		case limitMaxInputBytes:
+			n, err := parsePositive(name, value)
+			if err != nil {
+				return nil, err
+			}
+			result.MaxInputBytes = int64(n)
+		case limitMaxAliasExpansion:
+			n, err := parsePositive(name, value)
+			if err != nil {
+				return nil, err
+			}
+			result.MaxAliasExpansion = n
+		case limitMaxSchemaDepth:
+			n, err := parsePositive(name, value)
+			if err != nil {
+				return nil, err
+			}
+			result.MaxSchemaDepth = n
+		case limitMaxSchemas:
+			n, err := parsePositive(name, value)
+			if err != nil {
+				return nil, err
+			}
+			result.MaxSchemas = n
+		case limitTimeout:
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("invalid %s value %q: should be a positive duration, for example: 30s", name, value)
+			}
+			result.Timeout = d
+		default:
+			return nil, fmt.Errorf("invalid limit %q, allowed limits: %s", option, strings.Join(GetLimitsOptions(), ", "))
+		}
+	}
+	return result, nil
+}
+
+func parsePositive(name, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s value %q: should be a positive number", name, value)
+	}
+	return n, nil
+}
+
+// Start starts the clock of the timeout, it is called when the limits are first checked if it wasn't called before
+// call it at the beginning of the run so that the timeout covers all of it
+func (limits *Limits) Start() {
+	if limits == nil {
+		return
+	}
+
+	limits.start.Do(func() {
+		if limits.Timeout > 0 {
+			limits.deadline = time.Now().Add(limits.Timeout)
+		}
+	})
+}
+
+// checkTime fails if the run has exceeded the timeout, element describes what was being processed at the time
+func (limits *Limits) checkTime(element string) error {
+	limits.Start()
+
+	if limits.deadline.IsZero() || time.Now().Before(limits.deadline) {
+		return nil
+	}
+	return fmt.Errorf("%w: the run took longer than %s=%s, while processing %s", ErrLimitExceeded, limitTimeout, limits.Timeout, element)
+}
+
+// Deadline returns the end of the timeout of the run, ok is false if there is no timeout
+func (limits *Limits) Deadline() (deadline time.Time, ok bool) {
+	if limits == nil {
+		return time.Time{}, false
+	}
+
+	limits.Start()
+	return limits.deadline, !limits.deadline.IsZero()
+}
+
+// CheckTime fails if the run has exceeded the timeout, element describes what was being processed at the time
+func (limits *Limits) CheckTime(element string) error {
+	if limits == nil {
+		return nil
+	}
+	return limits.checkTime(element)
+}
+
+// Copy returns limits with the same settings whose timeout hasn't started, for repeated runs like the analyses of the language server
+func (limits *Limits) Copy() *Limits {
+	if limits == nil {
+		return nil
+	}
+
+	return &Limits{
+		MaxInputBytes:     limits.MaxInputBytes,
+		MaxAliasExpansion: limits.MaxAliasExpansion,
+		MaxSchemaDepth:    limits.MaxSchemaDepth,
+		MaxSchemas:        limits.MaxSchemas,
+		Timeout:           limits.Timeout,
+	}
+}
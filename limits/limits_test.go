@@ -0,0 +1,87 @@
+package limits_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/limits"
+)
+
+func TestParseLimits(t *testing.T) {
+	result, err := limits.ParseLimits([]string{"max-input-bytes=1000", "max-alias-expansion=100", "max-schema-depth=10", "max-schemas=50", "timeout=30s"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), result.MaxInputBytes)
+	require.Equal(t, 100, result.MaxAliasExpansion)
+	require.Equal(t, 10, result.MaxSchemaDepth)
+	require.Equal(t, 50, result.MaxSchemas)
+	require.Equal(t, 30*time.Second, result.Timeout)
+}
+
+func TestParseLimits_Empty(t *testing.T) {
+	result, err := limits.ParseLimits(nil)
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+func TestParseLimits_Invalid(t *testing.T) {
+	_, err := limits.ParseLimits([]string{"max-depth=10"})
+	require.EqualError(t, err, `invalid limit "max-depth=10", allowed limits: max-input-bytes=<n>, max-alias-expansion=<n>, max-schema-depth=<n>, max-schemas=<n>, timeout=<duration>`)
+
+	_, err = limits.ParseLimits([]string{"max-schemas=0"})
+	require.EqualError(t, err, `invalid max-schemas value "0": should be a positive number`)
+
+	_, err = limits.ParseLimits([]string{"max-schema-depth"})
+	require.EqualError(t, err, `invalid max-schema-depth value "": should be a positive number`)
+
+	_, err = limits.ParseLimits([]string{"timeout=10"})
+	require.EqualError(t, err, `invalid timeout value "10": should be a positive duration, for example: 30s`)
+}
+
+func TestWalk_Depth(t *testing.T) {
+	walk := (&limits.Limits{MaxSchemaDepth: 2}).NewWalk()
+	require.NoError(t, walk.Enter(openapi3.NewSchemaRef("#/components/schemas/Node", nil)))
+	require.NoError(t, walk.Enter(openapi3.NewSchemaRef("", nil)))
+	require.EqualError(t, walk.Enter(openapi3.NewSchemaRef("", nil)), `resource limit exceeded: an inline schema under "#/components/schemas/Node" is nested deeper than max-schema-depth=2`)
+
+	walk.Leave()
+	require.NoError(t, walk.Enter(openapi3.NewSchemaRef("#/components/schemas/Leaf", nil)))
+}
+
+func TestWalk_Components(t *testing.T) {
+	pet := openapi3.NewObjectSchema()
+	walk := (&limits.Limits{MaxSchemaDepth: 1}).NewWalk()
+	walk.AddComponents(&openapi3.Components{Schemas: openapi3.Schemas{"Pet": openapi3.NewSchemaRef("", pet)}})
+
+	require.NoError(t, walk.Enter(nil, openapi3.NewSchemaRef("", pet)))
+	require.EqualError(t, walk.Enter(openapi3.NewSchemaRef("", openapi3.NewStringSchema())), `resource limit exceeded: an inline schema under "#/components/schemas/Pet" is nested deeper than max-schema-depth=1`)
+}
+
+func TestWalk_Schemas(t *testing.T) {
+	walk := (&limits.Limits{MaxSchemas: 3}).NewWalk()
+	require.NoError(t, walk.Enter())
+	require.NoError(t, walk.Enter())
+	require.NoError(t, walk.Check(1))
+	require.Error(t, walk.Check(2))
+	require.NoError(t, walk.Enter())
+	pet := openapi3.NewSchemaRef("#/components/schemas/Pet", nil)
+	require.ErrorIs(t, walk.Enter(pet), limits.ErrLimitExceeded)
+	require.EqualError(t, walk.Enter(pet), `resource limit exceeded: visiting schema "#/components/schemas/Pet" exceeds max-schemas=3`)
+}
+
+func TestWalk_Timeout(t *testing.T) {
+	l := &limits.Limits{Timeout: time.Nanosecond}
+	l.Start()
+	time.Sleep(time.Millisecond)
+	require.EqualError(t, l.NewWalk().Enter(), "resource limit exceeded: the run took longer than timeout=1ns, while processing an inline schema")
+}
+
+func TestWalk_Unlimited(t *testing.T) {
+	var l *limits.Limits
+	walk := l.NewWalk()
+	require.Nil(t, walk)
+	require.NoError(t, walk.Enter())
+	require.NoError(t, walk.Check(1000))
+	walk.Leave()
+}
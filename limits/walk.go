@@ -0,0 +1,115 @@
+package limits
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Walk enforces MaxSchemaDepth, MaxSchemas and Timeout while walking the schemas of a spec, or of a pair of specs
+type Walk struct {
+	limits     *Limits
+	schemas    int
+	names      []string                    // the names of the schemas that are being walked, from the outermost one, "" for inline schemas
+	components map[*openapi3.Schema]string // the names of component schemas, which have no ref of their own
+}
+
+// NewWalk starts a walk, a nil Limits returns a nil Walk which is unlimited
+func (limits *Limits) NewWalk() *Walk {
+	if limits == nil {
+		return nil
+	}
+	return &Walk{
+		limits:     limits,
+		components: map[*openapi3.Schema]string{},
+	}
+}
+
+// AddComponents names the component schemas of a spec after their refs, like #/components/schemas/Pet, so that errors can refer to them and to their inline subschemas
+func (walk *Walk) AddComponents(components *openapi3.Components) {
+	if walk == nil || components == nil {
+		return
+	}
+
+	for name, schema := range components.Schemas {
+		if schema != nil && schema.Value != nil {
+			walk.components[schema.Value] = "#/components/schemas/" + name
+		}
+	}
+}
+
+// Enter is called before walking into a schema, or into a pair of schemas which are compared
+// Leave must be called after walking the schema, unless Enter returned an error
+func (walk *Walk) Enter(schemas ...*openapi3.SchemaRef) error {
+	if walk == nil {
+		return nil
+	}
+
+	name := walk.getName(schemas)
+
+	if walk.limits.MaxSchemaDepth > 0 && len(walk.names) >= walk.limits.MaxSchemaDepth {
+		return fmt.Errorf("%w: %s is nested deeper than %s=%d", ErrLimitExceeded, walk.describe(name), limitMaxSchemaDepth, walk.limits.MaxSchemaDepth)
+	}
+
+	if err := walk.check(1, name); err != nil {
+		return err
+	}
+
+	walk.schemas++
+	walk.names = append(walk.names, name)
+	return nil
+}
+
+// Leave is called after walking a schema
+func (walk *Walk) Leave() {
+	if walk == nil {
+		return
+	}
+	walk.names = walk.names[:len(walk.names)-1]
+}
+
+// Check fails if visiting n more schemas would exceed MaxSchemas, or if the run exceeded the timeout
+// it can be called before creating schemas which will be visited, like the combinations of oneOf schemas which are merged, to avoid creating too many of them
+func (walk *Walk) Check(n int) error {
+	if walk == nil {
+		return nil
+	}
+	return walk.check(n, "")
+}
+
+func (walk *Walk) check(n int, name string) error {
+	if walk.limits.MaxSchemas > 0 && n > walk.limits.MaxSchemas-walk.schemas {
+		return fmt.Errorf("%w: visiting %s exceeds %s=%d", ErrLimitExceeded, walk.describe(name), limitMaxSchemas, walk.limits.MaxSchemas)
+	}
+
+	return walk.limits.checkTime(walk.describe(name))
+}
+
+// getName returns the ref of the first schema which has one, or the name of the first component schema, or "" for inline schemas
+func (walk *Walk) getName(schemas []*openapi3.SchemaRef) string {
+	for _, schema := range schemas {
+		if schema != nil && schema.Ref != "" {
+			return schema.Ref
+		}
+	}
+	for _, schema := range schemas {
+		if schema != nil && walk.components[schema.Value] != "" {
+			return walk.components[schema.Value]
+		}
+	}
+	return ""
+}
+
+// describe returns a description of a schema for error messages, an inline schema is described by the nearest named schema that contains it
+func (walk *Walk) describe(name string) string {
+	if name != "" {
+		return fmt.Sprintf("schema %q", name)
+	}
+
+	for i := len(walk.names) - 1; i >= 0; i-- {
+		if walk.names[i] != "" {
+			return fmt.Sprintf("an inline schema under %q", walk.names[i])
+		}
+	}
+	return "an inline schema"
+}
@@ -14,11 +14,32 @@ const (
 type Check func(string, *load.SpecInfo) []*Error
 
 type Error struct {
-	Id      string `json:"id,omitempty" yaml:"id,omitempty"`
-	Text    string `json:"text,omitempty" yaml:"text,omitempty"`
-	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
-	Level   int    `json:"level" yaml:"level"`
-	Source  string `json:"source,omitempty" yaml:"source,omitempty"`
+	Id           string `json:"id,omitempty" yaml:"id,omitempty"`
+	Text         string `json:"text,omitempty" yaml:"text,omitempty"`
+	Comment      string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Level        int    `json:"level" yaml:"level"`
+	Source       string `json:"source,omitempty" yaml:"source,omitempty"`
+	Operation    string `json:"operation,omitempty" yaml:"operation,omitempty"`
+	Path         string `json:"path,omitempty" yaml:"path,omitempty"`
+	SourceLine   int    `json:"sourceLine,omitempty" yaml:"sourceLine,omitempty"`
+	SourceColumn int    `json:"sourceColumn,omitempty" yaml:"sourceColumn,omitempty"`
+}
+
+// withSource sets the zero-based line and column of the error to the position of a spec element, if it can be located
+func (e *Error) withSource(sourceMap *load.SourceMap, element any) *Error {
+	if position, ok := sourceMap.ElementPosition(element); ok {
+		e.SourceLine = position.Line
+		e.SourceColumn = position.Column
+	}
+	return e
+}
+
+// LevelString returns the name of the error level
+func (e *Error) LevelString() string {
+	if e.Level == LEVEL_ERROR {
+		return "error"
+	}
+	return "warning"
 }
 
 type Errors []*Error
@@ -35,6 +56,10 @@ func (e Errors) Less(i, j int) bool {
 		return iv.Level < jv.Level
 	case iv.Source != jv.Source:
 		return iv.Source < jv.Source
+	case iv.Path != jv.Path:
+		return iv.Path < jv.Path
+	case iv.Operation != jv.Operation:
+		return iv.Operation < jv.Operation
 	case iv.Id != jv.Id:
 		return iv.Id < jv.Id
 	case iv.Text != jv.Text:
@@ -48,6 +73,25 @@ func (e Errors) Swap(i, j int) {
 	e[i], e[j] = e[j], e[i]
 }
 
+// HasLevelOrHigher indicates whether the errors include an error with the given level or a more severe one
+func (e Errors) HasLevelOrHigher(level int) bool {
+	for _, err := range e {
+		if err.Level <= level {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLevelCount returns the number of errors for each level
+func (e Errors) GetLevelCount() map[int]int {
+	counts := map[int]int{}
+	for _, err := range e {
+		counts[err.Level]++
+	}
+	return counts
+}
+
 func Run(config *Config, source string, spec *load.SpecInfo) Errors {
 	result := make(Errors, 0)
 
@@ -1,5 +1,18 @@
 package lint
 
+import (
+	"fmt"
+	"sort"
+)
+
+// Check ids that can be used to select individual checks
+const (
+	SchemaCheckId         = "schema"
+	PathParamsCheckId     = "path-params"
+	RequiredParamsCheckId = "required-params"
+	InfoCheckId           = "info"
+)
+
 type Config struct {
 	Checks []Check
 }
@@ -14,6 +27,36 @@ func DefaultConfig() *Config {
 	}
 }
 
+// NewConfigFromIds returns a config with the checks matching the given ids
+func NewConfigFromIds(ids []string) (*Config, error) {
+	checks := make([]Check, 0, len(ids))
+	for _, id := range ids {
+		check, ok := checksById[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown lint check %q", id)
+		}
+		checks = append(checks, check)
+	}
+	return NewConfig(checks), nil
+}
+
+// GetCheckIds returns the ids of all lint checks
+func GetCheckIds() []string {
+	result := make([]string, 0, len(checksById))
+	for id := range checksById {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+	return result
+}
+
+var checksById = map[string]Check{
+	SchemaCheckId:         SchemaCheck,
+	PathParamsCheckId:     PathParamsCheck,
+	RequiredParamsCheckId: RequiredParamsCheck,
+	InfoCheckId:           InfoCheck,
+}
+
 func defaultChecks() []Check {
 	return []Check{
 		SchemaCheck,
@@ -0,0 +1,29 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/lint"
+)
+
+func TestGetCheckIds(t *testing.T) {
+	require.Equal(t, []string{"info", "path-params", "required-params", "schema"}, lint.GetCheckIds())
+}
+
+func TestNewConfigFromIds(t *testing.T) {
+
+	const source = "../data/lint/info/no-info.yaml"
+	config, err := lint.NewConfigFromIds([]string{lint.PathParamsCheckId, lint.InfoCheckId})
+	require.NoError(t, err)
+	require.Len(t, config.Checks, 2)
+
+	errs := lint.Run(config, source, loadFrom(t, source))
+	require.Len(t, errs, 1)
+	require.Equal(t, "info-missing", errs[0].Id)
+}
+
+func TestNewConfigFromIds_Unknown(t *testing.T) {
+	_, err := lint.NewConfigFromIds([]string{"unknown"})
+	require.EqualError(t, err, `unknown lint check "unknown"`)
+}
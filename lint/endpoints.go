@@ -0,0 +1,46 @@
+package lint
+
+import (
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/utils"
+)
+
+// FilterEndpoints returns the errors that belong to one of the given endpoints
+// Errors on a path without an operation, like a path-level parameter, are kept if any of the endpoints is under that path
+// Errors that don't belong to any path, like a missing info section, are dropped
+func (e Errors) FilterEndpoints(endpoints []diff.Endpoint) Errors {
+	methodsByPath := map[string]utils.StringSet{}
+	for _, endpoint := range endpoints {
+		if _, ok := methodsByPath[endpoint.Path]; !ok {
+			methodsByPath[endpoint.Path] = utils.StringSet{}
+		}
+		methodsByPath[endpoint.Path].Add(endpoint.Method)
+	}
+
+	result := make(Errors, 0, len(e))
+	for _, err := range e {
+		methods, ok := methodsByPath[err.Path]
+		if !ok {
+			continue
+		}
+		if err.Operation != "" && !methods.Contains(err.Operation) {
+			continue
+		}
+		result = append(result, err)
+	}
+	return result
+}
+
+// GetChangedEndpoints returns the endpoints that were added or modified in the revision
+func GetChangedEndpoints(endpointsDiff *diff.EndpointsDiff) []diff.Endpoint {
+	if endpointsDiff.Empty() {
+		return nil
+	}
+
+	result := make([]diff.Endpoint, 0, len(endpointsDiff.Added)+len(endpointsDiff.Modified))
+	result = append(result, endpointsDiff.Added...)
+	for endpoint := range endpointsDiff.Modified {
+		result = append(result, endpoint)
+	}
+	return result
+}
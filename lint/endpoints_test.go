@@ -0,0 +1,41 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/lint"
+)
+
+func TestFilterEndpoints(t *testing.T) {
+	errs := lint.Errors{
+		{Id: "operation-changed", Operation: "GET", Path: "/changed"},
+		{Id: "operation-unchanged", Operation: "POST", Path: "/changed"},
+		{Id: "path-changed", Path: "/changed"},
+		{Id: "path-unchanged", Path: "/unchanged"},
+		{Id: "spec"},
+	}
+
+	filtered := errs.FilterEndpoints([]diff.Endpoint{{Method: "GET", Path: "/changed"}})
+	require.Len(t, filtered, 2)
+	require.Equal(t, "operation-changed", filtered[0].Id)
+	require.Equal(t, "path-changed", filtered[1].Id)
+}
+
+func TestGetChangedEndpoints(t *testing.T) {
+	endpointsDiff := &diff.EndpointsDiff{
+		Added:    diff.Endpoints{{Method: "GET", Path: "/added"}},
+		Deleted:  diff.Endpoints{{Method: "GET", Path: "/deleted"}},
+		Modified: diff.ModifiedEndpoints{{Method: "PUT", Path: "/modified"}: &diff.MethodDiff{}},
+	}
+
+	require.ElementsMatch(t, []diff.Endpoint{
+		{Method: "GET", Path: "/added"},
+		{Method: "PUT", Path: "/modified"},
+	}, lint.GetChangedEndpoints(endpointsDiff))
+}
+
+func TestGetChangedEndpoints_Nil(t *testing.T) {
+	require.Empty(t, lint.GetChangedEndpoints(nil))
+}
@@ -25,19 +25,20 @@ func PathParamsCheck(source string, s *load.SpecInfo) []*Error {
 			}
 
 			if !parameter.Value.Required {
-				result = append(result, &Error{
+				result = append(result, (&Error{
 					Id:     "path-param-not-required",
 					Level:  LEVEL_ERROR,
 					Text:   fmt.Sprintf("path parameter %q should have required=true: %s", parameter.Value.Name, path),
 					Source: source,
-				})
+					Path:   path,
+				}).withSource(s.SourceMap, parameter.Value))
 			}
 
 			pathParams.Add(parameter.Value.Name)
 		}
 
 		for method, op := range pathItem.Operations() {
-			result = append(result, checkOperationPathParams(pathParamsFromURL, pathParams, path, method, op, source)...)
+			result = append(result, checkOperationPathParams(pathParamsFromURL, pathParams, path, method, op, source, s.SourceMap)...)
 		}
 	}
 
@@ -49,7 +50,7 @@ func getPathParamsFromURL(path string) utils.StringSet {
 	return utils.StringList(pathParams).ToStringSet()
 }
 
-func checkOperationPathParams(pathParamsFromURL, pathParams utils.StringSet, path, method string, op *openapi3.Operation, source string) []*Error {
+func checkOperationPathParams(pathParamsFromURL, pathParams utils.StringSet, path, method string, op *openapi3.Operation, source string, sourceMap *load.SourceMap) []*Error {
 	result := make([]*Error, 0)
 
 	opParams := utils.StringSet{}
@@ -59,42 +60,50 @@ func checkOperationPathParams(pathParamsFromURL, pathParams utils.StringSet, pat
 		}
 
 		if !parameter.Value.Required {
-			result = append(result, &Error{
-				Id:     "path-param-not-required",
-				Level:  LEVEL_ERROR,
-				Text:   fmt.Sprintf("path parameter %q should have required=true: %s %s", parameter.Value.Name, method, path),
-				Source: source,
-			})
+			result = append(result, (&Error{
+				Id:        "path-param-not-required",
+				Level:     LEVEL_ERROR,
+				Text:      fmt.Sprintf("path parameter %q should have required=true: %s %s", parameter.Value.Name, method, path),
+				Source:    source,
+				Operation: method,
+				Path:      path,
+			}).withSource(sourceMap, parameter.Value))
 		}
 
 		opParams.Add(parameter.Value.Name)
 	}
 
 	for param := range pathParams.Plus(opParams).Minus(pathParamsFromURL) {
-		result = append(result, &Error{
-			Id:     "path-param-extra",
-			Level:  LEVEL_ERROR,
-			Text:   getParamMissingText(opParams, param, method, path),
-			Source: source,
-		})
+		result = append(result, (&Error{
+			Id:        "path-param-extra",
+			Level:     LEVEL_ERROR,
+			Text:      getParamMissingText(opParams, param, method, path),
+			Source:    source,
+			Operation: method,
+			Path:      path,
+		}).withSource(sourceMap, op))
 	}
 
 	for param := range pathParamsFromURL.Minus(pathParams).Minus(opParams) {
-		result = append(result, &Error{
-			Id:     "path-param-missing",
-			Level:  LEVEL_WARN,
-			Text:   fmt.Sprintf("path parameter %q appears in the URL path but is missing from the parameters section of the path and operation: %s %s", param, method, path),
-			Source: source,
-		})
+		result = append(result, (&Error{
+			Id:        "path-param-missing",
+			Level:     LEVEL_WARN,
+			Text:      fmt.Sprintf("path parameter %q appears in the URL path but is missing from the parameters section of the path and operation: %s %s", param, method, path),
+			Source:    source,
+			Operation: method,
+			Path:      path,
+		}).withSource(sourceMap, op))
 	}
 
 	for param := range pathParams.Intersection(opParams) {
-		result = append(result, &Error{
-			Id:     "path-param-duplicate",
-			Level:  LEVEL_WARN,
-			Text:   fmt.Sprintf("path parameter %q is defined both in path and in operation: %s %s", param, method, path),
-			Source: source,
-		})
+		result = append(result, (&Error{
+			Id:        "path-param-duplicate",
+			Level:     LEVEL_WARN,
+			Text:      fmt.Sprintf("path parameter %q is defined both in path and in operation: %s %s", param, method, path),
+			Source:    source,
+			Operation: method,
+			Path:      path,
+		}).withSource(sourceMap, op))
 	}
 
 	return result
@@ -2,20 +2,18 @@ package lint
 
 import (
 	"regexp"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
-func checkRegex(pattern string, s *state) *Error {
+func checkRegex(schema *openapi3.Schema, s *state) *Error {
+	pattern := schema.Pattern
 	if pattern == "" {
 		return nil
 	}
 
 	if err := validate(s.cache, pattern); err != nil {
-		return &Error{
-			Id:     "invalid-regex-pattern",
-			Level:  LEVEL_ERROR,
-			Text:   err.Error(),
-			Source: s.source,
-		}
+		return s.newError("invalid-regex-pattern", LEVEL_ERROR, err.Error(), schema)
 	}
 
 	return nil
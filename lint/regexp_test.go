@@ -3,8 +3,10 @@ package lint_test
 import (
 	"testing"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/stretchr/testify/require"
 	"github.com/tufin/oasdiff/lint"
+	"github.com/tufin/oasdiff/load"
 )
 
 func TestRegexCheck(t *testing.T) {
@@ -35,3 +37,17 @@ func TestRegexCheck_Circular(t *testing.T) {
 	errs := lint.Run(lint.NewConfig([]lint.Check{lint.SchemaCheck}), source, loadFrom(t, source))
 	require.Empty(t, errs)
 }
+
+func TestRegexCheck_Location(t *testing.T) {
+
+	const source = "../data/lint/regex/openapi-invalid-regex.yaml"
+	spec, err := load.NewSpecInfo(openapi3.NewLoader(), load.NewSource(source))
+	require.NoError(t, err)
+
+	errs := lint.Run(lint.NewConfig([]lint.Check{lint.SchemaCheck}), source, spec)
+	require.Len(t, errs, 1)
+	require.Equal(t, "/api/{domain}/{project}/badges/security-score", errs[0].Path)
+	require.Empty(t, errs[0].Operation)
+	require.Equal(t, 26, errs[0].SourceLine)
+	require.Equal(t, 6, errs[0].SourceColumn)
+}
@@ -21,23 +21,24 @@ func RequiredParamsCheck(source string, s *load.SpecInfo) []*Error {
 			}
 
 			if parameter.Value.Schema != nil && parameter.Value.Schema.Value.Default != nil {
-				result = append(result, &Error{
+				result = append(result, (&Error{
 					Id:     "required-param-with-default",
 					Level:  LEVEL_ERROR,
 					Text:   fmt.Sprintf("required path parameter %q shouldn't have a default value: %s", parameter.Value.Name, path),
 					Source: source,
-				})
+					Path:   path,
+				}).withSource(s.SourceMap, parameter.Value))
 			}
 		}
 		for method, op := range pathItem.Operations() {
-			result = append(result, checkOperationRequiredParams(path, method, op, source)...)
+			result = append(result, checkOperationRequiredParams(path, method, op, source, s.SourceMap)...)
 		}
 	}
 
 	return result
 }
 
-func checkOperationRequiredParams(path, method string, op *openapi3.Operation, source string) []*Error {
+func checkOperationRequiredParams(path, method string, op *openapi3.Operation, source string, sourceMap *load.SourceMap) []*Error {
 	result := make([]*Error, 0)
 
 	for _, parameter := range op.Parameters {
@@ -46,12 +47,14 @@ func checkOperationRequiredParams(path, method string, op *openapi3.Operation, s
 		}
 
 		if parameter.Value.Schema != nil && parameter.Value.Schema.Value.Default != nil {
-			result = append(result, &Error{
-				Id:     "required-param-with-default",
-				Level:  LEVEL_ERROR,
-				Text:   fmt.Sprintf("required path parameter %q shouldn't have a default value: %s %s", parameter.Value.Name, method, path),
-				Source: source,
-			})
+			result = append(result, (&Error{
+				Id:        "required-param-with-default",
+				Level:     LEVEL_ERROR,
+				Text:      fmt.Sprintf("required path parameter %q shouldn't have a default value: %s %s", parameter.Value.Name, method, path),
+				Source:    source,
+				Operation: method,
+				Path:      path,
+			}).withSource(sourceMap, parameter.Value))
 		}
 	}
 
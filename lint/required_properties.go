@@ -19,12 +19,7 @@ func checkRequireProperties(schema *openapi3.Schema, s *state) *Error {
 	}
 
 	if extraRequiredProps := requiredProps.Minus(props); !extraRequiredProps.Empty() {
-		return &Error{
-			Id:     "extra_required_props",
-			Level:  LEVEL_ERROR,
-			Text:   fmt.Sprintf("none-existing properties %v defined as required", extraRequiredProps.ToStringList()),
-			Source: s.source,
-		}
+		return s.newError("extra_required_props", LEVEL_ERROR, fmt.Sprintf("none-existing properties %v defined as required", extraRequiredProps.ToStringList()), schema)
 	}
 
 	return nil
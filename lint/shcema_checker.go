@@ -8,18 +8,34 @@ import (
 
 type state struct {
 	source      string
+	sourceMap   *load.SourceMap
 	cache       map[string]error
 	visitedRefs utils.VisitedRefs
+	path        string // the endpoint currently being checked
+	method      string
 }
 
-func newState(source string) *state {
+func newState(source string, sourceMap *load.SourceMap) *state {
 	return &state{
 		source:      source,
+		sourceMap:   sourceMap,
 		cache:       map[string]error{},
 		visitedRefs: utils.VisitedRefs{},
 	}
 }
 
+// newError creates an error for the endpoint currently being checked, located at the given schema
+func (s *state) newError(id string, level int, text string, schema *openapi3.Schema) *Error {
+	return (&Error{
+		Id:        id,
+		Level:     level,
+		Text:      text,
+		Source:    s.source,
+		Operation: s.method,
+		Path:      s.path,
+	}).withSource(s.sourceMap, schema)
+}
+
 func SchemaCheck(source string, spec *load.SpecInfo) []*Error {
 	result := make([]*Error, 0)
 
@@ -27,11 +43,17 @@ func SchemaCheck(source string, spec *load.SpecInfo) []*Error {
 		return result
 	}
 
-	s := newState(source)
+	s := newState(source, spec.SourceMap)
+
+	for path, pathItem := range spec.Spec.Paths.Map() {
+		s.path, s.method = path, ""
+		result = append(result, checkParameters(pathItem.Parameters, s)...)
 
-	for _, path := range spec.Spec.Paths.Map() {
-		result = append(result, checkParameters(path.Parameters, s)...)
-		result = append(result, checkOperations(path.Operations(), s)...)
+		// errors in callbacks are attributed to the operation that defines them
+		for method, op := range pathItem.Operations() {
+			s.method = method
+			result = append(result, checkOperation(op, s)...)
+		}
 	}
 
 	return result
@@ -40,29 +62,35 @@ func SchemaCheck(source string, spec *load.SpecInfo) []*Error {
 func checkOperations(operations map[string]*openapi3.Operation, s *state) []*Error {
 	result := make([]*Error, 0)
 	for _, op := range operations {
+		result = append(result, checkOperation(op, s)...)
+	}
+	return result
+}
 
-		result = append(result, checkParameters(op.Parameters, s)...)
+func checkOperation(op *openapi3.Operation, s *state) []*Error {
+	result := make([]*Error, 0)
 
-		if op.RequestBody != nil {
-			for _, mediaType := range op.RequestBody.Value.Content {
-				result = append(result, checkSchemaRef(mediaType.Schema, s)...)
-			}
+	result = append(result, checkParameters(op.Parameters, s)...)
+
+	if op.RequestBody != nil {
+		for _, mediaType := range op.RequestBody.Value.Content {
+			result = append(result, checkSchemaRef(mediaType.Schema, s)...)
 		}
+	}
 
-		for _, response := range op.Responses.Map() {
-			for _, mediaType := range response.Value.Content {
-				result = append(result, checkSchemaRef(mediaType.Schema, s)...)
-			}
-			for _, header := range response.Value.Headers {
-				result = append(result, checkSchemaRef(header.Value.Schema, s)...)
-			}
+	for _, response := range op.Responses.Map() {
+		for _, mediaType := range response.Value.Content {
+			result = append(result, checkSchemaRef(mediaType.Schema, s)...)
+		}
+		for _, header := range response.Value.Headers {
+			result = append(result, checkSchemaRef(header.Value.Schema, s)...)
 		}
+	}
 
-		for _, callback := range op.Callbacks {
-			for _, pathItem := range callback.Value.Map() {
-				result = append(result, checkParameters(pathItem.Parameters, s)...)
-				result = append(result, checkOperations(pathItem.Operations(), s)...)
-			}
+	for _, callback := range op.Callbacks {
+		for _, pathItem := range callback.Value.Map() {
+			result = append(result, checkParameters(pathItem.Parameters, s)...)
+			result = append(result, checkOperations(pathItem.Operations(), s)...)
 		}
 	}
 	return result
@@ -131,7 +159,7 @@ func checkSchemaRef(schema *openapi3.SchemaRef, s *state) []*Error {
 func runCheckers(schema *openapi3.Schema, s *state) []*Error {
 	result := make([]*Error, 0)
 
-	if err := checkRegex(schema.Pattern, s); err != nil {
+	if err := checkRegex(schema, s); err != nil {
 		result = append(result, err)
 	}
 
@@ -0,0 +1,213 @@
+package load
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPI 3.0 and 3.1 express some schema constraints differently:
+// - nullable: 3.0 uses `nullable: true` while 3.1 adds "null" to the types, e.g. `type: [string, "null"]`
+// - exclusive bounds: 3.0 uses `minimum: 5` with `exclusiveMinimum: true` while 3.1 uses `exclusiveMinimum: 5`
+//
+// kin-openapi models the 3.0 form of exclusive bounds only, so the numeric 3.1 form is converted while loading the spec.
+// The nullable forms are both supported by kin-openapi, so they are only mapped onto a common model by NormalizeDialect.
+
+// NormalizeDialect maps the OpenAPI 3.0 and 3.1 forms of nullable schemas onto the 3.0 form
+// A "null" type which appears alongside other types is removed and the schema is marked as nullable instead.
+func NormalizeDialect(spec *openapi3.T) {
+	if spec == nil {
+		return
+	}
+
+	// webhooks which can't be resolved are left as they are, the error is reported when they are compared
+	_ = ResolveWebhooks(spec)
+
+	newSchemaWalker(normalizeNullable).spec(spec)
+}
+
+func normalizeNullable(schema *openapi3.Schema) {
+	if schema.Type == nil || len(*schema.Type) < 2 || !schema.Type.Includes(openapi3.TypeNull) {
+		return
+	}
+
+	types := make(openapi3.Types, 0, len(*schema.Type)-1)
+	for _, typ := range *schema.Type {
+		if typ != openapi3.TypeNull {
+			types = append(types, typ)
+		}
+	}
+
+	schema.Type = &types
+	schema.Nullable = true
+}
+
+// dialectLoader returns a loader which converts the numeric exclusive bounds of OpenAPI 3.1 when the original loader is a kin-openapi loader
+func dialectLoader(loader Loader) Loader {
+	if original, ok := loader.(*openapi3.Loader); ok {
+		return newDialectLoader(original)
+	}
+	return loader
+}
+
+// newDialectLoader returns a loader which converts the numeric exclusive bounds of OpenAPI 3.1 into the form that kin-openapi supports
+// settings, like whether external refs are allowed, how refs are read, the ref policy and the limits, are taken from the original loader
+func newDialectLoader(original *openapi3.Loader) *openapi3.Loader {
+	result := openapi3.NewLoader()
+	result.IsExternalRefsAllowed = original.IsExternalRefsAllowed
+	result.Context = original.Context
+
+	read := original.ReadFromURIFunc
+	if read == nil {
+		read = defaultReadFromURI
+	}
+	result.ReadFromURIFunc = readWithExclusiveBounds(readWithLimits(readWithRefPolicy(read)))
+
+	return result
+}
+
+// readWithExclusiveBounds wraps a function that reads specs and converts their numeric exclusive bounds
+func readWithExclusiveBounds(read openapi3.ReadFromURIFunc) openapi3.ReadFromURIFunc {
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := read(loader, location)
+		if err != nil {
+			return nil, err
+		}
+		return normalizeExclusiveBounds(data)
+	}
+}
+
+// loadFromStdin loads a spec from stdin, converting its numeric exclusive bounds when the loader is a kin-openapi loader
+func loadFromStdin(loader Loader) (*openapi3.T, error) {
+	original, ok := loader.(*openapi3.Loader)
+	if !ok {
+		return loader.LoadFromStdin()
+	}
+
+	data, err := io.ReadAll(getLimits(original).Reader(os.Stdin))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkLimits(original, nil, data); err != nil {
+		return nil, err
+	}
+
+	if data, err = normalizeExclusiveBounds(data); err != nil {
+		return nil, err
+	}
+
+	return loadFromData(original, data, nil)
+}
+
+// schemaDataKeys are schema fields whose values are instance data rather than schemas, so they are not converted
+var schemaDataKeys = map[string]struct{}{
+	"example":    {},
+	"default":    {},
+	"enum":       {},
+	ConstKeyword: {},
+}
+
+// normalizeExclusiveBounds converts numeric exclusiveMinimum and exclusiveMaximum (3.1) into minimum and maximum with boolean exclusive flags (3.0)
+// Data without numeric exclusive bounds is returned as is.
+func normalizeExclusiveBounds(data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte("exclusiveM")) {
+		return data, nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		// leave the data as is and let the loader report the error
+		return data, nil
+	}
+
+	if !convertExclusiveBounds(&root) {
+		return data, nil
+	}
+
+	result, err := yaml.Marshal(&root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert exclusive bounds: %w", err)
+	}
+	return result, nil
+}
+
+// convertExclusiveBounds converts the numeric exclusive bounds in a node and its descendants and returns whether anything changed
+func convertExclusiveBounds(node *yaml.Node) bool {
+	changed := false
+
+	if node.Kind == yaml.MappingNode {
+		changed = convertExclusiveBound(node, "exclusiveMinimum", "minimum", func(exclusive, inclusive float64) bool { return exclusive >= inclusive })
+		changed = convertExclusiveBound(node, "exclusiveMaximum", "maximum", func(exclusive, inclusive float64) bool { return exclusive <= inclusive }) || changed
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if _, ok := schemaDataKeys[node.Content[i].Value]; ok {
+				continue
+			}
+			changed = convertExclusiveBounds(node.Content[i+1]) || changed
+		}
+		return changed
+	}
+
+	for _, child := range node.Content {
+		changed = convertExclusiveBounds(child) || changed
+	}
+	return changed
+}
+
+// convertExclusiveBound converts a numeric exclusive bound of a mapping node
+// stricter indicates whether the exclusive bound is at least as strict as the inclusive one, in which case it replaces it
+// otherwise, the exclusive bound is redundant and removed
+func convertExclusiveBound(node *yaml.Node, exclusiveKey, inclusiveKey string, stricter func(exclusive, inclusive float64) bool) bool {
+	exclusiveIndex := mappingIndex(node, exclusiveKey)
+	if exclusiveIndex < 0 {
+		return false
+	}
+
+	exclusiveNode := node.Content[exclusiveIndex+1]
+	exclusive, ok := numericValue(exclusiveNode)
+	if !ok {
+		return false
+	}
+
+	inclusiveIndex := mappingIndex(node, inclusiveKey)
+	if inclusiveIndex >= 0 {
+		if inclusive, ok := numericValue(node.Content[inclusiveIndex+1]); ok && !stricter(exclusive, inclusive) {
+			node.Content = append(node.Content[:exclusiveIndex], node.Content[exclusiveIndex+2:]...)
+			return true
+		}
+		node.Content[inclusiveIndex+1] = exclusiveNode
+	} else {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: inclusiveKey}, exclusiveNode)
+	}
+
+	node.Content[exclusiveIndex+1] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+	return true
+}
+
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func numericValue(node *yaml.Node) (float64, bool) {
+	if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(node.Value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,111 @@
+package load_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+func getProduct(t *testing.T, options ...load.Option) *openapi3.Schema {
+	t.Helper()
+
+	specInfo, err := load.NewSpecInfo(openapi3.NewLoader(), load.NewSource("../data/dialect/openapi-3.1.yaml"), options...)
+	require.NoError(t, err)
+	return specInfo.Spec.Components.Schemas["Product"].Value
+}
+
+func TestDialect_ExclusiveBounds(t *testing.T) {
+	product := getProduct(t)
+
+	price := product.Properties["price"].Value
+	require.Equal(t, 0.0, *price.Min)
+	require.True(t, price.ExclusiveMin)
+
+	discount := product.Properties["discount"].Value
+	require.Equal(t, 0.0, *discount.Min)
+	require.False(t, discount.ExclusiveMin)
+	require.Equal(t, 100.0, *discount.Max)
+	require.True(t, discount.ExclusiveMax)
+}
+
+func TestDialect_ExclusiveBoundsWithMinimum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Stricter:
+      type: number
+      minimum: 0
+      exclusiveMinimum: 5
+    Redundant:
+      type: number
+      minimum: 10
+      exclusiveMinimum: 5
+    Legacy:
+      type: number
+      minimum: 0
+      exclusiveMinimum: true
+    Example:
+      type: object
+      example:
+        exclusiveMinimum: 5
+`), 0644))
+
+	specInfo, err := load.NewSpecInfo(openapi3.NewLoader(), load.NewSource(path))
+	require.NoError(t, err)
+	schemas := specInfo.Spec.Components.Schemas
+
+	// the exclusive bound is stricter, so it replaces the minimum
+	require.Equal(t, 5.0, *schemas["Stricter"].Value.Min)
+	require.True(t, schemas["Stricter"].Value.ExclusiveMin)
+
+	// the minimum is stricter, so the exclusive bound is dropped
+	require.Equal(t, 10.0, *schemas["Redundant"].Value.Min)
+	require.False(t, schemas["Redundant"].Value.ExclusiveMin)
+
+	// the 3.0 form remains as is
+	require.Equal(t, 0.0, *schemas["Legacy"].Value.Min)
+	require.True(t, schemas["Legacy"].Value.ExclusiveMin)
+
+	// examples are data rather than schemas
+	require.Equal(t, map[string]any{"exclusiveMinimum": 5.0}, schemas["Example"].Value.Example)
+}
+
+func TestDialect_NullableNotNormalized(t *testing.T) {
+	description := getProduct(t).Properties["description"].Value
+	require.Equal(t, &openapi3.Types{openapi3.TypeString, openapi3.TypeNull}, description.Type)
+	require.False(t, description.Nullable)
+}
+
+func TestDialect_WithNormalizeDialect(t *testing.T) {
+	description := getProduct(t, load.WithNormalizeDialect()).Properties["description"].Value
+	require.Equal(t, &openapi3.Types{openapi3.TypeString}, description.Type)
+	require.True(t, description.Nullable)
+}
+
+func TestNormalizeDialect(t *testing.T) {
+	schema := &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNull}}
+	spec := &openapi3.T{
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{
+				"null": openapi3.NewSchemaRef("", schema),
+			},
+		},
+	}
+
+	// a schema which only allows null remains as is
+	load.NormalizeDialect(spec)
+	require.Equal(t, &openapi3.Types{openapi3.TypeNull}, schema.Type)
+	require.False(t, schema.Nullable)
+
+	load.NormalizeDialect(nil)
+}
@@ -1,3 +1,3 @@
-// Package load loads OpenAPI specs from different sources like URLs, paths, globs and stdin
+// Package load loads OpenAPI specs from different sources like URLs, paths, globs, git revisions and stdin
 // Optionally, specs can be preprocessed after loading
 package load
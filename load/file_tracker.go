@@ -0,0 +1,94 @@
+package load
+
+import (
+	"crypto/sha256"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// FileTracker records the local files which a loader reads, including the files of external references, along with a hash of their contents
+// it is used to find out whether a spec needs to be reloaded
+type FileTracker struct {
+	mu     sync.Mutex
+	hashes map[string][sha256.Size]byte
+}
+
+// NewFileTracker creates an empty FileTracker
+func NewFileTracker() *FileTracker {
+	return &FileTracker{
+		hashes: map[string][sha256.Size]byte{},
+	}
+}
+
+// Track configures the loader to record the local files that it reads
+// files in git revisions and URLs aren't recorded, since they are not expected to change
+func (tracker *FileTracker) Track(loader *openapi3.Loader) {
+	read := loader.ReadFromURIFunc
+	if read == nil {
+		// unlike defaultReadFromURI, local files are not cached, so that changed files are read again
+		read = uncachedReadFromURI
+	}
+
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := read(loader, location)
+		if location.Scheme == "" && location.Host == "" {
+			tracker.add(filepath.Clean(filepath.FromSlash(location.Path)), data, err)
+		}
+		return data, err
+	}
+}
+
+// Add records a local file with its current contents
+// this is useful for files which the loader failed to read, like the file of a spec which doesn't exist yet
+func (tracker *FileTracker) Add(file string) {
+	data, err := os.ReadFile(file)
+	tracker.add(filepath.Clean(file), data, err)
+}
+
+func (tracker *FileTracker) add(file string, data []byte, err error) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	tracker.hashes[file] = hashFile(data, err)
+}
+
+// hashFile hashes the contents of a file, a file which can't be read has the hash of empty contents
+func hashFile(data []byte, err error) [sha256.Size]byte {
+	if err != nil {
+		data = nil
+	}
+	return sha256.Sum256(data)
+}
+
+// Files returns the recorded files, sorted
+func (tracker *FileTracker) Files() []string {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	result := make([]string, 0, len(tracker.hashes))
+	for file := range tracker.hashes {
+		result = append(result, file)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// Changed returns the recorded files whose contents changed since they were recorded, sorted
+func (tracker *FileTracker) Changed() []string {
+	result := []string{}
+	for _, file := range tracker.Files() {
+		tracker.mu.Lock()
+		hash := tracker.hashes[file]
+		tracker.mu.Unlock()
+
+		if hashFile(os.ReadFile(file)) != hash {
+			result = append(result, file)
+		}
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package load_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+const trackedSpec = `openapi: 3.0.1
+info:
+  title: Test API
+  version: v1
+paths:
+  /test:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "schemas.yaml#/Test"
+`
+
+const trackedSchemas = `Test:
+  type: string
+`
+
+func writeTrackedSpec(t *testing.T) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	spec, schemas := filepath.Join(dir, "spec.yaml"), filepath.Join(dir, "schemas.yaml")
+	require.NoError(t, os.WriteFile(spec, []byte(trackedSpec), 0o644))
+	require.NoError(t, os.WriteFile(schemas, []byte(trackedSchemas), 0o644))
+	return spec, schemas
+}
+
+func TestFileTracker_Files(t *testing.T) {
+	spec, schemas := writeTrackedSpec(t)
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	tracker := load.NewFileTracker()
+	tracker.Track(loader)
+
+	_, err := load.NewSpecInfo(loader, load.NewSource(spec))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{spec, schemas}, tracker.Files())
+	require.Empty(t, tracker.Changed())
+
+	require.NoError(t, os.WriteFile(schemas, []byte("Test:\n  type: integer\n"), 0o644))
+	require.Equal(t, []string{schemas}, tracker.Changed())
+}
+
+func TestFileTracker_Add(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "spec.yaml")
+
+	tracker := load.NewFileTracker()
+	tracker.Add(file)
+	require.Equal(t, []string{file}, tracker.Files())
+	require.Empty(t, tracker.Changed())
+
+	require.NoError(t, os.WriteFile(file, []byte(trackedSpec), 0o644))
+	require.Equal(t, []string{file}, tracker.Changed())
+}
@@ -0,0 +1,272 @@
+package load
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// GitPrefix identifies sources that are read from a git revision, for example: git:origin/main:api/openapi.yaml
+const GitPrefix = "git:"
+
+// parseGitSource splits a git source of the form git:<rev>:<path> into its revision and path
+func parseGitSource(source string) (string, string, bool) {
+	if !strings.HasPrefix(source, GitPrefix) {
+		return "", "", false
+	}
+
+	rev, path, found := strings.Cut(strings.TrimPrefix(source, GitPrefix), ":")
+	if !found || rev == "" || path == "" {
+		return "", "", false
+	}
+
+	return rev, path, true
+}
+
+// IsGitSource indicates whether the given spec location refers to a git revision
+func IsGitSource(source string) bool {
+	_, _, ok := parseGitSource(source)
+	return ok
+}
+
+// runGit runs a git command in the current working directory and returns its standard output
+func runGit(args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s failed: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// gitObjectPath converts a local path into a path that git resolves relative to the current working directory
+func gitObjectPath(file string) (string, error) {
+	file = filepath.Clean(file)
+
+	if filepath.IsAbs(file) {
+		topLevel, err := runGit("rev-parse", "--show-toplevel")
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(strings.TrimSpace(string(topLevel)), file)
+		if err != nil {
+			return "", err
+		}
+		return filepath.ToSlash(rel), nil
+	}
+
+	file = filepath.ToSlash(file)
+	if strings.HasPrefix(file, "../") {
+		return file, nil
+	}
+	return "./" + file, nil
+}
+
+// readGitBlob reads the content of a file at a given revision from the local repository
+func readGitBlob(rev, file string) ([]byte, error) {
+	objectPath, err := gitObjectPath(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return runGit("cat-file", "blob", rev+":"+objectPath)
+}
+
+// newGitLoader returns a loader which reads local files, including relative external refs, from the given revision
+// settings, like whether external refs are allowed, how remote refs are read, the ref policy and the limits, are taken from the original loader when possible
+func newGitLoader(loader Loader, rev string) *openapi3.Loader {
+	result := openapi3.NewLoader()
+
+	var readRemote openapi3.ReadFromURIFunc = readFromHTTP
+	if original, ok := loader.(*openapi3.Loader); ok {
+		result.IsExternalRefsAllowed = original.IsExternalRefsAllowed
+		result.Context = original.Context
+		if original.ReadFromURIFunc != nil {
+			readRemote = original.ReadFromURIFunc
+		}
+	}
+
+	readGit := func(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme != "" || location.Host != "" {
+			return nil, openapi3.ErrURINotSupported
+		}
+		return readGitBlob(rev, location.Path)
+	}
+
+	result.ReadFromURIFunc = readWithExclusiveBounds(readWithLimits(readWithRefPolicy(openapi3.ReadFromURIs(readGit, readRemote))))
+	return result
+}
+
+// fromGit loads a spec from a file at a given revision of the local git repository
+// the source map of the spec locates its elements in the file at that revision
+func fromGit(loader Loader, rev, file string) (*SpecInfo, error) {
+	spec, data, err := fromGitWithLoader(newGitLoader(loader, rev), rev, file)
+	if err != nil {
+		return nil, err
+	}
+
+	specInfo := newSpecInfo(spec, GitPrefix+rev+":"+file)
+	specInfo.SourceMap = newDataSourceMap(file, data, spec)
+	return specInfo, nil
+}
+
+// fromGitWithLoader loads a spec from a file at a given revision, and returns it along with the contents of the file
+func fromGitWithLoader(gitLoader *openapi3.Loader, rev, file string) (*openapi3.T, []byte, error) {
+	data, err := readGitBlob(rev, file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	location := fileLocation(file)
+
+	if err := checkLimits(gitLoader, location, data); err != nil {
+		return nil, nil, err
+	}
+
+	normalized, err := normalizeExclusiveBounds(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spec, err := loadFromData(gitLoader, normalized, location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := resolveWebhooks(gitLoader, spec, location); err != nil {
+		return nil, nil, err
+	}
+
+	if err := resolveSchemaKeywords(gitLoader, spec, location); err != nil {
+		return nil, nil, err
+	}
+
+	return spec, data, nil
+}
+
+// fromGitGlob loads all specs matching a glob at a given revision of the local git repository
+func fromGitGlob(loader Loader, rev, glob string) ([]*SpecInfo, error) {
+	files, err := globGit(rev, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*SpecInfo, 0, len(files))
+	for _, file := range files {
+		specInfo, err := fromGit(loader, rev, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %q: %w", file, err)
+		}
+		result = append(result, specInfo)
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("no matching files")
+	}
+
+	return result, nil
+}
+
+// globGit returns the files at a given revision that match the glob
+// paths are returned in the same form as the glob, relative to the current working directory
+func globGit(rev, glob string) ([]string, error) {
+	glob = filepath.ToSlash(filepath.Clean(glob))
+
+	// list files under the static part of the glob to avoid listing the whole tree
+	dir := staticGlobDir(glob)
+	objectPath, err := gitObjectPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := runGit("ls-tree", "-r", "--name-only", "--full-name", rev, "--", objectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := runGit("rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+	cwd := strings.TrimSuffix(strings.TrimSpace(string(prefix)), "/")
+
+	result := []string{}
+	for _, name := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if name == "" {
+			continue
+		}
+
+		// convert the repository-relative name back to a path relative to the working directory
+		file, err := filepath.Rel(filepath.FromSlash("/"+cwd), filepath.FromSlash("/"+name))
+		if err != nil {
+			continue
+		}
+		file = filepath.ToSlash(file)
+
+		if matchGlob(strings.Split(glob, "/"), strings.Split(file, "/")) {
+			result = append(result, file)
+		}
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
+
+// staticGlobDir returns the leading directories of a glob that don't contain any wildcards
+func staticGlobDir(glob string) string {
+	segments := strings.Split(glob, "/")
+	static := []string{}
+	for _, segment := range segments[:len(segments)-1] {
+		if strings.ContainsAny(segment, "*?[") {
+			break
+		}
+		static = append(static, segment)
+	}
+
+	if len(static) == 0 {
+		return "."
+	}
+
+	return strings.Join(static, "/")
+}
+
+// matchGlob matches path segments against glob segments, where "**" matches any number of directories
+func matchGlob(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlob(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+		return false
+	}
+
+	return matchGlob(pattern[1:], segments[1:])
+}
@@ -0,0 +1,122 @@
+package load
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// GitCommit is a commit of the local git repository
+type GitCommit struct {
+	Hash    string    `json:"hash" yaml:"hash"`
+	Author  string    `json:"author" yaml:"author"`
+	Date    time.Time `json:"date" yaml:"date"`
+	Subject string    `json:"subject" yaml:"subject"`
+}
+
+// ShortHash returns the abbreviated hash of the commit
+func (commit *GitCommit) ShortHash() string {
+	if len(commit.Hash) > 7 {
+		return commit.Hash[:7]
+	}
+	return commit.Hash
+}
+
+// Source returns the git source of a file, or a glob, at the commit
+func (commit *GitCommit) Source(path string) string {
+	return GitPrefix + commit.Hash + ":" + path
+}
+
+// gitLogFieldSeparator separates the fields of a commit in the output of git log
+const gitLogFieldSeparator = "\x1f"
+
+// GetGitLog returns the commits which modified the files matching any of the given paths or globs, from the oldest to the newest
+// only the first parent of merge commits is followed, so that each commit can be compared with the previous one
+// revRange limits the commits, for example: v1.0..main, an empty range means HEAD
+func GetGitLog(paths []string, revRange string) ([]*GitCommit, error) {
+	if revRange == "" {
+		revRange = "HEAD"
+	}
+
+	args := []string{"log", "--first-parent", "--reverse", "--format=%H%x1f%an%x1f%aI%x1f%s", revRange, "--"}
+	for _, path := range paths {
+		args = append(args, ":(glob)"+gitPathspec(path))
+	}
+
+	output, err := runGit(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*GitCommit{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, gitLogFieldSeparator, 4)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected git log output: %q", line)
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid date of commit %s: %w", fields[0], err)
+		}
+
+		result = append(result, &GitCommit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Subject: fields[3],
+		})
+	}
+
+	return result, nil
+}
+
+// GetGitSpecFiles returns the files of a spec at a given revision: the spec itself and the local files of its external refs
+// in composed mode, path is a glob and the files of all matching specs are returned
+// the spec is loaded with the settings of the given loader, like whether external refs are allowed, the ref policy and the limits
+func GetGitSpecFiles(loader Loader, rev, path string, composed bool) ([]string, error) {
+	specs := []string{path}
+	if composed {
+		var err error
+		if specs, err = globGit(rev, path); err != nil {
+			return nil, err
+		}
+	}
+
+	result := []string{}
+	for _, spec := range specs {
+		gitLoader := newGitLoader(loader, rev)
+		read := gitLoader.ReadFromURIFunc
+		gitLoader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+			data, err := read(loader, location)
+			if err == nil && location.Scheme == "" && location.Host == "" && !slices.Contains(result, location.Path) {
+				result = append(result, location.Path)
+			}
+			return data, err
+		}
+
+		if _, _, err := fromGitWithLoader(gitLoader, rev, spec); err != nil {
+			return nil, fmt.Errorf("failed to load %q: %w", spec, err)
+		}
+		if !slices.Contains(result, spec) {
+			result = append(result, spec)
+		}
+	}
+
+	slices.Sort(result)
+	return result, nil
+}
+
+// gitPathspec converts a local path or glob into a pathspec, which git resolves relative to the current working directory
+func gitPathspec(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
@@ -0,0 +1,191 @@
+package load_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+const gitSpec = `openapi: 3.0.1
+info:
+  title: Test API
+  version: v1
+paths:
+  /test:
+    get:
+      responses:
+        "200":
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: "common/schemas.yaml#/Item"
+`
+
+// initGitRepo creates a repository with a spec that references an external file, and commits it
+func initGitRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "api", "common"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api", "openapi.yaml"), []byte(gitSpec), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api", "common", "schemas.yaml"), []byte("Item:\n  type: string\n"), 0644))
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	// change the working tree after the commit
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api", "common", "schemas.yaml"), []byte("Item:\n  type: integer\n"), 0644))
+
+	return dir
+}
+
+func newRefsLoader() *openapi3.Loader {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	return loader
+}
+
+func TestSource_Git(t *testing.T) {
+	source := load.NewSource("git:origin/main:api/openapi.yaml")
+	require.True(t, source.IsGit())
+	require.False(t, source.IsFile())
+	require.Equal(t, "git:origin/main:api/openapi.yaml", source.String())
+}
+
+func TestSource_GitInvalid(t *testing.T) {
+	require.True(t, load.NewSource("git:api/openapi.yaml").IsFile())
+}
+
+func TestGit_ExternalRefs(t *testing.T) {
+	t.Chdir(initGitRepo(t))
+
+	specInfo, err := load.NewSpecInfo(newRefsLoader(), load.NewSource("git:HEAD:api/openapi.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "git:HEAD:api/openapi.yaml", specInfo.Url)
+
+	schema := specInfo.Spec.Paths.Value("/test").Get.Responses.Value("200").Value.Content["application/json"].Schema.Value
+	require.True(t, schema.Type.Is("string"))
+
+	// the working tree version references the modified file
+	specInfo, err = load.NewSpecInfo(newRefsLoader(), load.NewSource("api/openapi.yaml"))
+	require.NoError(t, err)
+	schema = specInfo.Spec.Paths.Value("/test").Get.Responses.Value("200").Value.Content["application/json"].Schema.Value
+	require.True(t, schema.Type.Is("integer"))
+}
+
+func TestGit_Subdirectory(t *testing.T) {
+	dir := initGitRepo(t)
+	t.Chdir(filepath.Join(dir, "api"))
+
+	_, err := load.NewSpecInfo(newRefsLoader(), load.NewSource("git:HEAD:openapi.yaml"))
+	require.NoError(t, err)
+}
+
+func TestGit_Glob(t *testing.T) {
+	t.Chdir(initGitRepo(t))
+
+	specInfos, err := load.NewSpecInfoFromGlob(newRefsLoader(), "git:HEAD:api/*.yaml")
+	require.NoError(t, err)
+	require.Len(t, specInfos, 1)
+	require.Equal(t, "git:HEAD:api/openapi.yaml", specInfos[0].Url)
+
+	specInfos, err = load.NewSpecInfoFromGlob(newRefsLoader(), "git:HEAD:**/openapi.yaml")
+	require.NoError(t, err)
+	require.Len(t, specInfos, 1)
+}
+
+// specs at git revisions are located in the file at the revision, rather than in the working tree
+func TestGit_SourceMap(t *testing.T) {
+	t.Chdir(initGitRepo(t))
+	require.NoError(t, os.WriteFile(filepath.Join("api", "openapi.yaml"), []byte("# modified\n\n"+gitSpec), 0644))
+
+	specInfo, err := load.NewSpecInfo(newRefsLoader(), load.NewSource("git:HEAD:api/openapi.yaml"))
+	require.NoError(t, err)
+	require.NotNil(t, specInfo.SourceMap)
+	require.Equal(t, "api/openapi.yaml", specInfo.SourceMap.File)
+
+	position, ok := specInfo.SourceMap.ElementPosition(specInfo.Spec.Paths.Value("/test").Get)
+	require.True(t, ok)
+	require.Equal(t, 6, position.Line)
+
+	specInfos, err := load.NewSpecInfoFromGlob(newRefsLoader(), "git:HEAD:api/*.yaml")
+	require.NoError(t, err)
+	require.NotNil(t, specInfos[0].SourceMap)
+}
+
+func TestGit_NoMatch(t *testing.T) {
+	t.Chdir(initGitRepo(t))
+
+	_, err := load.NewSpecInfoFromGlob(newRefsLoader(), "git:HEAD:api/*.json")
+	require.EqualError(t, err, "no matching files")
+}
+
+func TestGit_InvalidRevision(t *testing.T) {
+	t.Chdir(initGitRepo(t))
+
+	_, err := load.NewSpecInfo(newRefsLoader(), load.NewSource("git:no-such-rev:api/openapi.yaml"))
+	require.Error(t, err)
+}
+
+func TestGit_Log(t *testing.T) {
+	t.Chdir(initGitRepo(t))
+
+	commits, err := load.GetGitLog([]string{"api/openapi.yaml"}, "")
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, "test", commits[0].Author)
+	require.Equal(t, "initial", commits[0].Subject)
+	require.Len(t, commits[0].ShortHash(), 7)
+	require.Equal(t, "git:"+commits[0].Hash+":api/openapi.yaml", commits[0].Source("api/openapi.yaml"))
+
+	commits, err = load.GetGitLog([]string{"api/no-such-file.yaml"}, "")
+	require.NoError(t, err)
+	require.Empty(t, commits)
+}
+
+func TestGit_LogInvalidRevision(t *testing.T) {
+	t.Chdir(initGitRepo(t))
+
+	_, err := load.GetGitLog([]string{"api/openapi.yaml"}, "no-such-rev")
+	require.Error(t, err)
+}
+
+func TestGit_SpecFiles(t *testing.T) {
+	t.Chdir(initGitRepo(t))
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+
+	files, err := load.GetGitSpecFiles(loader, "HEAD", "api/openapi.yaml", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"api/common/schemas.yaml", "api/openapi.yaml"}, files)
+
+	files, err = load.GetGitSpecFiles(loader, "HEAD", "api/*.yaml", true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"api/common/schemas.yaml", "api/openapi.yaml"}, files)
+}
+
+func TestGit_SpecFilesExternalRefsNotAllowed(t *testing.T) {
+	t.Chdir(initGitRepo(t))
+
+	_, err := load.GetGitSpecFiles(openapi3.NewLoader(), "HEAD", "api/openapi.yaml", false)
+	require.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package load
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/limits"
+)
+
+type limitsKey struct{}
+
+// WithLimits configures a loader to enforce the limits on each document that it reads, and on flattening allOf, a nil Limits leaves the loader as is
+func WithLimits(loader *openapi3.Loader, l *limits.Limits) {
+	if l == nil {
+		return
+	}
+
+	ctx := loader.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	loader.Context = context.WithValue(ctx, limitsKey{}, l)
+}
+
+// getLimits returns the limits of a loader, or nil if the loader is unlimited
+func getLimits(loader Loader) *limits.Limits {
+	original, ok := loader.(*openapi3.Loader)
+	if !ok || original == nil || original.Context == nil {
+		return nil
+	}
+	l, _ := original.Context.Value(limitsKey{}).(*limits.Limits)
+	return l
+}
+
+// checkLimits enforces the limits of the loader, if there are any, on a spec that was read without the read function of the loader, like a spec from stdin
+func checkLimits(loader *openapi3.Loader, location *url.URL, data []byte) error {
+	name := "stdin"
+	if location != nil {
+		name = location.String()
+	}
+	return getLimits(loader).CheckDocument(name, data)
+}
+
+// readWithLimits wraps a function that reads specs and enforces the limits of the loader, if there are any, on the documents that it reads
+func readWithLimits(read openapi3.ReadFromURIFunc) openapi3.ReadFromURIFunc {
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := read(loader, location)
+		if err != nil {
+			return nil, err
+		}
+		if err := getLimits(loader).CheckDocument(location.String(), data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+}
@@ -14,17 +14,90 @@ type Loader interface {
 	LoadFromStdin() (*openapi3.T, error)
 }
 
-// from is a convenience function that opens an OpenAPI spec from a URL or a local path based on the format of the path parameter
+// from is a convenience function that opens an OpenAPI spec from a URL, a local path or stdin based on the format of the path parameter
+// specs at git revisions are loaded by fromGit
+// OpenAPI 3.1 webhooks are resolved with the same loader as the rest of the spec
 func from(loader Loader, source *Source) (*openapi3.T, error) {
 
+	var spec *openapi3.T
+	var location *url.URL
+	var err error
+
 	switch source.Type {
 	case SourceTypeStdin:
-		return loader.LoadFromStdin()
+		loader = dialectLoader(loader)
+		spec, err = loadFromStdin(loader)
 	case SourceTypeURL:
-		return loader.LoadFromURI(source.Uri)
+		loader = dialectLoader(loader)
+		spec, err = loadFromLocation(loader, source.Uri)
+		location = source.Uri
 	default:
-		return loader.LoadFromFile(source.Path)
+		return fromFile(loader, source.Path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveWebhooks(webhooksLoader(loader), spec, location); err != nil {
+		return nil, err
+	}
+
+	if err := resolveSchemaKeywords(webhooksLoader(loader), spec, location); err != nil {
+		return nil, err
 	}
+
+	return spec, nil
+}
+
+// fromFile loads a spec from a local file
+func fromFile(loader Loader, file string) (*openapi3.T, error) {
+	loader = dialectLoader(loader)
+
+	spec, err := loadFromLocation(loader, fileLocation(file))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveWebhooks(webhooksLoader(loader), spec, fileLocation(file)); err != nil {
+		return nil, err
+	}
+
+	if err := resolveSchemaKeywords(webhooksLoader(loader), spec, fileLocation(file)); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// fromData loads a spec from its contents
+// relative references are resolved against the location, if there is one, and according to the settings of the loader, like IsExternalRefsAllowed
+func fromData(loader *openapi3.Loader, data []byte, location *url.URL) (*openapi3.T, error) {
+	loader = newDialectLoader(loader)
+
+	if err := checkLimits(loader, location, data); err != nil {
+		return nil, err
+	}
+
+	data, err := normalizeExclusiveBounds(data)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := loadFromData(loader, data, location)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveWebhooks(loader, spec, location); err != nil {
+		return nil, err
+	}
+
+	if err := resolveSchemaKeywords(loader, spec, location); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
 }
 
 func getURL(rawURL string) (*url.URL, error) {
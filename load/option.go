@@ -27,11 +27,12 @@ func GetOption(option Option, enable bool) Option {
 }
 
 // WithFlattenAllOf returns SpecInfos with flattened allOf
+// the limits of the loader, if there are any, apply to flattening each spec
 func WithFlattenAllOf() Option {
 	return func(loader Loader, specInfos []*SpecInfo) ([]*SpecInfo, error) {
 		var err error
 		for _, specInfo := range specInfos {
-			if specInfo.Spec, err = allof.MergeSpec(specInfo.Spec); err != nil {
+			if specInfo.Spec, err = allof.MergeSpecWithLimits(specInfo.Spec, getLimits(loader)); err != nil {
 				return nil, fmt.Errorf("failed to flatten allOf in %q: %w", specInfo.Url, err)
 			}
 		}
@@ -59,3 +60,13 @@ func WithLowercaseHeaders() Option {
 		return specInfos, nil
 	}
 }
+
+// WithNormalizeDialect returns SpecInfos with the OpenAPI 3.0 and 3.1 forms of nullable schemas mapped onto a common model
+func WithNormalizeDialect() Option {
+	return func(loader Loader, specInfos []*SpecInfo) ([]*SpecInfo, error) {
+		for _, specInfo := range specInfos {
+			NormalizeDialect(specInfo.Spec)
+		}
+		return specInfos, nil
+	}
+}
@@ -0,0 +1,271 @@
+package load
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// RefLockVersion is the version of the lockfile format
+const RefLockVersion = 1
+
+// RefLock pins the remote documents that specs refer to, to copies in a local cache, along with their hashes
+// specs which are loaded with a RefLock read remote documents from the cache only, so that loading them is reproducible and doesn't require network access
+type RefLock struct {
+	Version   int            `yaml:"version"`
+	Cache     string         `yaml:"cache"` // the directory of the cached documents, relative to the lockfile
+	Documents []RefLockEntry `yaml:"documents"`
+
+	dir string // the directory of the lockfile
+}
+
+// RefLockEntry is a remote document in the cache
+type RefLockEntry struct {
+	URL    string `yaml:"url"`
+	File   string `yaml:"file"` // the cached copy, relative to the cache directory
+	SHA256 string `yaml:"sha256"`
+}
+
+// LoadRefLock reads a lockfile
+func LoadRefLock(file string) (*RefLock, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	result, err := ReadRefLock(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lockfile %q: %w", file, err)
+	}
+	result.dir = filepath.Dir(file)
+	return result, nil
+}
+
+// ReadRefLock reads a lockfile from a reader, the cache is relative to the current working directory
+func ReadRefLock(source io.Reader) (*RefLock, error) {
+	var result RefLock
+
+	decoder := yaml.NewDecoder(source)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&result); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty lockfile")
+		}
+		return nil, err
+	}
+
+	if result.Version != RefLockVersion {
+		return nil, fmt.Errorf("unsupported lockfile version %d", result.Version)
+	}
+
+	for i, entry := range result.Documents {
+		if entry.URL == "" || entry.File == "" || entry.SHA256 == "" {
+			return nil, fmt.Errorf("missing url, file or sha256 in document #%d", i+1)
+		}
+	}
+
+	return &result, nil
+}
+
+// SaveRefLock writes a lockfile
+func (lock *RefLock) SaveRefLock(file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	if err := lock.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Write writes the lockfile in YAML format
+func (lock *RefLock) Write(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(lock); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func (lock *RefLock) getCacheDir() string {
+	if filepath.IsAbs(lock.Cache) {
+		return lock.Cache
+	}
+	return filepath.Join(lock.dir, lock.Cache)
+}
+
+func (lock *RefLock) getEntry(uri string) *RefLockEntry {
+	for i := range lock.Documents {
+		if lock.Documents[i].URL == uri {
+			return &lock.Documents[i]
+		}
+	}
+	return nil
+}
+
+// WithRefLock configures a loader to read remote documents from the cache of a lockfile only
+// reading a document which isn't in the lockfile, or whose cached copy doesn't match its hash, fails
+func WithRefLock(loader *openapi3.Loader, lock *RefLock) {
+	if lock == nil {
+		return
+	}
+
+	read := loader.ReadFromURIFunc
+	if read == nil {
+		read = defaultReadFromURI
+	}
+
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if isLocalLocation(location) {
+			return read(loader, location)
+		}
+		return lock.read(location)
+	}
+}
+
+func (lock *RefLock) read(location *url.URL) ([]byte, error) {
+	entry := lock.getEntry(location.String())
+	if entry == nil {
+		return nil, fmt.Errorf("%q isn't in the lockfile, run 'oasdiff refs lock' to add it", location.String())
+	}
+
+	cacheDir := lock.getCacheDir()
+	file := filepath.Join(cacheDir, filepath.FromSlash(entry.File))
+	if !isWithinDir(realPath(file), realPath(cacheDir)) {
+		return nil, fmt.Errorf("the cached copy of %q is outside of the cache directory %q", location.String(), cacheDir)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the cached copy of %q: %w", location.String(), err)
+	}
+
+	if hash := hashDocument(data); hash != entry.SHA256 {
+		return nil, fmt.Errorf("the cached copy of %q in %q doesn't match the hash in the lockfile: expected sha256 %s, got %s", location.String(), file, entry.SHA256, hash)
+	}
+
+	return data, nil
+}
+
+func hashDocument(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// RefRecorder records the remote documents that a loader reads, to lock them
+type RefRecorder struct {
+	mu        sync.Mutex
+	documents map[string][]byte
+}
+
+// NewRefRecorder creates an empty RefRecorder
+func NewRefRecorder() *RefRecorder {
+	return &RefRecorder{
+		documents: map[string][]byte{},
+	}
+}
+
+// Track configures the loader to record the remote documents that it reads
+func (recorder *RefRecorder) Track(loader *openapi3.Loader) {
+	read := loader.ReadFromURIFunc
+	if read == nil {
+		read = defaultReadFromURI
+	}
+
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := read(loader, location)
+		if err == nil && !isLocalLocation(location) {
+			recorder.mu.Lock()
+			recorder.documents[location.String()] = data
+			recorder.mu.Unlock()
+		}
+		return data, err
+	}
+}
+
+// Lock writes the recorded documents to a cache directory, and returns a lockfile which pins them
+// the cache directory is stored relative to the directory of the lockfile
+func (recorder *RefRecorder) Lock(lockfile, cacheDir string) (*RefLock, error) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	cache, err := filepath.Rel(filepath.Dir(lockfile), cacheDir)
+	if err != nil {
+		cache = cacheDir
+	}
+
+	result := &RefLock{
+		Version:   RefLockVersion,
+		Cache:     filepath.ToSlash(cache),
+		Documents: make([]RefLockEntry, 0, len(recorder.documents)),
+		dir:       filepath.Dir(lockfile),
+	}
+
+	uris := make([]string, 0, len(recorder.documents))
+	for uri := range recorder.documents {
+		uris = append(uris, uri)
+	}
+	slices.Sort(uris)
+
+	for _, uri := range uris {
+		data := recorder.documents[uri]
+
+		file, err := getCacheFile(uri)
+		if err != nil {
+			return nil, err
+		}
+
+		target := filepath.Join(cacheDir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return nil, err
+		}
+
+		result.Documents = append(result.Documents, RefLockEntry{
+			URL:    uri,
+			File:   file,
+			SHA256: hashDocument(data),
+		})
+	}
+
+	return result, nil
+}
+
+// getCacheFile returns the path of the cached copy of a remote document, relative to the cache directory, like example.com/schemas/pet.yaml
+func getCacheFile(uri string) (string, error) {
+	location, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	// cleaning the path as an absolute path keeps it within the directory of the host
+	file := path.Clean("/" + location.Path)
+	if strings.HasSuffix(file, "/") {
+		file += "index"
+	}
+	if location.RawQuery != "" {
+		file += "-" + hashDocument([]byte(location.RawQuery))[:8]
+	}
+
+	return strings.ReplaceAll(location.Host, ":", "_") + file, nil
+}
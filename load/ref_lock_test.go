@@ -0,0 +1,102 @@
+package load_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+// lockRefs locks the remote refs of a spec whose schemas are served by a local server, and returns the lockfile
+func lockRefs(t *testing.T) (string, string) {
+	t.Helper()
+
+	server := httptest.NewServer(http.FileServer(http.Dir("../data/ref-mirror/schemas")))
+	defer server.Close()
+
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "openapi.yaml")
+	data, err := os.ReadFile(refMirrorSpec)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(spec, []byte(strings.ReplaceAll(string(data), "https://schemas.example.com/shared", server.URL)), 0644))
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	recorder := load.NewRefRecorder()
+	recorder.Track(loader)
+	_, err = load.NewSpecInfo(loader, load.NewSource(spec))
+	require.NoError(t, err)
+
+	lockfile := filepath.Join(dir, "oasdiff-refs.lock")
+	lock, err := recorder.Lock(lockfile, filepath.Join(dir, "cache"))
+	require.NoError(t, err)
+	require.NoError(t, lock.SaveRefLock(lockfile))
+
+	return spec, lockfile
+}
+
+func newRefLockLoader(t *testing.T, lockfile string) *openapi3.Loader {
+	t.Helper()
+
+	lock, err := load.LoadRefLock(lockfile)
+	require.NoError(t, err)
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	load.WithRefLock(loader, lock)
+	return loader
+}
+
+func TestRefLock(t *testing.T) {
+	spec, lockfile := lockRefs(t)
+
+	lock, err := load.LoadRefLock(lockfile)
+	require.NoError(t, err)
+	require.Equal(t, "cache", lock.Cache)
+	require.Len(t, lock.Documents, 2)
+	require.True(t, strings.HasSuffix(lock.Documents[0].URL, "/pet.yaml"))
+	require.True(t, strings.HasSuffix(lock.Documents[0].File, "/pet.yaml"))
+	require.Len(t, lock.Documents[0].SHA256, 64)
+
+	// the server is closed, so the refs can only be read from the cache
+	specInfo, err := load.NewSpecInfo(newRefLockLoader(t, lockfile), load.NewSource(spec))
+	require.NoError(t, err)
+	schema := specInfo.Spec.Paths.Value("/pets").Get.Responses.Value("200").Value.Content["application/json"].Schema
+	require.True(t, schema.Value.Properties["tag"].Value.Type.Is("string"))
+}
+
+func TestRefLock_HashMismatch(t *testing.T) {
+	spec, lockfile := lockRefs(t)
+
+	lock, err := load.LoadRefLock(lockfile)
+	require.NoError(t, err)
+	cached := filepath.Join(filepath.Dir(lockfile), "cache", filepath.FromSlash(lock.Documents[0].File))
+	require.NoError(t, os.WriteFile(cached, []byte("Pet:\n  type: integer\n"), 0644))
+
+	_, err = load.NewSpecInfo(newRefLockLoader(t, lockfile), load.NewSource(spec))
+	require.ErrorContains(t, err, "doesn't match the hash in the lockfile")
+}
+
+func TestRefLock_NotLocked(t *testing.T) {
+	_, lockfile := lockRefs(t)
+
+	_, err := load.NewSpecInfo(newRefLockLoader(t, lockfile), load.NewSource(refMirrorSpec))
+	require.ErrorContains(t, err, `"https://schemas.example.com/shared/pet.yaml" isn't in the lockfile, run 'oasdiff refs lock' to add it`)
+}
+
+func TestRefLock_Invalid(t *testing.T) {
+	_, err := load.ReadRefLock(strings.NewReader("version: 2\n"))
+	require.EqualError(t, err, "unsupported lockfile version 2")
+
+	_, err = load.ReadRefLock(strings.NewReader(""))
+	require.EqualError(t, err, "empty lockfile")
+
+	_, err = load.ReadRefLock(strings.NewReader("version: 1\ndocuments:\n  - url: https://example.com/pet.yaml\n"))
+	require.EqualError(t, err, "missing url, file or sha256 in document #1")
+}
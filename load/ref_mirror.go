@@ -0,0 +1,96 @@
+package load
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// RefMirror serves the remote documents under a URL prefix from a local directory, for example, to resolve refs to a schema server without network access
+type RefMirror struct {
+	Prefix string // a URL prefix, like https://schemas.example.com/shared/
+	Dir    string // the local directory which mirrors the prefix
+}
+
+// ParseRefMirrors creates RefMirrors from a list of mappings like https://schemas.example.com/shared/=./schemas
+func ParseRefMirrors(mappings []string) ([]*RefMirror, error) {
+	result := make([]*RefMirror, 0, len(mappings))
+	for _, mapping := range mappings {
+		prefix, dir, found := strings.Cut(mapping, "=")
+		if !found || prefix == "" || dir == "" {
+			return nil, fmt.Errorf("invalid ref mirror %q, should be <url-prefix>=<dir>", mapping)
+		}
+
+		location, err := url.Parse(prefix)
+		if err != nil || (location.Scheme != "http" && location.Scheme != "https") || location.Host == "" {
+			return nil, fmt.Errorf("invalid ref mirror %q, the prefix should be an http or https URL", mapping)
+		}
+
+		result = append(result, &RefMirror{Prefix: prefix, Dir: dir})
+	}
+	return result, nil
+}
+
+// WithRefMirrors configures a loader to read the remote documents under the prefixes of the mirrors from their local directories
+// a document under several prefixes is read from the mirror with the longest prefix, other documents are read as before
+func WithRefMirrors(loader *openapi3.Loader, mirrors []*RefMirror) {
+	if len(mirrors) == 0 {
+		return
+	}
+
+	read := loader.ReadFromURIFunc
+	if read == nil {
+		read = defaultReadFromURI
+	}
+
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if mirror, rest := findRefMirror(mirrors, location); mirror != nil {
+			return mirror.read(location, rest)
+		}
+		return read(loader, location)
+	}
+}
+
+// findRefMirror returns the mirror with the longest prefix of a URL, and the rest of the URL
+func findRefMirror(mirrors []*RefMirror, location *url.URL) (*RefMirror, string) {
+	if isLocalLocation(location) {
+		return nil, ""
+	}
+
+	var result *RefMirror
+	uri := location.String()
+	for _, mirror := range mirrors {
+		if strings.HasPrefix(uri, mirror.Prefix) && (result == nil || len(mirror.Prefix) > len(result.Prefix)) {
+			result = mirror
+		}
+	}
+
+	if result == nil {
+		return nil, ""
+	}
+	return result, strings.TrimPrefix(uri, result.Prefix)
+}
+
+// read reads the file of a document from the mirror, the file must be within the directory of the mirror
+func (mirror *RefMirror) read(location *url.URL, rest string) ([]byte, error) {
+	rest, _, _ = strings.Cut(rest, "?")
+	rest, err := url.PathUnescape(rest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q from the mirror in %q: %w", location.String(), mirror.Dir, err)
+	}
+
+	file := filepath.Join(mirror.Dir, filepath.FromSlash(rest))
+	if !isWithinDir(realPath(file), realPath(mirror.Dir)) {
+		return nil, fmt.Errorf("failed to read %q from the mirror in %q: the file is outside of the mirror", location.String(), mirror.Dir)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q from the mirror in %q: %w", location.String(), mirror.Dir, err)
+	}
+	return data, nil
+}
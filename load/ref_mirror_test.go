@@ -0,0 +1,69 @@
+package load_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+const refMirrorSpec = "../data/ref-mirror/openapi.yaml"
+
+func newRefMirrorLoader(t *testing.T, mappings ...string) *openapi3.Loader {
+	t.Helper()
+
+	mirrors, err := load.ParseRefMirrors(mappings)
+	require.NoError(t, err)
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	load.WithRefMirrors(loader, mirrors)
+	return loader
+}
+
+func TestParseRefMirrors(t *testing.T) {
+	mirrors, err := load.ParseRefMirrors([]string{"https://schemas.example.com/shared/=schemas"})
+	require.NoError(t, err)
+	require.Equal(t, []*load.RefMirror{{Prefix: "https://schemas.example.com/shared/", Dir: "schemas"}}, mirrors)
+}
+
+func TestParseRefMirrors_Invalid(t *testing.T) {
+	_, err := load.ParseRefMirrors([]string{"https://schemas.example.com/shared/"})
+	require.EqualError(t, err, `invalid ref mirror "https://schemas.example.com/shared/", should be <url-prefix>=<dir>`)
+
+	_, err = load.ParseRefMirrors([]string{"schemas/=schemas"})
+	require.EqualError(t, err, `invalid ref mirror "schemas/=schemas", the prefix should be an http or https URL`)
+}
+
+func TestRefMirror(t *testing.T) {
+	// the ref to the mirror, and the relative ref within the mirrored document, are read from the local directory
+	specInfo, err := load.NewSpecInfo(newRefMirrorLoader(t, "https://schemas.example.com/shared/=../data/ref-mirror/schemas"), load.NewSource(refMirrorSpec))
+	require.NoError(t, err)
+
+	schema := specInfo.Spec.Paths.Value("/pets").Get.Responses.Value("200").Value.Content["application/json"].Schema
+	require.True(t, schema.Value.Properties["tag"].Value.Type.Is("string"))
+}
+
+func TestRefMirror_LongestPrefix(t *testing.T) {
+	_, err := load.NewSpecInfo(newRefMirrorLoader(t, "https://schemas.example.com/=../data/no-such-dir", "https://schemas.example.com/shared/=../data/ref-mirror/schemas"), load.NewSource(refMirrorSpec))
+	require.NoError(t, err)
+}
+
+func TestRefMirror_MissingFile(t *testing.T) {
+	_, err := load.NewSpecInfo(newRefMirrorLoader(t, "https://schemas.example.com/shared/=../data/ref-policy"), load.NewSource(refMirrorSpec))
+	require.ErrorContains(t, err, `failed to read "https://schemas.example.com/shared/pet.yaml" from the mirror in "../data/ref-policy"`)
+}
+
+func TestRefMirror_OutsideOfMirror(t *testing.T) {
+	loader := newRefMirrorLoader(t, "https://schemas.example.com/shared/=../data/ref-mirror/schemas")
+	_, err := load.NewSpecInfoFromData(loader, "spec", []byte(`openapi: 3.0.1
+info:
+  title: Pet Store
+  version: v1
+paths:
+  /pets:
+    $ref: "https://schemas.example.com/shared/%2E%2E/%2E%2E/openapi.yaml#/paths/~1pets"
+`))
+	require.ErrorContains(t, err, "the file is outside of the mirror")
+}
@@ -0,0 +1,448 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// RefPolicy restricts the documents that are read through external refs while loading a spec, for example, a spec from an untrusted pull request
+// the spec itself is always allowed, and it counts towards the limits
+type RefPolicy struct {
+	NoRemote     bool     // refs to URLs are not allowed, except for refs to the host of a spec which was loaded from a URL
+	AllowedHosts []string // refs to URLs are allowed only to these hosts, and to the host of a spec which was loaded from a URL, any host if empty
+	SpecDirOnly  bool     // refs to local files are allowed only within the directory tree of the spec, and of AllowedDirs
+	AllowedDirs  []string // refs to local files are allowed only within these directory trees, and the directory tree of the spec if SpecDirOnly is set, any file if both are unset
+	MaxBytes     int64    // the total size of the documents that are read, unlimited if 0
+	MaxDocuments int      // the number of documents that are read, unlimited if 0
+}
+
+// ErrRefPolicy is returned when a spec refers to a document which isn't allowed by the ref policy
+var ErrRefPolicy = errors.New("ref policy violation")
+
+const (
+	refPolicyNoRemote     = "no-remote"
+	refPolicySpecDir      = "spec-dir"
+	refPolicyAllowHost    = "allow-host"
+	refPolicyAllowDir     = "allow-dir"
+	refPolicyMaxBytes     = "max-bytes"
+	refPolicyMaxDocuments = "max-documents"
+)
+
+// GetRefPolicyOptions returns the options of ParseRefPolicy
+func GetRefPolicyOptions() []string {
+	return []string{
+		refPolicyNoRemote,
+		refPolicySpecDir,
+		refPolicyAllowHost + "=<host>",
+		refPolicyAllowDir + "=<dir>",
+		refPolicyMaxBytes + "=<n>",
+		refPolicyMaxDocuments + "=<n>",
+	}
+}
+
+// ParseRefPolicy creates a RefPolicy from a list of options like no-remote, spec-dir, allow-host=<host>, allow-dir=<dir>, max-bytes=<n> and max-documents=<n>
+// an empty list returns nil, which allows all refs
+func ParseRefPolicy(options []string) (*RefPolicy, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+
+	result := &RefPolicy{}
+	for _, option := range options {
+		name, value, hasValue := strings.Cut(option, "=")
+
+		switch name {
+		case refPolicyNoRemote, refPolicySpecDir:
+			if hasValue {
+				return nil, fmt.Errorf("ref policy option %q doesn't take a value", name)
+			}
+		case refPolicyAllowHost, refPolicyAllowDir, refPolicyMaxBytes, refPolicyMaxDocuments:
+			if value == "" {
+				return nil, fmt.Errorf("ref policy option %q requires a value, for example: %s=<value>", name, name)
+			}
+		}
+
+		switch name {
+		case refPolicyNoRemote:
+			result.NoRemote = true
+		case refPolicySpecDir:
+			result.SpecDirOnly = true
+		case refPolicyAllowHost:
+			result.AllowedHosts = append(result.AllowedHosts, value)
+		case refPolicyAllowDir:
+			result.AllowedDirs = append(result.AllowedDirs, value)
+		case refPolicyMaxBytes:
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid %s value %q: should be a positive number", name, value)
+			}
+			result.MaxBytes = n
+		case refPolicyMaxDocuments:
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid %s value %q: should be a positive number", name, value)
+			}
+			result.MaxDocuments = n
+		default:
+			return nil, fmt.Errorf("invalid ref policy option %q, allowed options: %s", option, strings.Join(GetRefPolicyOptions(), ", "))
+		}
+	}
+	return result, nil
+}
+
+type refGuardKey struct{}
+
+// WithRefPolicy configures a loader to enforce a ref policy, a nil policy leaves the loader as is
+// the limits apply to all the specs that are loaded with the loader, for example, all the specs matching a glob
+func WithRefPolicy(loader *openapi3.Loader, policy *RefPolicy) {
+	if policy == nil {
+		return
+	}
+
+	ctx := loader.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	loader.Context = context.WithValue(ctx, refGuardKey{}, newRefGuard(policy))
+}
+
+// refGuard enforces a ref policy on the documents that a loader reads
+type refGuard struct {
+	policy *RefPolicy
+	client *http.Client
+
+	mu        sync.Mutex
+	roots     []*url.URL // the specs, which are always allowed
+	specDirs  []string   // the directories of the specs which were loaded from local files
+	specHosts []string   // the hosts of the specs which were loaded from URLs
+	bytes     int64
+	documents int
+}
+
+func newRefGuard(policy *RefPolicy) *refGuard {
+	guard := &refGuard{policy: policy}
+	guard.client = &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// a redirect mustn't lead to a host which isn't allowed
+			if err := guard.checkRemote(req.URL); err != nil {
+				return err
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}
+	return guard
+}
+
+// getRefGuard returns the ref guard of a loader, or nil if the loader has no ref policy
+func getRefGuard(loader *openapi3.Loader) *refGuard {
+	if loader == nil || loader.Context == nil {
+		return nil
+	}
+	guard, _ := loader.Context.Value(refGuardKey{}).(*refGuard)
+	return guard
+}
+
+// addRefPolicyRoot records the location of a spec which is about to be loaded, its external refs are resolved relative to it
+// a nil location means that the refs are resolved relative to the current working directory
+func addRefPolicyRoot(loader *openapi3.Loader, location *url.URL) {
+	guard := getRefGuard(loader)
+	if guard == nil {
+		return
+	}
+
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+
+	if location == nil {
+		guard.specDirs = append(guard.specDirs, realPath("."))
+		return
+	}
+
+	if slices.ContainsFunc(guard.roots, func(root *url.URL) bool { return root.String() == location.String() }) {
+		return
+	}
+
+	guard.roots = append(guard.roots, location)
+	if isLocalLocation(location) {
+		guard.specDirs = append(guard.specDirs, filepath.Dir(realPath(filepath.FromSlash(location.Path))))
+	} else {
+		guard.specHosts = append(guard.specHosts, location.Host)
+	}
+}
+
+// countRefPolicyRoot adds a spec which was read to the budget of the ref policy of the loader, if there is one
+func countRefPolicyRoot(loader *openapi3.Loader, location *url.URL, size int) error {
+	guard := getRefGuard(loader)
+	if guard == nil {
+		return nil
+	}
+
+	name := "stdin"
+	if location != nil {
+		name = location.String()
+	}
+	return guard.count(name, int64(size))
+}
+
+// readWithRefPolicy wraps a function that reads specs and enforces the ref policy of the loader, if there is one
+// redirects are checked when remote documents are read with readFromHTTP
+func readWithRefPolicy(read openapi3.ReadFromURIFunc) openapi3.ReadFromURIFunc {
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		guard := getRefGuard(loader)
+		if guard == nil {
+			return read(loader, location)
+		}
+		return guard.read(read, loader, location)
+	}
+}
+
+func (guard *refGuard) read(read openapi3.ReadFromURIFunc, loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if isLocalLocation(location) {
+		if err := guard.checkLocal(location); err != nil {
+			return nil, err
+		}
+		data, err := read(loader, location)
+		if err != nil {
+			return nil, err
+		}
+		if err := guard.countRef(location, len(data)); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	if err := guard.checkRemote(location); err != nil {
+		return nil, err
+	}
+	data, err := read(loader, location)
+	if err != nil {
+		return nil, err
+	}
+	if err := guard.countRef(location, len(data)); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (guard *refGuard) isRoot(location *url.URL) bool {
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+
+	return slices.ContainsFunc(guard.roots, func(root *url.URL) bool {
+		return root.String() == location.String()
+	})
+}
+
+// checkLocal checks that a local file is within the allowed directories, and that it fits in the remaining budget
+func (guard *refGuard) checkLocal(location *url.URL) error {
+	file := realPath(filepath.FromSlash(location.Path))
+
+	if !guard.isRoot(location) {
+		if dirs := guard.allowedDirs(); dirs != nil && !slices.ContainsFunc(dirs, func(dir string) bool { return isWithinDir(file, dir) }) {
+			return fmt.Errorf("%w: %q is outside of the allowed directories", ErrRefPolicy, location.Path)
+		}
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		// the file isn't on the disk, for example, because it is read from a git revision
+		return nil
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%w: %q isn't a regular file", ErrRefPolicy, location.Path)
+	}
+	return guard.checkBytes(location.Path, info.Size())
+}
+
+// allowedDirs returns the directories which local files are allowed in, or nil if all files are allowed
+func (guard *refGuard) allowedDirs() []string {
+	if !guard.policy.SpecDirOnly && len(guard.policy.AllowedDirs) == 0 {
+		return nil
+	}
+
+	result := []string{}
+	for _, dir := range guard.policy.AllowedDirs {
+		result = append(result, realPath(dir))
+	}
+	if guard.policy.SpecDirOnly {
+		guard.mu.Lock()
+		result = append(result, guard.specDirs...)
+		guard.mu.Unlock()
+	}
+	return result
+}
+
+// checkRemote checks that a URL is allowed
+func (guard *refGuard) checkRemote(location *url.URL) error {
+	if guard.isRoot(location) {
+		return nil
+	}
+
+	if location.Scheme != "http" && location.Scheme != "https" {
+		return fmt.Errorf("%w: %q has an unsupported scheme", ErrRefPolicy, location.String())
+	}
+
+	guard.mu.Lock()
+	specHost := slices.Contains(guard.specHosts, location.Host)
+	guard.mu.Unlock()
+	if specHost {
+		return nil
+	}
+
+	if guard.policy.NoRemote {
+		return fmt.Errorf("%w: %q is a remote ref, which isn't allowed", ErrRefPolicy, location.String())
+	}
+
+	if len(guard.policy.AllowedHosts) > 0 && !slices.Contains(guard.policy.AllowedHosts, location.Host) && !slices.Contains(guard.policy.AllowedHosts, location.Hostname()) {
+		return fmt.Errorf("%w: %q is on a host which isn't allowed", ErrRefPolicy, location.String())
+	}
+
+	return nil
+}
+
+// readFromHTTP reads a remote document like openapi3.ReadFromHTTP
+// when the loader has a ref policy, redirects are checked, and no more than the remaining budget is read
+func readFromHTTP(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if location.Scheme == "" || location.Host == "" {
+		return nil, openapi3.ErrURINotSupported
+	}
+
+	guard := getRefGuard(loader)
+	client := http.DefaultClient
+	if guard != nil {
+		client = guard.client
+	}
+
+	resp, err := client.Get(location.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 399 {
+		return nil, fmt.Errorf("error loading %q: request returned status code %d", location.String(), resp.StatusCode)
+	}
+
+	if guard == nil {
+		return io.ReadAll(resp.Body)
+	}
+
+	body := io.Reader(resp.Body)
+	remaining := guard.remainingBytes()
+	if remaining >= 0 {
+		// read one more byte to find out whether the document exceeds the budget
+		body = io.LimitReader(body, remaining+1)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// a truncated document is an error, rather than data, so that it isn't cached
+	if err := guard.checkBytes(location.String(), int64(len(data))); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// uncachedReadFromURI reads remote documents with readFromHTTP and local files, each time that they are referenced
+var uncachedReadFromURI = openapi3.ReadFromURIs(readFromHTTP, openapi3.ReadFromFile)
+
+// defaultReadFromURI reads remote documents and local files like openapi3.DefaultReadFromURI, remote documents are read with readFromHTTP
+// documents are cached for the lifetime of the process
+var defaultReadFromURI = openapi3.URIMapCache(uncachedReadFromURI)
+
+// WithoutCache configures a loader to read documents again each time, rather than from the cache of the process, so that changed files are noticed
+// it is meant for long-running processes, like the language server, and should be called before the other options which wrap the reader of the loader
+func WithoutCache(loader *openapi3.Loader) {
+	if loader.ReadFromURIFunc == nil {
+		loader.ReadFromURIFunc = uncachedReadFromURI
+	}
+}
+
+// remainingBytes returns the number of bytes that may still be read, or -1 if unlimited
+func (guard *refGuard) remainingBytes() int64 {
+	if guard.policy.MaxBytes == 0 {
+		return -1
+	}
+
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+	return max(guard.policy.MaxBytes-guard.bytes, 0)
+}
+
+func (guard *refGuard) checkBytes(name string, size int64) error {
+	if remaining := guard.remainingBytes(); remaining >= 0 && size > remaining {
+		return fmt.Errorf("%w: reading %q exceeds the limit of %d bytes", ErrRefPolicy, name, guard.policy.MaxBytes)
+	}
+	return nil
+}
+
+// countRef adds a document which was read through an external ref to the budget, the specs themselves are counted by countRefPolicyRoot
+func (guard *refGuard) countRef(location *url.URL, size int) error {
+	if guard.isRoot(location) {
+		return nil
+	}
+	return guard.count(location.String(), int64(size))
+}
+
+// count adds a document which was read to the budget
+func (guard *refGuard) count(name string, size int64) error {
+	if err := guard.checkBytes(name, size); err != nil {
+		return err
+	}
+
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+
+	if guard.policy.MaxDocuments > 0 && guard.documents >= guard.policy.MaxDocuments {
+		return fmt.Errorf("%w: reading %q exceeds the limit of %d documents", ErrRefPolicy, name, guard.policy.MaxDocuments)
+	}
+
+	guard.documents++
+	guard.bytes += size
+	return nil
+}
+
+func isLocalLocation(location *url.URL) bool {
+	return location.Host == "" && (location.Scheme == "" || location.Scheme == "file")
+}
+
+// realPath returns the absolute path of a file with symbolic links resolved, so that a link can't lead outside of the allowed directories
+// when the file doesn't exist, the links in its directory are resolved
+func realPath(file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.Clean(file)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		return filepath.Join(dir, filepath.Base(abs))
+	}
+	return abs
+}
+
+// isWithinDir indicates whether a file is within the directory tree of dir
+func isWithinDir(file, dir string) bool {
+	rel, err := filepath.Rel(dir, file)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
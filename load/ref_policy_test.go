@@ -0,0 +1,197 @@
+package load_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+const refPolicySpec = "../data/ref-policy/spec/openapi.yaml"
+
+func newRefPolicyLoader(policy *load.RefPolicy) *openapi3.Loader {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	load.WithRefPolicy(loader, policy)
+	return loader
+}
+
+// writeRemoteRefSpec writes a spec with a ref to a URL
+func writeRemoteRefSpec(t *testing.T, ref string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`openapi: 3.0.1
+info:
+  title: Pet Store
+  version: v1
+paths:
+  /pets:
+    $ref: "`+ref+`"
+`), 0644))
+	return file
+}
+
+func newPetsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`Pets:
+  get:
+    responses:
+      "200":
+        description: Success
+`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestParseRefPolicy(t *testing.T) {
+	policy, err := load.ParseRefPolicy([]string{"no-remote", "spec-dir", "allow-host=example.com", "allow-dir=shared", "max-bytes=1000", "max-documents=10"})
+	require.NoError(t, err)
+	require.Equal(t, &load.RefPolicy{
+		NoRemote:     true,
+		SpecDirOnly:  true,
+		AllowedHosts: []string{"example.com"},
+		AllowedDirs:  []string{"shared"},
+		MaxBytes:     1000,
+		MaxDocuments: 10,
+	}, policy)
+}
+
+func TestParseRefPolicy_Empty(t *testing.T) {
+	policy, err := load.ParseRefPolicy(nil)
+	require.NoError(t, err)
+	require.Nil(t, policy)
+}
+
+func TestParseRefPolicy_Invalid(t *testing.T) {
+	_, err := load.ParseRefPolicy([]string{"no-local"})
+	require.EqualError(t, err, `invalid ref policy option "no-local", allowed options: no-remote, spec-dir, allow-host=<host>, allow-dir=<dir>, max-bytes=<n>, max-documents=<n>`)
+
+	_, err = load.ParseRefPolicy([]string{"allow-host"})
+	require.EqualError(t, err, `ref policy option "allow-host" requires a value, for example: allow-host=<value>`)
+
+	_, err = load.ParseRefPolicy([]string{"spec-dir=true"})
+	require.EqualError(t, err, `ref policy option "spec-dir" doesn't take a value`)
+
+	_, err = load.ParseRefPolicy([]string{"max-bytes=-1"})
+	require.EqualError(t, err, `invalid max-bytes value "-1": should be a positive number`)
+}
+
+func TestRefPolicy_None(t *testing.T) {
+	_, err := load.NewSpecInfo(newRefPolicyLoader(nil), load.NewSource(refPolicySpec))
+	require.NoError(t, err)
+}
+
+func TestRefPolicy_SpecDir(t *testing.T) {
+	_, err := load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{SpecDirOnly: true}), load.NewSource(refPolicySpec))
+	require.ErrorIs(t, err, load.ErrRefPolicy)
+	require.ErrorContains(t, err, `error resolving reference "../shared/pet.yaml#/Pet"`)
+	require.ErrorContains(t, err, "is outside of the allowed directories")
+}
+
+func TestRefPolicy_AllowDir(t *testing.T) {
+	_, err := load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{SpecDirOnly: true, AllowedDirs: []string{"../data/ref-policy/shared"}}), load.NewSource(refPolicySpec))
+	require.NoError(t, err)
+}
+
+func TestRefPolicy_AllowDirWithoutSpecDir(t *testing.T) {
+	// the directory of the spec isn't allowed, but the spec itself is
+	_, err := load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{AllowedDirs: []string{"../data/ref-policy/shared"}}), load.NewSource(refPolicySpec))
+	require.ErrorIs(t, err, load.ErrRefPolicy)
+	require.ErrorContains(t, err, `error resolving reference "schemas.yaml#/Pets"`)
+}
+
+func TestRefPolicy_Symlink(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(`openapi: 3.0.1
+info:
+  title: Pet Store
+  version: v1
+paths:
+  /pets:
+    $ref: "link.yaml#/Pets"
+`), 0644))
+
+	abs, err := filepath.Abs("../data/ref-policy/shared/pet.yaml")
+	require.NoError(t, err)
+	if err := os.Symlink(abs, filepath.Join(dir, "link.yaml")); err != nil {
+		t.Skip("symbolic links are not supported")
+	}
+
+	_, err = load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{SpecDirOnly: true}), load.NewSource(filepath.Join(dir, "openapi.yaml")))
+	require.ErrorIs(t, err, load.ErrRefPolicy)
+}
+
+func TestRefPolicy_NoRemote(t *testing.T) {
+	server := newPetsServer(t)
+	spec := writeRemoteRefSpec(t, server.URL+"/pets.yaml#/Pets")
+
+	_, err := load.NewSpecInfo(newRefPolicyLoader(nil), load.NewSource(spec))
+	require.NoError(t, err)
+
+	_, err = load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{NoRemote: true}), load.NewSource(spec))
+	require.ErrorIs(t, err, load.ErrRefPolicy)
+	require.ErrorContains(t, err, "is a remote ref, which isn't allowed")
+}
+
+func TestRefPolicy_AllowHost(t *testing.T) {
+	server := newPetsServer(t)
+	spec := writeRemoteRefSpec(t, server.URL+"/pets.yaml#/Pets")
+
+	_, err := load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{AllowedHosts: []string{"127.0.0.1"}}), load.NewSource(spec))
+	require.NoError(t, err)
+
+	_, err = load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{AllowedHosts: []string{"example.com"}}), load.NewSource(spec))
+	require.ErrorIs(t, err, load.ErrRefPolicy)
+	require.ErrorContains(t, err, "is on a host which isn't allowed")
+}
+
+func TestRefPolicy_Redirect(t *testing.T) {
+	target := newPetsServer(t)
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL+"/pets.yaml", http.StatusFound))
+	t.Cleanup(redirect.Close)
+
+	spec := writeRemoteRefSpec(t, redirect.URL+"/pets.yaml#/Pets")
+
+	// both servers listen on 127.0.0.1, so the redirect is allowed only when the ports match
+	_, err := load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{AllowedHosts: []string{redirect.Listener.Addr().String()}}), load.NewSource(spec))
+	require.ErrorIs(t, err, load.ErrRefPolicy)
+}
+
+func TestRefPolicy_RemoteSpec(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("../data/ref-policy")))
+	t.Cleanup(server.Close)
+
+	// the spec and the refs to its host are allowed
+	_, err := load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{NoRemote: true}), load.NewSource(server.URL+"/spec/openapi.yaml"))
+	require.NoError(t, err)
+}
+
+func TestRefPolicy_MaxDocuments(t *testing.T) {
+	_, err := load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{MaxDocuments: 3}), load.NewSource(refPolicySpec))
+	require.NoError(t, err)
+
+	_, err = load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{MaxDocuments: 2}), load.NewSource(refPolicySpec))
+	require.ErrorIs(t, err, load.ErrRefPolicy)
+	require.ErrorContains(t, err, "exceeds the limit of 2 documents")
+}
+
+func TestRefPolicy_MaxBytes(t *testing.T) {
+	_, err := load.NewSpecInfo(newRefPolicyLoader(&load.RefPolicy{MaxBytes: 300}), load.NewSource(refPolicySpec))
+	require.ErrorIs(t, err, load.ErrRefPolicy)
+	require.ErrorContains(t, err, "exceeds the limit of 300 bytes")
+}
+
+func TestRefPolicy_Glob(t *testing.T) {
+	// the refs of all the specs that match the glob are checked
+	_, err := load.NewSpecInfoFromGlob(newRefPolicyLoader(&load.RefPolicy{SpecDirOnly: true}), "../data/ref-policy/spec/openapi*.yaml")
+	require.ErrorIs(t, err, load.ErrRefPolicy)
+}
@@ -0,0 +1,323 @@
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// JSON Schema 2020-12 keywords which OpenAPI 3.1 schemas may use: https://json-schema.org/draft/2020-12/json-schema-core
+// kin-openapi doesn't model them, so they are kept among the extensions of the schema
+const (
+	ConstKeyword                 = "const"
+	PrefixItemsKeyword           = "prefixItems"
+	ContainsKeyword              = "contains"
+	MinContainsKeyword           = "minContains"
+	MaxContainsKeyword           = "maxContains"
+	IfKeyword                    = "if"
+	ThenKeyword                  = "then"
+	ElseKeyword                  = "else"
+	DependentRequiredKeyword     = "dependentRequired"
+	DependentSchemasKeyword      = "dependentSchemas"
+	UnevaluatedPropertiesKeyword = "unevaluatedProperties"
+	UnevaluatedItemsKeyword      = "unevaluatedItems"
+	PropertyNamesKeyword         = "propertyNames"
+)
+
+var schemaKeywords = map[string]struct{}{
+	ConstKeyword:                 {},
+	PrefixItemsKeyword:           {},
+	ContainsKeyword:              {},
+	MinContainsKeyword:           {},
+	MaxContainsKeyword:           {},
+	IfKeyword:                    {},
+	ThenKeyword:                  {},
+	ElseKeyword:                  {},
+	DependentRequiredKeyword:     {},
+	DependentSchemasKeyword:      {},
+	UnevaluatedPropertiesKeyword: {},
+	UnevaluatedItemsKeyword:      {},
+	PropertyNamesKeyword:         {},
+}
+
+// IsSchemaKeyword indicates whether a schema extension is actually a JSON Schema 2020-12 keyword
+func IsSchemaKeyword(name string) bool {
+	_, ok := schemaKeywords[name]
+	return ok
+}
+
+// SchemaKeywords are the JSON Schema 2020-12 keywords of a schema
+type SchemaKeywords struct {
+	Const                 any
+	HasConst              bool
+	PrefixItems           openapi3.SchemaRefs
+	Contains              *openapi3.SchemaRef
+	MinContains           *uint64
+	MaxContains           *uint64
+	If                    *openapi3.SchemaRef
+	Then                  *openapi3.SchemaRef
+	Else                  *openapi3.SchemaRef
+	DependentRequired     map[string][]string
+	DependentSchemas      openapi3.Schemas
+	UnevaluatedProperties openapi3.AdditionalProperties
+	UnevaluatedItems      openapi3.AdditionalProperties
+	PropertyNames         *openapi3.SchemaRef
+}
+
+// GetSchemaKeywords returns the JSON Schema 2020-12 keywords of a schema
+// Specs loaded by this package, or passed to ResolveSchemaKeywords, have the schemas of their keywords resolved already.
+// Otherwise, keywords are parsed on first use and their references are left unresolved.
+// Malformed keywords are ignored.
+func GetSchemaKeywords(schema *openapi3.Schema) SchemaKeywords {
+	result := SchemaKeywords{}
+	if schema == nil || len(schema.Extensions) == 0 {
+		return result
+	}
+
+	_ = parseSchemaKeywords(schema)
+
+	result.Const, result.HasConst = schema.Extensions[ConstKeyword]
+	result.PrefixItems, _ = schema.Extensions[PrefixItemsKeyword].(openapi3.SchemaRefs)
+	result.Contains, _ = schema.Extensions[ContainsKeyword].(*openapi3.SchemaRef)
+	result.MinContains = getUInt64Keyword(schema, MinContainsKeyword)
+	result.MaxContains = getUInt64Keyword(schema, MaxContainsKeyword)
+	result.If, _ = schema.Extensions[IfKeyword].(*openapi3.SchemaRef)
+	result.Then, _ = schema.Extensions[ThenKeyword].(*openapi3.SchemaRef)
+	result.Else, _ = schema.Extensions[ElseKeyword].(*openapi3.SchemaRef)
+	result.DependentRequired = getDependentRequired(schema)
+	result.DependentSchemas, _ = schema.Extensions[DependentSchemasKeyword].(openapi3.Schemas)
+	result.UnevaluatedProperties, _ = schema.Extensions[UnevaluatedPropertiesKeyword].(openapi3.AdditionalProperties)
+	result.UnevaluatedItems, _ = schema.Extensions[UnevaluatedItemsKeyword].(openapi3.AdditionalProperties)
+	result.PropertyNames, _ = schema.Extensions[PropertyNamesKeyword].(*openapi3.SchemaRef)
+
+	return result
+}
+
+// ResolveSchemaKeywords parses the webhooks and the keywords of all schemas in a spec and resolves the references in them
+// Only local references (#/components/...) can be resolved because the location of the spec is unknown, other references are an error.
+// Specs loaded by this package are resolved already, so they are left unchanged.
+func ResolveSchemaKeywords(spec *openapi3.T) error {
+	if spec == nil {
+		return nil
+	}
+
+	// the webhooks are parsed first so that their schemas are resolved too
+	if err := ResolveWebhooks(spec); err != nil {
+		return err
+	}
+
+	return resolveSchemaKeywords(newLocalLoader(), spec, nil)
+}
+
+// resolveSchemaKeywords parses the keywords of all schemas in a spec and resolves the references in their subschemas
+// resolving subschemas may reach new schemas with keywords of their own, so this is repeated until no new keywords are found
+func resolveSchemaKeywords(loader *openapi3.Loader, spec *openapi3.T, location *url.URL) error {
+	if spec == nil {
+		return nil
+	}
+
+	attempted := map[*openapi3.SchemaRef]struct{}{}
+	for {
+		pending, err := parseAllSchemaKeywords(spec)
+		if err != nil {
+			return err
+		}
+
+		// subschemas which were already resolved once, but still have unresolved references, are not retried
+		progress := false
+		for _, schemaRef := range pending {
+			if _, ok := attempted[schemaRef]; !ok {
+				attempted[schemaRef] = struct{}{}
+				progress = true
+			}
+		}
+		if !progress {
+			return nil
+		}
+
+		// keyword subschemas are resolved as if they were additional component schemas of the original spec
+		// local references are resolved to the same objects as in the original spec
+		components := openapi3.Components{Schemas: openapi3.Schemas{}}
+		if spec.Components != nil {
+			components = *spec.Components
+			components.Schemas = make(openapi3.Schemas, len(spec.Components.Schemas)+len(pending))
+			for name, schemaRef := range spec.Components.Schemas {
+				components.Schemas[name] = schemaRef
+			}
+		}
+		for i, schemaRef := range pending {
+			components.Schemas[fmt.Sprintf("oasdiff-schema-keyword-%d", i)] = schemaRef
+		}
+
+		if err := loader.ResolveRefsIn(&openapi3.T{Components: &components, Paths: openapi3.NewPaths()}, location); err != nil {
+			return fmt.Errorf("failed to resolve schema keywords: %w", err)
+		}
+	}
+}
+
+// parseAllSchemaKeywords parses the keywords of all schemas in a spec
+// it returns the subschemas that were parsed and the references that are still unresolved, e.g. in keywords that were parsed on first use
+func parseAllSchemaKeywords(spec *openapi3.T) (openapi3.SchemaRefs, error) {
+	var pending openapi3.SchemaRefs
+	var err error
+
+	walker := newSchemaWalker(func(schema *openapi3.Schema) {
+		if err != nil {
+			return
+		}
+		var subschemas openapi3.SchemaRefs
+		if subschemas, err = parseSchemaKeywordsWithSubschemas(schema); err == nil {
+			pending = append(pending, subschemas...)
+		}
+	})
+	walker.unresolved = func(schemaRef *openapi3.SchemaRef) {
+		pending = append(pending, schemaRef)
+	}
+	walker.spec(spec)
+
+	return pending, err
+}
+
+// parseSchemaKeywords replaces the raw keywords of a schema with their parsed values
+func parseSchemaKeywords(schema *openapi3.Schema) error {
+	_, err := parseSchemaKeywordsWithSubschemas(schema)
+	return err
+}
+
+// parseSchemaKeywordsWithSubschemas replaces the raw keywords of a schema with their parsed values and returns the subschemas that were parsed
+func parseSchemaKeywordsWithSubschemas(schema *openapi3.Schema) (openapi3.SchemaRefs, error) {
+	var result openapi3.SchemaRefs
+
+	for _, keyword := range []string{ContainsKeyword, IfKeyword, ThenKeyword, ElseKeyword, PropertyNamesKeyword} {
+		raw, ok := schema.Extensions[keyword]
+		if !ok {
+			continue
+		}
+		if _, ok := raw.(*openapi3.SchemaRef); ok {
+			continue
+		}
+		var schemaRef *openapi3.SchemaRef
+		if value, ok := raw.(bool); ok {
+			schemaRef = booleanSchema(value)
+		} else if err := convertKeyword(schema, keyword, &schemaRef); err != nil {
+			return nil, err
+		}
+		if schemaRef == nil {
+			delete(schema.Extensions, keyword)
+			continue
+		}
+		schema.Extensions[keyword] = schemaRef
+		result = append(result, schemaRef)
+	}
+
+	if raw, ok := schema.Extensions[PrefixItemsKeyword]; ok {
+		if _, ok := raw.(openapi3.SchemaRefs); !ok {
+			var schemaRefs openapi3.SchemaRefs
+			if err := convertKeyword(schema, PrefixItemsKeyword, &schemaRefs); err != nil {
+				return nil, err
+			}
+			schema.Extensions[PrefixItemsKeyword] = schemaRefs
+			result = append(result, schemaRefs...)
+		}
+	}
+
+	if raw, ok := schema.Extensions[DependentSchemasKeyword]; ok {
+		if _, ok := raw.(openapi3.Schemas); !ok {
+			var schemas openapi3.Schemas
+			if err := convertKeyword(schema, DependentSchemasKeyword, &schemas); err != nil {
+				return nil, err
+			}
+			schema.Extensions[DependentSchemasKeyword] = schemas
+			for _, name := range sortedSchemaNames(schemas) {
+				result = append(result, schemas[name])
+			}
+		}
+	}
+
+	for _, keyword := range []string{UnevaluatedPropertiesKeyword, UnevaluatedItemsKeyword} {
+		raw, ok := schema.Extensions[keyword]
+		if !ok {
+			continue
+		}
+		if _, ok := raw.(openapi3.AdditionalProperties); ok {
+			continue
+		}
+		var unevaluated openapi3.AdditionalProperties
+		if err := convertKeyword(schema, keyword, &unevaluated); err != nil {
+			return nil, err
+		}
+		schema.Extensions[keyword] = unevaluated
+		if unevaluated.Schema != nil {
+			result = append(result, unevaluated.Schema)
+		}
+	}
+
+	return result, nil
+}
+
+// convertKeyword converts the raw value of a keyword into its parsed form
+func convertKeyword(schema *openapi3.Schema, keyword string, target any) error {
+	data, err := json.Marshal(schema.Extensions[keyword])
+	if err != nil {
+		return fmt.Errorf("failed to read schema keyword %q: %w", keyword, err)
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to read schema keyword %q: %w", keyword, err)
+	}
+
+	return nil
+}
+
+// booleanSchema returns the schema equivalent to a boolean schema: true accepts any instance and false accepts none
+func booleanSchema(value bool) *openapi3.SchemaRef {
+	if value {
+		return openapi3.NewSchemaRef("", openapi3.NewSchema())
+	}
+	return openapi3.NewSchemaRef("", &openapi3.Schema{Not: openapi3.NewSchemaRef("", openapi3.NewSchema())})
+}
+
+func getUInt64Keyword(schema *openapi3.Schema, keyword string) *uint64 {
+	var result uint64
+	switch value := schema.Extensions[keyword].(type) {
+	case float64:
+		if value < 0 {
+			return nil
+		}
+		result = uint64(value)
+	case int:
+		if value < 0 {
+			return nil
+		}
+		result = uint64(value)
+	case uint64:
+		result = value
+	default:
+		return nil
+	}
+	return &result
+}
+
+func getDependentRequired(schema *openapi3.Schema) map[string][]string {
+	if _, ok := schema.Extensions[DependentRequiredKeyword]; !ok {
+		return nil
+	}
+
+	var result map[string][]string
+	if err := convertKeyword(schema, DependentRequiredKeyword, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+func sortedSchemaNames(schemas openapi3.Schemas) []string {
+	result := make([]string, 0, len(schemas))
+	for name := range schemas {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,73 @@
+package load_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+func TestGetSchemaKeywords(t *testing.T) {
+	specInfo, err := load.NewSpecInfo(MockLoader{}, load.NewSource("../data/schema-keywords/revision.yaml"))
+	require.NoError(t, err)
+
+	properties := specInfo.Spec.Components.Schemas["Order"].Value.Properties
+
+	kind := load.GetSchemaKeywords(properties["kind"].Value)
+	require.True(t, kind.HasConst)
+	require.Equal(t, "standard", kind.Const)
+
+	// references in keyword subschemas are resolved to the components of the spec
+	coordinates := load.GetSchemaKeywords(properties["coordinates"].Value)
+	require.Len(t, coordinates.PrefixItems, 2)
+	require.Same(t, specInfo.Spec.Components.Schemas["Latitude"].Value, coordinates.PrefixItems[0].Value)
+	require.NotNil(t, coordinates.UnevaluatedItems.Has)
+	require.False(t, *coordinates.UnevaluatedItems.Has)
+
+	tags := load.GetSchemaKeywords(properties["tags"].Value)
+	require.Equal(t, &openapi3.Types{"string"}, tags.Contains.Value.Type)
+	require.Equal(t, uint64(2), *tags.MinContains)
+	require.Nil(t, tags.MaxContains)
+
+	delivery := load.GetSchemaKeywords(properties["delivery"].Value)
+	require.Equal(t, []string{"phone", "email"}, delivery.Then.Value.Required)
+	require.Nil(t, delivery.Else)
+
+	// nested keywords are parsed too
+	require.Equal(t, true, load.GetSchemaKeywords(delivery.If.Value.Properties["express"].Value).Const)
+
+	billing := load.GetSchemaKeywords(properties["billing"].Value)
+	require.Equal(t, map[string][]string{"card": {"address", "zip"}}, billing.DependentRequired)
+}
+
+func TestGetSchemaKeywords_Lazy(t *testing.T) {
+	schema := openapi3.NewSchema()
+	require.NoError(t, schema.UnmarshalJSON([]byte(`{"type":"object","propertyNames":{"pattern":"^[a-z]+$"},"dependentSchemas":{"card":{"required":["zip"]}},"unevaluatedProperties":{"type":"string"}}`)))
+
+	keywords := load.GetSchemaKeywords(schema)
+	require.Equal(t, "^[a-z]+$", keywords.PropertyNames.Value.Pattern)
+	require.Equal(t, []string{"zip"}, keywords.DependentSchemas["card"].Value.Required)
+	require.Nil(t, keywords.UnevaluatedProperties.Has)
+	require.Equal(t, &openapi3.Types{"string"}, keywords.UnevaluatedProperties.Schema.Value.Type)
+	require.False(t, keywords.HasConst)
+}
+
+func TestGetSchemaKeywords_BooleanSchema(t *testing.T) {
+	schema := openapi3.NewSchema()
+	require.NoError(t, schema.UnmarshalJSON([]byte(`{"if":true,"else":false}`)))
+
+	keywords := load.GetSchemaKeywords(schema)
+	require.Nil(t, keywords.If.Value.Not)
+	require.NotNil(t, keywords.Else.Value.Not)
+}
+
+func TestGetSchemaKeywords_None(t *testing.T) {
+	require.Equal(t, load.SchemaKeywords{}, load.GetSchemaKeywords(nil))
+	require.Equal(t, load.SchemaKeywords{}, load.GetSchemaKeywords(openapi3.NewSchema()))
+}
+
+func TestIsSchemaKeyword(t *testing.T) {
+	require.True(t, load.IsSchemaKeyword("unevaluatedProperties"))
+	require.False(t, load.IsSchemaKeyword("x-extensible-enum"))
+}
@@ -0,0 +1,193 @@
+package load
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// schemaWalker visits each schema of a spec once, including the subschemas of JSON Schema 2020-12 keywords that were parsed already
+type schemaWalker struct {
+	visit      func(schema *openapi3.Schema)
+	unresolved func(schemaRef *openapi3.SchemaRef)
+	visited    map[*openapi3.Schema]struct{}
+}
+
+func newSchemaWalker(visit func(schema *openapi3.Schema)) *schemaWalker {
+	return &schemaWalker{
+		visit:   visit,
+		visited: map[*openapi3.Schema]struct{}{},
+	}
+}
+
+func (w *schemaWalker) spec(spec *openapi3.T) {
+	if components := spec.Components; components != nil {
+		for _, schemaRef := range components.Schemas {
+			w.schemaRef(schemaRef)
+		}
+		for _, parameterRef := range components.Parameters {
+			w.parameterRef(parameterRef)
+		}
+		for _, headerRef := range components.Headers {
+			w.headerRef(headerRef)
+		}
+		for _, requestBodyRef := range components.RequestBodies {
+			w.requestBodyRef(requestBodyRef)
+		}
+		for _, responseRef := range components.Responses {
+			w.responseRef(responseRef)
+		}
+		for _, callbackRef := range components.Callbacks {
+			w.callbackRef(callbackRef)
+		}
+	}
+
+	w.paths(spec.Paths)
+	if webhooks, ok := spec.Extensions[WebhooksField].(*openapi3.Paths); ok {
+		w.paths(webhooks)
+	}
+}
+
+func (w *schemaWalker) paths(paths *openapi3.Paths) {
+	if paths == nil {
+		return
+	}
+
+	for _, pathItem := range paths.Map() {
+		w.pathItem(pathItem)
+	}
+}
+
+func (w *schemaWalker) pathItem(pathItem *openapi3.PathItem) {
+	if pathItem == nil {
+		return
+	}
+
+	for _, parameterRef := range pathItem.Parameters {
+		w.parameterRef(parameterRef)
+	}
+
+	for _, operation := range pathItem.Operations() {
+		for _, parameterRef := range operation.Parameters {
+			w.parameterRef(parameterRef)
+		}
+		w.requestBodyRef(operation.RequestBody)
+		if operation.Responses != nil {
+			for _, responseRef := range operation.Responses.Map() {
+				w.responseRef(responseRef)
+			}
+		}
+		for _, callbackRef := range operation.Callbacks {
+			w.callbackRef(callbackRef)
+		}
+	}
+}
+
+func (w *schemaWalker) callbackRef(callbackRef *openapi3.CallbackRef) {
+	if callbackRef == nil || callbackRef.Value == nil {
+		return
+	}
+
+	for _, pathItem := range callbackRef.Value.Map() {
+		w.pathItem(pathItem)
+	}
+}
+
+func (w *schemaWalker) parameterRef(parameterRef *openapi3.ParameterRef) {
+	if parameterRef == nil || parameterRef.Value == nil {
+		return
+	}
+
+	w.schemaRef(parameterRef.Value.Schema)
+	w.content(parameterRef.Value.Content)
+}
+
+func (w *schemaWalker) headerRef(headerRef *openapi3.HeaderRef) {
+	if headerRef == nil || headerRef.Value == nil {
+		return
+	}
+
+	w.schemaRef(headerRef.Value.Schema)
+	w.content(headerRef.Value.Content)
+}
+
+func (w *schemaWalker) requestBodyRef(requestBodyRef *openapi3.RequestBodyRef) {
+	if requestBodyRef == nil || requestBodyRef.Value == nil {
+		return
+	}
+
+	w.content(requestBodyRef.Value.Content)
+}
+
+func (w *schemaWalker) responseRef(responseRef *openapi3.ResponseRef) {
+	if responseRef == nil || responseRef.Value == nil {
+		return
+	}
+
+	for _, headerRef := range responseRef.Value.Headers {
+		w.headerRef(headerRef)
+	}
+	w.content(responseRef.Value.Content)
+}
+
+func (w *schemaWalker) content(content openapi3.Content) {
+	for _, mediaType := range content {
+		if mediaType != nil {
+			w.schemaRef(mediaType.Schema)
+		}
+	}
+}
+
+func (w *schemaWalker) schemaRefs(schemaRefs openapi3.SchemaRefs) {
+	for _, schemaRef := range schemaRefs {
+		w.schemaRef(schemaRef)
+	}
+}
+
+func (w *schemaWalker) schemaRef(schemaRef *openapi3.SchemaRef) {
+	if schemaRef == nil {
+		return
+	}
+
+	if schemaRef.Value == nil {
+		if schemaRef.Ref != "" && w.unresolved != nil {
+			w.unresolved(schemaRef)
+		}
+		return
+	}
+
+	schema := schemaRef.Value
+	if _, ok := w.visited[schema]; ok {
+		return
+	}
+	w.visited[schema] = struct{}{}
+
+	w.visit(schema)
+
+	w.schemaRefs(schema.OneOf)
+	w.schemaRefs(schema.AnyOf)
+	w.schemaRefs(schema.AllOf)
+	w.schemaRef(schema.Not)
+	w.schemaRef(schema.Items)
+	for _, property := range schema.Properties {
+		w.schemaRef(property)
+	}
+	w.schemaRef(schema.AdditionalProperties.Schema)
+
+	for _, keyword := range []string{ContainsKeyword, IfKeyword, ThenKeyword, ElseKeyword, PropertyNamesKeyword} {
+		if subschema, ok := schema.Extensions[keyword].(*openapi3.SchemaRef); ok {
+			w.schemaRef(subschema)
+		}
+	}
+	if prefixItems, ok := schema.Extensions[PrefixItemsKeyword].(openapi3.SchemaRefs); ok {
+		w.schemaRefs(prefixItems)
+	}
+	if dependentSchemas, ok := schema.Extensions[DependentSchemasKeyword].(openapi3.Schemas); ok {
+		for _, subschema := range dependentSchemas {
+			w.schemaRef(subschema)
+		}
+	}
+	for _, keyword := range []string{UnevaluatedPropertiesKeyword, UnevaluatedItemsKeyword} {
+		if unevaluated, ok := schema.Extensions[keyword].(openapi3.AdditionalProperties); ok {
+			w.schemaRef(unevaluated.Schema)
+		}
+	}
+}
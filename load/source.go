@@ -11,6 +11,7 @@ const (
 	SourceTypeStdin SourceType = iota
 	SourceTypeURL
 	SourceTypeFile
+	SourceTypeGit
 )
 
 type Source struct {
@@ -27,6 +28,13 @@ func NewSource(path string) *Source {
 		}
 	}
 
+	if IsGitSource(path) {
+		return &Source{
+			Path: path,
+			Type: SourceTypeGit,
+		}
+	}
+
 	if uri, err := getURL(path); err == nil {
 		return &Source{
 			Path: path,
@@ -59,3 +67,7 @@ func (source *Source) IsStdin() bool {
 func (source *Source) IsFile() bool {
 	return source.Type == SourceTypeFile
 }
+
+func (source *Source) IsGit() bool {
+	return source.Type == SourceTypeGit
+}
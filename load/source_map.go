@@ -0,0 +1,356 @@
+package load
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// Position is the zero-based location of a spec element in its source file
+type Position struct {
+	Line      int
+	Column    int
+	LineEnd   int
+	ColumnEnd int
+}
+
+// SourceMap records where the elements of a spec are located in its source file
+// Elements can be looked up by JSON pointer (RFC 6901) or by the kin-openapi object that represents them
+type SourceMap struct {
+	File      string
+	positions map[string]Position
+	elements  map[any]string
+}
+
+// NewSourceMap parses the raw YAML or JSON data of a spec and maps its elements to their positions
+func NewSourceMap(file string, data []byte, spec *openapi3.T) (*SourceMap, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse source positions of %q: %w", file, err)
+	}
+
+	sourceMap := SourceMap{
+		File:      file,
+		positions: map[string]Position{},
+		elements:  map[any]string{},
+	}
+
+	if len(root.Content) > 0 {
+		sourceMap.positions[""] = Position{}
+		sourceMap.addNode("", root.Content[0])
+	}
+	sourceMap.addSpec(spec)
+
+	return &sourceMap, nil
+}
+
+// newFileSourceMap creates a SourceMap for a local spec file
+// source positions are best-effort, so any failure results in a nil map rather than an error
+func newFileSourceMap(file string, spec *openapi3.T) *SourceMap {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+
+	return newDataSourceMap(file, data, spec)
+}
+
+// newDataSourceMap creates a SourceMap from the contents of a spec file, for example, a file at a git revision
+// like newFileSourceMap, any failure results in a nil map rather than an error
+func newDataSourceMap(file string, data []byte, spec *openapi3.T) *SourceMap {
+	sourceMap, err := NewSourceMap(file, data, spec)
+	if err != nil {
+		return nil
+	}
+
+	return sourceMap
+}
+
+// PointerPosition returns the position of the element at the given JSON pointer
+// If the pointer doesn't appear in the source file, for example because it crosses a $ref, the position of the nearest enclosing element is returned
+func (sourceMap *SourceMap) PointerPosition(pointer string) (Position, bool) {
+	if sourceMap == nil {
+		return Position{}, false
+	}
+
+	for {
+		if position, ok := sourceMap.positions[pointer]; ok {
+			return position, true
+		}
+
+		i := strings.LastIndex(pointer, "/")
+		if i < 0 {
+			return Position{}, false
+		}
+		pointer = pointer[:i]
+	}
+}
+
+// ElementPointer returns the JSON pointer of a kin-openapi object, like *openapi3.Operation or *openapi3.Schema
+func (sourceMap *SourceMap) ElementPointer(element any) (string, bool) {
+	if sourceMap == nil || element == nil {
+		return "", false
+	}
+
+	pointer, ok := sourceMap.elements[element]
+	return pointer, ok
+}
+
+// ElementPosition returns the position of a kin-openapi object, like *openapi3.Operation or *openapi3.Schema
+func (sourceMap *SourceMap) ElementPosition(element any) (Position, bool) {
+	pointer, ok := sourceMap.ElementPointer(element)
+	if !ok {
+		return Position{}, false
+	}
+
+	return sourceMap.PointerPosition(pointer)
+}
+
+func (sourceMap *SourceMap) addNode(pointer string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPointer := pointer + "/" + EscapePointerToken(key.Value)
+			sourceMap.positions[childPointer] = newPosition(key, value)
+			sourceMap.addNode(childPointer, value)
+		}
+	case yaml.SequenceNode:
+		for i, value := range node.Content {
+			childPointer := pointer + "/" + strconv.Itoa(i)
+			sourceMap.positions[childPointer] = newPosition(value, value)
+			sourceMap.addNode(childPointer, value)
+		}
+	}
+}
+
+func newPosition(start, value *yaml.Node) Position {
+	position := Position{
+		Line:    start.Line - 1,
+		Column:  start.Column - 1,
+		LineEnd: lastLine(value) - 1,
+	}
+
+	if value.Kind == yaml.ScalarNode && value.Line == start.Line && !strings.Contains(value.Value, "\n") {
+		position.ColumnEnd = value.Column - 1 + len(value.Value)
+	}
+
+	return position
+}
+
+func lastLine(node *yaml.Node) int {
+	if len(node.Content) == 0 {
+		return node.Line
+	}
+
+	return lastLine(node.Content[len(node.Content)-1])
+}
+
+// EscapePointerToken escapes a single reference token of a JSON pointer according to RFC 6901
+func EscapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// addSpec maps the objects of the spec to their JSON pointers
+// components are added first, so that objects referenced with $ref are mapped to their definition
+func (sourceMap *SourceMap) addSpec(spec *openapi3.T) {
+	if spec == nil {
+		return
+	}
+
+	if components := spec.Components; components != nil {
+		for name, schema := range components.Schemas {
+			sourceMap.addSchema("/components/schemas/"+EscapePointerToken(name), schema)
+		}
+		for name, parameter := range components.Parameters {
+			sourceMap.addParameter("/components/parameters/"+EscapePointerToken(name), parameter)
+		}
+		for name, header := range components.Headers {
+			sourceMap.addHeader("/components/headers/"+EscapePointerToken(name), header)
+		}
+		for name, requestBody := range components.RequestBodies {
+			sourceMap.addRequestBody("/components/requestBodies/"+EscapePointerToken(name), requestBody)
+		}
+		for name, response := range components.Responses {
+			sourceMap.addResponse("/components/responses/"+EscapePointerToken(name), response)
+		}
+		for name, securityScheme := range components.SecuritySchemes {
+			if securityScheme != nil && securityScheme.Value != nil {
+				sourceMap.add("/components/securitySchemes/"+EscapePointerToken(name), securityScheme.Value)
+			}
+		}
+	}
+
+	if spec.Paths != nil {
+		for path, pathItem := range spec.Paths.Map() {
+			sourceMap.addPathItem("/paths/"+EscapePointerToken(path), pathItem)
+		}
+	}
+
+	// the source map is best-effort, webhooks which can't be resolved are reported when they are compared
+	if webhooks, err := GetWebhooks(spec); err == nil && webhooks != nil {
+		for name, pathItem := range webhooks.Map() {
+			sourceMap.addPathItem("/"+WebhooksField+"/"+EscapePointerToken(name), pathItem)
+		}
+	}
+}
+
+func (sourceMap *SourceMap) addPathItem(pathPointer string, pathItem *openapi3.PathItem) {
+	if pathItem == nil || !sourceMap.add(pathPointer, pathItem) {
+		return
+	}
+
+	for i, parameter := range pathItem.Parameters {
+		sourceMap.addParameter(pathPointer+"/parameters/"+strconv.Itoa(i), parameter)
+	}
+
+	for method, operation := range pathItem.Operations() {
+		operationPointer := pathPointer + "/" + strings.ToLower(method)
+		if !sourceMap.add(operationPointer, operation) {
+			continue
+		}
+
+		for i, parameter := range operation.Parameters {
+			sourceMap.addParameter(operationPointer+"/parameters/"+strconv.Itoa(i), parameter)
+		}
+
+		sourceMap.addRequestBody(operationPointer+"/requestBody", operation.RequestBody)
+
+		if operation.Responses != nil {
+			for status, response := range operation.Responses.Map() {
+				sourceMap.addResponse(operationPointer+"/responses/"+EscapePointerToken(status), response)
+			}
+		}
+	}
+}
+
+// add maps an object to a pointer unless it was already mapped, and reports whether it was added
+func (sourceMap *SourceMap) add(pointer string, element any) bool {
+	if _, ok := sourceMap.elements[element]; ok {
+		return false
+	}
+
+	sourceMap.elements[element] = pointer
+	return true
+}
+
+func (sourceMap *SourceMap) addParameter(pointer string, parameter *openapi3.ParameterRef) {
+	if parameter == nil || parameter.Value == nil || !sourceMap.add(pointer, parameter.Value) {
+		return
+	}
+
+	sourceMap.addSchema(pointer+"/schema", parameter.Value.Schema)
+	sourceMap.addContent(pointer+"/content", parameter.Value.Content)
+}
+
+func (sourceMap *SourceMap) addHeader(pointer string, header *openapi3.HeaderRef) {
+	if header == nil || header.Value == nil || !sourceMap.add(pointer, header.Value) {
+		return
+	}
+
+	sourceMap.addSchema(pointer+"/schema", header.Value.Schema)
+	sourceMap.addContent(pointer+"/content", header.Value.Content)
+}
+
+func (sourceMap *SourceMap) addRequestBody(pointer string, requestBody *openapi3.RequestBodyRef) {
+	if requestBody == nil || requestBody.Value == nil || !sourceMap.add(pointer, requestBody.Value) {
+		return
+	}
+
+	sourceMap.addContent(pointer+"/content", requestBody.Value.Content)
+}
+
+func (sourceMap *SourceMap) addResponse(pointer string, response *openapi3.ResponseRef) {
+	if response == nil || response.Value == nil || !sourceMap.add(pointer, response.Value) {
+		return
+	}
+
+	for name, header := range response.Value.Headers {
+		sourceMap.addHeader(pointer+"/headers/"+EscapePointerToken(name), header)
+	}
+	sourceMap.addContent(pointer+"/content", response.Value.Content)
+}
+
+func (sourceMap *SourceMap) addContent(pointer string, content openapi3.Content) {
+	for mediaType, mediaTypeObject := range content {
+		mediaTypePointer := pointer + "/" + EscapePointerToken(mediaType)
+		if mediaTypeObject == nil || !sourceMap.add(mediaTypePointer, mediaTypeObject) {
+			continue
+		}
+		sourceMap.addSchema(mediaTypePointer+"/schema", mediaTypeObject.Schema)
+	}
+}
+
+func (sourceMap *SourceMap) addSchema(pointer string, schema *openapi3.SchemaRef) {
+	if schema == nil || schema.Value == nil || !sourceMap.add(pointer, schema.Value) {
+		return
+	}
+
+	value := schema.Value
+	for name, property := range value.Properties {
+		sourceMap.addSchema(pointer+"/properties/"+EscapePointerToken(name), property)
+	}
+	sourceMap.addSchema(pointer+"/items", value.Items)
+	sourceMap.addSchema(pointer+"/not", value.Not)
+	sourceMap.addSchema(pointer+"/additionalProperties", value.AdditionalProperties.Schema)
+	for i, subschema := range value.AllOf {
+		sourceMap.addSchema(pointer+"/allOf/"+strconv.Itoa(i), subschema)
+	}
+	for i, subschema := range value.AnyOf {
+		sourceMap.addSchema(pointer+"/anyOf/"+strconv.Itoa(i), subschema)
+	}
+	for i, subschema := range value.OneOf {
+		sourceMap.addSchema(pointer+"/oneOf/"+strconv.Itoa(i), subschema)
+	}
+}
+
+// SourceMaps is a collection of source maps, one for each loaded spec file
+type SourceMaps []*SourceMap
+
+// NewSourceMaps collects the source maps of the given specs, skipping specs that don't have one
+func NewSourceMaps(specInfos ...*SpecInfo) SourceMaps {
+	result := SourceMaps{}
+	for _, specInfo := range specInfos {
+		if specInfo != nil && specInfo.SourceMap != nil {
+			result = append(result, specInfo.SourceMap)
+		}
+	}
+	return result
+}
+
+// LocateElement returns the file and position of a kin-openapi object in any of the source maps
+func (sourceMaps SourceMaps) LocateElement(element any) (string, Position, bool) {
+	for _, sourceMap := range sourceMaps {
+		if position, ok := sourceMap.ElementPosition(element); ok {
+			return sourceMap.File, position, true
+		}
+	}
+	return "", Position{}, false
+}
+
+// LocatePointer returns the file and exact position of a JSON pointer in the first source map that contains it
+func (sourceMaps SourceMaps) LocatePointer(pointer string) (string, Position, bool) {
+	for _, sourceMap := range sourceMaps {
+		if position, ok := sourceMap.positions[pointer]; ok {
+			return sourceMap.File, position, true
+		}
+	}
+	return "", Position{}, false
+}
+
+// LocateElementPointer returns the file and position of a JSON pointer relative to a kin-openapi object in any of the source maps, like /enum/2 under a schema
+// If the pointer doesn't appear in the source file, the position of the nearest enclosing element is returned
+func (sourceMaps SourceMaps) LocateElementPointer(element any, pointer string) (string, Position, bool) {
+	for _, sourceMap := range sourceMaps {
+		if elementPointer, ok := sourceMap.ElementPointer(element); ok {
+			if position, ok := sourceMap.PointerPosition(elementPointer + pointer); ok {
+				return sourceMap.File, position, true
+			}
+		}
+	}
+	return "", Position{}, false
+}
@@ -0,0 +1,71 @@
+package load_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+func TestSourceMap_File(t *testing.T) {
+	specInfo, err := load.NewSpecInfo(MockLoader{}, load.NewSource("../data/openapi-test1.yaml"))
+	require.NoError(t, err)
+	require.NotNil(t, specInfo.SourceMap)
+	require.Equal(t, "../data/openapi-test1.yaml", specInfo.SourceMap.File)
+
+	operation := specInfo.Spec.Paths.Value("/api/{domain}/{project}/badges/security-score").Get
+	position, ok := specInfo.SourceMap.ElementPosition(operation)
+	require.True(t, ok)
+	require.Equal(t, 32, position.Line)
+	require.Equal(t, 4, position.Column)
+
+	param := operation.Parameters.GetByInAndName("cookie", "test")
+	position, ok = specInfo.SourceMap.ElementPosition(param)
+	require.True(t, ok)
+	require.Equal(t, 61, position.Line)
+	require.Equal(t, 66, position.LineEnd)
+}
+
+func TestSourceMap_URL(t *testing.T) {
+	specInfo, err := load.NewSpecInfo(MockLoader{}, load.NewSource("https://localhost/data/openapi-test1.yaml"))
+	require.NoError(t, err)
+	require.Nil(t, specInfo.SourceMap)
+}
+
+func TestSourceMap_Glob(t *testing.T) {
+	specInfos, err := load.NewSpecInfoFromGlob(MockLoader{}, "../data/composed/base/*.yaml")
+	require.NoError(t, err)
+	for _, specInfo := range specInfos {
+		require.NotNil(t, specInfo.SourceMap)
+	}
+	require.Len(t, load.NewSourceMaps(specInfos...), len(specInfos))
+}
+
+func TestSourceMap_PointerPosition(t *testing.T) {
+	data := []byte(`openapi: 3.0.1
+paths:
+  /a/b:
+    get:
+      summary: test
+`)
+	sourceMap, err := load.NewSourceMap("spec.yaml", data, nil)
+	require.NoError(t, err)
+
+	position, ok := sourceMap.PointerPosition("/paths/~1a~1b/get/summary")
+	require.True(t, ok)
+	require.Equal(t, load.Position{Line: 4, Column: 6, LineEnd: 4, ColumnEnd: 19}, position)
+
+	// missing elements fall back to their nearest ancestor
+	position, ok = sourceMap.PointerPosition("/paths/~1a~1b/get/parameters/0")
+	require.True(t, ok)
+	require.Equal(t, 3, position.Line)
+}
+
+func TestSourceMap_Invalid(t *testing.T) {
+	_, err := load.NewSourceMap("spec.yaml", []byte("a: [b"), nil)
+	require.Error(t, err)
+}
+
+func TestEscapePointerToken(t *testing.T) {
+	require.Equal(t, "~1a~1~0b", load.EscapePointerToken("/a/~b"))
+}
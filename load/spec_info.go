@@ -11,9 +11,10 @@ import (
 
 // SpecInfo contains information about an OpenAPI spec and its metadata
 type SpecInfo struct {
-	Url     string
-	Spec    *openapi3.T
-	Version string
+	Url       string
+	Spec      *openapi3.T
+	Version   string
+	SourceMap *SourceMap
 }
 
 func (specInfo *SpecInfo) GetVersion() string {
@@ -39,7 +40,7 @@ func getVersion(spec *openapi3.T) string {
 	return spec.Info.Version
 }
 
-// NewSpecInfo creates a SpecInfo from a local file path, a URL, or stdin
+// NewSpecInfo creates a SpecInfo from a local file path, a URL, a git revision, or stdin
 func NewSpecInfo(loader Loader, source *Source, options ...Option) (*SpecInfo, error) {
 	specInfo, err := loadSpecInfo(loader, source)
 	if err != nil {
@@ -55,7 +56,38 @@ func NewSpecInfo(loader Loader, source *Source, options ...Option) (*SpecInfo, e
 	return specInfos[0], nil
 }
 
-// NewSpecInfoFromGlob creates SpecInfos from local files matching the specified glob parameter
+// NewSpecInfoFromData creates a SpecInfo from the contents of a spec, for example, a spec which was uploaded to a server
+// the name identifies the spec in the output, like the path of a file
+func NewSpecInfoFromData(loader *openapi3.Loader, name string, data []byte, options ...Option) (*SpecInfo, error) {
+	return newSpecInfoFromData(loader, name, data, nil, options...)
+}
+
+// NewSpecInfoFromFileData creates a SpecInfo from the unsaved contents of a local file, for example, a file which is being edited
+// references are resolved relative to the file, like they are when the file is loaded from disk
+func NewSpecInfoFromFileData(loader *openapi3.Loader, file string, data []byte, options ...Option) (*SpecInfo, error) {
+	return newSpecInfoFromData(loader, file, data, fileLocation(file), options...)
+}
+
+func newSpecInfoFromData(loader *openapi3.Loader, name string, data []byte, location *url.URL, options ...Option) (*SpecInfo, error) {
+	spec, err := fromData(loader, data, location)
+	if err != nil {
+		return nil, err
+	}
+	specInfo := newSpecInfo(spec, name)
+	if sourceMap, err := NewSourceMap(name, data, spec); err == nil {
+		specInfo.SourceMap = sourceMap
+	}
+	specInfos := []*SpecInfo{specInfo}
+
+	for _, option := range options {
+		if specInfos, err = option(loader, specInfos); err != nil {
+			return nil, err
+		}
+	}
+	return specInfos[0], nil
+}
+
+// NewSpecInfoFromGlob creates SpecInfos from local files, or files at a git revision, matching the specified glob parameter
 func NewSpecInfoFromGlob(loader Loader, glob string, options ...Option) ([]*SpecInfo, error) {
 	specInfos, err := fromGlob(loader, glob)
 	if err != nil {
@@ -71,25 +103,38 @@ func NewSpecInfoFromGlob(loader Loader, glob string, options ...Option) ([]*Spec
 }
 
 func loadSpecInfo(loader Loader, source *Source) (*SpecInfo, error) {
+	if source.Type == SourceTypeGit {
+		rev, path, _ := parseGitSource(source.Path)
+		return fromGit(loader, rev, path)
+	}
+
 	s, err := from(loader, source)
 	if err != nil {
 		return nil, err
 	}
-	return newSpecInfo(s, source.Path), nil
+	specInfo := newSpecInfo(s, source.Path)
+	if source.IsFile() {
+		specInfo.SourceMap = newFileSourceMap(source.Path, s)
+	}
+	return specInfo, nil
 }
 
 func fromGlob(loader Loader, glob string) ([]*SpecInfo, error) {
+	if rev, path, ok := parseGitSource(glob); ok {
+		return fromGitGlob(loader, rev, path)
+	}
+
 	files, err := filepathx.Glob(glob)
 	if err != nil {
 		return nil, err
 	}
 	result := make([]*SpecInfo, 0)
 	for _, file := range files {
-		spec, err := loader.LoadFromFile(file)
+		spec, err := fromFile(loader, file)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load %q: %w", file, err)
 		}
-		result = append(result, &SpecInfo{Url: file, Spec: spec})
+		result = append(result, &SpecInfo{Url: file, Spec: spec, SourceMap: newFileSourceMap(file, spec)})
 	}
 
 	if len(result) > 0 {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/stretchr/testify/require"
 	"github.com/tufin/oasdiff/load"
 )
@@ -114,3 +115,46 @@ func TestSpecInfo_GlobOptions(t *testing.T) {
 	_, err := load.NewSpecInfoFromGlob(MockLoader{}, "../data/*.yaml", load.WithIdentity(), load.WithFlattenAllOf(), load.WithFlattenParams())
 	require.NoError(t, err)
 }
+
+func TestSpecInfo_Data(t *testing.T) {
+	data, err := os.ReadFile("../data/openapi-test1.yaml")
+	require.NoError(t, err)
+
+	specInfo, err := load.NewSpecInfoFromData(openapi3.NewLoader(), "base", data)
+	require.NoError(t, err)
+	require.Equal(t, "base", specInfo.Url)
+	require.NotNil(t, specInfo.SourceMap)
+}
+
+func TestSpecInfo_DataExternalRef(t *testing.T) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = false
+
+	_, err := load.NewSpecInfoFromData(loader, "base", []byte(`openapi: 3.0.1
+info:
+  title: Test API
+  version: v1
+paths:
+  /test:
+    $ref: "../data/openapi-test1.yaml#/paths/~1api~1{domain}~1{project}~1badges~1security-score"
+`))
+	require.Error(t, err)
+}
+
+func TestSpecInfo_FileData(t *testing.T) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+
+	specInfo, err := load.NewSpecInfoFromFileData(loader, "../data/unsaved.yaml", []byte(`openapi: 3.0.1
+info:
+  title: Test API
+  version: v1
+paths:
+  /test:
+    $ref: "openapi-test1.yaml#/paths/~1api~1{domain}~1{project}~1badges~1security-score"
+`))
+	require.NoError(t, err)
+	require.Equal(t, "../data/unsaved.yaml", specInfo.Url)
+	require.NotNil(t, specInfo.Spec.Paths.Value("/test").Get)
+	require.Equal(t, "../data/unsaved.yaml", specInfo.SourceMap.File)
+}
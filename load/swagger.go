@@ -0,0 +1,147 @@
+package load
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/yaml"
+)
+
+// Swagger 2.0 specs are converted to OpenAPI 3.0 while loading, so that they can be compared with other specs, for example, during a migration to OpenAPI 3:
+// - host, basePath and schemes are mapped to servers
+// - body and formData parameters are mapped to request bodies
+// - definitions, parameters, responses and securityDefinitions are mapped to components
+// - produces and consumes are mapped to the media types of the content
+//
+// Only the root document is converted, the files of its external refs are read as is.
+
+// swagger2Version is the value of the swagger field of Swagger 2.0 specs
+const swagger2Version = "2.0"
+
+// isSwagger2 indicates whether the data is a Swagger 2.0 spec
+func isSwagger2(data []byte) bool {
+	if !bytes.Contains(data, []byte("swagger")) {
+		return false
+	}
+
+	var header struct {
+		Swagger string `json:"swagger"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		// leave the data as is and let the loader report the error
+		return false
+	}
+	return header.Swagger == swagger2Version
+}
+
+// fromSwagger2 converts a Swagger 2.0 spec to OpenAPI 3.0 and resolves its refs relative to the location, if there is one
+func fromSwagger2(loader *openapi3.Loader, data []byte, location *url.URL) (*openapi3.T, error) {
+	var doc2 openapi2.T
+	if err := yaml.Unmarshal(data, &doc2); err != nil {
+		return nil, fmt.Errorf("failed to load Swagger 2.0 spec: %w", err)
+	}
+
+	spec, err := openapi2conv.ToV3WithLoader(&doc2, loader, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Swagger 2.0 spec to OpenAPI 3: %w", err)
+	}
+
+	// the converter maps basePath to servers only along with a host
+	if doc2.Host == "" && doc2.BasePath != "" {
+		spec.AddServer(&openapi3.Server{URL: doc2.BasePath})
+	}
+
+	removeConversionExtensions(spec)
+
+	return spec, nil
+}
+
+// conversionExtensions are added by the converter to convert specs back to Swagger 2.0, they would show up as changes when a converted spec is compared with an OpenAPI 3 spec
+var conversionExtensions = []string{"x-originalParamName", "x-formData-name"}
+
+// removeConversionExtensions removes the extensions that the converter adds to request bodies and to the properties of form data
+func removeConversionExtensions(spec *openapi3.T) {
+	requestBodies := []*openapi3.RequestBodyRef{}
+	if spec.Components != nil {
+		for _, requestBody := range spec.Components.RequestBodies {
+			requestBodies = append(requestBodies, requestBody)
+		}
+	}
+	for _, pathItem := range spec.Paths.Map() {
+		for _, operation := range pathItem.Operations() {
+			requestBodies = append(requestBodies, operation.RequestBody)
+		}
+	}
+
+	for _, requestBody := range requestBodies {
+		if requestBody == nil || requestBody.Value == nil {
+			continue
+		}
+		deleteExtensions(requestBody.Value.Extensions)
+		for _, mediaType := range requestBody.Value.Content {
+			if mediaType.Schema == nil || mediaType.Schema.Value == nil {
+				continue
+			}
+			for _, property := range mediaType.Schema.Value.Properties {
+				if property.Value != nil {
+					deleteExtensions(property.Value.Extensions)
+				}
+			}
+		}
+	}
+}
+
+func deleteExtensions(extensions map[string]any) {
+	for _, extension := range conversionExtensions {
+		delete(extensions, extension)
+	}
+}
+
+// loadFromData loads a spec from its contents, converting it from Swagger 2.0 if needed
+// relative references are resolved against the location, if there is one
+// the spec counts towards the limits of the ref policy of the loader, if there is one
+func loadFromData(loader *openapi3.Loader, data []byte, location *url.URL) (*openapi3.T, error) {
+	addRefPolicyRoot(loader, location)
+	if err := countRefPolicyRoot(loader, location, len(data)); err != nil {
+		return nil, err
+	}
+
+	if isSwagger2(data) {
+		return fromSwagger2(loader, data, location)
+	}
+
+	if location != nil {
+		return loader.LoadFromDataWithPath(data, location)
+	}
+	return loader.LoadFromData(data)
+}
+
+// loadFromLocation loads a spec from a local file or a URL, converting it from Swagger 2.0 if needed, when the loader is a kin-openapi loader
+func loadFromLocation(loader Loader, location *url.URL) (*openapi3.T, error) {
+	original, ok := loader.(*openapi3.Loader)
+	if !ok {
+		if location.Scheme == "" && location.Host == "" {
+			return loader.LoadFromFile(location.Path)
+		}
+		return loader.LoadFromURI(location)
+	}
+
+	read := original.ReadFromURIFunc
+	if read == nil {
+		read = defaultReadFromURI
+	}
+
+	// the spec is allowed by the ref policy of the loader, if there is one, even if its refs wouldn't be
+	addRefPolicyRoot(original, location)
+
+	data, err := read(original, location)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadFromData(original, data, location)
+}
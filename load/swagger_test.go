@@ -0,0 +1,112 @@
+package load_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+func newSwaggerLoader() *openapi3.Loader {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	return loader
+}
+
+func requireConvertedPetStore(t *testing.T, spec *openapi3.T) {
+	t.Helper()
+
+	require.Equal(t, "3.0.3", spec.OpenAPI)
+	require.Equal(t, "/v1", spec.Servers[0].URL)
+
+	// body parameters are converted to request bodies with the media types of consumes
+	createPet := spec.Paths.Value("/pets").Post
+	require.Empty(t, createPet.Parameters)
+	require.True(t, createPet.RequestBody.Value.Required)
+	require.Equal(t, "#/components/schemas/Pet", createPet.RequestBody.Value.Content["application/json"].Schema.Ref)
+	require.NotContains(t, createPet.RequestBody.Value.Extensions, "x-originalParamName")
+
+	// formData parameters are converted to the properties of a form
+	uploadPhoto := spec.Paths.Value("/pets/{id}/photo").Post
+	require.Len(t, uploadPhoto.Parameters, 1)
+	file := uploadPhoto.RequestBody.Value.Content["multipart/form-data"].Schema.Value.Properties["file"]
+	require.Equal(t, "binary", file.Value.Format)
+	require.NotContains(t, file.Value.Extensions, "x-formData-name")
+
+	// responses get the media types of produces
+	require.NotNil(t, spec.Paths.Value("/pets").Get.Responses.Value("200").Value.Content["application/json"])
+
+	// definitions are converted to components, and their external refs are resolved
+	pet := spec.Components.Schemas["Pet"]
+	require.True(t, pet.Value.Properties["tag"].Value.Type.Is("string"))
+}
+
+func TestSwagger2_File(t *testing.T) {
+	specInfo, err := load.NewSpecInfo(newSwaggerLoader(), load.NewSource("../data/swagger2/petstore-v2.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "v1", specInfo.Version)
+	requireConvertedPetStore(t, specInfo.Spec)
+}
+
+func TestSwagger2_Glob(t *testing.T) {
+	specInfos, err := load.NewSpecInfoFromGlob(newSwaggerLoader(), "../data/swagger2/petstore-v2*.yaml")
+	require.NoError(t, err)
+	require.Len(t, specInfos, 1)
+	requireConvertedPetStore(t, specInfos[0].Spec)
+}
+
+func TestSwagger2_URL(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("../data/swagger2")))
+	defer server.Close()
+
+	specInfo, err := load.NewSpecInfo(newSwaggerLoader(), load.NewSource(server.URL+"/petstore-v2.yaml"))
+	require.NoError(t, err)
+	requireConvertedPetStore(t, specInfo.Spec)
+}
+
+func TestSwagger2_Stdin(t *testing.T) {
+	file, err := os.Open("../data/swagger2/petstore-v2.yaml")
+	require.NoError(t, err)
+	defer file.Close()
+
+	oldStdin := os.Stdin
+	defer func() { os.Stdin = oldStdin }() // Restore original Stdin
+
+	// refs are resolved relative to the working directory
+	t.Chdir("../data/swagger2")
+
+	os.Stdin = file
+	specInfo, err := load.NewSpecInfo(newSwaggerLoader(), load.NewSource("-"))
+	require.NoError(t, err)
+	requireConvertedPetStore(t, specInfo.Spec)
+}
+
+func TestSwagger2_Data(t *testing.T) {
+	specInfo, err := load.NewSpecInfoFromData(openapi3.NewLoader(), "base", []byte(`swagger: "2.0"
+info:
+  title: Test API
+  version: v1
+host: example.com
+basePath: /api
+schemes:
+  - https
+paths: {}
+`))
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/api", specInfo.Spec.Servers[0].URL)
+}
+
+func TestSwagger2_Invalid(t *testing.T) {
+	_, err := load.NewSpecInfoFromData(openapi3.NewLoader(), "base", []byte(`swagger: "2.0"
+info:
+  title: Test API
+  version: v1
+host: example.com/api
+paths: {}
+`))
+	require.EqualError(t, err, `failed to convert Swagger 2.0 spec to OpenAPI 3: invalid host "example.com/api". This MUST be the host only and does not include the scheme nor sub-paths.`)
+}
@@ -0,0 +1,103 @@
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// WebhooksField is the top-level field of OpenAPI 3.1 specs which describes the requests that the API sends to its subscribers
+// kin-openapi doesn't model webhooks, so they are kept among the extensions of the spec
+const WebhooksField = "webhooks"
+
+// GetWebhooks returns the webhooks of an OpenAPI 3.1 spec as a map of webhook names to path items, or nil if there are none
+// Specs loaded by this package, or passed to ResolveWebhooks, have their webhooks resolved already and the same webhooks are returned each time.
+// Otherwise, the webhooks are parsed without modifying the spec, and only local references (#/components/...) are resolved, other references are an error.
+func GetWebhooks(spec *openapi3.T) (*openapi3.Paths, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	return parseWebhooks(newLocalLoader(), spec, nil)
+}
+
+// ResolveWebhooks parses the webhooks of a spec, resolves their references and stores them in the spec, so that they can be modified
+// Only local references (#/components/...) can be resolved because the location of the spec is unknown, other references are an error.
+func ResolveWebhooks(spec *openapi3.T) error {
+	if spec == nil {
+		return nil
+	}
+
+	return resolveWebhooks(newLocalLoader(), spec, nil)
+}
+
+// resolveWebhooks parses the webhooks of a spec, resolves their references and stores them back in the spec extensions
+func resolveWebhooks(loader *openapi3.Loader, spec *openapi3.T, location *url.URL) error {
+	webhooks, err := parseWebhooks(loader, spec, location)
+	if err != nil || webhooks == nil {
+		return err
+	}
+
+	spec.Extensions[WebhooksField] = webhooks
+	return nil
+}
+
+// parseWebhooks returns the webhooks of a spec with their references resolved, or the webhooks which were resolved already
+func parseWebhooks(loader *openapi3.Loader, spec *openapi3.T, location *url.URL) (*openapi3.Paths, error) {
+	raw, ok := spec.Extensions[WebhooksField]
+	if !ok {
+		return nil, nil
+	}
+	if webhooks, ok := raw.(*openapi3.Paths); ok {
+		return webhooks, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhooks: %w", err)
+	}
+
+	var pathItems map[string]*openapi3.PathItem
+	if err := json.Unmarshal(data, &pathItems); err != nil {
+		return nil, fmt.Errorf("failed to read webhooks: %w", err)
+	}
+
+	webhooks := openapi3.NewPathsWithCapacity(len(pathItems))
+	for name, pathItem := range pathItems {
+		if pathItem != nil {
+			webhooks.Set(name, pathItem)
+		}
+	}
+
+	// webhooks are resolved as if they were the paths of a spec that shares the original components
+	// local references are resolved to the same objects as in the original spec
+	if err := loader.ResolveRefsIn(&openapi3.T{Components: spec.Components, Paths: webhooks}, location); err != nil {
+		return nil, fmt.Errorf("failed to resolve webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// webhooksLoader returns the loader that resolves the references in webhooks and schema keywords, preferring the loader which loaded the spec
+// other loaders can't be trusted with the settings of the original loader, like the ref policy and the limits, so only local references are resolved
+func webhooksLoader(loader Loader) *openapi3.Loader {
+	if original, ok := loader.(*openapi3.Loader); ok {
+		return original
+	}
+	return newLocalLoader()
+}
+
+// newLocalLoader returns a loader which resolves local references (#/components/...) and fails on external references
+func newLocalLoader() *openapi3.Loader {
+	result := openapi3.NewLoader()
+	result.IsExternalRefsAllowed = false
+	return result
+}
+
+// fileLocation returns the location of a local file, used to resolve relative references
+func fileLocation(file string) *url.URL {
+	return &url.URL{Path: filepath.ToSlash(file)}
+}
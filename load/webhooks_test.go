@@ -0,0 +1,81 @@
+package load_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/load"
+)
+
+func TestGetWebhooks(t *testing.T) {
+	specInfo, err := load.NewSpecInfo(MockLoader{}, load.NewSource("../data/webhooks/base.yaml"))
+	require.NoError(t, err)
+
+	webhooks, err := load.GetWebhooks(specInfo.Spec)
+	require.NoError(t, err)
+	require.NotNil(t, webhooks)
+	require.Equal(t, 2, webhooks.Len())
+
+	// references are resolved to the components of the spec
+	schema := webhooks.Value("newPet").Post.RequestBody.Value.Content["application/json"].Schema
+	require.Same(t, specInfo.Spec.Components.Schemas["Pet"].Value, schema.Value)
+
+	// the webhooks were resolved when the spec was loaded
+	again, err := load.GetWebhooks(specInfo.Spec)
+	require.NoError(t, err)
+	require.Same(t, webhooks, again)
+}
+
+func TestGetWebhooks_None(t *testing.T) {
+	webhooks, err := load.GetWebhooks(nil)
+	require.NoError(t, err)
+	require.Nil(t, webhooks)
+
+	webhooks, err = load.GetWebhooks(&openapi3.T{})
+	require.NoError(t, err)
+	require.Nil(t, webhooks)
+}
+
+func TestGetWebhooks_Unresolved(t *testing.T) {
+	spec := &openapi3.T{
+		Extensions: map[string]any{
+			load.WebhooksField: map[string]any{
+				"newPet": map[string]any{"post": map[string]any{"responses": map[string]any{"200": map[string]any{"description": "ok"}}}},
+			},
+		},
+	}
+
+	webhooks, err := load.GetWebhooks(spec)
+	require.NoError(t, err)
+	require.Equal(t, 1, webhooks.Len())
+
+	// the spec isn't modified
+	_, ok := spec.Extensions[load.WebhooksField].(*openapi3.Paths)
+	require.False(t, ok)
+}
+
+func TestGetWebhooks_InvalidRef(t *testing.T) {
+	spec := &openapi3.T{
+		Extensions: map[string]any{
+			load.WebhooksField: map[string]any{
+				"newPet": map[string]any{"$ref": "#/components/pathItems/missing"},
+			},
+		},
+	}
+
+	_, err := load.GetWebhooks(spec)
+	require.ErrorContains(t, err, "failed to resolve webhooks")
+}
+
+func TestSourceMap_Webhooks(t *testing.T) {
+	specInfo, err := load.NewSpecInfo(MockLoader{}, load.NewSource("../data/webhooks/base.yaml"))
+	require.NoError(t, err)
+
+	webhooks, err := load.GetWebhooks(specInfo.Spec)
+	require.NoError(t, err)
+	operation := webhooks.Value("petRemoved").Post
+	pointer, ok := specInfo.SourceMap.ElementPointer(operation)
+	require.True(t, ok)
+	require.Equal(t, "/webhooks/petRemoved/post", pointer)
+}
@@ -0,0 +1,268 @@
+package lsp
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+	"gopkg.in/yaml.v3"
+)
+
+// codeActions returns the quick fixes of the diagnostics of a document: ignoring the change, or deprecating the operation which it belongs to
+func (server *Server) codeActions(doc *document, diagnostics []diagnostic) []codeAction {
+	result := []codeAction{}
+	if doc.analysis == nil {
+		return result
+	}
+
+	for _, diag := range diagnostics {
+		if diag.Data == nil {
+			continue
+		}
+		change := doc.analysis.findChange(diag.Data.Fingerprint)
+		if change == nil {
+			continue
+		}
+
+		if action, ok := server.ignoreAction(change, diag); ok {
+			result = append(result, action)
+		}
+		if action, ok := server.deprecateAction(doc, change, diag); ok {
+			result = append(result, action)
+		}
+	}
+
+	return result
+}
+
+func (analysis *analysis) findChange(fingerprint string) checker.Change {
+	for _, change := range analysis.changes {
+		if checker.GetFingerprint(change) == fingerprint {
+			return change
+		}
+	}
+	return nil
+}
+
+func (server *Server) getIgnoreFile(level checker.Level) string {
+	switch level {
+	case checker.ERR:
+		return server.config.ErrIgnoreFile
+	case checker.WARN:
+		return server.config.WarnIgnoreFile
+	}
+	return ""
+}
+
+// ignoreAction appends the change to the ignore file of its level, if there is one
+// the ignore file is created if it doesn't exist yet
+func (server *Server) ignoreAction(change checker.Change, diag diagnostic) (codeAction, bool) {
+	ignoreFile := server.getIgnoreFile(change.GetLevel())
+	if ignoreFile == "" {
+		return codeAction{}, false
+	}
+
+	content, err := os.ReadFile(ignoreFile)
+	exists := err == nil
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return codeAction{}, false
+	}
+
+	var text string
+	if checker.IsStructuredIgnoreFile(ignoreFile) {
+		entry, err := getIgnoreEntry(change)
+		if err != nil {
+			return codeAction{}, false
+		}
+		if strings.TrimSpace(string(content)) == "" {
+			text = "ignore:\n"
+		}
+		text += entry
+	} else {
+		text = getIgnoreLine(change, server.localizer) + "\n"
+	}
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		text = "\n" + text
+	}
+
+	uri := pathToURI(ignoreFile)
+	documentChanges := []any{}
+	if !exists {
+		documentChanges = append(documentChanges, createFile{Kind: "create", URI: uri, Options: createFileOptions{IgnoreIfExists: true}})
+	}
+	end := getEndPosition(string(content))
+	documentChanges = append(documentChanges, textDocumentEdit{
+		TextDocument: versionedTextDocumentIdentifier{URI: uri},
+		Edits:        []textEdit{{Range: textRange{Start: end, End: end}, NewText: text}},
+	})
+
+	return codeAction{
+		Title:       fmt.Sprintf("Ignore %s in %s", change.GetId(), ignoreFile),
+		Kind:        codeActionQuickFix,
+		Diagnostics: []diagnostic{diag},
+		Edit:        &workspaceEdit{DocumentChanges: documentChanges},
+	}, true
+}
+
+// ignoreEntry is an entry of a structured ignore file, see checker.IgnoreEntry
+type ignoreEntry struct {
+	Id     string   `yaml:"id"`
+	Method string   `yaml:"method,omitempty"`
+	Path   string   `yaml:"path,omitempty"`
+	Args   []string `yaml:"args,omitempty,flow"`
+	Reason string   `yaml:"reason"`
+	Owner  string   `yaml:"owner"`
+}
+
+// getIgnoreEntry returns an entry of a structured ignore file which matches the change
+// the reason and the owner are left for the author to fill in
+func getIgnoreEntry(change checker.Change) (string, error) {
+	entry := ignoreEntry{
+		Id:     change.GetId(),
+		Method: change.GetOperation(),
+		Path:   change.GetPath(),
+		Reason: "TODO",
+		Owner:  "TODO",
+	}
+	// only string arguments are listed, since other arguments are matched by their formatted value
+	for _, arg := range change.GetArgs() {
+		if s, ok := arg.(string); ok && s != "" {
+			entry.Args = append(entry.Args, s)
+		}
+	}
+
+	data, err := yaml.Marshal([]ignoreEntry{entry})
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.SplitAfter(strings.TrimSuffix(string(data), "\n"), "\n")
+	return "  " + strings.Join(lines, "  ") + "\n", nil
+}
+
+// getIgnoreLine returns a line of a textual ignore file which matches the change
+func getIgnoreLine(change checker.Change, l checker.Localizer) string {
+	text := change.GetUncolorizedText(l)
+	switch c := change.(type) {
+	case checker.ApiChange:
+		return c.Operation + " " + c.Path + " " + text
+	case checker.WebhookChange:
+		return "webhook " + c.Operation + " " + c.Webhook + " " + text
+	case checker.ComponentChange:
+		return "components " + text
+	case checker.SecurityChange:
+		return "security " + text
+	}
+	return text
+}
+
+// getEndPosition returns the position after the last character of a text
+func getEndPosition(text string) position {
+	i := strings.LastIndex(text, "\n")
+	return position{
+		Line:      strings.Count(text, "\n"),
+		Character: len(utf16.Encode([]rune(text[i+1:]))),
+	}
+}
+
+// deprecateAction marks the operation of a change as deprecated with a sunset date, so that it can be removed later without breaking clients
+// only operations in YAML block style are supported, where the new fields can be inserted as lines after the operation key
+func (server *Server) deprecateAction(doc *document, change checker.Change, diag diagnostic) (codeAction, bool) {
+	apiChange, ok := change.(checker.ApiChange)
+	if !ok || doc.analysis.revision == nil {
+		return codeAction{}, false
+	}
+
+	operation := getOperation(doc.analysis.revision.Spec, apiChange.Path, apiChange.Operation)
+	if operation == nil {
+		return codeAction{}, false
+	}
+	_, hasSunset := operation.Extensions[diff.SunsetExtension]
+	if operation.Deprecated && hasSunset {
+		return codeAction{}, false
+	}
+
+	sourceMaps := load.NewSourceMaps(doc.analysis.revision)
+	pointer := getOperationPointer(apiChange.Path, apiChange.Operation)
+	_, position, ok := sourceMaps.LocatePointer(pointer)
+	if !ok {
+		return codeAction{}, false
+	}
+	if _, _, ok := sourceMaps.LocatePointer(pointer + "/deprecated"); ok && !operation.Deprecated {
+		// deprecated: false, which would have to be edited rather than inserted
+		return codeAction{}, false
+	}
+
+	indent, ok := doc.getChildIndent(position.Line)
+	if !ok {
+		return codeAction{}, false
+	}
+
+	days := server.config.DeprecationDaysStable
+	if stability, ok := operation.Extensions[diff.XStabilityLevelExtension].(string); ok && stability == checker.STABILITY_BETA {
+		days = server.config.DeprecationDaysBeta
+	}
+	sunset := server.now().AddDate(0, 0, int(days)).Format("2006-01-02")
+
+	text := ""
+	if !operation.Deprecated {
+		text += indent + "deprecated: true\n"
+	}
+	if !hasSunset {
+		text += indent + diff.SunsetExtension + ": \"" + sunset + "\"\n"
+	}
+
+	start := doc.position(position.Line+1, 0)
+	version := doc.version
+	return codeAction{
+		Title:       fmt.Sprintf("Deprecate %s %s with sunset date %s", apiChange.Operation, apiChange.Path, sunset),
+		Kind:        codeActionQuickFix,
+		Diagnostics: []diagnostic{diag},
+		Edit: &workspaceEdit{DocumentChanges: []any{textDocumentEdit{
+			TextDocument: versionedTextDocumentIdentifier{URI: doc.uri, Version: &version},
+			Edits:        []textEdit{{Range: textRange{Start: start, End: start}, NewText: text}},
+		}}},
+	}, true
+}
+
+func getOperation(spec *openapi3.T, path, method string) *openapi3.Operation {
+	if spec == nil || spec.Paths == nil {
+		return nil
+	}
+	pathItem := spec.Paths.Value(path)
+	if pathItem == nil {
+		return nil
+	}
+	return pathItem.GetOperation(method)
+}
+
+// getChildIndent returns the indentation of the fields of a YAML mapping in block style, given the line of its key
+func (doc *document) getChildIndent(keyLine int) (string, bool) {
+	key, ok := doc.line(keyLine)
+	if !ok || !strings.HasSuffix(strings.TrimSpace(key), ":") {
+		return "", false
+	}
+
+	for line := keyLine + 1; ; line++ {
+		text, ok := doc.line(line)
+		if !ok {
+			return "", false
+		}
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := text[:len(text)-len(strings.TrimLeft(text, " "))]
+		if len(indent) <= len(key)-len(strings.TrimLeft(key, " ")) {
+			return "", false
+		}
+		return indent, true
+	}
+}
@@ -0,0 +1,248 @@
+package lsp
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/lint"
+	"github.com/tufin/oasdiff/load"
+)
+
+// analysis is the result of comparing a document with its baseline
+type analysis struct {
+	revision    *load.SpecInfo  // the spec of the document, nil if it can't be loaded
+	changes     checker.Changes // the changes which are published as diagnostics, code actions refer to them by fingerprint
+	diagnostics []diagnostic
+}
+
+const (
+	diagnosticSource     = "oasdiff"
+	lintDiagnosticSource = "oasdiff lint"
+)
+
+func (server *Server) analyze(doc *document) *analysis {
+	result := &analysis{
+		diagnostics: []diagnostic{},
+	}
+
+	analysisLimits := server.config.Limits.Copy()
+	analysisLimits.Start()
+
+	revision, err := load.NewSpecInfoFromFileData(server.newLoader(analysisLimits), doc.path, []byte(doc.text))
+	if err != nil {
+		result.diagnostics = append(result.diagnostics, doc.newDiagnostic(getErrorLine(err), 0, 0, severityError, "", diagnosticSource, fmt.Sprintf("failed to load spec: %v", err)))
+		return result
+	}
+	result.revision = revision
+
+	if changes, err := server.getChanges(doc, revision, analysisLimits); err != nil {
+		result.diagnostics = append(result.diagnostics, doc.newDiagnostic(0, 0, 0, severityWarning, "", diagnosticSource, err.Error()))
+	} else {
+		result.changes = changes
+		for _, change := range changes {
+			result.diagnostics = append(result.diagnostics, doc.changeDiagnostic(revision.SourceMap, change, server.localizer))
+		}
+	}
+
+	if server.config.Lint {
+		for _, err := range lint.Run(lint.DefaultConfig(), doc.path, revision) {
+			severity := severityWarning
+			if err.Level == lint.LEVEL_ERROR {
+				severity = severityError
+			}
+			result.diagnostics = append(result.diagnostics, doc.newDiagnostic(err.SourceLine, err.SourceColumn, 0, severity, err.Id, lintDiagnosticSource, err.Text))
+		}
+	}
+
+	return result
+}
+
+// newLoader returns a loader which enforces the limits of an analysis
+// documents are read again in each analysis, so that changes to the baseline and to referenced files are noticed
+func (server *Server) newLoader(analysisLimits *limits.Limits) *openapi3.Loader {
+	if server.config.NewLoader != nil {
+		return server.config.NewLoader(analysisLimits)
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	load.WithoutCache(loader)
+	load.WithLimits(loader, analysisLimits)
+	return loader
+}
+
+// getBaseSource returns the source of the baseline of a document
+func (server *Server) getBaseSource(doc *document) string {
+	if server.config.BaseRev != "" {
+		return "git:" + server.config.BaseRev + ":" + doc.path
+	}
+	return server.config.Base
+}
+
+// getChanges compares the baseline with the document, and filters the changes with the ignore files
+func (server *Server) getChanges(doc *document, revision *load.SpecInfo, analysisLimits *limits.Limits) (checker.Changes, error) {
+	baseSource := server.getBaseSource(doc)
+	base, err := load.NewSpecInfo(server.newLoader(analysisLimits), load.NewSource(baseSource))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base spec from %s: %v", baseSource, err)
+	}
+	// changes are located in the document only, since the baseline may be an older version of the same file
+	base.SourceMap = nil
+
+	diffReport, operationsSources, err := diff.GetWithOperationsSourcesMap(diff.NewConfig().WithLimits(analysisLimits), base, revision)
+	if err != nil {
+		return nil, fmt.Errorf("diff failed: %v", err)
+	}
+
+	config := checker.NewConfig(checker.GetAllChecks()).
+		WithOptionalChecks(server.config.IncludeChecks).
+		WithDeprecation(server.config.DeprecationDaysBeta, server.config.DeprecationDaysStable).
+		WithSourceMaps(nil, load.NewSourceMaps(revision))
+
+	changes := checker.CheckBackwardCompatibilityUntilLevel(config, diffReport, operationsSources, server.config.Level)
+
+	if changes, err = server.ignore(checker.ERR, changes, server.config.ErrIgnoreFile); err != nil {
+		return nil, fmt.Errorf("failed to apply error ignore file %s: %v", server.config.ErrIgnoreFile, err)
+	}
+	if changes, err = server.ignore(checker.WARN, changes, server.config.WarnIgnoreFile); err != nil {
+		return nil, fmt.Errorf("failed to apply warning ignore file %s: %v", server.config.WarnIgnoreFile, err)
+	}
+
+	return changes, nil
+}
+
+// ignore removes the changes of the given level which are listed in the ignore file
+// unlike the changelog command, a missing ignore file is treated as empty, since the ignore code action creates it
+// and expired or stale entries of structured ignore files are not reported, since the document is still being edited
+func (server *Server) ignore(level checker.Level, changes checker.Changes, ignoreFile string) (checker.Changes, error) {
+	if ignoreFile == "" {
+		return changes, nil
+	}
+	if _, err := os.Stat(ignoreFile); errors.Is(err, fs.ErrNotExist) {
+		return changes, nil
+	}
+
+	if !checker.IsStructuredIgnoreFile(ignoreFile) {
+		return checker.ProcessIgnoredBackwardCompatibilityErrors(level, changes, ignoreFile, server.localizer)
+	}
+
+	entries, err := checker.LoadIgnoreEntries(ignoreFile)
+	if err != nil {
+		return nil, err
+	}
+	result, _ := entries.Apply(level, changes, server.now())
+	return result, nil
+}
+
+var errorLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+// getErrorLine returns the zero-based line of a YAML or JSON syntax error, or zero if the error has no line
+func getErrorLine(err error) int {
+	match := errorLineRegexp.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0
+	}
+	line, err := strconv.Atoi(match[1])
+	if err != nil || line < 1 {
+		return 0
+	}
+	return line - 1
+}
+
+// changeDiagnostic creates a diagnostic at the source position of a change
+// changes of deleted elements, which are only located in the baseline, are published at the nearest enclosing element of the document, like the operation or the path
+func (doc *document) changeDiagnostic(sourceMap *load.SourceMap, change checker.Change, l checker.Localizer) diagnostic {
+	line, column, columnEnd := change.GetSourceLine(), change.GetSourceColumn(), change.GetSourceColumnEnd()
+	if sourceMap == nil || change.GetSourceFile() != sourceMap.File {
+		position, _ := sourceMap.PointerPosition(getChangePointer(change))
+		line, column, columnEnd = position.Line, position.Column, position.ColumnEnd
+	}
+
+	message := change.GetUncolorizedText(l)
+	if comment := change.GetComment(l); comment != "" {
+		message += "\n" + comment
+	}
+
+	result := doc.newDiagnostic(line, column, columnEnd, getSeverity(change.GetLevel()), change.GetId(), diagnosticSource, message)
+	result.Data = &diagnosticData{Fingerprint: checker.GetFingerprint(change)}
+	return result
+}
+
+// getChangePointer returns a JSON pointer to the element of the spec which a change belongs to
+func getChangePointer(change checker.Change) string {
+	switch c := change.(type) {
+	case checker.ApiChange:
+		return getOperationPointer(c.Path, c.Operation)
+	case checker.WebhookChange:
+		return "/webhooks/" + load.EscapePointerToken(c.Webhook) + "/" + strings.ToLower(c.Operation)
+	case checker.ComponentChange:
+		return "/components/" + c.Component
+	case checker.SecurityChange:
+		return "/security"
+	}
+	return ""
+}
+
+func getOperationPointer(path, method string) string {
+	return "/paths/" + load.EscapePointerToken(path) + "/" + strings.ToLower(method)
+}
+
+func getSeverity(level checker.Level) int {
+	switch level {
+	case checker.ERR:
+		return severityError
+	case checker.WARN:
+		return severityWarning
+	}
+	return severityInformation
+}
+
+// newDiagnostic creates a diagnostic which starts at the given zero-based line and column
+// the diagnostic ends at the given column, or at the end of the line if the column is zero
+func (doc *document) newDiagnostic(line, column, columnEnd int, severity int, code, source, message string) diagnostic {
+	start := doc.position(line, column)
+	end := doc.lineEnd(line)
+	if columnEnd > column {
+		end = doc.position(line, columnEnd)
+	}
+
+	return diagnostic{
+		Range:    textRange{Start: start, End: end},
+		Severity: severity,
+		Code:     code,
+		Source:   source,
+		Message:  message,
+	}
+}
+
+// line returns a line of the document, without the line break
+func (doc *document) line(line int) (string, bool) {
+	lines := strings.Split(doc.text, "\n")
+	if line < 0 || line >= len(lines) {
+		return "", false
+	}
+	return strings.TrimSuffix(lines[line], "\r"), true
+}
+
+// position converts a column in characters, like YAML columns, to a position in UTF-16 code units, like LSP positions
+func (doc *document) position(line, column int) position {
+	text, _ := doc.line(line)
+	runes := []rune(text)
+	column = min(max(column, 0), len(runes))
+	return position{Line: line, Character: len(utf16.Encode(runes[:column]))}
+}
+
+func (doc *document) lineEnd(line int) position {
+	text, _ := doc.line(line)
+	return position{Line: line, Character: len(utf16.Encode([]rune(text)))}
+}
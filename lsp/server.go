@@ -0,0 +1,247 @@
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/build"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/limits"
+)
+
+// ErrExitWithoutShutdown is returned by Run when the client sends an exit notification before a shutdown request
+var ErrExitWithoutShutdown = errors.New("exit without shutdown")
+
+// Config holds the baseline and the checker settings of the server
+type Config struct {
+	Base                  string // the source of the baseline spec of all documents: a file, a URL, or a git revision like git:main:openapi.yaml
+	BaseRev               string // a git revision, each document is compared with its own version at this revision
+	Level                 checker.Level
+	Lang                  string
+	ErrIgnoreFile         string
+	WarnIgnoreFile        string
+	IncludeChecks         []string
+	DeprecationDaysBeta   uint
+	DeprecationDaysStable uint
+	Lint                  bool // publish lint errors, along with the changes
+	// Limits are the limits of each analysis, the timeout is measured from the start of the analysis
+	Limits *limits.Limits
+	// NewLoader creates the loaders of the documents and the baselines, for example, with a ref policy, and enforces the limits of the analysis
+	// if nil, specs are loaded with external refs allowed and without restrictions other than the limits
+	// the loaders mustn't cache documents between analyses, see load.WithoutCache
+	NewLoader func(analysisLimits *limits.Limits) *openapi3.Loader
+}
+
+// Server is a language server which compares the open specs with their baseline, and publishes the changes as diagnostics
+// messages are handled one by one, so the state of the server isn't shared between goroutines
+type Server struct {
+	config      *Config
+	localizer   checker.Localizer
+	documents   map[string]*document
+	conn        *conn
+	initialized bool
+	shutdown    bool
+	now         func() time.Time
+}
+
+// document is an open spec and the result of its last analysis
+type document struct {
+	uri      string
+	path     string
+	version  int
+	text     string
+	analysis *analysis
+}
+
+// New creates a language server with the given configuration
+func New(config *Config) *Server {
+	return &Server{
+		config:    config,
+		localizer: checker.NewLocalizer(config.Lang),
+		documents: map[string]*document{},
+		now:       time.Now,
+	}
+}
+
+// Run serves the client until it sends an exit notification, or closes the input
+func (server *Server) Run(r io.Reader, w io.Writer) error {
+	server.conn = newConn(r, w)
+
+	for {
+		content, err := server.conn.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			if err := server.conn.write(response{JSONRPC: "2.0", Error: &responseError{Code: codeParseError, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if req.Method == "exit" {
+			if !server.shutdown {
+				return ErrExitWithoutShutdown
+			}
+			return nil
+		}
+
+		// errors of the protocol are sent to the client, while other errors, like failures to write to the client, end the session
+		result, err := server.handle(&req)
+		var respErr *responseError
+		if err != nil && !errors.As(err, &respErr) {
+			return err
+		}
+		if req.ID == nil {
+			// notifications have no response
+			continue
+		}
+		if err := server.conn.write(response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: respErr}); err != nil {
+			return err
+		}
+	}
+}
+
+func (server *Server) handle(req *request) (any, error) {
+	if req.Method == "initialize" {
+		server.initialized = true
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync: textDocumentSyncOptions{
+					OpenClose: true,
+					Change:    textDocumentSyncFull,
+					Save:      true,
+				},
+				CodeActionProvider: codeActionOptions{
+					CodeActionKinds: []string{codeActionQuickFix},
+				},
+			},
+			ServerInfo: serverInfo{
+				Name:    "oasdiff",
+				Version: build.Version,
+			},
+		}, nil
+	}
+
+	if !server.initialized {
+		return nil, &responseError{Code: codeServerNotInitialized, Message: "server not initialized"}
+	}
+	if server.shutdown {
+		return nil, &responseError{Code: codeInvalidRequest, Message: "server is shutting down"}
+	}
+
+	switch req.Method {
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		server.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		path, err := uriToPath(params.TextDocument.URI)
+		if err != nil {
+			return nil, invalidParams(err)
+		}
+		doc := &document{
+			uri:     params.TextDocument.URI,
+			path:    path,
+			version: params.TextDocument.Version,
+			text:    params.TextDocument.Text,
+		}
+		server.documents[doc.uri] = doc
+		return nil, server.publish(doc)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		doc, ok := server.documents[params.TextDocument.URI]
+		if !ok || len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// with full sync, the last change holds the whole text of the document
+		doc.text = params.ContentChanges[len(params.ContentChanges)-1].Text
+		if params.TextDocument.Version != nil {
+			doc.version = *params.TextDocument.Version
+		}
+		return nil, server.publish(doc)
+	case "textDocument/didSave":
+		// the ignore files or the baseline may have been saved too
+		var params didSaveParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		for _, doc := range server.documents {
+			if err := server.publish(doc); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(server.documents, params.TextDocument.URI)
+		return nil, server.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []diagnostic{}})
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		doc, ok := server.documents[params.TextDocument.URI]
+		if !ok {
+			return []codeAction{}, nil
+		}
+		return server.codeActions(doc, params.Context.Diagnostics), nil
+	}
+
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+}
+
+// publish analyzes a document and sends its diagnostics to the client
+func (server *Server) publish(doc *document) error {
+	doc.analysis = server.analyze(doc)
+	return server.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: doc.uri, Diagnostics: doc.analysis.diagnostics})
+}
+
+func (server *Server) notify(method string, params any) error {
+	return server.conn.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func invalidParams(err error) error {
+	return &responseError{Code: codeInvalidParams, Message: err.Error()}
+}
+
+// uriToPath converts a file URI to a local path
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", errors.New("unsupported document URI, expected a file URI: " + uri)
+	}
+	return filepath.FromSlash(u.Path), nil
+}
+
+// pathToURI converts a local path to a file URI
+func pathToURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
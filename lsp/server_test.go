@@ -0,0 +1,426 @@
+package lsp
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/limits"
+)
+
+// testClient drives a server over in-memory pipes, like an editor does over stdio
+type testClient struct {
+	t      *testing.T
+	conn   *conn
+	done   chan error
+	nextID int
+}
+
+func newTestClient(t *testing.T, config *Config) *testClient {
+	t.Helper()
+
+	server := New(config)
+	server.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+
+	client := &testClient{
+		t:    t,
+		conn: newConn(clientIn, clientOut),
+		done: make(chan error, 1),
+	}
+	go func() {
+		client.done <- server.Run(serverIn, serverOut)
+		_ = serverOut.Close()
+	}()
+
+	client.request("initialize", map[string]any{})
+	client.notify("initialized", map[string]any{})
+	return client
+}
+
+func (client *testClient) notify(method string, params any) {
+	client.t.Helper()
+	require.NoError(client.t, client.conn.write(notification{JSONRPC: "2.0", Method: method, Params: params}))
+}
+
+// request sends a request and reads messages until its response
+func (client *testClient) request(method string, params any) response {
+	client.t.Helper()
+
+	client.nextID++
+	id := json.RawMessage(strings.TrimSpace(string(mustMarshal(client.t, client.nextID))))
+	require.NoError(client.t, client.conn.write(request{JSONRPC: "2.0", ID: &id, Method: method, Params: mustMarshal(client.t, params)}))
+
+	for {
+		content, err := client.conn.read()
+		require.NoError(client.t, err)
+		var message struct {
+			response
+			Method string `json:"method"`
+		}
+		require.NoError(client.t, json.Unmarshal(content, &message))
+		if message.Method == "" {
+			require.Equal(client.t, string(id), string(*message.ID))
+			return message.response
+		}
+	}
+}
+
+// readDiagnostics reads the next message, which must publish diagnostics
+func (client *testClient) readDiagnostics() publishDiagnosticsParams {
+	client.t.Helper()
+
+	content, err := client.conn.read()
+	require.NoError(client.t, err)
+	var message struct {
+		Method string                   `json:"method"`
+		Params publishDiagnosticsParams `json:"params"`
+	}
+	require.NoError(client.t, json.Unmarshal(content, &message))
+	require.Equal(client.t, "textDocument/publishDiagnostics", message.Method)
+	return message.Params
+}
+
+func (client *testClient) open(path, text string) publishDiagnosticsParams {
+	client.t.Helper()
+
+	client.notify("textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: pathToURI(path), LanguageID: "yaml", Version: 1, Text: text}})
+	return client.readDiagnostics()
+}
+
+func (client *testClient) codeActions(path string, diagnostics []diagnostic) []codeAction {
+	client.t.Helper()
+
+	params := codeActionParams{TextDocument: textDocumentIdentifier{URI: pathToURI(path)}}
+	params.Context.Diagnostics = diagnostics
+	result := client.request("textDocument/codeAction", params)
+	require.Nil(client.t, result.Error)
+
+	var actions []codeAction
+	require.NoError(client.t, json.Unmarshal(mustMarshal(client.t, result.Result), &actions))
+	return actions
+}
+
+func (client *testClient) close() error {
+	client.request("shutdown", nil)
+	client.notify("exit", nil)
+	return <-client.done
+}
+
+func mustMarshal(t *testing.T, value any) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	return data
+}
+
+func readFile(t *testing.T, file string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func findDiagnostic(diagnostics []diagnostic, code string) (diagnostic, bool) {
+	for _, diag := range diagnostics {
+		if diag.Code == code {
+			return diag, true
+		}
+	}
+	return diagnostic{}, false
+}
+
+func findAction(actions []codeAction, prefix string) (codeAction, bool) {
+	for _, action := range actions {
+		if strings.HasPrefix(action.Title, prefix) {
+			return action, true
+		}
+	}
+	return codeAction{}, false
+}
+
+const securityScorePath = "/api/{domain}/{project}/badges/security-score"
+
+func TestServer_Diagnostics(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN})
+
+	result := client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml"))
+	require.Equal(t, pathToURI("../data/openapi-test3.yaml"), result.URI)
+
+	diag, ok := findDiagnostic(result.Diagnostics, "response-success-status-removed")
+	require.True(t, ok)
+	require.Equal(t, severityError, diag.Severity)
+	require.Equal(t, "oasdiff", diag.Source)
+	require.Equal(t, textRange{Start: position{Line: 17, Character: 4}, End: position{Line: 17, Character: 8}}, diag.Range)
+	require.NotNil(t, diag.Data)
+
+	diag, ok = findDiagnostic(result.Diagnostics, "request-parameter-removed")
+	require.True(t, ok)
+	require.Equal(t, severityWarning, diag.Severity)
+
+	_, ok = findDiagnostic(result.Diagnostics, "api-tag-removed")
+	require.False(t, ok)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_DidChange(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN})
+
+	require.NotEmpty(t, client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml")).Diagnostics)
+
+	version := 2
+	client.notify("textDocument/didChange", map[string]any{
+		"textDocument":   versionedTextDocumentIdentifier{URI: pathToURI("../data/openapi-test3.yaml"), Version: &version},
+		"contentChanges": []map[string]string{{"text": readFile(t, "../data/openapi-test1.yaml")}},
+	})
+	require.Empty(t, client.readDiagnostics().Diagnostics)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_DidSave_BaseChanged(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte(readFile(t, "../data/openapi-test1.yaml")), 0o644))
+
+	client := newTestClient(t, &Config{Base: base, Level: checker.WARN})
+
+	require.NotEmpty(t, client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml")).Diagnostics)
+
+	// the baseline is read again rather than from the cache of the previous analysis
+	require.NoError(t, os.WriteFile(base, []byte(readFile(t, "../data/openapi-test3.yaml")), 0o644))
+	client.notify("textDocument/didSave", didSaveParams{TextDocument: textDocumentIdentifier{URI: pathToURI(base)}})
+	require.Empty(t, client.readDiagnostics().Diagnostics)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_DidClose(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN})
+
+	require.NotEmpty(t, client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml")).Diagnostics)
+
+	client.notify("textDocument/didClose", didCloseParams{TextDocument: textDocumentIdentifier{URI: pathToURI("../data/openapi-test3.yaml")}})
+	require.Empty(t, client.readDiagnostics().Diagnostics)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_InvalidSpec(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN})
+
+	result := client.open("../data/openapi-test3.yaml", "openapi: 3.0.1\ninfo:\n  title: [\n")
+	require.Len(t, result.Diagnostics, 1)
+	require.Equal(t, severityError, result.Diagnostics[0].Severity)
+	require.Contains(t, result.Diagnostics[0].Message, "failed to load spec")
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_Limits(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN, Limits: &limits.Limits{MaxInputBytes: 100}})
+
+	result := client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml"))
+	require.Len(t, result.Diagnostics, 1)
+	require.Equal(t, severityError, result.Diagnostics[0].Severity)
+	require.Contains(t, result.Diagnostics[0].Message, limits.ErrLimitExceeded.Error())
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_NewLoader(t *testing.T) {
+	loaders := 0
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN, NewLoader: func(analysisLimits *limits.Limits) *openapi3.Loader {
+		loaders++
+		return openapi3.NewLoader()
+	}})
+
+	// the document and the baseline are loaded with the configured loader
+	client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml"))
+	require.Equal(t, 2, loaders)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_InvalidBase(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/no-such-file.yaml", Level: checker.WARN})
+
+	result := client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml"))
+	require.Len(t, result.Diagnostics, 1)
+	require.Equal(t, severityWarning, result.Diagnostics[0].Severity)
+	require.Contains(t, result.Diagnostics[0].Message, "failed to load base spec from ../data/no-such-file.yaml")
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_BaseRev(t *testing.T) {
+	client := newTestClient(t, &Config{BaseRev: "HEAD", Level: checker.WARN})
+
+	// the document is compared with its committed version
+	result := client.open("../data/openapi-test1.yaml", readFile(t, "../data/openapi-test3.yaml"))
+	_, ok := findDiagnostic(result.Diagnostics, "response-success-status-removed")
+	require.True(t, ok)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_Lint(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN, Lint: true})
+
+	result := client.open("../data/openapi-test1.yaml", strings.Replace(readFile(t, "../data/openapi-test1.yaml"), "title: Tufin", "title: ''", 1))
+	diag, ok := findDiagnostic(result.Diagnostics, "info-title-missing")
+	require.True(t, ok)
+	require.Equal(t, "oasdiff lint", diag.Source)
+	require.Equal(t, severityError, diag.Severity)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_IgnoreStructured(t *testing.T) {
+	ignoreFile := filepath.Join(t.TempDir(), "ignore.yaml")
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN, WarnIgnoreFile: ignoreFile})
+
+	result := client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml"))
+	diag, ok := findDiagnostic(result.Diagnostics, "request-parameter-removed")
+	require.True(t, ok)
+
+	action, ok := findAction(client.codeActions("../data/openapi-test3.yaml", []diagnostic{diag}), "Ignore request-parameter-removed")
+	require.True(t, ok)
+	require.Equal(t, codeActionQuickFix, action.Kind)
+
+	// the ignore file doesn't exist, so it is created first
+	documentChanges := action.Edit.DocumentChanges
+	require.Len(t, documentChanges, 2)
+	require.Equal(t, "create", documentChanges[0].(map[string]any)["kind"])
+
+	var edit textDocumentEdit
+	require.NoError(t, json.Unmarshal(mustMarshal(t, documentChanges[1]), &edit))
+	require.Equal(t, pathToURI(ignoreFile), edit.TextDocument.URI)
+	require.Equal(t, position{}, edit.Edits[0].Range.Start)
+
+	entries, err := checker.ParseIgnoreEntries(strings.NewReader(edit.Edits[0].NewText))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "request-parameter-removed", entries[0].Id)
+	require.Equal(t, "GET", entries[0].Method)
+	require.Equal(t, securityScorePath, entries[0].Path)
+	require.Equal(t, "TODO", entries[0].Owner)
+
+	// once the edit is saved, the change is no longer published, while the other removed parameters still are
+	require.NoError(t, os.WriteFile(ignoreFile, []byte(edit.Edits[0].NewText), 0o644))
+	client.notify("textDocument/didSave", didSaveParams{TextDocument: textDocumentIdentifier{URI: pathToURI(ignoreFile)}})
+	diagnostics := client.readDiagnostics().Diagnostics
+	require.Len(t, diagnostics, len(result.Diagnostics)-1)
+	for _, other := range diagnostics {
+		require.NotEqual(t, diag.Message, other.Message)
+	}
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_IgnoreText(t *testing.T) {
+	ignoreFile := filepath.Join(t.TempDir(), "ignore.txt")
+	require.NoError(t, os.WriteFile(ignoreFile, []byte("GET /other some change"), 0o644))
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN, ErrIgnoreFile: ignoreFile})
+
+	result := client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml"))
+	diag, ok := findDiagnostic(result.Diagnostics, "response-success-status-removed")
+	require.True(t, ok)
+
+	action, ok := findAction(client.codeActions("../data/openapi-test3.yaml", []diagnostic{diag}), "Ignore response-success-status-removed")
+	require.True(t, ok)
+
+	// the ignore file exists, so the line is appended to it
+	require.Len(t, action.Edit.DocumentChanges, 1)
+	var edit textDocumentEdit
+	require.NoError(t, json.Unmarshal(mustMarshal(t, action.Edit.DocumentChanges[0]), &edit))
+	require.Equal(t, textRange{Start: position{Line: 0, Character: 22}, End: position{Line: 0, Character: 22}}, edit.Edits[0].Range)
+	require.Equal(t, "\nGET "+securityScorePath+" "+diag.Message+"\n", edit.Edits[0].NewText)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_IgnoreNoFile(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN})
+
+	result := client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml"))
+	diag, ok := findDiagnostic(result.Diagnostics, "response-success-status-removed")
+	require.True(t, ok)
+
+	_, ok = findAction(client.codeActions("../data/openapi-test3.yaml", []diagnostic{diag}), "Ignore")
+	require.False(t, ok)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_Deprecate(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN, DeprecationDaysStable: 30})
+
+	result := client.open("../data/openapi-test3.yaml", readFile(t, "../data/openapi-test3.yaml"))
+	diag, ok := findDiagnostic(result.Diagnostics, "response-success-status-removed")
+	require.True(t, ok)
+
+	action, ok := findAction(client.codeActions("../data/openapi-test3.yaml", []diagnostic{diag}), "Deprecate")
+	require.True(t, ok)
+	require.Equal(t, "Deprecate GET "+securityScorePath+" with sunset date 2026-01-31", action.Title)
+
+	var edit textDocumentEdit
+	require.NoError(t, json.Unmarshal(mustMarshal(t, action.Edit.DocumentChanges[0]), &edit))
+	require.Equal(t, pathToURI("../data/openapi-test3.yaml"), edit.TextDocument.URI)
+	require.Equal(t, 1, *edit.TextDocument.Version)
+	require.Equal(t, textRange{Start: position{Line: 18}, End: position{Line: 18}}, edit.Edits[0].Range)
+	require.Equal(t, "      deprecated: true\n      x-sunset: \"2026-01-31\"\n", edit.Edits[0].NewText)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_DeprecateFlowStyle(t *testing.T) {
+	client := newTestClient(t, &Config{Base: "../data/openapi-test1.yaml", Level: checker.WARN})
+
+	// the operation of the change is in flow style, so fields can't be inserted as lines
+	result := client.open("../data/openapi-test3.yaml", `{"openapi": "3.0.1", "info": {"title": "Tufin", "version": "1"}, "paths": {"`+securityScorePath+`": {"get": {"responses": {"201": {"description": "OK"}}}}}}`)
+	diag, ok := findDiagnostic(result.Diagnostics, "response-success-status-removed")
+	require.True(t, ok)
+
+	_, ok = findAction(client.codeActions("../data/openapi-test3.yaml", []diagnostic{diag}), "Deprecate")
+	require.False(t, ok)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_NotInitialized(t *testing.T) {
+	server := New(&Config{})
+	content := `{"jsonrpc":"2.0","id":1,"method":"shutdown"}`
+	input := "Content-Length: " + strconv.Itoa(len(content)) + "\r\n\r\n" + content
+	output := strings.Builder{}
+	require.NoError(t, server.Run(strings.NewReader(input), &output))
+	require.Contains(t, output.String(), `"code":-32002`)
+}
+
+func TestServer_MethodNotFound(t *testing.T) {
+	client := newTestClient(t, &Config{})
+
+	result := client.request("textDocument/hover", map[string]any{})
+	require.Equal(t, codeMethodNotFound, result.Error.Code)
+
+	require.NoError(t, client.close())
+}
+
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	client := newTestClient(t, &Config{})
+
+	client.notify("exit", nil)
+	require.ErrorIs(t, <-client.done, ErrExitWithoutShutdown)
+}
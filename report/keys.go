@@ -15,7 +15,9 @@ type DiffT interface {
 		*diff.MediaTypeDiff |
 		*diff.HeaderDiff |
 		diff.SecurityScopesDiff |
-		*diff.StringsDiff
+		*diff.StringsDiff |
+		*diff.PathDiff |
+		*diff.MethodDiff
 }
 
 func getKeys[diff DiffT](m map[string]diff) utils.StringList {
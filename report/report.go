@@ -57,6 +57,10 @@ func (r *report) output(d *diff.Diff) {
 		r.printEndpoints(d.EndpointsDiff)
 	}
 
+	if !d.WebhooksDiff.Empty() {
+		r.printWebhooks(d.WebhooksDiff)
+	}
+
 	if d.ExtensionsDiff.Empty() &&
 		d.SecurityDiff.Empty() &&
 		d.ServersDiff.Empty() {
@@ -113,6 +117,49 @@ func (r *report) printEndpoints(d *diff.EndpointsDiff) {
 	}
 }
 
+func (r *report) printWebhooks(d *diff.WebhooksDiff) {
+
+	r.printTitle("New Webhooks", len(d.Added))
+	sort.Sort(d.Added)
+	for _, added := range d.Added {
+		r.print(added)
+	}
+	r.print("")
+
+	r.printTitle("Deleted Webhooks", len(d.Deleted))
+	sort.Sort(d.Deleted)
+	for _, deleted := range d.Deleted {
+		r.print(deleted)
+	}
+	r.print("")
+
+	r.printTitle("Modified Webhooks", len(d.Modified))
+	for _, name := range getKeys(d.Modified) {
+		r.print(name)
+		r.indent().printWebhook(d.Modified[name])
+		r.print("")
+	}
+}
+
+func (r *report) printWebhook(d *diff.PathDiff) {
+	if d.OperationsDiff.Empty() {
+		return
+	}
+
+	for _, method := range d.OperationsDiff.Added.Sort() {
+		r.print("New operation:", method)
+	}
+
+	for _, method := range d.OperationsDiff.Deleted.Sort() {
+		r.print("Deleted operation:", method)
+	}
+
+	for _, method := range getKeys(d.OperationsDiff.Modified) {
+		r.print(method)
+		r.indent().printMethod(d.OperationsDiff.Modified[method])
+	}
+}
+
 func (r *report) printServers(d *diff.ServersDiff) {
 	if d.Empty() {
 		return
@@ -139,6 +186,8 @@ func (r *report) printMethod(d *diff.MethodDiff) {
 		return
 	}
 
+	r.printValue(d.PathDiff, "Path")
+
 	if !d.ExtensionsDiff.Empty() {
 		r.print("Extensions changed")
 		r.indent().printExtensions(d.ExtensionsDiff)
@@ -470,6 +519,107 @@ func (r *report) printSchema(d *diff.SchemaDiff) {
 	}
 
 	r.printMessage(d.DiscriminatorDiff, "Discriminator changed")
+
+	r.printConst(d.ConstDiff)
+
+	if !d.PrefixItemsDiff.Empty() {
+		r.print("PrefixItems changed")
+		r.indent().printPrefixItems(d.PrefixItemsDiff)
+	}
+
+	if !d.ContainsDiff.Empty() {
+		r.print("Contains changed")
+		r.indent().printSchema(d.ContainsDiff)
+	}
+
+	r.printValue(d.MinContainsDiff, "MinContains")
+	r.printValue(d.MaxContainsDiff, "MaxContains")
+
+	if !d.IfDiff.Empty() {
+		r.print("If changed")
+		r.indent().printSchema(d.IfDiff)
+	}
+
+	if !d.ThenDiff.Empty() {
+		r.print("Then changed")
+		r.indent().printSchema(d.ThenDiff)
+	}
+
+	if !d.ElseDiff.Empty() {
+		r.print("Else changed")
+		r.indent().printSchema(d.ElseDiff)
+	}
+
+	if !d.DependentRequiredDiff.Empty() {
+		r.print("DependentRequired changed")
+		r.indent().printDependentRequired(d.DependentRequiredDiff)
+	}
+
+	if !d.DependentSchemasDiff.Empty() {
+		r.print("DependentSchemas changed")
+		r.indent().printProperties(d.DependentSchemasDiff)
+	}
+
+	r.printValue(d.UnevaluatedPropertiesAllowedDiff, "UnevaluatedProperties")
+
+	if !d.UnevaluatedPropertiesDiff.Empty() {
+		r.print("UnevaluatedProperties changed")
+		r.indent().printSchema(d.UnevaluatedPropertiesDiff)
+	}
+
+	r.printValue(d.UnevaluatedItemsAllowedDiff, "UnevaluatedItems")
+
+	if !d.UnevaluatedItemsDiff.Empty() {
+		r.print("UnevaluatedItems changed")
+		r.indent().printSchema(d.UnevaluatedItemsDiff)
+	}
+
+	if !d.PropertyNamesDiff.Empty() {
+		r.print("PropertyNames changed")
+		r.indent().printSchema(d.PropertyNamesDiff)
+	}
+}
+
+func (r *report) printPrefixItems(d *diff.PrefixItemsDiff) {
+	if d.Empty() {
+		return
+	}
+
+	for _, index := range d.Added {
+		r.print("New prefix item:", index)
+	}
+
+	for _, index := range d.Deleted {
+		r.print("Deleted prefix item:", index)
+	}
+
+	indexes := make([]int, 0, len(d.Modified))
+	for index := range d.Modified {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		r.print("Modified prefix item:", index)
+		r.indent().printSchema(d.Modified[index])
+	}
+}
+
+func (r *report) printDependentRequired(d *diff.DependentRequiredDiff) {
+	if d.Empty() {
+		return
+	}
+
+	for _, property := range d.Added {
+		r.print("New dependency:", property)
+	}
+
+	for _, property := range d.Deleted {
+		r.print("Deleted dependency:", property)
+	}
+
+	for _, property := range getKeys(d.Modified) {
+		r.printStrings(d.Modified[property], "Properties required by "+property)
+	}
 }
 
 func (r *report) printSchemaListDiff(d *diff.SubschemasDiff) {
@@ -635,6 +785,14 @@ func (r *report) printValue(d *diff.ValueDiff, title string) {
 	r.print(title, "changed from", quote(d.From), "to", quote(d.To))
 }
 
+func (r *report) printConst(d *diff.ConstDiff) {
+	if d.Empty() {
+		return
+	}
+
+	r.print("Const", "changed from", quote(d.From), "to", quote(d.To))
+}
+
 func (r *report) printStrings(d *diff.StringsDiff, title string) {
 	if d.Empty() {
 		return
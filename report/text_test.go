@@ -104,3 +104,61 @@ func TestText_RequestBody(t *testing.T) {
 	textReport := report.GetTextReportAsString(dd)
 	require.Contains(t, textReport, "Request body changed")
 }
+
+func TestText_Webhooks(t *testing.T) {
+	loader := openapi3.NewLoader()
+
+	s1, err := loader.LoadFromFile("../data/webhooks/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := loader.LoadFromFile("../data/webhooks/revision.yaml")
+	require.NoError(t, err)
+
+	dd, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	text := report.GetTextReportAsString(dd)
+	require.Contains(t, text, "### New Webhooks: 1\n-------------------\npetUpdated\n")
+	require.Contains(t, text, "### Deleted Webhooks: 1\n-----------------------\npetRemoved\n")
+	require.Contains(t, text, "### Modified Webhooks: 1\n------------------------\nnewPet\n- POST\n")
+	require.Contains(t, text, "- Deleted property: color\n")
+}
+
+func TestText_SchemaKeywords(t *testing.T) {
+	loader := openapi3.NewLoader()
+
+	s1, err := loader.LoadFromFile("../data/schema-keywords/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := loader.LoadFromFile("../data/schema-keywords/revision.yaml")
+	require.NoError(t, err)
+
+	dd, err := diff.Get(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	text := report.GetTextReportAsString(dd)
+	require.Contains(t, text, "- Const changed from null to 'standard'\n")
+	require.Contains(t, text, "- DependentRequired changed\n")
+	require.Contains(t, text, "- New dependency: card\n")
+	require.Contains(t, text, "- Modified prefix item: 0\n")
+	require.Contains(t, text, "- MinContains changed from 1 to 2\n")
+	require.Contains(t, text, "- Then changed\n")
+	require.Contains(t, text, "- UnevaluatedProperties changed from null to false\n")
+	require.Contains(t, text, "- UnevaluatedItems changed from null to false\n")
+}
+
+func TestText_MovedEndpoint(t *testing.T) {
+	s1, err := openapi3.NewLoader().LoadFromFile("../data/moved-endpoints/base.yaml")
+	require.NoError(t, err)
+
+	s2, err := openapi3.NewLoader().LoadFromFile("../data/moved-endpoints/revision.yaml")
+	require.NoError(t, err)
+
+	config := diff.NewConfig()
+	config.MatchMovedEndpoints = true
+	dd, err := diff.Get(config, s1, s2)
+	require.NoError(t, err)
+
+	text := report.GetTextReportAsString(dd)
+	require.Contains(t, text, "GET /accounts/{accountId}\n- Path changed from '/users/{id}' to '/accounts/{accountId}'\n")
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/limits"
+)
+
+// Options are the options of a comparison, named after the flags of the corresponding CLI commands
+// options which refer to files on the server, like ignore files and custom rules, aren't supported
+type Options struct {
+	Format                 string   `json:"format"`
+	Lang                   string   `json:"lang"`
+	Color                  string   `json:"color"`
+	Level                  string   `json:"level"`
+	ExcludeElements        []string `json:"exclude-elements"`
+	MatchPath              string   `json:"match-path"`
+	UnmatchPath            string   `json:"unmatch-path"`
+	FilterExtension        string   `json:"filter-extension"`
+	PrefixBase             string   `json:"prefix-base"`
+	PrefixRevision         string   `json:"prefix-revision"`
+	StripPrefixBase        string   `json:"strip-prefix-base"`
+	StripPrefixRevision    string   `json:"strip-prefix-revision"`
+	IncludePathParams      bool     `json:"include-path-params"`
+	MatchMovedEndpoints    bool     `json:"match-moved-endpoints"`
+	FlattenAllOf           bool     `json:"flatten-allof"`
+	FlattenParams          bool     `json:"flatten-params"`
+	CaseInsensitiveHeaders bool     `json:"case-insensitive-headers"`
+	NormalizeDialect       bool     `json:"normalize-dialect"`
+	IncludeChecks          []string `json:"include-checks"`
+	DeprecationDaysBeta    uint     `json:"deprecation-days-beta"`
+	DeprecationDaysStable  uint     `json:"deprecation-days-stable"`
+	Attributes             []string `json:"attributes"`
+}
+
+func (options *Options) toDiffConfig() *diff.Config {
+	config := diff.NewConfig().WithExcludeElements(options.ExcludeElements)
+	config.MatchPath = options.MatchPath
+	config.UnmatchPath = options.UnmatchPath
+	config.FilterExtension = options.FilterExtension
+	config.PathPrefixBase = options.PrefixBase
+	config.PathPrefixRevision = options.PrefixRevision
+	config.PathStripPrefixBase = options.StripPrefixBase
+	config.PathStripPrefixRevision = options.StripPrefixRevision
+	config.IncludePathParams = options.IncludePathParams
+	config.MatchMovedEndpoints = options.MatchMovedEndpoints
+
+	return config
+}
+
+// set sets an option by its name, as sent in a multipart form
+// list options can be repeated, or sent as comma-separated values
+func (options *Options) set(name, value string) error {
+	v := reflect.ValueOf(options).Elem()
+	t := v.Type()
+	for i := range t.NumField() {
+		if t.Field(i).Tag.Get("json") != name {
+			continue
+		}
+
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value %q of option %q, expected a boolean", value, name)
+			}
+			field.SetBool(b)
+		case reflect.Uint:
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value %q of option %q, expected a non-negative integer", value, name)
+			}
+			field.SetUint(n)
+		case reflect.Slice:
+			for _, item := range strings.Split(value, ",") {
+				field.Set(reflect.Append(field, reflect.ValueOf(item)))
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown option %q", name)
+}
+
+// comparisonRequest is a pair of specs to compare, with the options of the comparison
+type comparisonRequest struct {
+	Options
+	base     []byte
+	revision []byte
+	limits   *limits.Limits
+}
+
+// jsonRequest is the JSON form of a comparison request, with the specs as strings and the options alongside them
+type jsonRequest struct {
+	Base     string `json:"base"`
+	Revision string `json:"revision"`
+	Options
+}
+
+// readRequest reads a comparison request from a multipart form or from a JSON body
+func readRequest(r *http.Request) (*comparisonRequest, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, newHTTPError(http.StatusUnsupportedMediaType, "missing or invalid content type, use multipart/form-data or application/json")
+	}
+
+	var result *comparisonRequest
+	switch mediaType {
+	case "multipart/form-data":
+		result, err = readMultipartRequest(r)
+	case "application/json":
+		result, err = readJSONRequest(r)
+	default:
+		return nil, newHTTPError(http.StatusUnsupportedMediaType, "unsupported content type %q, use multipart/form-data or application/json", mediaType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.base) == 0 {
+		return nil, newHTTPError(http.StatusBadRequest, "missing base spec")
+	}
+	if len(result.revision) == 0 {
+		return nil, newHTTPError(http.StatusBadRequest, "missing revision spec")
+	}
+
+	return result, nil
+}
+
+func readMultipartRequest(r *http.Request) (*comparisonRequest, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, getReadError(err)
+	}
+
+	result := comparisonRequest{}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, getReadError(err)
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, getReadError(err)
+		}
+
+		switch name := part.FormName(); name {
+		case "base":
+			result.base = data
+		case "revision":
+			result.revision = data
+		default:
+			if err := result.set(name, string(data)); err != nil {
+				return nil, newHTTPError(http.StatusBadRequest, "%v", err)
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+func readJSONRequest(r *http.Request) (*comparisonRequest, error) {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	var request jsonRequest
+	if err := decoder.Decode(&request); err != nil {
+		return nil, getReadError(err)
+	}
+
+	return &comparisonRequest{
+		Options:  request.Options,
+		base:     []byte(request.Base),
+		revision: []byte(request.Revision),
+	}, nil
+}
+
+// getReadError distinguishes requests which exceed the size limit from malformed requests
+func getReadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return newHTTPError(http.StatusRequestEntityTooLarge, "request body exceeds the limit of %d bytes", maxBytesErr.Limit)
+	}
+	return newHTTPError(http.StatusBadRequest, "invalid request body: %v", err)
+}
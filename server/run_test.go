@@ -0,0 +1,89 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getStatus(t *testing.T, err error) int {
+	t.Helper()
+
+	var httpErr *httpError
+	require.True(t, errors.As(err, &httpErr))
+	return httpErr.status
+}
+
+func TestRun_Busy(t *testing.T) {
+	config := NewConfig()
+	config.MaxConcurrent = 1
+	server := New(config)
+	server.slots <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := server.run(ctx, func() ([]byte, error) { return nil, nil })
+	require.Equal(t, http.StatusServiceUnavailable, getStatus(t, err))
+}
+
+func TestRun_Timeout(t *testing.T) {
+	config := NewConfig()
+	config.MaxConcurrent = 1
+	server := New(config)
+
+	release := make(chan struct{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := server.run(ctx, func() ([]byte, error) {
+		<-release
+		return nil, nil
+	})
+	require.Equal(t, http.StatusGatewayTimeout, getStatus(t, err))
+
+	// the comparison keeps its slot until it completes
+	require.Len(t, server.slots, 1)
+	close(release)
+	require.Eventually(t, func() bool { return len(server.slots) == 0 }, time.Second, time.Millisecond)
+}
+
+func TestServer_TimeoutFreesSlot(t *testing.T) {
+	config := NewConfig()
+	config.MaxConcurrent = 1
+	config.Timeout = 100 * time.Millisecond
+	server := New(config)
+
+	// flattening this spec takes much longer than the timeout
+	spec, err := os.ReadFile("../data/limits/combinations.yaml")
+	require.NoError(t, err)
+	body, err := json.Marshal(map[string]any{"base": string(spec), "revision": string(spec), "flatten-allof": true})
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/diff", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+
+	// the comparison stops at the timeout of its limits, rather than keeping its slot until it completes
+	require.Eventually(t, func() bool { return len(server.slots) == 0 }, 5*time.Second, time.Millisecond)
+}
+
+func TestRun_Panic(t *testing.T) {
+	server := New(NewConfig())
+
+	_, err := server.run(context.Background(), func() ([]byte, error) {
+		panic("unexpected")
+	})
+	require.EqualError(t, err, "comparison failed: unexpected")
+	require.Eventually(t, func() bool { return len(server.slots) == 0 }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,388 @@
+package server
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/checker/localizations"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/formatters"
+	"github.com/tufin/oasdiff/limits"
+	"github.com/tufin/oasdiff/load"
+)
+
+const (
+	DefaultMaxRequestSize    = int64(10 << 20)
+	DefaultTimeout           = 30 * time.Second
+	DefaultMaxAliasExpansion = 100000
+	DefaultMaxSchemaDepth    = 64
+)
+
+// Config holds the limits of the server
+type Config struct {
+	MaxRequestSize int64          // the max size of a request body in bytes
+	Timeout        time.Duration  // the max duration of a comparison, including the wait for a free slot
+	MaxConcurrent  int            // the max number of comparisons that run at the same time
+	Limits         *limits.Limits // the limits on the specs of each comparison, their timeout is replaced by Timeout
+}
+
+// NewConfig creates a new configuration with default values
+func NewConfig() *Config {
+	return &Config{
+		MaxRequestSize: DefaultMaxRequestSize,
+		Timeout:        DefaultTimeout,
+		MaxConcurrent:  runtime.NumCPU(),
+		Limits:         NewLimits(),
+	}
+}
+
+// NewLimits returns the default limits on the specs of each comparison
+func NewLimits() *limits.Limits {
+	return &limits.Limits{
+		MaxInputBytes:     DefaultMaxRequestSize,
+		MaxAliasExpansion: DefaultMaxAliasExpansion,
+		MaxSchemaDepth:    DefaultMaxSchemaDepth,
+	}
+}
+
+// Server is an HTTP API which exposes the diff, summary, breaking and changelog commands
+// the localizers and the rule tables are created once and shared by all requests, since they are only read
+type Server struct {
+	config         *Config
+	localizers     map[string]checker.Localizer
+	checks         checker.BackwardCompatibilityChecks
+	levels         map[string]checker.Level
+	optionalChecks []string
+	slots          chan struct{}
+}
+
+// New creates a server with the given configuration
+func New(config *Config) *Server {
+	localizers := map[string]checker.Localizer{}
+	for _, lang := range localizations.GetSupportedLanguages() {
+		localizers[lang] = checker.NewLocalizer(lang)
+	}
+
+	return &Server{
+		config:         config,
+		localizers:     localizers,
+		checks:         checker.GetAllChecks(),
+		levels:         checker.GetCheckLevels(),
+		optionalChecks: checker.GetOptionalRuleIds(),
+		slots:          make(chan struct{}, max(config.MaxConcurrent, 1)),
+	}
+}
+
+//go:embed openapi.yaml
+var openAPI []byte
+
+// Handler returns the HTTP handler of the server
+func (server *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /diff", server.handle(formatters.OutputDiff, formatters.FormatYAML, server.renderDiff))
+	mux.HandleFunc("POST /summary", server.handle(formatters.OutputSummary, formatters.FormatYAML, server.renderSummary))
+	mux.HandleFunc("POST /breaking", server.handle(formatters.OutputChangelog, formatters.FormatText, server.renderBreaking))
+	mux.HandleFunc("POST /changelog", server.handle(formatters.OutputChangelog, formatters.FormatText, server.renderChangelog))
+	mux.HandleFunc("GET /openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", formatters.GetContentType(formatters.FormatYAML))
+		_, _ = w.Write(openAPI)
+	})
+	return mux
+}
+
+// render compares the specs of a request and renders the result with the formatter
+type render func(request *comparisonRequest, formatter formatters.Formatter) ([]byte, error)
+
+func (server *Server) handle(output formatters.Output, defaultFormat formatters.Format, render render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, server.config.MaxRequestSize)
+
+		request, err := readRequest(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if err := server.validateOptions(&request.Options, output, defaultFormat); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		formatter, err := formatters.Lookup(request.Format, formatters.FormatterOpts{Language: request.Lang, Localizer: server.localizers[request.Lang]})
+		if err != nil {
+			writeError(w, newHTTPError(http.StatusBadRequest, "%v", err))
+			return
+		}
+
+		// the comparison stops at the same deadline as the request, so that it frees its slot
+		request.limits = server.newLimits()
+		deadline, _ := request.limits.Deadline()
+		ctx, cancel := context.WithDeadline(r.Context(), deadline)
+		defer cancel()
+
+		body, err := server.run(ctx, func() ([]byte, error) {
+			return render(request, formatter)
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", formatters.GetContentType(formatters.Format(request.Format)))
+		_, _ = w.Write(body)
+	}
+}
+
+// validateOptions applies the defaults of the command, and rejects invalid options before they reach code which assumes valid input
+func (server *Server) validateOptions(options *Options, output formatters.Output, defaultFormat formatters.Format) error {
+	if options.Format == "" {
+		options.Format = string(defaultFormat)
+	}
+	if options.Lang == "" {
+		options.Lang = localizations.LangDefault
+	}
+	if options.Color == "" {
+		options.Color = "never"
+	}
+	if options.Level == "" {
+		options.Level = "INFO"
+	}
+
+	if err := validateStrings(formatters.SupportedFormatsByContentType(output), "format", options.Format); err != nil {
+		return err
+	}
+	if err := validateStrings(localizations.GetSupportedLanguages(), "lang", options.Lang); err != nil {
+		return err
+	}
+	if err := validateStrings(checker.GetSupportedColorValues(), "color", options.Color); err != nil {
+		return err
+	}
+	if err := validateStrings([]string{"ERR", "WARN", "INFO"}, "level", options.Level); err != nil {
+		return err
+	}
+	if err := validateStrings(diff.GetExcludeDiffOptions(), "exclude-elements", options.ExcludeElements...); err != nil {
+		return err
+	}
+	if err := validateStrings(server.optionalChecks, "include-checks", options.IncludeChecks...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateStrings(allowedValues []string, name string, values ...string) error {
+	for _, value := range values {
+		if !slices.Contains(allowedValues, value) {
+			return newHTTPError(http.StatusBadRequest, "invalid %s %q, allowed values: %s", name, value, strings.Join(allowedValues, ", "))
+		}
+	}
+	return nil
+}
+
+// newLimits returns the limits of a comparison, whose timeout starts now
+func (server *Server) newLimits() *limits.Limits {
+	result := server.config.Limits.Copy()
+	if result == nil {
+		result = &limits.Limits{}
+	}
+	result.Timeout = server.config.Timeout
+	result.Start()
+	return result
+}
+
+// run runs a comparison once a slot is free, within the deadline of the context
+// when the deadline passes, the comparison keeps its slot until it stops in the background, which keeps the load of the server bounded
+// comparisons check the deadline of their limits while they load and compare the specs, so they stop soon after it passes
+func (server *Server) run(ctx context.Context, compare func() ([]byte, error)) ([]byte, error) {
+	select {
+	case server.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, newHTTPError(http.StatusServiceUnavailable, "too many concurrent comparisons, try again later")
+	}
+
+	type result struct {
+		body []byte
+		err  error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		defer func() {
+			<-server.slots
+		}()
+		defer func() {
+			// a panic in a comparison fails the request rather than the server
+			if r := recover(); r != nil {
+				done <- result{err: fmt.Errorf("comparison failed: %v", r)}
+			}
+		}()
+
+		body, err := compare()
+		done <- result{body: body, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		if result.err != nil && isPastDeadline(ctx) {
+			// the comparison failed because the timeout of its limits expired
+			return nil, newHTTPError(http.StatusGatewayTimeout, "comparison didn't complete within %s", server.config.Timeout)
+		}
+		return result.body, result.err
+	case <-ctx.Done():
+		return nil, newHTTPError(http.StatusGatewayTimeout, "comparison didn't complete within %s", server.config.Timeout)
+	}
+}
+
+func isPastDeadline(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && !time.Now().Before(deadline)
+}
+
+// comparison is the result of comparing the specs of a request
+type comparison struct {
+	diffReport        *diff.Diff
+	operationsSources *diff.OperationsSourcesMap
+	specInfoPair      *load.SpecInfoPair
+}
+
+func (server *Server) compare(request *comparisonRequest) (*comparison, error) {
+	// uploaded specs may not refer to files or URLs which are accessible to the server
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = false
+	load.WithLimits(loader, request.limits)
+
+	options := []load.Option{
+		load.GetOption(load.WithFlattenAllOf(), request.FlattenAllOf),
+		load.GetOption(load.WithFlattenParams(), request.FlattenParams),
+		load.GetOption(load.WithLowercaseHeaders(), request.CaseInsensitiveHeaders),
+		load.GetOption(load.WithNormalizeDialect(), request.NormalizeDialect),
+	}
+
+	s1, err := load.NewSpecInfoFromData(loader, "base", request.base, options...)
+	if err != nil {
+		return nil, newHTTPError(http.StatusUnprocessableEntity, "failed to load base spec: %v", err)
+	}
+
+	s2, err := load.NewSpecInfoFromData(loader, "revision", request.revision, options...)
+	if err != nil {
+		return nil, newHTTPError(http.StatusUnprocessableEntity, "failed to load revision spec: %v", err)
+	}
+
+	config := request.toDiffConfig().WithLimits(request.limits)
+	if request.Format == string(formatters.FormatJSON) {
+		// like the diff command, since endpoints can't be represented as JSON keys
+		config = config.WithExcludeElements(append(slices.Clone(request.ExcludeElements), diff.ExcludeEndpointsOption))
+	}
+
+	diffReport, operationsSources, err := diff.GetWithOperationsSourcesMap(config, s1, s2)
+	if err != nil {
+		return nil, newHTTPError(http.StatusUnprocessableEntity, "diff failed: %v", err)
+	}
+
+	return &comparison{
+		diffReport:        diffReport,
+		operationsSources: operationsSources,
+		specInfoPair:      load.NewSpecInfoPair(s1, s2),
+	}, nil
+}
+
+// newCheckerConfig creates a checker configuration from the rule tables of the server
+// the checks are shared, while the levels are copied since the request may override them
+func (server *Server) newCheckerConfig(request *comparisonRequest, result *comparison) *checker.Config {
+	config := &checker.Config{
+		Checks:    slices.Clip(server.checks),
+		LogLevels: maps.Clone(server.levels),
+	}
+
+	return config.
+		WithOptionalChecks(request.IncludeChecks).
+		WithDeprecation(request.DeprecationDaysBeta, request.DeprecationDaysStable).
+		WithAttributes(request.Attributes).
+		WithSourceMaps(load.NewSourceMaps(result.specInfoPair.Base), load.NewSourceMaps(result.specInfoPair.Revision))
+}
+
+func (server *Server) renderDiff(request *comparisonRequest, formatter formatters.Formatter) ([]byte, error) {
+	result, err := server.compare(request)
+	if err != nil {
+		return nil, err
+	}
+	return formatter.RenderDiff(result.diffReport, formatters.RenderOpts{ColorMode: checker.ColorNever})
+}
+
+func (server *Server) renderSummary(request *comparisonRequest, formatter formatters.Formatter) ([]byte, error) {
+	result, err := server.compare(request)
+	if err != nil {
+		return nil, err
+	}
+	return formatter.RenderSummary(result.diffReport, formatters.RenderOpts{ColorMode: checker.ColorNever})
+}
+
+func (server *Server) renderBreaking(request *comparisonRequest, formatter formatters.Formatter) ([]byte, error) {
+	return server.renderChanges(request, formatter, checker.WARN)
+}
+
+func (server *Server) renderChangelog(request *comparisonRequest, formatter formatters.Formatter) ([]byte, error) {
+	level, err := checker.NewLevel(request.Level)
+	if err != nil {
+		return nil, newHTTPError(http.StatusBadRequest, "%v", err)
+	}
+	return server.renderChanges(request, formatter, level)
+}
+
+func (server *Server) renderChanges(request *comparisonRequest, formatter formatters.Formatter, level checker.Level) ([]byte, error) {
+	result, err := server.compare(request)
+	if err != nil {
+		return nil, err
+	}
+
+	colorMode, err := checker.NewColorMode(request.Color)
+	if err != nil {
+		return nil, newHTTPError(http.StatusBadRequest, "%v", err)
+	}
+
+	changes := checker.CheckBackwardCompatibilityUntilLevel(server.newCheckerConfig(request, result), result.diffReport, result.operationsSources, level)
+
+	return formatter.RenderChangelog(changes, formatters.RenderOpts{ColorMode: colorMode}, result.specInfoPair)
+}
+
+// httpError is an error with the HTTP status of the response
+type httpError struct {
+	status int
+	err    error
+}
+
+func newHTTPError(status int, format string, args ...any) *httpError {
+	return &httpError{
+		status: status,
+		err:    fmt.Errorf(format, args...),
+	}
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+// writeError writes an error as a JSON object with an "error" field
+// errors without an HTTP status, like errors of the formatters, are internal errors
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var httpErr *httpError
+	if errors.As(err, &httpErr) {
+		status = httpErr.status
+	}
+
+	w.Header().Set("Content-Type", formatters.GetContentType(formatters.FormatJSON))
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,257 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/server"
+)
+
+func readSpec(t *testing.T, file string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	return string(data)
+}
+
+// newMultipartRequest creates a multipart request with the specs and the options as form fields
+func newMultipartRequest(t *testing.T, path string, base, revision string, options map[string]string) *http.Request {
+	t.Helper()
+
+	body := bytes.Buffer{}
+	writer := multipart.NewWriter(&body)
+	for name, value := range map[string]string{"base": base, "revision": revision} {
+		part, err := writer.CreateFormFile(name, name+".yaml")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(value))
+		require.NoError(t, err)
+	}
+	for name, value := range options {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	require.NoError(t, writer.Close())
+
+	request := httptest.NewRequest(http.MethodPost, path, &body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func newJSONRequest(t *testing.T, path string, body map[string]any) *http.Request {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	request.Header.Set("Content-Type", "application/json")
+	return request
+}
+
+func serve(handler http.Handler, request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func getError(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var result map[string]string
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	return result["error"]
+}
+
+func TestServer_BreakingMultipart(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newMultipartRequest(t, "/breaking", readSpec(t, "../data/openapi-test1.yaml"), readSpec(t, "../data/openapi-test3.yaml"), map[string]string{"format": "json"}))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	require.Contains(t, recorder.Body.String(), `"id":"response-success-status-removed"`)
+	require.Contains(t, recorder.Body.String(), `"source":"revision"`)
+}
+
+func TestServer_ChangelogJSON(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newJSONRequest(t, "/changelog", map[string]any{
+		"base":     readSpec(t, "../data/openapi-test1.yaml"),
+		"revision": readSpec(t, "../data/openapi-test3.yaml"),
+		"lang":     "ru",
+		"level":    "WARN",
+	}))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+	require.Contains(t, recorder.Body.String(), "удален успешный (2xx) статус ответа '200'")
+	require.NotContains(t, recorder.Body.String(), "\x1b[")
+}
+
+func TestServer_DiffDefaultFormat(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newMultipartRequest(t, "/diff", readSpec(t, "../data/openapi-test1.yaml"), readSpec(t, "../data/openapi-test3.yaml"), map[string]string{"exclude-elements": "description,examples"}))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/yaml", recorder.Header().Get("Content-Type"))
+	require.True(t, strings.HasPrefix(recorder.Body.String(), "extensions:"))
+}
+
+func TestServer_Summary(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newMultipartRequest(t, "/summary", readSpec(t, "../data/openapi-test1.yaml"), readSpec(t, "../data/openapi-test3.yaml"), map[string]string{"format": "json"}))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `"diff":true`)
+}
+
+func TestServer_MethodNotAllowed(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, httptest.NewRequest(http.MethodGet, "/diff", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestServer_UnsupportedContentType(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	request := httptest.NewRequest(http.MethodPost, "/diff", strings.NewReader("base"))
+	request.Header.Set("Content-Type", "text/plain")
+	recorder := serve(handler, request)
+	require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+	require.Equal(t, `unsupported content type "text/plain", use multipart/form-data or application/json`, getError(t, recorder))
+}
+
+func TestServer_TooLarge(t *testing.T) {
+	config := server.NewConfig()
+	config.MaxRequestSize = 1000
+	handler := server.New(config).Handler()
+
+	recorder := serve(handler, newMultipartRequest(t, "/diff", readSpec(t, "../data/openapi-test1.yaml"), readSpec(t, "../data/openapi-test3.yaml"), nil))
+	require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	require.Equal(t, "request body exceeds the limit of 1000 bytes", getError(t, recorder))
+}
+
+func TestServer_MissingRevision(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newJSONRequest(t, "/diff", map[string]any{"base": readSpec(t, "../data/openapi-test1.yaml")}))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Equal(t, "missing revision spec", getError(t, recorder))
+}
+
+func TestServer_UnknownJSONOption(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newJSONRequest(t, "/diff", map[string]any{"base": "a", "revision": "b", "formats": "json"}))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Equal(t, `invalid request body: json: unknown field "formats"`, getError(t, recorder))
+}
+
+func TestServer_UnknownMultipartOption(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newMultipartRequest(t, "/diff", "a", "b", map[string]string{"err-ignore": "/etc/passwd"}))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Equal(t, `unknown option "err-ignore"`, getError(t, recorder))
+}
+
+func TestServer_InvalidBoolOption(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newMultipartRequest(t, "/diff", "a", "b", map[string]string{"flatten-allof": "yes please"}))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Equal(t, `invalid value "yes please" of option "flatten-allof", expected a boolean`, getError(t, recorder))
+}
+
+func TestServer_UnsupportedFormat(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newMultipartRequest(t, "/summary", "a", "b", map[string]string{"format": "text"}))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Equal(t, `invalid format "text", allowed values: json, yaml`, getError(t, recorder))
+}
+
+func TestServer_InvalidIncludeChecks(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newMultipartRequest(t, "/breaking", "a", "b", map[string]string{"include-checks": "no-such-check"}))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, getError(t, recorder), `invalid include-checks "no-such-check"`)
+}
+
+func TestServer_InvalidSpec(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, newMultipartRequest(t, "/breaking", "{", readSpec(t, "../data/openapi-test3.yaml"), nil))
+	require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	require.Contains(t, getError(t, recorder), "failed to load base spec")
+}
+
+func TestServer_ExternalRef(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	spec := `openapi: 3.0.1
+info:
+  title: Test API
+  version: v1
+paths:
+  /test:
+    $ref: "../data/openapi-test1.yaml#/paths/~1api~1{domain}~1{project}~1badges~1security-score"
+`
+	recorder := serve(handler, newMultipartRequest(t, "/diff", spec, readSpec(t, "../data/openapi-test3.yaml"), nil))
+	require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	require.Contains(t, getError(t, recorder), "failed to load base spec")
+}
+
+func TestServer_Concurrent(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+	base, revision := readSpec(t, "../data/openapi-test1.yaml"), readSpec(t, "../data/openapi-test3.yaml")
+
+	wg := sync.WaitGroup{}
+	results := make([]*httptest.ResponseRecorder, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			options := map[string]string{"format": "json", "include-checks": "response-non-success-status-removed"}
+			if i%2 == 0 {
+				options["lang"] = "ru"
+			}
+			results[i] = serve(handler, newMultipartRequest(t, "/breaking", base, revision, options))
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		require.Equal(t, http.StatusOK, result.Code)
+	}
+	require.Equal(t, results[0].Body.String(), results[2].Body.String())
+	require.Equal(t, results[1].Body.String(), results[3].Body.String())
+	require.NotEqual(t, results[0].Body.String(), results[1].Body.String())
+}
+
+func TestServer_OpenAPI(t *testing.T) {
+	handler := server.New(server.NewConfig()).Handler()
+
+	recorder := serve(handler, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/yaml", recorder.Header().Get("Content-Type"))
+
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromData(recorder.Body.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(loader.Context))
+	for _, path := range []string{"/diff", "/summary", "/breaking", "/changelog", "/openapi.yaml"} {
+		require.NotNil(t, spec.Paths.Value(path), path)
+	}
+}
@@ -0,0 +1,190 @@
+package traffic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sample is a recorded call to the API
+type Sample struct {
+	Method  string
+	Path    string              // the path of the URL, without the query string
+	Query   map[string][]string // query parameters
+	Headers map[string]string   // request headers, by lowercase name
+	Body    any                 // the request body, decoded from JSON when possible
+}
+
+// Samples is a list of recorded calls
+type Samples []*Sample
+
+// Load reads recorded calls from a HAR file (with a .har extension) or from a JSON-lines access log
+func Load(file string) (Samples, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(file), ".har") {
+		return ParseHAR(f)
+	}
+	return ParseJSONLines(f)
+}
+
+type harFile struct {
+	Log struct {
+		Entries []struct {
+			Request harRequest `json:"request"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+type harRequest struct {
+	Method   string         `json:"method"`
+	URL      string         `json:"url"`
+	Headers  []harNameValue `json:"headers"`
+	PostData *struct {
+		Text string `json:"text"`
+	} `json:"postData"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ParseHAR reads the requests of a HTTP Archive (HAR)
+func ParseHAR(source io.Reader) (Samples, error) {
+	var file harFile
+	if err := json.NewDecoder(source).Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid HAR file: %w", err)
+	}
+
+	result := make(Samples, 0, len(file.Log.Entries))
+	for i, entry := range file.Log.Entries {
+		headers := map[string]string{}
+		for _, header := range entry.Request.Headers {
+			headers[strings.ToLower(header.Name)] = header.Value
+		}
+
+		body := ""
+		if entry.Request.PostData != nil {
+			body = entry.Request.PostData.Text
+		}
+
+		sample, err := newSample(entry.Request.Method, entry.Request.URL, headers, decodeBody(body))
+		if err != nil {
+			return nil, fmt.Errorf("invalid HAR entry #%d: %w", i+1, err)
+		}
+		result = append(result, sample)
+	}
+
+	return result, nil
+}
+
+// accessLogRecord is a line of a JSON-lines access log
+type accessLogRecord struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"` // may include a query string, or be a full URL
+	Headers map[string]string `json:"headers"`
+	Body    json.RawMessage   `json:"body"` // a JSON value, or a string which contains the raw body
+}
+
+// ParseJSONLines reads an access log with one JSON record per line, for example:
+// {"method": "POST", "path": "/users?notify=true", "headers": {"X-Tenant": "acme"}, "body": {"name": "joe"}}
+func ParseJSONLines(source io.Reader) (Samples, error) {
+	result := Samples{}
+
+	scanner := bufio.NewScanner(source)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var record accessLogRecord
+		if err := json.Unmarshal(text, &record); err != nil {
+			return nil, fmt.Errorf("invalid record on line %d: %w", line, err)
+		}
+
+		headers := map[string]string{}
+		for name, value := range record.Headers {
+			headers[strings.ToLower(name)] = value
+		}
+
+		sample, err := newSample(record.Method, record.Path, headers, decodeRawBody(record.Body))
+		if err != nil {
+			return nil, fmt.Errorf("invalid record on line %d: %w", line, err)
+		}
+		result = append(result, sample)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func newSample(method, rawURL string, headers map[string]string, body any) (*Sample, error) {
+	if method == "" {
+		return nil, fmt.Errorf("missing method")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Path == "" {
+		return nil, fmt.Errorf("missing path")
+	}
+
+	return &Sample{
+		Method:  strings.ToUpper(method),
+		Path:    u.Path,
+		Query:   u.Query(),
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
+// decodeRawBody decodes a JSON body, a string body is decoded again since access logs often record bodies as strings
+func decodeRawBody(raw json.RawMessage) any {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var result any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil
+	}
+
+	if text, ok := result.(string); ok {
+		return decodeBody(text)
+	}
+	return result
+}
+
+// decodeBody decodes a JSON body, other bodies are returned as is
+func decodeBody(text string) any {
+	if text == "" {
+		return nil
+	}
+
+	var result any
+	if err := json.Unmarshal([]byte(text), &result); err != nil {
+		return text
+	}
+	return result
+}
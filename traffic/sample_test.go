@@ -0,0 +1,62 @@
+package traffic_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/traffic"
+)
+
+func TestLoad_JSONLines(t *testing.T) {
+	samples, err := traffic.Load("../data/traffic/access.jsonl")
+	require.NoError(t, err)
+	require.Len(t, samples, 7)
+
+	require.Equal(t, "POST", samples[0].Method)
+	require.Equal(t, "/v1/users", samples[0].Path)
+	require.Equal(t, map[string]any{"name": "joe", "role": "admin"}, samples[0].Body)
+
+	// bodies recorded as strings are decoded too
+	require.Equal(t, map[string]any{"name": "ann", "nickname": "annie", "role": "guest"}, samples[1].Body)
+
+	require.Equal(t, "/v1/users/42", samples[3].Path)
+	require.Equal(t, []string{"name"}, samples[3].Query["fields"])
+	require.Nil(t, samples[3].Body)
+}
+
+func TestLoad_HAR(t *testing.T) {
+	samples, err := traffic.Load("../data/traffic/calls.har")
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+
+	require.Equal(t, "POST", samples[0].Method)
+	require.Equal(t, "/v1/users", samples[0].Path)
+	require.Equal(t, "application/json", samples[0].Headers["content-type"])
+	require.Equal(t, map[string]any{"name": "max", "role": "guest"}, samples[0].Body)
+	require.Equal(t, []string{"id"}, samples[1].Query["fields"])
+}
+
+func TestLoad_NoFile(t *testing.T) {
+	_, err := traffic.Load("../data/traffic/no-file.har")
+	require.Error(t, err)
+}
+
+func TestParseJSONLines_Invalid(t *testing.T) {
+	_, err := traffic.ParseJSONLines(strings.NewReader("{\"method\": \"GET\", \"path\": \"/users\"}\nnot json\n"))
+	require.ErrorContains(t, err, "invalid record on line 2")
+
+	_, err = traffic.ParseJSONLines(strings.NewReader(`{"path": "/users"}`))
+	require.EqualError(t, err, "invalid record on line 1: missing method")
+
+	_, err = traffic.ParseJSONLines(strings.NewReader(`{"method": "GET"}`))
+	require.EqualError(t, err, "invalid record on line 1: missing path")
+}
+
+func TestParseHAR_Invalid(t *testing.T) {
+	_, err := traffic.ParseHAR(strings.NewReader("not json"))
+	require.ErrorContains(t, err, "invalid HAR file")
+
+	_, err = traffic.ParseHAR(strings.NewReader(`{"log": {"entries": [{"request": {"url": "https://example.com/users"}}]}}`))
+	require.EqualError(t, err, "invalid HAR entry #1: missing method")
+}
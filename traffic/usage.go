@@ -0,0 +1,305 @@
+package traffic
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/utils"
+)
+
+// Usage maps recorded calls onto the endpoints of the specs
+type Usage struct {
+	calls     map[string]map[string][]*call // path -> method -> calls
+	Unmatched int                           // the number of samples which didn't match any endpoint
+}
+
+// call is a sample which was mapped onto an endpoint
+type call struct {
+	sample     *Sample
+	pathParams map[string]string
+}
+
+// pathTemplate is a path of a spec, like /users/{id}
+type pathTemplate struct {
+	path      string
+	regex     *regexp.Regexp
+	params    []string
+	variables uint
+}
+
+// NewUsage maps the samples onto the endpoints of the specs, typically the base and the revision
+// like diff, paths are matched regardless of the names of their path parameters
+// when a sample matches several paths of a spec, the path with the fewest parameters is preferred, so /users/me is preferred over /users/{id}
+// sample paths which include the base path of a server of the spec, like /api/v1/users for the server https://example.com/api/v1, are also matched
+func NewUsage(samples Samples, specs ...*openapi3.T) *Usage {
+	result := Usage{
+		calls: map[string]map[string][]*call{},
+	}
+
+	templates := make([][]*pathTemplate, len(specs))
+	for i, spec := range specs {
+		if spec != nil && spec.Paths != nil {
+			templates[i] = getPathTemplates(spec.Paths)
+		}
+	}
+
+	for _, sample := range samples {
+		matched := false
+		for i, spec := range specs {
+			if templates[i] == nil {
+				continue
+			}
+			for _, path := range getSamplePaths(sample.Path, spec.Servers) {
+				if template, pathParams, ok := matchPath(templates[i], path); ok {
+					result.add(template.path, sample, pathParams)
+					matched = true
+					break
+				}
+			}
+		}
+		if !matched {
+			result.Unmatched++
+		}
+	}
+
+	return &result
+}
+
+func (usage *Usage) add(path string, sample *Sample, pathParams map[string]string) {
+	if _, ok := usage.calls[path]; !ok {
+		usage.calls[path] = map[string][]*call{}
+	}
+
+	// the base and the revision usually share paths, so the same sample may be mapped twice onto the same endpoint
+	for _, existing := range usage.calls[path][sample.Method] {
+		if existing.sample == sample {
+			return
+		}
+	}
+
+	usage.calls[path][sample.Method] = append(usage.calls[path][sample.Method], &call{sample: sample, pathParams: pathParams})
+}
+
+func getPathTemplates(paths *openapi3.Paths) []*pathTemplate {
+	result := make([]*pathTemplate, 0, paths.Len())
+	for _, path := range paths.InMatchingOrder() {
+		normalized, variables, params := utils.NormalizeTemplatedPath(path)
+		pattern := strings.ReplaceAll(regexp.QuoteMeta(normalized), `\{\}`, `([^/]+)`)
+		regex, err := regexp.Compile("^" + pattern + "$")
+		if err != nil {
+			continue
+		}
+		result = append(result, &pathTemplate{path: path, regex: regex, params: params, variables: variables})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].variables < result[j].variables
+	})
+	return result
+}
+
+// getSamplePaths returns the path of the sample, followed by the path without the base path of each server
+func getSamplePaths(path string, servers openapi3.Servers) []string {
+	result := []string{path}
+	for _, server := range servers {
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			continue
+		}
+		basePath := strings.TrimSuffix(u.Path, "/")
+		if basePath != "" && strings.HasPrefix(path, basePath+"/") {
+			result = append(result, strings.TrimPrefix(path, basePath))
+		}
+	}
+	return result
+}
+
+func matchPath(templates []*pathTemplate, path string) (*pathTemplate, map[string]string, bool) {
+	for _, template := range templates {
+		match := template.regex.FindStringSubmatch(path)
+		if match == nil {
+			continue
+		}
+
+		pathParams := map[string]string{}
+		for i, param := range template.params {
+			if i+1 < len(match) {
+				pathParams[param] = match[i+1]
+			}
+		}
+		return template, pathParams, true
+	}
+	return nil, nil, false
+}
+
+// Count returns the number of recorded calls affected by the change
+// all calls of the endpoint of the change are affected, except for changes to a specific request property, parameter or enum value, which only affect the calls that use them
+// it returns false for changes which don't belong to an endpoint
+func (usage *Usage) Count(change checker.Change) (int, bool) {
+	if change.GetPath() == "" || change.GetOperation() == "" {
+		return 0, false
+	}
+
+	filter := getCallFilter(change)
+
+	result := 0
+	for _, call := range usage.calls[change.GetPath()][strings.ToUpper(change.GetOperation())] {
+		if filter(call) {
+			result++
+		}
+	}
+	return result, true
+}
+
+// Annotate returns the changes annotated with the number of recorded calls that they affect
+func (usage *Usage) Annotate(changes checker.Changes) checker.Changes {
+	result := make(checker.Changes, len(changes))
+	for i, change := range changes {
+		result[i] = change
+		if count, ok := usage.Count(change); ok {
+			result[i] = checker.WithObservedCalls(change, count)
+		}
+	}
+	return result
+}
+
+// DropUnused removes the breaking changes which don't affect any recorded call
+// changes which don't belong to an endpoint are kept since their usage is unknown
+func (usage *Usage) DropUnused(changes checker.Changes) checker.Changes {
+	result := make(checker.Changes, 0, len(changes))
+	for _, change := range changes {
+		if count, ok := usage.Count(change); ok && count == 0 && change.IsBreaking() {
+			continue
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+func getCallFilter(change checker.Change) func(*call) bool {
+	args := change.GetArgs()
+
+	switch change.GetId() {
+	case checker.RequestPropertyRemovedId:
+		if len(args) == 1 {
+			return func(c *call) bool {
+				return len(getPropertyValues(c.sample.Body, fmt.Sprint(args[0]))) > 0
+			}
+		}
+	case checker.RequestPropertyEnumValueRemovedId, checker.RequestReadOnlyPropertyEnumValueRemovedId:
+		if len(args) == 2 {
+			return func(c *call) bool {
+				return containsValue(getPropertyValues(c.sample.Body, fmt.Sprint(args[1])), args[0])
+			}
+		}
+	case checker.RequestBodyEnumValueRemovedId:
+		if len(args) == 1 {
+			return func(c *call) bool {
+				return containsValue([]any{c.sample.Body}, args[0])
+			}
+		}
+	case checker.RequestParameterRemovedId:
+		if len(args) == 2 {
+			return func(c *call) bool {
+				_, ok := c.getParameter(fmt.Sprint(args[0]), fmt.Sprint(args[1]))
+				return ok
+			}
+		}
+	case checker.RequestParameterEnumValueRemovedId:
+		if len(args) == 3 {
+			return func(c *call) bool {
+				value, ok := c.getParameter(fmt.Sprint(args[1]), fmt.Sprint(args[2]))
+				return ok && valueToString(value) == valueToString(args[0])
+			}
+		}
+	}
+
+	return func(*call) bool { return true }
+}
+
+// getParameter returns the value of a parameter of the call, by its location (path, query, header or cookie) and name
+func (c *call) getParameter(location, name string) (string, bool) {
+	switch location {
+	case openapi3.ParameterInPath:
+		value, ok := c.pathParams[name]
+		return value, ok
+	case openapi3.ParameterInQuery:
+		values, ok := c.sample.Query[name]
+		if !ok || len(values) == 0 {
+			return "", false
+		}
+		return values[0], true
+	case openapi3.ParameterInHeader:
+		value, ok := c.sample.Headers[strings.ToLower(name)]
+		return value, ok
+	case openapi3.ParameterInCookie:
+		for _, cookie := range strings.Split(c.sample.Headers["cookie"], ";") {
+			if key, value, ok := strings.Cut(strings.TrimSpace(cookie), "="); ok && key == name {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// getPropertyValues returns the values of a property in a body, by the property name used in changes, like data/items/name
+// arrays are traversed, and subschema tokens like allOf[#1] are skipped since they don't appear in the body
+func getPropertyValues(body any, property string) []any {
+	values := []any{body}
+	for _, token := range strings.Split(property, "/") {
+		if token == "" || token == "items" || isSubschemaToken(token) {
+			continue
+		}
+
+		next := []any{}
+		for _, value := range flattenArrays(values) {
+			if object, ok := value.(map[string]any); ok {
+				if child, ok := object[token]; ok {
+					next = append(next, child)
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+func flattenArrays(values []any) []any {
+	result := []any{}
+	for _, value := range values {
+		if array, ok := value.([]any); ok {
+			result = append(result, flattenArrays(array)...)
+			continue
+		}
+		result = append(result, value)
+	}
+	return result
+}
+
+func isSubschemaToken(token string) bool {
+	return strings.HasPrefix(token, "allOf[") || strings.HasPrefix(token, "anyOf[") || strings.HasPrefix(token, "oneOf[")
+}
+
+func containsValue(values []any, expected any) bool {
+	for _, value := range flattenArrays(values) {
+		if valueToString(value) == valueToString(expected) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueToString compares values regardless of their type, since JSON numbers are decoded as floats while enum values may be integers
+func valueToString(value any) string {
+	if number, ok := value.(float64); ok && number == math.Trunc(number) && math.Abs(number) < 1e15 {
+		return strconv.FormatInt(int64(number), 10)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,115 @@
+package traffic_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"github.com/tufin/oasdiff/checker"
+	"github.com/tufin/oasdiff/diff"
+	"github.com/tufin/oasdiff/load"
+	"github.com/tufin/oasdiff/traffic"
+)
+
+func loadSpec(t *testing.T, file string) *openapi3.T {
+	t.Helper()
+
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromFile(file)
+	require.NoError(t, err)
+	return spec
+}
+
+func getChanges(t *testing.T, base, revision *openapi3.T) checker.Changes {
+	t.Helper()
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), &load.SpecInfo{Spec: base}, &load.SpecInfo{Spec: revision})
+	require.NoError(t, err)
+	return checker.CheckBackwardCompatibility(checker.NewConfig(checker.GetAllChecks()), d, osm)
+}
+
+func getUsage(t *testing.T) (*traffic.Usage, checker.Changes) {
+	t.Helper()
+
+	base := loadSpec(t, "../data/traffic/base.yaml")
+	revision := loadSpec(t, "../data/traffic/revision.yaml")
+
+	samples, err := traffic.Load("../data/traffic/access.jsonl")
+	require.NoError(t, err)
+
+	return traffic.NewUsage(samples, base, revision), getChanges(t, base, revision)
+}
+
+func findChange(t *testing.T, changes checker.Changes, id string) checker.Change {
+	t.Helper()
+
+	for _, change := range changes {
+		if change.GetId() == id {
+			return change
+		}
+	}
+	require.Failf(t, "change not found", "id: %s", id)
+	return nil
+}
+
+func TestUsage_Unmatched(t *testing.T) {
+	usage, _ := getUsage(t)
+	require.Equal(t, 1, usage.Unmatched)
+}
+
+func TestUsage_Count(t *testing.T) {
+	usage, changes := getUsage(t)
+
+	for id, expected := range map[string]int{
+		checker.APIPathRemovedWithoutDeprecationId: 0,
+		checker.RequestPropertyEnumValueRemovedId:  1,
+		checker.RequestPropertyRemovedId:           1,
+		checker.RequestParameterRemovedId:          1,
+	} {
+		count, ok := usage.Count(findChange(t, changes, id))
+		require.True(t, ok, id)
+		require.Equal(t, expected, count, id)
+	}
+}
+
+func TestUsage_CountComponentChange(t *testing.T) {
+	usage, _ := getUsage(t)
+
+	_, ok := usage.Count(checker.ComponentChange{Id: "some-id"})
+	require.False(t, ok)
+}
+
+func TestUsage_CountPathParameter(t *testing.T) {
+	usage, _ := getUsage(t)
+
+	change := checker.ApiChange{
+		Id:        checker.RequestParameterEnumValueRemovedId,
+		Args:      []any{"42", "path", "id"},
+		Operation: "GET",
+		Path:      "/users/{id}",
+	}
+	count, ok := usage.Count(change)
+	require.True(t, ok)
+	require.Equal(t, 1, count)
+}
+
+func TestUsage_Annotate(t *testing.T) {
+	usage, changes := getUsage(t)
+
+	annotated := usage.Annotate(changes)
+	require.Len(t, annotated, len(changes))
+
+	calls, ok := findChange(t, annotated, checker.RequestPropertyRemovedId).GetObservedCalls()
+	require.True(t, ok)
+	require.Equal(t, 1, calls)
+}
+
+func TestUsage_DropUnused(t *testing.T) {
+	usage, changes := getUsage(t)
+
+	result := usage.DropUnused(changes)
+	require.Len(t, result, len(changes)-1)
+	for _, change := range result {
+		require.NotEqual(t, checker.APIPathRemovedWithoutDeprecationId, change.GetId())
+	}
+}